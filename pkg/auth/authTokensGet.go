@@ -9,12 +9,19 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"sort"
 	"strings"
 
 	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
 	"github.com/galasa-dev/cli/pkg/tokensformatter"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+var (
+	tokenFormatters = createTokenFormatters()
 )
 
 // GetTokens - performs all the logic to implement the `galasactl auth tokens get` command
@@ -22,12 +29,23 @@ func GetTokens(
 	apiClient *galasaapi.APIClient,
 	console spi.Console,
 	loginId string,
+	outputFormat string,
+	tableOptions *utils.TableOptions,
 ) error {
 
-	authTokens, err := getAuthTokensFromRestApi(apiClient, loginId)
+	chosenFormatter, err := validateTokenFormatFlag(outputFormat)
+
+	if err == nil {
+		err = utils.ApplyTableOptions(chosenFormatter, tableOptions)
+	}
 
 	if err == nil {
-		err = formatFetchedTokensAndWriteToConsole(authTokens, console)
+		var authTokens []galasaapi.AuthToken
+		authTokens, err = getAuthTokensFromRestApi(apiClient, loginId)
+
+		if err == nil {
+			err = formatFetchedTokensAndWriteToConsole(authTokens, chosenFormatter, console)
+		}
 	}
 
 	return err
@@ -74,11 +92,9 @@ func getAuthTokensFromRestApi(apiClient *galasaapi.APIClient, loginId string) ([
 	return authTokens, err
 }
 
-func formatFetchedTokensAndWriteToConsole(authTokens []galasaapi.AuthToken, console spi.Console) error {
+func formatFetchedTokensAndWriteToConsole(authTokens []galasaapi.AuthToken, chosenFormatter tokensformatter.TokenFormatter, console spi.Console) error {
 
-	summaryFormatter := tokensformatter.NewTokenSummaryFormatter()
-
-	outputText, err := summaryFormatter.FormatTokens(authTokens)
+	outputText, err := chosenFormatter.FormatTokens(authTokens)
 
 	if err == nil {
 		console.WriteString(outputText)
@@ -88,6 +104,54 @@ func formatFetchedTokensAndWriteToConsole(authTokens []galasaapi.AuthToken, cons
 
 }
 
+func createTokenFormatters() map[string]tokensformatter.TokenFormatter {
+	formatters := make(map[string]tokensformatter.TokenFormatter, 0)
+	summaryFormatter := tokensformatter.NewTokenSummaryFormatter()
+	csvFormatter := tokensformatter.NewTokenCsvFormatter()
+
+	formatters[summaryFormatter.GetName()] = summaryFormatter
+	formatters[csvFormatter.GetName()] = csvFormatter
+
+	return formatters
+}
+
+func GetTokenFormatterNamesAsString() string {
+	names := make([]string, 0, len(tokenFormatters))
+	for name := range tokenFormatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	formatterNames := strings.Builder{}
+
+	for index, formatterName := range names {
+
+		if index != 0 {
+			formatterNames.WriteString(", ")
+		}
+		formatterNames.WriteString("'" + formatterName + "'")
+	}
+
+	return formatterNames.String()
+}
+
+func validateTokenFormatFlag(outputFormatString string) (tokensformatter.TokenFormatter, error) {
+	var err error
+	var chosenFormatter tokensformatter.TokenFormatter
+
+	if templateformatter.IsTemplateFormat(outputFormatString) {
+		chosenFormatter, err = tokensformatter.NewTokenTemplateFormatter(outputFormatString)
+	} else {
+		var isPresent bool
+		chosenFormatter, isPresent = tokenFormatters[outputFormatString]
+
+		if !isPresent {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_OUTPUT_FORMAT, outputFormatString, GetTokenFormatterNamesAsString())
+		}
+	}
+
+	return chosenFormatter, err
+}
+
 func validateLoginIdFlag(loginId string) (string, error) {
 
 	var err error
@@ -113,7 +113,7 @@ Total:3
 `
 
 	//When
-	err := GetTokens(apiClient, console, "")
+	err := GetTokens(apiClient, console, "", "summary", nil)
 
 	//Then
 	assert.Nil(t, err)
@@ -131,7 +131,7 @@ func TestNoTokensPathReturnsOk(t *testing.T) {
 	expectedOutput := "Total:0\n"
 
 	//When
-	err := GetTokens(apiClient, console, "")
+	err := GetTokens(apiClient, console, "", "summary", nil)
 
 	//Then
 	assert.Nil(t, err)
@@ -148,7 +148,7 @@ func TestInvalidPathReturnsError(t *testing.T) {
 	console := utils.NewMockConsole()
 
 	//When
-	err := GetTokens(apiClient, console, "admin")
+	err := GetTokens(apiClient, console, "admin", "summary", nil)
 
 	//Then
 	assert.NotNil(t, err)
@@ -167,7 +167,7 @@ func TestMissingLoginIdFlagReturnsBadRequest(t *testing.T) {
 	expectedOutput := `GAL1166E: The loginId provided by the --login-id field cannot be an empty string.`
 
 	//When
-	err := GetTokens(apiClient, console, "   ")
+	err := GetTokens(apiClient, console, "   ", "summary", nil)
 
 	//Then
 	assert.NotNil(t, err)
@@ -185,7 +185,7 @@ func TestLoginIdWithSpacesReturnsBadRequest(t *testing.T) {
 	expectedOutput := `GAL1165E: 'galasa admin' is not supported as a valid login ID. Login ID should not contain spaces.`
 
 	//When
-	err := GetTokens(apiClient, console, "galasa admin")
+	err := GetTokens(apiClient, console, "galasa admin", "summary", nil)
 
 	//Then
 	assert.NotNil(t, err)
@@ -208,9 +208,47 @@ Total:2
 `
 
 	//When
-	err := GetTokens(apiClient, console, "mcobbett")
+	err := GetTokens(apiClient, console, "mcobbett", "summary", nil)
 
 	//Then
 	assert.Nil(t, err)
 	assert.Equal(t, expectedOutput, console.ReadText())
 }
+
+func TestMultipleTokensTemplateFormatReturnsOk(t *testing.T) {
+	//Given...
+	serverState := "populated"
+	server := NewAuthTokensServletMock(t, serverState)
+	apiClient := api.InitialiseAPI(server.URL)
+	defer server.Close()
+
+	console := utils.NewMockConsole()
+	expectedOutput := "mcobbett 2023-12-03 098234980123-1283182389\n" +
+		"mcobbett 2024-03-03 8218971d287s1-dhj32er2323\n" +
+		"savvas   2023-08-04 87a6sd87ahq2-2y8hqwdjj273\n"
+
+	//When
+	err := GetTokens(apiClient, console, "", `template={{padRight .owner.login_id 8}} {{formatDate .creation_time "2006-01-02"}} {{.token_id}}`, nil)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOutput, console.ReadText())
+}
+
+func TestUnknownTokensFormatReturnsError(t *testing.T) {
+	//Given...
+	serverState := "populated"
+	server := NewAuthTokensServletMock(t, serverState)
+	apiClient := api.InitialiseAPI(server.URL)
+	defer server.Close()
+
+	console := utils.NewMockConsole()
+
+	//When
+	err := GetTokens(apiClient, console, "", "yaml", nil)
+
+	//Then
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1067E")
+	assert.Contains(t, err.Error(), "'summary'")
+}
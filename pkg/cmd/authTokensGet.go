@@ -12,6 +12,7 @@ import (
 	"github.com/galasa-dev/cli/pkg/auth"
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
 	"github.com/galasa-dev/cli/pkg/utils"
 	"github.com/spf13/cobra"
 )
@@ -20,7 +21,13 @@ import (
 //
 //		auth tokens get
 //	 And then display all tokens or returns empty
+type AuthTokensGetCmdValues struct {
+	outputFormat    string
+	tableFlagValues TableFlagValues
+}
+
 type AuthTokensGetCommand struct {
+	values       *AuthTokensGetCmdValues
 	cobraCommand *cobra.Command
 }
 
@@ -51,8 +58,7 @@ func (cmd *AuthTokensGetCommand) CobraCommand() *cobra.Command {
 }
 
 func (cmd *AuthTokensGetCommand) Values() interface{} {
-	// There are no values.
-	return nil
+	return cmd.values
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -65,6 +71,7 @@ func (cmd *AuthTokensGetCommand) init(
 ) error {
 	var err error
 
+	cmd.values = &AuthTokensGetCmdValues{}
 	cmd.cobraCommand, err = cmd.createCobraCmd(factory, authTokensCommand, commsFlagSet)
 
 	return err
@@ -95,6 +102,12 @@ func (cmd *AuthTokensGetCommand) createCobraCmd(
 	}
 
 	addLoginIdFlagToAuthTokensGet(authGetTokensCobraCmd, authTokensGetCommandValues)
+
+	formatters := auth.GetTokenFormatterNamesAsString()
+	authGetTokensCobraCmd.Flags().StringVar(&cmd.values.outputFormat, "format", "summary", "the output format of the returned tokens. Supported formats are: "+formatters+". "+
+		templateformatter.TEMPLATE_FORMATS_HELP)
+	addTableFlags(authGetTokensCobraCmd.Flags(), &cmd.values.tableFlagValues)
+
 	authTokensCommand.CobraCommand().AddCommand(authGetTokensCobraCmd)
 
 	return authGetTokensCobraCmd, err
@@ -140,8 +153,14 @@ func (cmd *AuthTokensGetCommand) executeAuthTokensGet(
 			var apiClient *galasaapi.APIClient
 			apiClient, err = authenticator.GetAuthenticatedAPIClient()
 
+			var outputFormat string
+			if err == nil {
+				// A 'template-file=<path>' format is swapped for the go template held in that file.
+				outputFormat, err = templateformatter.ResolveTemplateFile(fileSystem, cmd.values.outputFormat)
+			}
+
 			if err == nil {
-				err = auth.GetTokens(apiClient, console, authTokenCmdValues.loginId)
+				err = auth.GetTokens(apiClient, console, authTokenCmdValues.loginId, outputFormat, cmd.values.tableFlagValues.toTableOptions())
 			}
 		}
 	}
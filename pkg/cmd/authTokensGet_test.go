@@ -21,7 +21,7 @@ func TestAuthTokensGetCommandInCommandCollection(t *testing.T) {
 	assert.Nil(t, err)
 
 	assert.Equal(t, COMMAND_NAME_AUTH_TOKENS_GET, AuthTokensGetCommand.Name())
-	assert.Nil(t, AuthTokensGetCommand.Values())
+	assert.NotNil(t, AuthTokensGetCommand.Values())
 	assert.NotNil(t, AuthTokensGetCommand.CobraCommand())
 }
 
@@ -58,3 +58,22 @@ func TestAuthTokensGetReturnsWithoutError(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestAuthTokensGetFormatFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_AUTH_TOKENS_GET, factory, t)
+
+	var args []string = []string{"auth", "tokens", "get", "--format", "template={{.token_id}}"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, "template={{.token_id}}", cmd.Values().(*AuthTokensGetCmdValues).outputFormat)
+}
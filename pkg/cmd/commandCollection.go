@@ -52,8 +52,21 @@ const (
 	COMMAND_NAME_RUNS_SUBMIT              = "runs submit"
 	COMMAND_NAME_RUNS_SUBMIT_LOCAL        = "runs submit local"
 	COMMAND_NAME_RUNS_RESET               = "runs reset"
+	COMMAND_NAME_RUNS_RERUN               = "runs rerun"
 	COMMAND_NAME_RUNS_CANCEL              = "runs cancel"
 	COMMAND_NAME_RUNS_DELETE              = "runs delete"
+	COMMAND_NAME_RUNS_WAIT                = "runs wait"
+	COMMAND_NAME_RUNS_CONTROL             = "runs control"
+	COMMAND_NAME_RUNS_CONTROL_PAUSE       = "runs control pause"
+	COMMAND_NAME_RUNS_CONTROL_RESUME      = "runs control resume"
+	COMMAND_NAME_RUNS_CONTROL_THROTTLE    = "runs control throttle"
+	COMMAND_NAME_RUNS_CONTROL_LIST        = "runs control list"
+	COMMAND_NAME_RUNS_CONTROL_CANCEL      = "runs control cancel"
+	COMMAND_NAME_RUNS_PORTFOLIO           = "runs portfolio"
+	COMMAND_NAME_RUNS_PORTFOLIO_SHOW      = "runs portfolio show"
+	COMMAND_NAME_RUNS_PORTFOLIO_VALIDATE  = "runs portfolio validate"
+	COMMAND_NAME_RUNS_PORTFOLIO_MERGE     = "runs portfolio merge"
+	COMMAND_NAME_RUNS_PORTFOLIO_DIFF      = "runs portfolio diff"
 	COMMAND_NAME_RESOURCES                = "resources"
 	COMMAND_NAME_RESOURCES_APPLY          = "resources apply"
 	COMMAND_NAME_RESOURCES_CREATE         = "resources create"
@@ -322,6 +335,8 @@ func (commands *commandCollectionImpl) addRunsCommands(factory spi.Factory, root
 	var runsResetCommand spi.GalasaCommand
 	var runsCancelCommand spi.GalasaCommand
 	var runsDeleteCommand spi.GalasaCommand
+	var runsWaitCommand spi.GalasaCommand
+	var runsRerunCommand spi.GalasaCommand
 
 	runsCommand, err = NewRunsCmd(rootCommand, commsFlagSet)
 	if err == nil {
@@ -340,6 +355,18 @@ func (commands *commandCollectionImpl) addRunsCommands(factory spi.Factory, root
 								runsCancelCommand, err = NewRunsCancelCommand(factory, runsCommand, commsFlagSet)
 								if err == nil {
 									runsDeleteCommand, err = NewRunsDeleteCommand(factory, runsCommand, commsFlagSet)
+									if err == nil {
+										runsWaitCommand, err = NewRunsWaitCommand(factory, runsCommand, commsFlagSet)
+										if err == nil {
+											runsRerunCommand, err = NewRunsRerunCommand(factory, runsCommand, commsFlagSet)
+											if err == nil {
+												err = commands.addRunsControlCommands(factory, runsCommand, commsFlagSet)
+												if err == nil {
+													err = commands.addRunsPortfolioCommands(factory, runsCommand, commsFlagSet)
+												}
+											}
+										}
+									}
 								}
 							}
 						}
@@ -359,6 +386,81 @@ func (commands *commandCollectionImpl) addRunsCommands(factory spi.Factory, root
 		commands.commandMap[runsResetCommand.Name()] = runsResetCommand
 		commands.commandMap[runsCancelCommand.Name()] = runsCancelCommand
 		commands.commandMap[runsDeleteCommand.Name()] = runsDeleteCommand
+		commands.commandMap[runsWaitCommand.Name()] = runsWaitCommand
+		commands.commandMap[runsRerunCommand.Name()] = runsRerunCommand
+	}
+
+	return err
+}
+
+func (commands *commandCollectionImpl) addRunsControlCommands(factory spi.Factory, runsCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+
+	var err error
+	var runsControlCommand spi.GalasaCommand
+	var runsControlPauseCommand spi.GalasaCommand
+	var runsControlResumeCommand spi.GalasaCommand
+	var runsControlThrottleCommand spi.GalasaCommand
+	var runsControlListCommand spi.GalasaCommand
+	var runsControlCancelCommand spi.GalasaCommand
+
+	runsControlCommand, err = NewRunsControlCommand(runsCommand)
+	if err == nil {
+		runsControlPauseCommand, err = NewRunsControlPauseCommand(factory, runsControlCommand, commsFlagSet)
+		if err == nil {
+			runsControlResumeCommand, err = NewRunsControlResumeCommand(factory, runsControlCommand, commsFlagSet)
+			if err == nil {
+				runsControlThrottleCommand, err = NewRunsControlThrottleCommand(factory, runsControlCommand, commsFlagSet)
+				if err == nil {
+					runsControlListCommand, err = NewRunsControlListCommand(factory, runsControlCommand, commsFlagSet)
+					if err == nil {
+						runsControlCancelCommand, err = NewRunsControlCancelCommand(factory, runsControlCommand, commsFlagSet)
+					}
+				}
+			}
+		}
+	}
+
+	if err == nil {
+		commands.commandMap[runsControlCommand.Name()] = runsControlCommand
+		commands.commandMap[runsControlPauseCommand.Name()] = runsControlPauseCommand
+		commands.commandMap[runsControlResumeCommand.Name()] = runsControlResumeCommand
+		commands.commandMap[runsControlThrottleCommand.Name()] = runsControlThrottleCommand
+		commands.commandMap[runsControlListCommand.Name()] = runsControlListCommand
+		commands.commandMap[runsControlCancelCommand.Name()] = runsControlCancelCommand
+	}
+
+	return err
+}
+
+func (commands *commandCollectionImpl) addRunsPortfolioCommands(factory spi.Factory, runsCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+
+	var err error
+	var runsPortfolioCommand spi.GalasaCommand
+	var runsPortfolioShowCommand spi.GalasaCommand
+	var runsPortfolioValidateCommand spi.GalasaCommand
+	var runsPortfolioMergeCommand spi.GalasaCommand
+	var runsPortfolioDiffCommand spi.GalasaCommand
+
+	runsPortfolioCommand, err = NewRunsPortfolioCommand(runsCommand)
+	if err == nil {
+		runsPortfolioShowCommand, err = NewRunsPortfolioShowCommand(factory, runsPortfolioCommand, commsFlagSet)
+		if err == nil {
+			runsPortfolioValidateCommand, err = NewRunsPortfolioValidateCommand(factory, runsPortfolioCommand, commsFlagSet)
+			if err == nil {
+				runsPortfolioMergeCommand, err = NewRunsPortfolioMergeCommand(factory, runsPortfolioCommand, commsFlagSet)
+				if err == nil {
+					runsPortfolioDiffCommand, err = NewRunsPortfolioDiffCommand(factory, runsPortfolioCommand, commsFlagSet)
+				}
+			}
+		}
+	}
+
+	if err == nil {
+		commands.commandMap[runsPortfolioCommand.Name()] = runsPortfolioCommand
+		commands.commandMap[runsPortfolioShowCommand.Name()] = runsPortfolioShowCommand
+		commands.commandMap[runsPortfolioValidateCommand.Name()] = runsPortfolioValidateCommand
+		commands.commandMap[runsPortfolioMergeCommand.Name()] = runsPortfolioMergeCommand
+		commands.commandMap[runsPortfolioDiffCommand.Name()] = runsPortfolioDiffCommand
 	}
 
 	return err
@@ -6,6 +6,7 @@
 package cmd
 
 import (
+	"github.com/galasa-dev/cli/pkg/utils"
 	"github.com/spf13/pflag"
 )
 
@@ -14,6 +15,13 @@ const (
 	OPTIONAL_FLAG  = false
 )
 
+// The values of the flags which change the layout of a table of results.
+type TableFlagValues struct {
+	columns     []string
+	sortBy      string
+	isNoHeaders bool
+}
+
 // ------------------------------------------------------------------------------------------------
 // Objectives
 //   Functions which add a flag to a cobra command in a different way,
@@ -36,3 +44,58 @@ func addRateLimitRetryFlags(flagSet *pflag.FlagSet, maxRetries *int, retryBackof
 	flagSet.Float64Var(retryBackoffSeconds, "rate-limit-retry-backoff-secs", float64(1),
 		"The amount of time in seconds to wait before retrying a command if it failed due to rate limits being exceeded. Defaults to 1 second.")
 }
+
+func addTableFlags(flagSet *pflag.FlagSet, tableFlagValues *TableFlagValues) {
+	flagSet.StringSliceVar(&tableFlagValues.columns, "columns", nil,
+		"Optional. A comma-separated list of the table columns to show, in the order to show them. "+
+			"A column is named by its heading, with or without the part in brackets. For example: --columns name,result. "+
+			"Only used with the 'summary' and 'csv' formats.")
+
+	flagSet.StringVar(&tableFlagValues.sortBy, "sort-by", "",
+		"Optional. The table column to sort the rows on. Add ':desc' to the column to sort in descending order. "+
+			"For example: --sort-by submitted-time:desc. Only used with the 'summary' and 'csv' formats.")
+
+	flagSet.BoolVar(&tableFlagValues.isNoHeaders, "no-headers", false,
+		"Optional. Leaves out the row of column headings. Only used with the 'summary' and 'csv' formats.")
+}
+
+// addSubmitReportFlags - The flags which say how the results of submitted test runs are reported once they finish.
+func addSubmitReportFlags(flagSet *pflag.FlagSet, values *utils.RunsSubmitCmdValues) {
+	flagSet.StringVar(&values.ReportYamlFilename, "reportyaml", "", "yaml file to record the final results in")
+	flagSet.StringVar(&values.ReportJsonFilename, "reportjson", "", "json file to record the final results in")
+	flagSet.StringVar(&values.ReportJunitFilename, "reportjunit", "", "junit xml file to record the final results in")
+	flagSet.StringVar(&values.ReportHtmlFilename, "reporthtml", "", "html file to record the final results in, as a single page which can be shared")
+	flagSet.StringVar(&values.ReportTapFilename, "reporttap", "", "TAP version 13 file to record the final results in")
+	flagSet.StringVar(&values.ReportCtrfFilename, "reportctrf", "", "Common Test Report Format (CTRF) json file to record the final results in")
+	flagSet.StringVar(&values.ReportMarkdownFilename, "reportmarkdown", "", "markdown file to record a summary of the final results in, "+
+		"suitable for a pull request comment or a CI job summary")
+
+	flagSet.IntVar(&values.ReportJunitRunLogLines, "reportjunitlog", 0,
+		"the number of lines from the end of the run log of each test run to include in the --reportjunit report, "+
+			"as the system-out of its test suite. The run logs are fetched from the Galasa ecosystem, so are not included when running tests locally. "+
+			"Defaults to 0, which leaves the run logs out of the report.")
+
+	flagSet.BoolVar(&values.NoExitCodeOnTestFailures, "noexitcodeontestfailures", false, "set to true if you don't want an exit code to be returned from galasactl if a test fails")
+}
+
+// addResultPolicyFlags - The flags which decide whether the results of submitted test runs fail galasactl.
+func addResultPolicyFlags(flagSet *pflag.FlagSet, values *utils.RunsSubmitCmdValues) {
+	flagSet.StringVar(&values.ResultPolicyFileName, "resultpolicy", "",
+		"a yaml file holding the result policy, which decides whether the results of the test runs fail galasactl. "+
+			"The policy can list the results which don't count as failures, the minimum pass rate, and the test classes which are quarantined. "+
+			"The --excuseresults, --quarantine and --minpassrate flags add to the policy in the file.")
+	flagSet.StringSliceVar(&values.ExcusedResults, "excuseresults", nil,
+		"a comma-separated list of the results which don't count as failures, such as 'EnvFail'. "+
+			"Test runs with these results are reported as they are, but don't fail galasactl.")
+	flagSet.Float64Var(&values.MinPassRate, "minpassrate", 0,
+		"the lowest percentage of the test runs which must pass, from 0 to 100. "+
+			"Quarantined test runs, and test runs with results given by --excuseresults, are not counted. "+
+			"Defaults to 0, where any test run which fails makes galasactl fail.")
+	flagSet.StringSliceVar(&values.Quarantine, "quarantine", nil,
+		"a comma-separated list of bundle/class patterns of the test classes which are quarantined, such as 'myBundle/com.myco.MyFlakyTest' or 'myBundle/*'. "+
+			"Quarantined test classes are run and reported as usual, but their failures don't fail galasactl.")
+}
+
+func (tableFlagValues *TableFlagValues) toTableOptions() *utils.TableOptions {
+	return utils.NewTableOptions(tableFlagValues.columns, tableFlagValues.sortBy, tableFlagValues.isNoHeaders)
+}
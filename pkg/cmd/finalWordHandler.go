@@ -58,7 +58,8 @@ func extractErrorDetails(errorToExctractFrom interface{}) (string, int, bool) {
 		galasaErrorPtr, isGalasaError := errorToExctractFrom.(*galasaErrors.GalasaError)
 		if isGalasaError {
 			errorType := (galasaErrorPtr).GetMessageType()
-			if errorType.Ordinal == galasaErrors.GALASA_ERROR_TESTS_FAILED.Ordinal {
+			if errorType.Ordinal == galasaErrors.GALASA_ERROR_TESTS_FAILED.Ordinal ||
+				errorType.Ordinal == galasaErrors.GALASA_ERROR_TESTS_BELOW_MIN_PASS_RATE.Ordinal {
 				// The failure was because some tests failed, rather than the tool or infrastructure failed.
 				exitCode = 2
 			}
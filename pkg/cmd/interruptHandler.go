@@ -0,0 +1,69 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+const (
+	// The conventional exit code of a process which was ended by an interrupt.
+	EXIT_CODE_INTERRUPTED = 130
+)
+
+// An Interruptible can be asked to stop what it is doing and finish early.
+type Interruptible interface {
+	Interrupt(reason string)
+}
+
+// watchForInterrupts - The first SIGINT or SIGTERM the process receives asks the target to
+// finish early. A second one exits the process straight away.
+// Call the returned function to stop watching.
+func watchForInterrupts(target Interruptible) func() {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	exitImmediately := func() {
+		os.Exit(EXIT_CODE_INTERRUPTED)
+	}
+	stopHandling := handleInterrupts(signals, target, exitImmediately)
+
+	return func() {
+		signal.Stop(signals)
+		stopHandling()
+	}
+}
+
+// handleInterrupts - Interrupts the target on the first signal received, and calls exitImmediately on the next.
+func handleInterrupts(signals <-chan os.Signal, target Interruptible, exitImmediately func()) func() {
+	done := make(chan struct{})
+
+	go func() {
+		interruptCount := 0
+		for {
+			select {
+			case receivedSignal := <-signals:
+				interruptCount += 1
+				if interruptCount == 1 {
+					log.Printf("Received signal %v. Finishing early.\n", receivedSignal)
+					target.Interrupt("received signal " + receivedSignal.String())
+				} else {
+					log.Printf("Received signal %v again. Exiting immediately.\n", receivedSignal)
+					exitImmediately()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockInterruptible struct {
+	reasons chan string
+}
+
+func (target *mockInterruptible) Interrupt(reason string) {
+	target.reasons <- reason
+}
+
+func TestFirstInterruptSignalInterruptsTheTarget(t *testing.T) {
+	// Given...
+	signals := make(chan os.Signal, 2)
+	target := &mockInterruptible{reasons: make(chan string, 2)}
+	exits := make(chan bool, 2)
+	stopHandling := handleInterrupts(signals, target, func() { exits <- true })
+	defer stopHandling()
+
+	// When...
+	signals <- os.Interrupt
+
+	// Then...
+	select {
+	case reason := <-target.reasons:
+		assert.Contains(t, reason, "interrupt")
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "The target was not interrupted.")
+	}
+	assert.Empty(t, exits)
+}
+
+func TestSecondInterruptSignalExitsImmediately(t *testing.T) {
+	// Given...
+	signals := make(chan os.Signal, 2)
+	target := &mockInterruptible{reasons: make(chan string, 2)}
+	exits := make(chan bool, 2)
+	stopHandling := handleInterrupts(signals, target, func() { exits <- true })
+	defer stopHandling()
+
+	// When...
+	signals <- os.Interrupt
+	signals <- os.Interrupt
+
+	// Then...
+	select {
+	case <-exits:
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "The second interrupt did not exit.")
+	}
+	assert.Equal(t, 1, len(target.reasons))
+}
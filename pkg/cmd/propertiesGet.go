@@ -13,6 +13,7 @@ import (
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/properties"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
 	"github.com/galasa-dev/cli/pkg/utils"
 	"github.com/spf13/cobra"
 )
@@ -30,6 +31,7 @@ type PropertiesGetCmdValues struct {
 	propertiesSuffix       string
 	propertiesInfix        string
 	propertiesOutputFormat string
+	tableFlagValues        TableFlagValues
 }
 
 type PropertiesGetCommand struct {
@@ -117,7 +119,9 @@ func (cmd *PropertiesGetCommand) createCobraCommand(
 			" The first character of each infix must be in the 'a'-'z' or 'A'-'Z' ranges, "+
 			"and following characters can be 'a'-'z', 'A'-'Z', '0'-'9', '.' (period), '-' (dash) or '_' (underscore)")
 	propertiesGetCobraCmd.PersistentFlags().StringVar(&cmd.values.propertiesOutputFormat, "format", "summary",
-		"output format for the data returned. Supported formats are: "+formatters+".")
+		"output format for the data returned. Supported formats are: "+formatters+". "+
+			templateformatter.TEMPLATE_FORMATS_HELP)
+	addTableFlags(propertiesGetCobraCmd.PersistentFlags(), &cmd.values.tableFlagValues)
 
 	// The namespace property is mandatory for get.
 	addNamespaceFlag(propertiesGetCobraCmd, true, propertiesCommandValues)
@@ -172,6 +176,12 @@ func (cmd *PropertiesGetCommand) executePropertiesGet(
 			)
 			apiClient, err = authenticator.GetAuthenticatedAPIClient()
 
+			var outputFormat string
+			if err == nil {
+				// A 'template-file=<path>' format is swapped for the go template held in that file.
+				outputFormat, err = templateformatter.ResolveTemplateFile(fileSystem, cmd.values.propertiesOutputFormat)
+			}
+
 			if err == nil {
 				// Call to process the command in a unit-testable way.
 				err = properties.GetProperties(
@@ -181,7 +191,8 @@ func (cmd *PropertiesGetCommand) executePropertiesGet(
 					cmd.values.propertiesSuffix,
 					cmd.values.propertiesInfix,
 					apiClient,
-					cmd.values.propertiesOutputFormat,
+					outputFormat,
+					cmd.values.tableFlagValues.toTableOptions(),
 					console,
 				)
 			}
@@ -13,6 +13,7 @@ import (
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/properties"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
 	"github.com/galasa-dev/cli/pkg/utils"
 	"github.com/spf13/cobra"
 )
@@ -96,7 +97,8 @@ func (cmd *PropertiesNamespaceGetCommand) createCobraCommand(
 
 	namespaceHasYamlFormat := false
 	formatters := properties.GetFormatterNamesString(properties.CreateFormatters(namespaceHasYamlFormat))
-	propertieNamespaceGetCobraCommand.PersistentFlags().StringVar(&cmd.values.namespaceOutputFormat, "format", "summary", "output format for the data returned. Supported formats are: "+formatters+".")
+	propertieNamespaceGetCobraCommand.PersistentFlags().StringVar(&cmd.values.namespaceOutputFormat, "format", "summary", "output format for the data returned. Supported formats are: "+formatters+". "+
+		templateformatter.TEMPLATE_FORMATS_HELP)
 
 	propertiesNamespaceCommand.CobraCommand().AddCommand(propertieNamespaceGetCobraCommand)
 
@@ -141,9 +143,15 @@ func (cmd *PropertiesNamespaceGetCommand) executePropertiesNamespaceGet(
 			)
 			apiClient, err = authenticator.GetAuthenticatedAPIClient()
 
+			var outputFormat string
+			if err == nil {
+				// A 'template-file=<path>' format is swapped for the go template held in that file.
+				outputFormat, err = templateformatter.ResolveTemplateFile(fileSystem, cmd.values.namespaceOutputFormat)
+			}
+
 			if err == nil {
 				// Call to process the command in a unit-testable way.
-				err = properties.GetPropertiesNamespaces(apiClient, cmd.values.namespaceOutputFormat, console)
+				err = properties.GetPropertiesNamespaces(apiClient, outputFormat, console)
 			}
 		}
 	}
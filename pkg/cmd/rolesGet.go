@@ -12,12 +12,14 @@ import (
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/roles"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
 	"github.com/galasa-dev/cli/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 type RolesGetCmdValues struct {
-	outputFormat string
+	outputFormat    string
+	tableFlagValues TableFlagValues
 }
 
 type RolesGetCommand struct {
@@ -92,7 +94,9 @@ func (cmd *RolesGetCommand) createCobraCmd(
 	addRolesNameFlag(RolesGetCobraCmd, false, RolesCommandValues)
 
 	formatters := roles.GetFormatterNamesAsString()
-	RolesGetCobraCmd.Flags().StringVar(&cmd.values.outputFormat, "format", "summary", "the output format of the returned Roles. Supported formats are: "+formatters+".")
+	RolesGetCobraCmd.Flags().StringVar(&cmd.values.outputFormat, "format", "summary", "the output format of the returned Roles. Supported formats are: "+formatters+". "+
+		templateformatter.TEMPLATE_FORMATS_HELP)
+	addTableFlags(RolesGetCobraCmd.Flags(), &cmd.values.tableFlagValues)
 
 	RolesCommand.CobraCommand().AddCommand(RolesGetCobraCmd)
 
@@ -139,8 +143,14 @@ func (cmd *RolesGetCommand) executeRolesGet(
 
 			byteReader := factory.GetByteReader()
 
+			var outputFormat string
 			if err == nil {
-				err = roles.GetRoles(RolesCmdValues.name, cmd.values.outputFormat, console, apiClient, byteReader)
+				// A 'template-file=<path>' format is swapped for the go template held in that file.
+				outputFormat, err = templateformatter.ResolveTemplateFile(fileSystem, cmd.values.outputFormat)
+			}
+
+			if err == nil {
+				err = roles.GetRoles(RolesCmdValues.name, outputFormat, cmd.values.tableFlagValues.toTableOptions(), console, apiClient, byteReader)
 			}
 		}
 	}
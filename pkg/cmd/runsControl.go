@@ -0,0 +1,111 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"log"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// Objective: Allow the user to do this:
+//    runs control pause --controlfile my.control
+//    runs control resume --controlfile my.control
+//    runs control throttle --controlfile my.control --throttle 5
+//    runs control list --controlfile my.control
+//    runs control cancel --controlfile my.control --name U123
+// to control a 'runs submit --controlfile my.control' command which is running.
+
+type RunsControlCmdValues struct {
+	controlFileName string
+}
+
+type RunsControlCommand struct {
+	values       *RunsControlCmdValues
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors
+// ------------------------------------------------------------------------------------------------
+func NewRunsControlCommand(runsCommand spi.GalasaCommand) (spi.GalasaCommand, error) {
+	cmd := new(RunsControlCommand)
+	err := cmd.init(runsCommand)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsControlCommand) Name() string {
+	return COMMAND_NAME_RUNS_CONTROL
+}
+
+func (cmd *RunsControlCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsControlCommand) Values() interface{} {
+	return cmd.values
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsControlCommand) init(runsCommand spi.GalasaCommand) error {
+	var err error
+	cmd.values = &RunsControlCmdValues{}
+	cmd.cobraCommand, err = cmd.createRunsControlCobraCmd(runsCommand)
+	return err
+}
+
+func (cmd *RunsControlCommand) createRunsControlCobraCmd(runsCommand spi.GalasaCommand) (*cobra.Command, error) {
+
+	var err error
+
+	runsControlCmd := &cobra.Command{
+		Use:     "control",
+		Short:   "control a runs submit command while it is running",
+		Long:    "Pause, resume, change the throttle of, list the state of, or cancel test runs of a 'runs submit' command which was started with the --controlfile flag",
+		Args:    cobra.NoArgs,
+		Aliases: []string{COMMAND_NAME_RUNS_CONTROL},
+	}
+
+	runsControlCmd.PersistentFlags().StringVar(&cmd.values.controlFileName, "controlfile", "",
+		"the control file written by the 'runs submit' command to control, using its --controlfile flag")
+
+	runsControlCmd.MarkPersistentFlagRequired("controlfile")
+
+	runsCommand.CobraCommand().AddCommand(runsControlCmd)
+
+	return runsControlCmd, err
+}
+
+// executeRunsControlAction - Sends one request to the 'runs submit' command named in the control file.
+func executeRunsControlAction(
+	factory spi.Factory,
+	runsControlValues *RunsControlCmdValues,
+	commsFlagSetValues *CommsFlagSetValues,
+	description string,
+	action func(controlFileName string, fileSystem spi.FileSystem, console spi.Console) error,
+) error {
+	executionFunc := func() error {
+		commsFlagSetValues.isCapturingLogs = true
+
+		log.Println("Galasa CLI - " + description)
+
+		fileSystem := factory.GetFileSystem()
+		controlFileName, err := files.TildaExpansion(fileSystem, runsControlValues.controlFileName)
+		if err == nil {
+			err = action(controlFileName, fileSystem, factory.GetStdOutConsole())
+		}
+		return err
+	}
+	return utils.CaptureExecutionLogs(factory, commsFlagSetValues.logFileName, executionFunc)
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"github.com/galasa-dev/cli/pkg/runs"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/spf13/cobra"
+)
+
+// Objective: Allow the user to do this:
+//    runs control cancel --controlfile my.control --name U123
+// // to cancel test runs of a running 'runs submit' command, or stop it submitting some of its tests.
+
+type RunsControlCancelCmdValues struct {
+	names []string
+}
+
+type RunsControlCancelCommand struct {
+	values       *RunsControlCancelCmdValues
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors
+// ------------------------------------------------------------------------------------------------
+func NewRunsControlCancelCommand(factory spi.Factory, runsControlCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) (spi.GalasaCommand, error) {
+	cmd := new(RunsControlCancelCommand)
+	err := cmd.init(factory, runsControlCommand, commsFlagSet)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsControlCancelCommand) Name() string {
+	return COMMAND_NAME_RUNS_CONTROL_CANCEL
+}
+
+func (cmd *RunsControlCancelCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsControlCancelCommand) Values() interface{} {
+	return cmd.values
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsControlCancelCommand) init(factory spi.Factory, runsControlCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+	var err error
+	cmd.values = &RunsControlCancelCmdValues{}
+	cmd.cobraCommand, err = cmd.createCobraCmd(factory, runsControlCommand, commsFlagSet.Values().(*CommsFlagSetValues))
+	return err
+}
+
+func (cmd *RunsControlCancelCommand) createCobraCmd(
+	factory spi.Factory,
+	runsControlCommand spi.GalasaCommand,
+	commsFlagSetValues *CommsFlagSetValues,
+) (*cobra.Command, error) {
+
+	var err error
+
+	runsControlValues := runsControlCommand.Values().(*RunsControlCmdValues)
+	runsControlCancelCmd := &cobra.Command{
+		Use:     "cancel",
+		Short:   "cancel test runs of a runs submit command",
+		Long:    "Cancels test runs which a running 'runs submit' command has in progress, or stops it submitting tests which are waiting to be submitted. The reports of the submission record that they were cancelled by 'runs control'.",
+		Args:    cobra.NoArgs,
+		Aliases: []string{COMMAND_NAME_RUNS_CONTROL_CANCEL},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return executeRunsControlAction(factory, runsControlValues, commsFlagSetValues,
+				"Cancel test runs of the submission of tests",
+				func(controlFileName string, fileSystem spi.FileSystem, console spi.Console) error {
+					return runs.CancelSubmissionRuns(controlFileName, cmd.values.names, fileSystem, console)
+				})
+		},
+	}
+
+	runsControlCancelCmd.PersistentFlags().StringSliceVar(&cmd.values.names, "name", make([]string, 0),
+		"the name of a test run in progress to cancel, or the bundle/class name of a test waiting to be submitted which should not be. "+
+			"Can be used more than once, or with a comma-separated list.")
+
+	runsControlCancelCmd.MarkPersistentFlagRequired("name")
+
+	runsControlCommand.CobraCommand().AddCommand(runsControlCancelCmd)
+
+	return runsControlCancelCmd, err
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunsControlCancelCommandInCommandCollection(t *testing.T) {
+
+	factory := utils.NewMockFactory()
+	commands, _ := NewCommandCollection(factory)
+
+	cmd, err := commands.GetCommand(COMMAND_NAME_RUNS_CONTROL_CANCEL)
+	assert.Nil(t, err)
+
+	assert.Equal(t, COMMAND_NAME_RUNS_CONTROL_CANCEL, cmd.Name())
+	assert.NotNil(t, cmd.CobraCommand())
+}
+
+func TestRunsControlCancelHelpFlagSetCorrectly(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "control", "cancel", "--help"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("Displays the options for the 'runs control cancel' command.", "", factory, t)
+}
+
+func TestRunsControlCancelWithoutControlFileReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "control", "cancel", "--name", "U123"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: required flag(s) \"controlfile\" not set", factory, t)
+}
+
+func TestRunsControlCancelWithoutNameReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "control", "cancel", "--controlfile", "my.control"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: required flag(s) \"name\" not set", factory, t)
+}
+
+func TestRunsControlCancelNameFlagCanBeUsedMoreThanOnce(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_CONTROL_CANCEL, factory, t)
+
+	var args []string = []string{"runs", "control", "cancel", "--controlfile", "my.control",
+		"--name", "U123", "--name", "myBundle/myClass1,myBundle/myClass2"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, []string{"U123", "myBundle/myClass1", "myBundle/myClass2"}, cmd.Values().(*RunsControlCancelCmdValues).names)
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"github.com/galasa-dev/cli/pkg/runs"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/spf13/cobra"
+)
+
+// Objective: Allow the user to do this:
+//    runs control list --controlfile my.control
+// // to see the state of each test in a running 'runs submit' command.
+
+type RunsControlListCommand struct {
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors
+// ------------------------------------------------------------------------------------------------
+func NewRunsControlListCommand(factory spi.Factory, runsControlCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) (spi.GalasaCommand, error) {
+	cmd := new(RunsControlListCommand)
+	err := cmd.init(factory, runsControlCommand, commsFlagSet)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsControlListCommand) Name() string {
+	return COMMAND_NAME_RUNS_CONTROL_LIST
+}
+
+func (cmd *RunsControlListCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsControlListCommand) Values() interface{} {
+	return nil
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsControlListCommand) init(factory spi.Factory, runsControlCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+	var err error
+	cmd.cobraCommand, err = cmd.createCobraCmd(factory, runsControlCommand, commsFlagSet.Values().(*CommsFlagSetValues))
+	return err
+}
+
+func (cmd *RunsControlListCommand) createCobraCmd(
+	factory spi.Factory,
+	runsControlCommand spi.GalasaCommand,
+	commsFlagSetValues *CommsFlagSetValues,
+) (*cobra.Command, error) {
+
+	var err error
+
+	runsControlValues := runsControlCommand.Values().(*RunsControlCmdValues)
+	runsControlListCmd := &cobra.Command{
+		Use:     "list",
+		Short:   "list the state of the tests of a runs submit command",
+		Long:    "Lists the tests of a running 'runs submit' command, whether they are waiting to be submitted, in progress, finished or lost, along with its throttle and whether it is paused.",
+		Args:    cobra.NoArgs,
+		Aliases: []string{COMMAND_NAME_RUNS_CONTROL_LIST},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return executeRunsControlAction(factory, runsControlValues, commsFlagSetValues,
+				"List the state of the submission of tests",
+				func(controlFileName string, fileSystem spi.FileSystem, console spi.Console) error {
+					return runs.ListSubmission(controlFileName, fileSystem, console)
+				})
+		},
+	}
+
+	runsControlCommand.CobraCommand().AddCommand(runsControlListCmd)
+
+	return runsControlListCmd, err
+}
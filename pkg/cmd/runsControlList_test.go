@@ -0,0 +1,57 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunsControlListCommandInCommandCollection(t *testing.T) {
+
+	factory := utils.NewMockFactory()
+	commands, _ := NewCommandCollection(factory)
+
+	cmd, err := commands.GetCommand(COMMAND_NAME_RUNS_CONTROL_LIST)
+	assert.Nil(t, err)
+
+	assert.Equal(t, COMMAND_NAME_RUNS_CONTROL_LIST, cmd.Name())
+	assert.NotNil(t, cmd.CobraCommand())
+}
+
+func TestRunsControlListHelpFlagSetCorrectly(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "control", "list", "--help"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("Displays the options for the 'runs control list' command.", "", factory, t)
+}
+
+func TestRunsControlListWithoutControlFileReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "control", "list"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: required flag(s) \"controlfile\" not set", factory, t)
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"github.com/galasa-dev/cli/pkg/runs"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/spf13/cobra"
+)
+
+// Objective: Allow the user to do this:
+//    runs control pause --controlfile my.control
+// // to stop a running 'runs submit' command submitting any more tests until it is resumed.
+
+type RunsControlPauseCommand struct {
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors
+// ------------------------------------------------------------------------------------------------
+func NewRunsControlPauseCommand(factory spi.Factory, runsControlCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) (spi.GalasaCommand, error) {
+	cmd := new(RunsControlPauseCommand)
+	err := cmd.init(factory, runsControlCommand, commsFlagSet)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsControlPauseCommand) Name() string {
+	return COMMAND_NAME_RUNS_CONTROL_PAUSE
+}
+
+func (cmd *RunsControlPauseCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsControlPauseCommand) Values() interface{} {
+	return nil
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsControlPauseCommand) init(factory spi.Factory, runsControlCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+	var err error
+	cmd.cobraCommand, err = cmd.createCobraCmd(factory, runsControlCommand, commsFlagSet.Values().(*CommsFlagSetValues))
+	return err
+}
+
+func (cmd *RunsControlPauseCommand) createCobraCmd(
+	factory spi.Factory,
+	runsControlCommand spi.GalasaCommand,
+	commsFlagSetValues *CommsFlagSetValues,
+) (*cobra.Command, error) {
+
+	var err error
+
+	runsControlValues := runsControlCommand.Values().(*RunsControlCmdValues)
+	runsControlPauseCmd := &cobra.Command{
+		Use:     "pause",
+		Short:   "stop a runs submit command submitting more tests",
+		Long:    "Stops a running 'runs submit' command submitting any more tests until it is resumed. Test runs already submitted carry on running.",
+		Args:    cobra.NoArgs,
+		Aliases: []string{COMMAND_NAME_RUNS_CONTROL_PAUSE},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return executeRunsControlAction(factory, runsControlValues, commsFlagSetValues,
+				"Pause the submission of tests",
+				func(controlFileName string, fileSystem spi.FileSystem, console spi.Console) error {
+					return runs.PauseSubmission(controlFileName, fileSystem, console)
+				})
+		},
+	}
+
+	runsControlCommand.CobraCommand().AddCommand(runsControlPauseCmd)
+
+	return runsControlPauseCmd, err
+}
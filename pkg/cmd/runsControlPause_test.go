@@ -0,0 +1,71 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunsControlPauseCommandInCommandCollection(t *testing.T) {
+
+	factory := utils.NewMockFactory()
+	commands, _ := NewCommandCollection(factory)
+
+	cmd, err := commands.GetCommand(COMMAND_NAME_RUNS_CONTROL_PAUSE)
+	assert.Nil(t, err)
+
+	assert.Equal(t, COMMAND_NAME_RUNS_CONTROL_PAUSE, cmd.Name())
+	assert.NotNil(t, cmd.CobraCommand())
+}
+
+func TestRunsControlPauseHelpFlagSetCorrectly(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "control", "pause", "--help"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("Displays the options for the 'runs control pause' command.", "", factory, t)
+}
+
+func TestRunsControlPauseWithoutControlFileReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "control", "pause"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: required flag(s) \"controlfile\" not set", factory, t)
+}
+
+func TestRunsControlPauseWithMissingControlFileReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "control", "pause", "--controlfile", "my.control"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1245E: Failed to read the control file 'my.control'.")
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"github.com/galasa-dev/cli/pkg/runs"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/spf13/cobra"
+)
+
+// Objective: Allow the user to do this:
+//    runs control resume --controlfile my.control
+// // to let a paused 'runs submit' command carry on submitting tests.
+
+type RunsControlResumeCommand struct {
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors
+// ------------------------------------------------------------------------------------------------
+func NewRunsControlResumeCommand(factory spi.Factory, runsControlCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) (spi.GalasaCommand, error) {
+	cmd := new(RunsControlResumeCommand)
+	err := cmd.init(factory, runsControlCommand, commsFlagSet)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsControlResumeCommand) Name() string {
+	return COMMAND_NAME_RUNS_CONTROL_RESUME
+}
+
+func (cmd *RunsControlResumeCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsControlResumeCommand) Values() interface{} {
+	return nil
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsControlResumeCommand) init(factory spi.Factory, runsControlCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+	var err error
+	cmd.cobraCommand, err = cmd.createCobraCmd(factory, runsControlCommand, commsFlagSet.Values().(*CommsFlagSetValues))
+	return err
+}
+
+func (cmd *RunsControlResumeCommand) createCobraCmd(
+	factory spi.Factory,
+	runsControlCommand spi.GalasaCommand,
+	commsFlagSetValues *CommsFlagSetValues,
+) (*cobra.Command, error) {
+
+	var err error
+
+	runsControlValues := runsControlCommand.Values().(*RunsControlCmdValues)
+	runsControlResumeCmd := &cobra.Command{
+		Use:     "resume",
+		Short:   "let a paused runs submit command submit tests again",
+		Long:    "Lets a 'runs submit' command which was paused carry on submitting tests.",
+		Args:    cobra.NoArgs,
+		Aliases: []string{COMMAND_NAME_RUNS_CONTROL_RESUME},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return executeRunsControlAction(factory, runsControlValues, commsFlagSetValues,
+				"Resume the submission of tests",
+				func(controlFileName string, fileSystem spi.FileSystem, console spi.Console) error {
+					return runs.ResumeSubmission(controlFileName, fileSystem, console)
+				})
+		},
+	}
+
+	runsControlCommand.CobraCommand().AddCommand(runsControlResumeCmd)
+
+	return runsControlResumeCmd, err
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"github.com/galasa-dev/cli/pkg/runs"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/spf13/cobra"
+)
+
+// Objective: Allow the user to do this:
+//    runs control throttle --controlfile my.control --throttle 5
+// // to change how many tests a running 'runs submit' command lets run at once.
+
+type RunsControlThrottleCmdValues struct {
+	throttle int
+}
+
+type RunsControlThrottleCommand struct {
+	values       *RunsControlThrottleCmdValues
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors
+// ------------------------------------------------------------------------------------------------
+func NewRunsControlThrottleCommand(factory spi.Factory, runsControlCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) (spi.GalasaCommand, error) {
+	cmd := new(RunsControlThrottleCommand)
+	err := cmd.init(factory, runsControlCommand, commsFlagSet)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsControlThrottleCommand) Name() string {
+	return COMMAND_NAME_RUNS_CONTROL_THROTTLE
+}
+
+func (cmd *RunsControlThrottleCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsControlThrottleCommand) Values() interface{} {
+	return cmd.values
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsControlThrottleCommand) init(factory spi.Factory, runsControlCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+	var err error
+	cmd.values = &RunsControlThrottleCmdValues{}
+	cmd.cobraCommand, err = cmd.createCobraCmd(factory, runsControlCommand, commsFlagSet.Values().(*CommsFlagSetValues))
+	return err
+}
+
+func (cmd *RunsControlThrottleCommand) createCobraCmd(
+	factory spi.Factory,
+	runsControlCommand spi.GalasaCommand,
+	commsFlagSetValues *CommsFlagSetValues,
+) (*cobra.Command, error) {
+
+	var err error
+
+	runsControlValues := runsControlCommand.Values().(*RunsControlCmdValues)
+	runsControlThrottleCmd := &cobra.Command{
+		Use:     "throttle",
+		Short:   "change the throttle of a runs submit command",
+		Long:    "Changes how many test runs a running 'runs submit' command lets run at the same time. Test runs already in progress are not affected.",
+		Args:    cobra.NoArgs,
+		Aliases: []string{COMMAND_NAME_RUNS_CONTROL_THROTTLE},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return executeRunsControlAction(factory, runsControlValues, commsFlagSetValues,
+				"Change the throttle of the submission of tests",
+				func(controlFileName string, fileSystem spi.FileSystem, console spi.Console) error {
+					return runs.SetSubmissionThrottle(controlFileName, cmd.values.throttle, fileSystem, console)
+				})
+		},
+	}
+
+	runsControlThrottleCmd.PersistentFlags().IntVar(&cmd.values.throttle, "throttle", 0,
+		"how many test runs may be running at the same time. Must be 1 or more.")
+
+	runsControlThrottleCmd.MarkPersistentFlagRequired("throttle")
+
+	runsControlCommand.CobraCommand().AddCommand(runsControlThrottleCmd)
+
+	return runsControlThrottleCmd, err
+}
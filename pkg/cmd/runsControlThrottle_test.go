@@ -0,0 +1,95 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunsControlThrottleCommandInCommandCollection(t *testing.T) {
+
+	factory := utils.NewMockFactory()
+	commands, _ := NewCommandCollection(factory)
+
+	cmd, err := commands.GetCommand(COMMAND_NAME_RUNS_CONTROL_THROTTLE)
+	assert.Nil(t, err)
+
+	assert.Equal(t, COMMAND_NAME_RUNS_CONTROL_THROTTLE, cmd.Name())
+	assert.NotNil(t, cmd.CobraCommand())
+}
+
+func TestRunsControlThrottleHelpFlagSetCorrectly(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "control", "throttle", "--help"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("Displays the options for the 'runs control throttle' command.", "", factory, t)
+}
+
+func TestRunsControlThrottleWithoutControlFileReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "control", "throttle", "--throttle", "5"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: required flag(s) \"controlfile\" not set", factory, t)
+}
+
+func TestRunsControlThrottleWithoutThrottleReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "control", "throttle", "--controlfile", "my.control"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: required flag(s) \"throttle\" not set", factory, t)
+}
+
+func TestRunsControlThrottleFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_CONTROL_THROTTLE, factory, t)
+
+	var args []string = []string{"runs", "control", "throttle", "--controlfile", "my.control", "--throttle", "5"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, 5, cmd.Values().(*RunsControlThrottleCmdValues).throttle)
+
+	parentCmd, _ := commandCollection.GetCommand(COMMAND_NAME_RUNS_CONTROL)
+	assert.Equal(t, "my.control", parentCmd.Values().(*RunsControlCmdValues).controlFileName)
+}
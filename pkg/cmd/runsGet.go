@@ -12,6 +12,7 @@ import (
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/runs"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
 	"github.com/galasa-dev/cli/pkg/utils"
 	"github.com/spf13/cobra"
 )
@@ -29,6 +30,9 @@ type RunsGetCmdValues struct {
 	result             string
 	isActiveRuns       bool
 	group              string
+	tableFlagValues    TableFlagValues
+	filters            runs.RunsGetFilters
+	shouldStream       bool
 }
 
 type RunsGetCommand struct {
@@ -92,6 +96,7 @@ func (cmd *RunsGetCommand) createCobraCommand(
 
 	units := runs.GetTimeUnitsForErrorMessage()
 	formatters := runs.GetFormatterNamesString(runs.CreateFormatters())
+	streamingFormatters := runs.GetStreamingFormatterNamesString(runs.CreateFormatters())
 	runsGetCobraCmd.PersistentFlags().StringVar(&cmd.values.runName, "name", "", "the name of the test run we want information about."+
 		" Cannot be used in conjunction with --requestor, --result or --active flags")
 	runsGetCobraCmd.PersistentFlags().StringVar(&cmd.values.group, "group", "", "the name of the group to return tests under that group."+
@@ -100,7 +105,9 @@ func (cmd *RunsGetCommand) createCobraCommand(
 		" made up of an integer and a time-unit qualifier. Supported time-units are "+units+". If missing, the TO part is defaulted to '0h'. Examples: '--age 1d',"+
 		" '--age 6h:1h' (list test runs which happened from 6 hours ago to 1 hour ago)."+
 		" The TO part must be a smaller time-span than the FROM part.")
-	runsGetCobraCmd.PersistentFlags().StringVar(&cmd.values.outputFormatString, "format", "summary", "output format for the data returned. Supported formats are: "+formatters+".")
+	runsGetCobraCmd.PersistentFlags().StringVar(&cmd.values.outputFormatString, "format", "summary", "output format for the data returned. Supported formats are: "+formatters+". "+
+		templateformatter.TEMPLATE_FORMATS_HELP)
+	addTableFlags(runsGetCobraCmd.PersistentFlags(), &cmd.values.tableFlagValues)
 	runsGetCobraCmd.PersistentFlags().StringVar(&cmd.values.requestor, "requestor", "", "the requestor of the test run we want information about."+
 		" Cannot be used in conjunction with --name flag.")
 	runsGetCobraCmd.PersistentFlags().StringVar(&cmd.values.result, "result", "", "A filter on the test runs we want information about. Optional. Default is to display test runs with any result. Case insensitive. Value can be a single value or a comma-separated list. For example \"--result Failed,Ignored,EnvFail\"."+
@@ -108,11 +115,30 @@ func (cmd *RunsGetCommand) createCobraCommand(
 	runsGetCobraCmd.PersistentFlags().BoolVar(&cmd.values.isActiveRuns, "active", false, "parameter to retrieve runs that have not finished yet."+
 		" Cannot be used in conjunction with --name or --result flag.")
 
+	runsGetCobraCmd.PersistentFlags().StringVar(&cmd.values.filters.Bundle, "bundle", "", "Optional. The name of the bundle holding the tests of the runs we want information about.")
+	runsGetCobraCmd.PersistentFlags().StringVar(&cmd.values.filters.Test, "test", "", "Optional. The full name of the test class of the runs we want information about,"+
+		" such as 'dev.galasa.example.MyTest'. Use '*' as a wildcard to match the full or the short name of the test class. For example \"--test '*.MyTest'\" or \"--test 'dev.galasa.example.*'\".")
+	runsGetCobraCmd.PersistentFlags().StringVar(&cmd.values.filters.Status, "status", "", "Optional. A filter on the status of the test runs we want information about. Value can be a single value or a comma-separated list."+
+		" For example \"--status queued,running\". Cannot be used in conjunction with --active flag.")
+	runsGetCobraCmd.PersistentFlags().StringVar(&cmd.values.filters.From, "from", "", "Optional. Get the test runs which were submitted at or after this time. Give a date such as '2026-10-01',"+
+		" or a date and time such as '2026-10-01T09:30Z'. Times without a time zone are in UTC. Cannot be used in conjunction with --age flag.")
+	runsGetCobraCmd.PersistentFlags().StringVar(&cmd.values.filters.To, "to", "", "Optional. Get the test runs which were submitted before this time, in the same formats as --from."+
+		" Cannot be used in conjunction with --age flag.")
+	runsGetCobraCmd.PersistentFlags().StringVar(&cmd.values.filters.Sort, "sort", "", "Optional. The field to sort the test runs on, before any --limit is applied. Add ':desc' to sort in descending order."+
+		" For example \"--sort end-time:desc\". Default is to sort on the time the runs were submitted, newest first.")
+	runsGetCobraCmd.PersistentFlags().IntVar(&cmd.values.filters.Limit, "limit", 0, "Optional. The most test runs to get. Default is to get every test run which matches.")
+	runsGetCobraCmd.PersistentFlags().BoolVar(&cmd.values.shouldStream, "stream", false, "Optional. Write the test runs a page at a time, as each page is got from the ecosystem,"+
+		" rather than once every test run has been got. Streamed test runs are not grouped by result."+
+		" The formats which can be streamed are: "+streamingFormatters+". Cannot be used in conjunction with --sort-by, or with a --sort field other than 'submitted-time'.")
+
 	runsGetCobraCmd.MarkFlagsMutuallyExclusive("name", "requestor")
 	runsGetCobraCmd.MarkFlagsMutuallyExclusive("name", "result")
 	runsGetCobraCmd.MarkFlagsMutuallyExclusive("name", "active")
 	runsGetCobraCmd.MarkFlagsMutuallyExclusive("result", "active")
 	runsGetCobraCmd.MarkFlagsMutuallyExclusive("group", "name")
+	runsGetCobraCmd.MarkFlagsMutuallyExclusive("age", "from")
+	runsGetCobraCmd.MarkFlagsMutuallyExclusive("age", "to")
+	runsGetCobraCmd.MarkFlagsMutuallyExclusive("active", "status")
 
 	runsCommand.CobraCommand().AddCommand(runsGetCobraCmd)
 
@@ -160,6 +186,12 @@ func (cmd *RunsGetCommand) executeRunsGet(
 			var apiClient *galasaapi.APIClient
 			apiClient, err = authenticator.GetAuthenticatedAPIClient()
 
+			var outputFormat string
+			if err == nil {
+				// A 'template-file=<path>' format is swapped for the go template held in that file.
+				outputFormat, err = templateformatter.ResolveTemplateFile(fileSystem, cmd.values.outputFormatString)
+			}
+
 			if err == nil {
 				// Call to process the command in a unit-testable way.
 				err = runs.GetRuns(
@@ -168,8 +200,11 @@ func (cmd *RunsGetCommand) executeRunsGet(
 					cmd.values.requestor,
 					cmd.values.result,
 					cmd.values.isActiveRuns,
-					cmd.values.outputFormatString,
+					outputFormat,
+					cmd.values.tableFlagValues.toTableOptions(),
 					cmd.values.group,
+					&cmd.values.filters,
+					cmd.values.shouldStream,
 					timeService,
 					console,
 					apiServerUrl,
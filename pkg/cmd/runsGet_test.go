@@ -192,6 +192,28 @@ func TestRunsGetFormatFlagReturnsOk(t *testing.T) {
 	assert.Contains(t, cmd.Values().(*RunsGetCmdValues).outputFormatString, "yaml")
 }
 
+func TestRunsGetTableFlagsReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_GET, factory, t)
+
+	var args []string = []string{"runs", "get", "--format", "csv", "--columns", "name,result", "--sort-by", "name:desc", "--no-headers"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw was reasonable
+	checkOutput("", "", factory, t)
+
+	tableFlagValues := cmd.Values().(*RunsGetCmdValues).tableFlagValues
+	assert.Equal(t, []string{"name", "result"}, tableFlagValues.columns)
+	assert.Equal(t, "name:desc", tableFlagValues.sortBy)
+	assert.True(t, tableFlagValues.isNoHeaders)
+}
+
 func TestRunsGetMultipleNameOverridesToLast(t *testing.T) {
 	// Given...
 	factory := utils.NewMockFactory()
@@ -333,3 +355,84 @@ func TestRunsGetGroupRunNameMutuallyExclusive(t *testing.T) {
 	// Check what the user saw is reasonable.
 	checkOutput("", "Error: if any flags in the group [group name] are set none of the others can be; [group name] were all set", factory, t)
 }
+
+func TestRunsGetFilterFlagsReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_GET, factory, t)
+
+	var args []string = []string{"runs", "get", "--bundle", "my.bundle", "--test", "*.MyTest", "--status", "queued,running",
+		"--from", "2026-10-01T00:00Z", "--to", "2026-10-02", "--sort", "end-time:desc", "--limit", "20"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw was reasonable
+	checkOutput("", "", factory, t)
+
+	filters := cmd.Values().(*RunsGetCmdValues).filters
+	assert.Equal(t, "my.bundle", filters.Bundle)
+	assert.Equal(t, "*.MyTest", filters.Test)
+	assert.Equal(t, "queued,running", filters.Status)
+	assert.Equal(t, "2026-10-01T00:00Z", filters.From)
+	assert.Equal(t, "2026-10-02", filters.To)
+	assert.Equal(t, "end-time:desc", filters.Sort)
+	assert.Equal(t, 20, filters.Limit)
+}
+
+func TestRunsGetStreamFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_GET, factory, t)
+
+	var args []string = []string{"runs", "get", "--age", "30d", "--format", "jsonl", "--stream"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw was reasonable
+	checkOutput("", "", factory, t)
+
+	assert.Contains(t, cmd.Values().(*RunsGetCmdValues).outputFormatString, "jsonl")
+	assert.True(t, cmd.Values().(*RunsGetCmdValues).shouldStream)
+}
+
+func TestRunsGetAgeFromMutuallyExclusive(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "get", "--age", "1d", "--from", "2026-10-01"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "if any flags in the group [age from] are set none of the others can be; [age from] were all set")
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: if any flags in the group [age from] are set none of the others can be; [age from] were all set", factory, t)
+}
+
+func TestRunsGetActiveStatusMutuallyExclusive(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "get", "--status", "queued", "--active"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "if any flags in the group [active status] are set none of the others can be; [active status] were all set")
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: if any flags in the group [active status] are set none of the others can be; [active status] were all set", factory, t)
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/spf13/cobra"
+)
+
+// Objective: Allow the user to do this:
+//    runs portfolio show --portfolio my.yaml
+//    runs portfolio validate --portfolio my.yaml
+//    runs portfolio merge --from a.yaml,b.yaml --portfolio merged.yaml
+//    runs portfolio diff --from old.yaml --to new.yaml
+// to work with the portfolios written by 'runs prepare' and edited by hand.
+
+type RunsPortfolioCommand struct {
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors
+// ------------------------------------------------------------------------------------------------
+func NewRunsPortfolioCommand(runsCommand spi.GalasaCommand) (spi.GalasaCommand, error) {
+	cmd := new(RunsPortfolioCommand)
+	err := cmd.init(runsCommand)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsPortfolioCommand) Name() string {
+	return COMMAND_NAME_RUNS_PORTFOLIO
+}
+
+func (cmd *RunsPortfolioCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsPortfolioCommand) Values() interface{} {
+	return nil
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsPortfolioCommand) init(runsCommand spi.GalasaCommand) error {
+	var err error
+	cmd.cobraCommand, err = cmd.createRunsPortfolioCobraCmd(runsCommand)
+	return err
+}
+
+func (cmd *RunsPortfolioCommand) createRunsPortfolioCobraCmd(runsCommand spi.GalasaCommand) (*cobra.Command, error) {
+
+	var err error
+
+	runsPortfolioCmd := &cobra.Command{
+		Use:     "portfolio",
+		Short:   "show, validate, merge or compare portfolios",
+		Long:    "Show the classes of a portfolio, check a portfolio for problems, merge several portfolios into one, or list the differences between two portfolios",
+		Args:    cobra.NoArgs,
+		Aliases: []string{COMMAND_NAME_RUNS_PORTFOLIO},
+	}
+
+	runsCommand.CobraCommand().AddCommand(runsPortfolioCmd)
+
+	return runsPortfolioCmd, err
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"log"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/runs"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// Objective: Allow the user to do this:
+//    runs portfolio diff --from old.yaml --to new.yaml
+// to see which classes were added, removed or changed between two portfolios.
+
+type RunsPortfolioDiffCmdValues struct {
+	fromFilename string
+	toFilename   string
+}
+
+type RunsPortfolioDiffCommand struct {
+	values       *RunsPortfolioDiffCmdValues
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors
+// ------------------------------------------------------------------------------------------------
+func NewRunsPortfolioDiffCommand(factory spi.Factory, runsPortfolioCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) (spi.GalasaCommand, error) {
+	cmd := new(RunsPortfolioDiffCommand)
+	err := cmd.init(factory, runsPortfolioCommand, commsFlagSet)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsPortfolioDiffCommand) Name() string {
+	return COMMAND_NAME_RUNS_PORTFOLIO_DIFF
+}
+
+func (cmd *RunsPortfolioDiffCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsPortfolioDiffCommand) Values() interface{} {
+	return cmd.values
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsPortfolioDiffCommand) init(factory spi.Factory, runsPortfolioCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+	var err error
+	cmd.values = &RunsPortfolioDiffCmdValues{}
+	cmd.cobraCommand, err = cmd.createCobraCmd(factory, runsPortfolioCommand, commsFlagSet.Values().(*CommsFlagSetValues))
+	return err
+}
+
+func (cmd *RunsPortfolioDiffCommand) createCobraCmd(
+	factory spi.Factory,
+	runsPortfolioCommand spi.GalasaCommand,
+	commsFlagSetValues *CommsFlagSetValues,
+) (*cobra.Command, error) {
+
+	var err error
+
+	runsPortfolioDiffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "list the differences between two portfolios",
+		Long: "Lists the differences between two portfolios class by class. A class only in the --from portfolio is shown with a '-', " +
+			"a class only in the --to portfolio with a '+', and each setting of a class which has changed with a '~'.",
+		Args:    cobra.NoArgs,
+		Aliases: []string{COMMAND_NAME_RUNS_PORTFOLIO_DIFF},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.executeRunsPortfolioDiff(factory, commsFlagSetValues)
+		},
+	}
+
+	runsPortfolioDiffCmd.Flags().StringVar(&cmd.values.fromFilename, "from", "", "the portfolio to compare against")
+	runsPortfolioDiffCmd.Flags().StringVar(&cmd.values.toFilename, "to", "", "the portfolio to compare")
+
+	runsPortfolioDiffCmd.MarkFlagRequired("from")
+	runsPortfolioDiffCmd.MarkFlagRequired("to")
+
+	runsPortfolioCommand.CobraCommand().AddCommand(runsPortfolioDiffCmd)
+
+	return runsPortfolioDiffCmd, err
+}
+
+func (cmd *RunsPortfolioDiffCommand) executeRunsPortfolioDiff(factory spi.Factory, commsFlagSetValues *CommsFlagSetValues) error {
+	executionFunc := func() error {
+		commsFlagSetValues.isCapturingLogs = true
+
+		log.Println("Galasa CLI - Compare portfolios")
+
+		fileSystem := factory.GetFileSystem()
+		fromFilename, err := files.TildaExpansion(fileSystem, cmd.values.fromFilename)
+		if err == nil {
+			var toFilename string
+			toFilename, err = files.TildaExpansion(fileSystem, cmd.values.toFilename)
+			if err == nil {
+				err = runs.DiffPortfolios(fileSystem, fromFilename, toFilename, factory.GetStdOutConsole())
+			}
+		}
+		return err
+	}
+	return utils.CaptureExecutionLogs(factory, commsFlagSetValues.logFileName, executionFunc)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunsPortfolioDiffCommandInCommandCollection(t *testing.T) {
+
+	factory := utils.NewMockFactory()
+	commands, _ := NewCommandCollection(factory)
+
+	cmd, err := commands.GetCommand(COMMAND_NAME_RUNS_PORTFOLIO_DIFF)
+	assert.Nil(t, err)
+
+	assert.Equal(t, COMMAND_NAME_RUNS_PORTFOLIO_DIFF, cmd.Name())
+	assert.NotNil(t, cmd.Values())
+	assert.IsType(t, &RunsPortfolioDiffCmdValues{}, cmd.Values())
+	assert.NotNil(t, cmd.CobraCommand())
+}
+
+func TestRunsPortfolioDiffHelpFlagSetCorrectly(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "portfolio", "diff", "--help"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("Displays the options for the 'runs portfolio diff' command.", "", factory, t)
+}
+
+func TestRunsPortfolioDiffNoFlagsReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "portfolio", "diff"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: required flag(s) \"from\", \"to\" not set", factory, t)
+}
+
+func TestRunsPortfolioDiffAllFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_PORTFOLIO_DIFF, factory, t)
+
+	var args []string = []string{"runs", "portfolio", "diff", "--from", "old.yaml", "--to", "new.yaml"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	checkOutput("", "", factory, t)
+
+	values := cmd.Values().(*RunsPortfolioDiffCmdValues)
+	assert.Equal(t, "old.yaml", values.fromFilename)
+	assert.Equal(t, "new.yaml", values.toFilename)
+}
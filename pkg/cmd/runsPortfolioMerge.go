@@ -0,0 +1,133 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"log"
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/runs"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// Objective: Allow the user to do this:
+//    runs portfolio merge --from a.yaml,b.yaml --portfolio merged.yaml --onconflict last
+// to combine several portfolios into one.
+
+type RunsPortfolioMergeCmdValues struct {
+	fromFilenames     []string
+	portfolioFilename string
+	conflictRule      string
+}
+
+type RunsPortfolioMergeCommand struct {
+	values       *RunsPortfolioMergeCmdValues
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors
+// ------------------------------------------------------------------------------------------------
+func NewRunsPortfolioMergeCommand(factory spi.Factory, runsPortfolioCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) (spi.GalasaCommand, error) {
+	cmd := new(RunsPortfolioMergeCommand)
+	err := cmd.init(factory, runsPortfolioCommand, commsFlagSet)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsPortfolioMergeCommand) Name() string {
+	return COMMAND_NAME_RUNS_PORTFOLIO_MERGE
+}
+
+func (cmd *RunsPortfolioMergeCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsPortfolioMergeCommand) Values() interface{} {
+	return cmd.values
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsPortfolioMergeCommand) init(factory spi.Factory, runsPortfolioCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+	var err error
+	cmd.values = &RunsPortfolioMergeCmdValues{}
+	cmd.cobraCommand, err = cmd.createCobraCmd(factory, runsPortfolioCommand, commsFlagSet.Values().(*CommsFlagSetValues))
+	return err
+}
+
+func (cmd *RunsPortfolioMergeCommand) createCobraCmd(
+	factory spi.Factory,
+	runsPortfolioCommand spi.GalasaCommand,
+	commsFlagSetValues *CommsFlagSetValues,
+) (*cobra.Command, error) {
+
+	var err error
+
+	runsPortfolioMergeCmd := &cobra.Command{
+		Use:   "merge",
+		Short: "merge several portfolios into one",
+		Long: "Writes a portfolio holding the classes of each of the portfolios given, in the order they are given. " +
+			"A class in more than one of them is only listed once, with the overrides from all of them. " +
+			"Where they set a different stream, obr, scheduling or value for an override of the same class, " +
+			"the --onconflict flag decides whether that is an error, or the first or last value is used.",
+		Args:    cobra.NoArgs,
+		Aliases: []string{COMMAND_NAME_RUNS_PORTFOLIO_MERGE},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.executeRunsPortfolioMerge(factory, commsFlagSetValues)
+		},
+	}
+
+	runsPortfolioMergeCmd.Flags().StringSliceVar(&cmd.values.fromFilenames, "from", make([]string, 0),
+		"the portfolios to merge, separated by commas. The flag can also be used more than once.")
+	runsPortfolioMergeCmd.Flags().StringVarP(&cmd.values.portfolioFilename, "portfolio", "p", "", "the merged portfolio to write")
+	runsPortfolioMergeCmd.Flags().StringVar(&cmd.values.conflictRule, "onconflict", runs.DEFAULT_MERGE_CONFLICT_RULE,
+		"what to do when the portfolios set a class up differently. One of '"+
+			strings.Join([]string{runs.MERGE_CONFLICT_ERROR, runs.MERGE_CONFLICT_FIRST, runs.MERGE_CONFLICT_LAST}, "', '")+
+			"'. 'first' and 'last' use the value from the first or last portfolio to set it.")
+
+	runsPortfolioMergeCmd.MarkFlagRequired("from")
+	runsPortfolioMergeCmd.MarkFlagRequired("portfolio")
+
+	runsPortfolioCommand.CobraCommand().AddCommand(runsPortfolioMergeCmd)
+
+	return runsPortfolioMergeCmd, err
+}
+
+func (cmd *RunsPortfolioMergeCommand) executeRunsPortfolioMerge(factory spi.Factory, commsFlagSetValues *CommsFlagSetValues) error {
+	executionFunc := func() error {
+		var err error
+		commsFlagSetValues.isCapturingLogs = true
+
+		log.Println("Galasa CLI - Merge portfolios")
+
+		fileSystem := factory.GetFileSystem()
+		fromFilenames := make([]string, 0, len(cmd.values.fromFilenames))
+		for _, fromFilename := range cmd.values.fromFilenames {
+			if err == nil {
+				fromFilename, err = files.TildaExpansion(fileSystem, fromFilename)
+				fromFilenames = append(fromFilenames, fromFilename)
+			}
+		}
+
+		var portfolioFilename string
+		if err == nil {
+			portfolioFilename, err = files.TildaExpansion(fileSystem, cmd.values.portfolioFilename)
+		}
+
+		if err == nil {
+			err = runs.MergePortfolios(fileSystem, fromFilenames, portfolioFilename, cmd.values.conflictRule, factory.GetStdOutConsole())
+		}
+		return err
+	}
+	return utils.CaptureExecutionLogs(factory, commsFlagSetValues.logFileName, executionFunc)
+}
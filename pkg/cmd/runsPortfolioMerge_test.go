@@ -0,0 +1,96 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunsPortfolioMergeCommandInCommandCollection(t *testing.T) {
+
+	factory := utils.NewMockFactory()
+	commands, _ := NewCommandCollection(factory)
+
+	cmd, err := commands.GetCommand(COMMAND_NAME_RUNS_PORTFOLIO_MERGE)
+	assert.Nil(t, err)
+
+	assert.Equal(t, COMMAND_NAME_RUNS_PORTFOLIO_MERGE, cmd.Name())
+	assert.NotNil(t, cmd.Values())
+	assert.IsType(t, &RunsPortfolioMergeCmdValues{}, cmd.Values())
+	assert.NotNil(t, cmd.CobraCommand())
+}
+
+func TestRunsPortfolioMergeHelpFlagSetCorrectly(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "portfolio", "merge", "--help"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("Displays the options for the 'runs portfolio merge' command.", "", factory, t)
+}
+
+func TestRunsPortfolioMergeNoFlagsReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "portfolio", "merge"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: required flag(s) \"from\", \"portfolio\" not set", factory, t)
+}
+
+func TestRunsPortfolioMergeAllFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_PORTFOLIO_MERGE, factory, t)
+
+	var args []string = []string{"runs", "portfolio", "merge", "--from", "a.yaml,b.yaml", "--from", "c.yaml",
+		"--portfolio", "merged.yaml", "--onconflict", "last"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	checkOutput("", "", factory, t)
+
+	values := cmd.Values().(*RunsPortfolioMergeCmdValues)
+	assert.Equal(t, []string{"a.yaml", "b.yaml", "c.yaml"}, values.fromFilenames)
+	assert.Equal(t, "merged.yaml", values.portfolioFilename)
+	assert.Equal(t, "last", values.conflictRule)
+}
+
+func TestRunsPortfolioMergeFailsOnConflictByDefault(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_PORTFOLIO_MERGE, factory, t)
+
+	var args []string = []string{"runs", "portfolio", "merge", "--from", "a.yaml,b.yaml", "--portfolio", "merged.yaml"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "error", cmd.Values().(*RunsPortfolioMergeCmdValues).conflictRule)
+}
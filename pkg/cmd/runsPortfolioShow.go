@@ -0,0 +1,106 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"log"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/runs"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// Objective: Allow the user to do this:
+//    runs portfolio show --portfolio my.yaml
+// to see the classes in a portfolio as a table.
+
+type RunsPortfolioShowCmdValues struct {
+	portfolioFilename string
+}
+
+type RunsPortfolioShowCommand struct {
+	values       *RunsPortfolioShowCmdValues
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors
+// ------------------------------------------------------------------------------------------------
+func NewRunsPortfolioShowCommand(factory spi.Factory, runsPortfolioCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) (spi.GalasaCommand, error) {
+	cmd := new(RunsPortfolioShowCommand)
+	err := cmd.init(factory, runsPortfolioCommand, commsFlagSet)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsPortfolioShowCommand) Name() string {
+	return COMMAND_NAME_RUNS_PORTFOLIO_SHOW
+}
+
+func (cmd *RunsPortfolioShowCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsPortfolioShowCommand) Values() interface{} {
+	return cmd.values
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsPortfolioShowCommand) init(factory spi.Factory, runsPortfolioCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+	var err error
+	cmd.values = &RunsPortfolioShowCmdValues{}
+	cmd.cobraCommand, err = cmd.createCobraCmd(factory, runsPortfolioCommand, commsFlagSet.Values().(*CommsFlagSetValues))
+	return err
+}
+
+func (cmd *RunsPortfolioShowCommand) createCobraCmd(
+	factory spi.Factory,
+	runsPortfolioCommand spi.GalasaCommand,
+	commsFlagSetValues *CommsFlagSetValues,
+) (*cobra.Command, error) {
+
+	var err error
+
+	runsPortfolioShowCmd := &cobra.Command{
+		Use:     "show",
+		Short:   "show the classes in a portfolio",
+		Long:    "Shows the classes in a portfolio as a table, with the stream, obr and overrides of each, and how they are scheduled if it is a v1beta portfolio.",
+		Args:    cobra.NoArgs,
+		Aliases: []string{COMMAND_NAME_RUNS_PORTFOLIO_SHOW},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.executeRunsPortfolioShow(factory, commsFlagSetValues)
+		},
+	}
+
+	runsPortfolioShowCmd.Flags().StringVarP(&cmd.values.portfolioFilename, "portfolio", "p", "", "the portfolio to show")
+	runsPortfolioShowCmd.MarkFlagRequired("portfolio")
+
+	runsPortfolioCommand.CobraCommand().AddCommand(runsPortfolioShowCmd)
+
+	return runsPortfolioShowCmd, err
+}
+
+func (cmd *RunsPortfolioShowCommand) executeRunsPortfolioShow(factory spi.Factory, commsFlagSetValues *CommsFlagSetValues) error {
+	executionFunc := func() error {
+		commsFlagSetValues.isCapturingLogs = true
+
+		log.Println("Galasa CLI - Show a portfolio")
+
+		fileSystem := factory.GetFileSystem()
+		portfolioFilename, err := files.TildaExpansion(fileSystem, cmd.values.portfolioFilename)
+		if err == nil {
+			err = runs.ShowPortfolio(fileSystem, portfolioFilename, factory.GetStdOutConsole())
+		}
+		return err
+	}
+	return utils.CaptureExecutionLogs(factory, commsFlagSetValues.logFileName, executionFunc)
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunsPortfolioShowCommandInCommandCollection(t *testing.T) {
+
+	factory := utils.NewMockFactory()
+	commands, _ := NewCommandCollection(factory)
+
+	cmd, err := commands.GetCommand(COMMAND_NAME_RUNS_PORTFOLIO_SHOW)
+	assert.Nil(t, err)
+
+	assert.Equal(t, COMMAND_NAME_RUNS_PORTFOLIO_SHOW, cmd.Name())
+	assert.NotNil(t, cmd.Values())
+	assert.IsType(t, &RunsPortfolioShowCmdValues{}, cmd.Values())
+	assert.NotNil(t, cmd.CobraCommand())
+}
+
+func TestRunsPortfolioShowHelpFlagSetCorrectly(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "portfolio", "show", "--help"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("Displays the options for the 'runs portfolio show' command.", "", factory, t)
+}
+
+func TestRunsPortfolioShowNoFlagsReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "portfolio", "show"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: required flag(s) \"portfolio\" not set", factory, t)
+}
+
+func TestRunsPortfolioShowPortfolioFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_PORTFOLIO_SHOW, factory, t)
+
+	var args []string = []string{"runs", "portfolio", "show", "--portfolio", "my.yaml"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, "my.yaml", cmd.Values().(*RunsPortfolioShowCmdValues).portfolioFilename)
+}
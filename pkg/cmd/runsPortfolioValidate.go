@@ -0,0 +1,165 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"log"
+
+	"github.com/galasa-dev/cli/pkg/api"
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/runs"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// Objective: Allow the user to do this:
+//    runs portfolio validate --portfolio my.yaml
+// to check a portfolio for problems before submitting it.
+
+type RunsPortfolioValidateCmdValues struct {
+	portfolioFilename string
+
+	// Don't contact the ecosystem, so the streams the classes use aren't checked.
+	isOffline bool
+}
+
+type RunsPortfolioValidateCommand struct {
+	values       *RunsPortfolioValidateCmdValues
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors
+// ------------------------------------------------------------------------------------------------
+func NewRunsPortfolioValidateCommand(factory spi.Factory, runsPortfolioCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) (spi.GalasaCommand, error) {
+	cmd := new(RunsPortfolioValidateCommand)
+	err := cmd.init(factory, runsPortfolioCommand, commsFlagSet)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsPortfolioValidateCommand) Name() string {
+	return COMMAND_NAME_RUNS_PORTFOLIO_VALIDATE
+}
+
+func (cmd *RunsPortfolioValidateCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsPortfolioValidateCommand) Values() interface{} {
+	return cmd.values
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsPortfolioValidateCommand) init(factory spi.Factory, runsPortfolioCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+	var err error
+	cmd.values = &RunsPortfolioValidateCmdValues{}
+	cmd.cobraCommand, err = cmd.createCobraCmd(factory, runsPortfolioCommand, commsFlagSet.Values().(*CommsFlagSetValues))
+	return err
+}
+
+func (cmd *RunsPortfolioValidateCommand) createCobraCmd(
+	factory spi.Factory,
+	runsPortfolioCommand spi.GalasaCommand,
+	commsFlagSetValues *CommsFlagSetValues,
+) (*cobra.Command, error) {
+
+	var err error
+
+	runsPortfolioValidateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "check a portfolio for problems",
+		Long: "Checks the structure, apiVersion and kind of a portfolio, and that each class in it names a test, is only listed once, " +
+			"uses a stream which the Galasa service knows about and has a valid obr. Use --offline to check the portfolio without contacting the Galasa service, " +
+			"in which case the streams are not checked.",
+		Args:    cobra.NoArgs,
+		Aliases: []string{COMMAND_NAME_RUNS_PORTFOLIO_VALIDATE},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.executeRunsPortfolioValidate(factory, commsFlagSetValues)
+		},
+	}
+
+	runsPortfolioValidateCmd.Flags().StringVarP(&cmd.values.portfolioFilename, "portfolio", "p", "", "the portfolio to validate")
+	runsPortfolioValidateCmd.Flags().BoolVar(&cmd.values.isOffline, "offline", false,
+		"don't contact the Galasa service, so the streams used by the classes in the portfolio are not checked")
+
+	runsPortfolioValidateCmd.MarkFlagRequired("portfolio")
+
+	runsPortfolioCommand.CobraCommand().AddCommand(runsPortfolioValidateCmd)
+
+	return runsPortfolioValidateCmd, err
+}
+
+func (cmd *RunsPortfolioValidateCommand) executeRunsPortfolioValidate(factory spi.Factory, commsFlagSetValues *CommsFlagSetValues) error {
+	executionFunc := func() error {
+		commsFlagSetValues.isCapturingLogs = true
+
+		log.Println("Galasa CLI - Validate a portfolio")
+
+		fileSystem := factory.GetFileSystem()
+		portfolioFilename, err := files.TildaExpansion(fileSystem, cmd.values.portfolioFilename)
+		if err == nil {
+			var launcherInstance launcher.Launcher
+			if !cmd.values.isOffline {
+				launcherInstance, err = cmd.getRemoteLauncher(factory, commsFlagSetValues)
+			}
+
+			if err == nil {
+				err = runs.ValidatePortfolioFile(fileSystem, portfolioFilename, launcherInstance, factory.GetStdOutConsole())
+			}
+		}
+		return err
+	}
+	return utils.CaptureExecutionLogs(factory, commsFlagSetValues.logFileName, executionFunc)
+}
+
+// getRemoteLauncher - The launcher which asks the Galasa service which streams it has.
+func (cmd *RunsPortfolioValidateCommand) getRemoteLauncher(factory spi.Factory, commsFlagSetValues *CommsFlagSetValues) (launcher.Launcher, error) {
+	var err error
+	var launcherInstance launcher.Launcher
+
+	fileSystem := factory.GetFileSystem()
+	env := factory.GetEnvironment()
+
+	var galasaHome spi.GalasaHome
+	galasaHome, err = utils.NewGalasaHome(fileSystem, env, commsFlagSetValues.CmdParamGalasaHomePath)
+	if err == nil {
+
+		commsRetrier := api.NewCommsRetrier(commsFlagSetValues.maxRetries, commsFlagSetValues.retryBackoffSeconds, factory.GetTimeService())
+
+		var urlService *api.RealUrlResolutionService = new(api.RealUrlResolutionService)
+		var bootstrapData *api.BootstrapData
+		loadBootstrapWithRetriesFunc := func() error {
+			bootstrapData, err = api.LoadBootstrap(galasaHome, fileSystem, env, commsFlagSetValues.bootstrap, urlService)
+			return err
+		}
+
+		err = commsRetrier.ExecuteCommandWithRateLimitRetries(loadBootstrapWithRetriesFunc)
+		if err == nil {
+
+			apiServerUrl := bootstrapData.ApiServerURL
+			log.Printf("The API Server is at '%s'\n", apiServerUrl)
+
+			var apiClient *galasaapi.APIClient
+			authenticator := factory.GetAuthenticator(
+				apiServerUrl,
+				galasaHome,
+			)
+			apiClient, err = authenticator.GetAuthenticatedAPIClient()
+			if err == nil {
+				launcherInstance = launcher.NewRemoteLauncher(apiServerUrl, apiClient, commsRetrier)
+			}
+		}
+	}
+	return launcherInstance, err
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunsPortfolioValidateCommandInCommandCollection(t *testing.T) {
+
+	factory := utils.NewMockFactory()
+	commands, _ := NewCommandCollection(factory)
+
+	cmd, err := commands.GetCommand(COMMAND_NAME_RUNS_PORTFOLIO_VALIDATE)
+	assert.Nil(t, err)
+
+	assert.Equal(t, COMMAND_NAME_RUNS_PORTFOLIO_VALIDATE, cmd.Name())
+	assert.NotNil(t, cmd.Values())
+	assert.IsType(t, &RunsPortfolioValidateCmdValues{}, cmd.Values())
+	assert.NotNil(t, cmd.CobraCommand())
+}
+
+func TestRunsPortfolioValidateHelpFlagSetCorrectly(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "portfolio", "validate", "--help"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("Displays the options for the 'runs portfolio validate' command.", "", factory, t)
+}
+
+func TestRunsPortfolioValidateNoFlagsReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "portfolio", "validate"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: required flag(s) \"portfolio\" not set", factory, t)
+}
+
+func TestRunsPortfolioValidateAllFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_PORTFOLIO_VALIDATE, factory, t)
+
+	var args []string = []string{"runs", "portfolio", "validate", "--portfolio", "my.yaml", "--offline"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	checkOutput("", "", factory, t)
+
+	values := cmd.Values().(*RunsPortfolioValidateCmdValues)
+	assert.Equal(t, "my.yaml", values.portfolioFilename)
+	assert.True(t, values.isOffline)
+}
+
+func TestRunsPortfolioValidateIsOnlineByDefault(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_PORTFOLIO_VALIDATE, factory, t)
+
+	var args []string = []string{"runs", "portfolio", "validate", "--portfolio", "my.yaml"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.False(t, cmd.Values().(*RunsPortfolioValidateCmdValues).isOffline)
+}
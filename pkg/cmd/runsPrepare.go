@@ -8,6 +8,7 @@ package cmd
 import (
 	"log"
 	"strings"
+	"time"
 
 	"github.com/galasa-dev/cli/pkg/api"
 	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
@@ -23,6 +24,18 @@ type RunsPrepareCmdValues struct {
 	portfolioFilename    string
 	prepareFlagOverrides *[]string
 	prepareAppend        *bool
+	portfolioVersion     string
+
+	// Scheduling for the classes being added, in a v1beta portfolio.
+	priority    int
+	maxAttempts int
+	timeout     time.Duration
+	labels      []string
+	dependsOn   []string
+
+	// A test report to take the classes from instead of the test catalog, and the results of the test runs to take.
+	fromReport    string
+	reportResults []string
 
 	prepareSelectionFlags *utils.TestSelectionFlagValues
 }
@@ -90,6 +103,30 @@ func (cmd *RunsPrepareCommand) createCobraCommand(
 	runsPrepareCobraCmd.Flags().StringVarP(&cmd.values.portfolioFilename, "portfolio", "p", "", "portfolio to add tests to")
 	cmd.values.prepareFlagOverrides = runsPrepareCobraCmd.Flags().StringSlice("override", make([]string, 0), "overrides to be sent with the tests (overrides in the portfolio will take precedence)")
 	cmd.values.prepareAppend = runsPrepareCobraCmd.Flags().Bool("append", false, "Append tests to existing portfolio")
+	runsPrepareCobraCmd.Flags().StringVar(&cmd.values.portfolioVersion, "portfolioversion", "",
+		"the format version of the portfolio to write, 'v1alpha' or 'v1beta'. "+
+			"Defaults to the version of the portfolio being appended to, or 'v1alpha' for a new portfolio. "+
+			"The --priority, --maxattempts, --timeout, --label and --dependson flags need 'v1beta'")
+	runsPrepareCobraCmd.Flags().IntVar(&cmd.values.priority, "priority", 0,
+		"the priority of the selected test classes. 'runs submit' submits classes with a higher priority first")
+	runsPrepareCobraCmd.Flags().IntVar(&cmd.values.maxAttempts, "maxattempts", 0,
+		"the most times each selected test class is attempted, overriding the --maxattempts flag of 'runs submit'")
+	runsPrepareCobraCmd.Flags().DurationVar(&cmd.values.timeout, "timeout", 0,
+		"how long each attempt at a selected test class may run for before it is cancelled, for example 30m or 2h")
+	runsPrepareCobraCmd.Flags().StringSliceVar(&cmd.values.labels, "label", make([]string, 0),
+		"labels to give the selected test classes, which are carried into the reports. Can be repeated or comma-separated")
+	runsPrepareCobraCmd.Flags().StringSliceVar(&cmd.values.dependsOn, "dependson", make([]string, 0),
+		"test classes (bundle/class) in the portfolio which must pass before the selected test classes are submitted. "+
+			"Can be repeated or comma-separated")
+	runsPrepareCobraCmd.Flags().StringVar(&cmd.values.fromReport, "from-report", "",
+		"a yaml, json or junit test report written by 'runs submit' or 'runs wait'. "+
+			"Instead of selecting tests from the test catalog, a class is added to the portfolio for each test run in the report "+
+			"with one of the --result results, with the stream, obr, overrides and gherkin feature it ran with. "+
+			"Cannot be used with flags which select tests")
+	runsPrepareCobraCmd.Flags().StringSliceVar(&cmd.values.reportResults, "result", make([]string, 0),
+		"the results of the test runs in the --from-report test report to add to the portfolio, such as 'Failed,EnvFail'. "+
+			"Case insensitive. A test run which was lost has the result 'Lost'. "+
+			"Can be repeated or comma-separated. Defaults to the test runs which failed, had an EnvFail or were lost")
 	runsPrepareCobraCmd.MarkFlagRequired("portfolio")
 
 	runs.AddCommandFlags(runsPrepareCobraCmd, cmd.values.prepareSelectionFlags)
@@ -140,6 +177,12 @@ func (cmd *RunsPrepareCommand) executeAssemble(
 			}
 
 			if err == nil {
+				err = cmd.validateReportFlags()
+			}
+
+			if err == nil && cmd.values.fromReport != "" {
+				err = cmd.prepareFromReport(fileSystem, testOverrides)
+			} else if err == nil {
 
 				commsRetrier := api.NewCommsRetrier(commsFlagSetValues.maxRetries, commsFlagSetValues.retryBackoffSeconds, factory.GetTimeService())
 
@@ -185,26 +228,10 @@ func (cmd *RunsPrepareCommand) executeAssemble(
 								}
 
 								if err == nil {
-
-									var portfolio *runs.Portfolio
-									if *cmd.values.prepareAppend {
-										portfolio, err = runs.ReadPortfolio(fileSystem, cmd.values.portfolioFilename)
-									} else {
-										portfolio = runs.NewPortfolio()
-									}
-
-									if err == nil {
+									err = cmd.addToPortfolio(fileSystem, func(portfolio *runs.Portfolio) error {
 										runs.AddClassesToPortfolio(&testSelection, &testOverrides, portfolio)
-
-										err = runs.WritePortfolio(fileSystem, cmd.values.portfolioFilename, portfolio)
-										if err == nil {
-											if *cmd.values.prepareAppend {
-												log.Println("Portfolio appended")
-											} else {
-												log.Println("Portfolio created")
-											}
-										}
-									}
+										return nil
+									})
 								}
 							}
 						}
@@ -215,3 +242,105 @@ func (cmd *RunsPrepareCommand) executeAssemble(
 	}
 	return err
 }
+
+// validateReportFlags - Tests come either from a test report, or from the test catalog, not both.
+func (cmd *RunsPrepareCommand) validateReportFlags() error {
+	var err error
+	if cmd.values.fromReport != "" {
+		if runs.AreSelectionFlagsProvided(cmd.values.prepareSelectionFlags) {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PREPARE_REPORT_MIXED_WITH_TESTS)
+		}
+	} else if len(cmd.values.reportResults) > 0 {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PREPARE_RESULT_WITHOUT_REPORT)
+	}
+	return err
+}
+
+// prepareFromReport - Adds the classes of the test runs in the test report with the results wanted
+// to the portfolio, so that they can be run again.
+func (cmd *RunsPrepareCommand) prepareFromReport(fileSystem spi.FileSystem, testOverrides map[string]string) error {
+	report, err := runs.ReadTestReport(fileSystem, cmd.values.fromReport)
+	if err == nil {
+		err = cmd.addToPortfolio(fileSystem, func(portfolio *runs.Portfolio) error {
+			var err error
+			count := runs.AddReportedRunsToPortfolio(report, cmd.values.reportResults, testOverrides, portfolio)
+			if count < 1 {
+				resultsWanted := "Failed, EnvFail or Lost"
+				if len(cmd.values.reportResults) > 0 {
+					resultsWanted = strings.Join(cmd.values.reportResults, ",")
+				}
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PREPARE_NO_RUNS_IN_REPORT, cmd.values.fromReport, resultsWanted)
+			} else {
+				log.Printf("%v test runs were taken from test report %v\n", count, cmd.values.fromReport)
+			}
+			return err
+		})
+	}
+	return err
+}
+
+// addToPortfolio - Creates the portfolio, or reads the one being appended to, adds classes to it, and writes it.
+func (cmd *RunsPrepareCommand) addToPortfolio(fileSystem spi.FileSystem, addClasses func(portfolio *runs.Portfolio) error) error {
+	var err error
+	var portfolio *runs.Portfolio
+	if *cmd.values.prepareAppend {
+		portfolio, err = runs.ReadPortfolio(fileSystem, cmd.values.portfolioFilename)
+	} else {
+		portfolio = runs.NewPortfolio()
+	}
+
+	if err == nil {
+		firstNewClassIndex := len(portfolio.Classes)
+		err = addClasses(portfolio)
+		if err == nil {
+			err = cmd.setPortfolioVersionAndScheduling(portfolio, firstNewClassIndex)
+		}
+	}
+
+	if err == nil {
+		err = runs.WritePortfolio(fileSystem, cmd.values.portfolioFilename, portfolio)
+		if err == nil {
+			if *cmd.values.prepareAppend {
+				log.Println("Portfolio appended")
+			} else {
+				log.Println("Portfolio created")
+			}
+		}
+	}
+	return err
+}
+
+// setPortfolioVersionAndScheduling - Changes the version of the portfolio if asked to, and gives the
+// classes which were just added to it the scheduling asked for.
+func (cmd *RunsPrepareCommand) setPortfolioVersionAndScheduling(portfolio *runs.Portfolio, firstNewClassIndex int) error {
+	var err error
+
+	if cmd.values.portfolioVersion != "" {
+		if !runs.IsSupportedPortfolioVersion(cmd.values.portfolioVersion) {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PREPARE_BAD_PORTFOLIO_VERSION, cmd.values.portfolioVersion)
+		} else {
+			portfolio.APIVersion = cmd.values.portfolioVersion
+		}
+	}
+
+	if err == nil {
+		scheduling := runs.PortfolioClassScheduling{
+			Priority:    cmd.values.priority,
+			MaxAttempts: cmd.values.maxAttempts,
+			Labels:      cmd.values.labels,
+			DependsOn:   cmd.values.dependsOn,
+		}
+		if cmd.values.timeout != 0 {
+			scheduling.Timeout = cmd.values.timeout.String()
+		}
+
+		for index := firstNewClassIndex; index < len(portfolio.Classes); index++ {
+			portfolio.Classes[index].PortfolioClassScheduling = scheduling
+		}
+
+		// Make sure what is written can be read back.
+		err = runs.ValidatePortfolioClasses(portfolio, cmd.values.portfolioFilename)
+	}
+
+	return err
+}
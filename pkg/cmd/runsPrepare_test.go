@@ -7,7 +7,10 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/runs"
 	"github.com/galasa-dev/cli/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -449,3 +452,227 @@ func TestRunsPrepareAllFlagsReturnOk(t *testing.T) {
 	assert.Equal(t, *cmd.Values().(*RunsPrepareCmdValues).prepareSelectionFlags.RegexSelect, true)
 	assert.Contains(t, cmd.Values().(*RunsPrepareCmdValues).prepareSelectionFlags.Stream, "stream")
 }
+
+func TestRunsPreparePortfolioVersionFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_PREPARE, factory, t)
+
+	var args []string = []string{"runs", "prepare", "--portfolio", "roo.yaml", "--portfolioversion", "v1beta"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, "v1beta", cmd.Values().(*RunsPrepareCmdValues).portfolioVersion)
+}
+
+func TestRunsPrepareSchedulingFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_PREPARE, factory, t)
+
+	var args []string = []string{"runs", "prepare", "--portfolio", "roo.yaml", "--portfolioversion", "v1beta",
+		"--priority", "5", "--maxattempts", "3", "--timeout", "30m", "--label", "smoke,cics", "--dependson", "myBundle/mySetup"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	values := cmd.Values().(*RunsPrepareCmdValues)
+	assert.Equal(t, 5, values.priority)
+	assert.Equal(t, 3, values.maxAttempts)
+	assert.Equal(t, 30*time.Minute, values.timeout)
+	assert.Equal(t, []string{"smoke", "cics"}, values.labels)
+	assert.Equal(t, []string{"myBundle/mySetup"}, values.dependsOn)
+}
+
+func newPortfolioWithSetupClassAndNewClass() *runs.Portfolio {
+	portfolio := runs.NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes,
+		runs.PortfolioClass{Bundle: "myBundle", Class: "mySetup"},
+		runs.PortfolioClass{Bundle: "myBundle", Class: "myTest"},
+	)
+	return portfolio
+}
+
+func TestRunsPrepareGivesNewClassesTheSchedulingAskedFor(t *testing.T) {
+	// Given...
+	cmd := &RunsPrepareCommand{values: &RunsPrepareCmdValues{
+		portfolioFilename: "my.portfolio",
+		portfolioVersion:  "v1beta",
+		priority:          5,
+		timeout:           30 * time.Minute,
+		labels:            []string{"smoke"},
+		dependsOn:         []string{"myBundle/mySetup"},
+	}}
+	portfolio := newPortfolioWithSetupClassAndNewClass()
+
+	// When...
+	err := cmd.setPortfolioVersionAndScheduling(portfolio, 1)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "v1beta", portfolio.APIVersion)
+	assert.Equal(t, 0, portfolio.Classes[0].Priority)
+	assert.Equal(t, 5, portfolio.Classes[1].Priority)
+	assert.Equal(t, "30m0s", portfolio.Classes[1].Timeout)
+	assert.Equal(t, []string{"smoke"}, portfolio.Classes[1].Labels)
+	assert.Equal(t, []string{"myBundle/mySetup"}, portfolio.Classes[1].DependsOn)
+}
+
+func TestRunsPrepareSchedulingInAV1alphaPortfolioFails(t *testing.T) {
+	// Given...
+	cmd := &RunsPrepareCommand{values: &RunsPrepareCmdValues{
+		portfolioFilename: "my.portfolio",
+		priority:          5,
+	}}
+	portfolio := newPortfolioWithSetupClassAndNewClass()
+
+	// When...
+	err := cmd.setPortfolioVersionAndScheduling(portfolio, 1)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1257E")
+}
+
+func TestRunsPrepareWithUnknownPortfolioVersionFails(t *testing.T) {
+	// Given...
+	cmd := &RunsPrepareCommand{values: &RunsPrepareCmdValues{
+		portfolioFilename: "my.portfolio",
+		portfolioVersion:  "v2",
+	}}
+	portfolio := newPortfolioWithSetupClassAndNewClass()
+
+	// When...
+	err := cmd.setPortfolioVersionAndScheduling(portfolio, 1)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1262E")
+}
+
+func TestRunsPrepareFromReportFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_PREPARE, factory, t)
+
+	var args []string = []string{"runs", "prepare", "--portfolio", "roo.yaml", "--from-report", "report.yaml", "--result", "Failed,Lost"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, "report.yaml", cmd.Values().(*RunsPrepareCmdValues).fromReport)
+	assert.Equal(t, []string{"Failed", "Lost"}, cmd.Values().(*RunsPrepareCmdValues).reportResults)
+}
+
+func newRunsPrepareCommandForReportTests(fromReport string, reportResults []string, bundles []string) *RunsPrepareCommand {
+	isAppending := false
+	regexSelect := false
+	return &RunsPrepareCommand{values: &RunsPrepareCmdValues{
+		portfolioFilename: "my.portfolio",
+		prepareAppend:     &isAppending,
+		fromReport:        fromReport,
+		reportResults:     reportResults,
+		prepareSelectionFlags: &utils.TestSelectionFlagValues{
+			Bundles:     &bundles,
+			Packages:    new([]string),
+			Tests:       new([]string),
+			Tags:        new([]string),
+			Classes:     new([]string),
+			RegexSelect: &regexSelect,
+			GherkinUrl:  new([]string),
+		},
+	}}
+}
+
+func TestRunsPrepareFromReportWithTestsSelectedFails(t *testing.T) {
+	// Given...
+	cmd := newRunsPrepareCommandForReportTests("report.yaml", nil, []string{"myBundle"})
+
+	// When...
+	err := cmd.validateReportFlags()
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1292E")
+}
+
+func TestRunsPrepareResultWithoutReportFails(t *testing.T) {
+	// Given...
+	cmd := newRunsPrepareCommandForReportTests("", []string{"Failed"}, []string{"myBundle"})
+
+	// When...
+	err := cmd.validateReportFlags()
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1293E")
+}
+
+func TestRunsPrepareFromReportWritesPortfolioOfFailedRuns(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	fileSystem.WriteTextFile("report.yaml", `tests:
+- name: U1
+  bundle: myBundle
+  class: myPassingClass
+  stream: myStream
+  result: Passed
+- name: U2
+  bundle: myBundle
+  class: myFailingClass
+  stream: myStream
+  result: Failed
+`)
+	cmd := newRunsPrepareCommandForReportTests("report.yaml", nil, nil)
+
+	// When...
+	err := cmd.prepareFromReport(fileSystem, map[string]string{})
+
+	// Then...
+	assert.Nil(t, err)
+	portfolio, err := runs.ReadPortfolio(fileSystem, "my.portfolio")
+	assert.Nil(t, err)
+	assert.Len(t, portfolio.Classes, 1)
+	assert.Equal(t, "myFailingClass", portfolio.Classes[0].Class)
+}
+
+func TestRunsPrepareFromReportWithNoRunsWantedFails(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	fileSystem.WriteTextFile("report.yaml", `tests:
+- name: U1
+  bundle: myBundle
+  class: myPassingClass
+  stream: myStream
+  result: Passed
+`)
+	cmd := newRunsPrepareCommandForReportTests("report.yaml", nil, nil)
+
+	// When...
+	err := cmd.prepareFromReport(fileSystem, map[string]string{})
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1294E")
+	isExists, _ := fileSystem.Exists("my.portfolio")
+	assert.False(t, isExists)
+}
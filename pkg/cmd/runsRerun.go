@@ -0,0 +1,370 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/galasa-dev/cli/pkg/api"
+	"github.com/galasa-dev/cli/pkg/embedded"
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/images"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/runs"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// Objective: Allow the user to do this:
+//    runs rerun --name U123
+// And then galasactl submits the test which run U123 ran again, optionally waiting for it to finish.
+
+type RunsRerunCmdValues struct {
+	runName string
+
+	// The result archive store doesn't record the stream or obr the test came from.
+	stream string
+	obr    string
+
+	isWaiting bool
+	isLocal   bool
+
+	// How the test is submitted, and reported on when it is waited for.
+	submitValues *utils.RunsSubmitCmdValues
+
+	// How the test is launched when it runs locally.
+	localParams *launcher.RunsSubmitLocalCmdParameters
+}
+
+type RunsRerunCommand struct {
+	values       *RunsRerunCmdValues
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors methods
+// ------------------------------------------------------------------------------------------------
+func NewRunsRerunCommand(factory spi.Factory, runsCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) (spi.GalasaCommand, error) {
+	cmd := new(RunsRerunCommand)
+	err := cmd.init(factory, runsCommand, commsFlagSet)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsRerunCommand) Name() string {
+	return COMMAND_NAME_RUNS_RERUN
+}
+
+func (cmd *RunsRerunCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsRerunCommand) Values() interface{} {
+	return cmd.values
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsRerunCommand) init(factory spi.Factory, runsCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+	var err error
+	cmd.values = &RunsRerunCmdValues{
+		submitValues: &utils.RunsSubmitCmdValues{},
+		localParams:  &launcher.RunsSubmitLocalCmdParameters{},
+	}
+	cmd.cobraCommand, err = cmd.createRunsRerunCobraCmd(
+		factory,
+		runsCommand,
+		commsFlagSet.Values().(*CommsFlagSetValues),
+	)
+	return err
+}
+
+func (cmd *RunsRerunCommand) createRunsRerunCobraCmd(factory spi.Factory,
+	runsCommand spi.GalasaCommand,
+	commsFlagSetValues *CommsFlagSetValues,
+) (*cobra.Command, error) {
+
+	var err error
+
+	runsRerunCmd := &cobra.Command{
+		Use:   "rerun",
+		Short: "submit the test which an earlier run ran again",
+		Long: "Look up an earlier test run in the result archive store, and submit the test class it ran again, " +
+			"to the ecosystem or to a local JVM, optionally waiting for it to finish and reporting on it like 'runs submit'.",
+		Args:    cobra.NoArgs,
+		Aliases: []string{"runs rerun"},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.executeRerun(factory, commsFlagSetValues)
+		},
+	}
+
+	submitValues := cmd.values.submitValues
+
+	runsRerunCmd.Flags().StringVar(&cmd.values.runName, "name", "", "the name of the test run to rerun")
+
+	runsRerunCmd.Flags().StringVar(&cmd.values.stream, "stream", "",
+		"the test stream to find the test class in. The result archive store doesn't record which stream the test run used.")
+	runsRerunCmd.Flags().StringVar(&cmd.values.obr, "obr", "",
+		"the maven coordinates of the obr which refers to the test bundle, in the form 'mvn:${TEST_OBR_GROUP_ID}/${TEST_OBR_ARTIFACT_ID}/${TEST_OBR_VERSION}/obr'. "+
+			"Required with --local. The result archive store doesn't record which obr the test run used.")
+
+	runsRerunCmd.Flags().StringSliceVar(&submitValues.Overrides, "override", make([]string, 0),
+		"overrides to be sent with the test, in place of any override with the same name in the override file. "+
+			"The overrides the earlier test run was submitted with are not carried over, as the result archive store doesn't record them. "+
+			"Each override is of the form 'name=value'. Multiple instances of this flag can be used. "+
+			"For example --override=prop1=val1 --override=prop2=val2")
+	runsRerunCmd.Flags().StringVar(&submitValues.OverrideFilePath, "overridefile", "",
+		"path to a properties file containing override properties. Defaults to overrides.properties in galasa home folder if that file exists. "+
+			"A file path of '-' disables reading any properties file.")
+	runsRerunCmd.Flags().StringVarP(&submitValues.GroupName, "group", "g", "",
+		"the group name to assign the new test run to. Defaults to the group of the earlier test run, "+
+			"or a psuedo unique id if the earlier test run was not in a group.")
+	runsRerunCmd.Flags().StringVar(&submitValues.RequestType, "requesttype", "CLI", "the type of request, used to allocate a run name. Defaults to CLI.")
+
+	runsRerunCmd.Flags().BoolVar(&submitValues.Trace, "trace", false, "Trace to be enabled on the test run")
+	runsRerunCmd.Flags().Lookup("trace").NoOptDefVal = "true"
+
+	runsRerunCmd.Flags().BoolVar(&cmd.values.isWaiting, "wait", false,
+		"set to true to wait for the new test run to finish, and report on it like 'runs submit'. "+
+			"Otherwise, galasactl shows the name of the new test run and exits as soon as it has been submitted.")
+
+	runsRerunCmd.Flags().IntVar(&submitValues.PollIntervalSeconds, "poll", runs.DEFAULT_POLL_INTERVAL_SECONDS,
+		"Optional. The interval time in seconds between successive polls of the test run status, with --wait. "+
+			"Defaults to "+strconv.Itoa(runs.DEFAULT_POLL_INTERVAL_SECONDS)+" seconds.")
+	runsRerunCmd.Flags().IntVar(&submitValues.ProgressReportIntervalMinutes, "progress", runs.DEFAULT_PROGRESS_REPORT_INTERVAL_MINUTES,
+		"in minutes, how often the cli will report the progress of the test run, with --wait. A value of 0 or less disables progress reporting.")
+
+	// The results are reported, and decide whether galasactl fails, in the same way as 'runs submit', with --wait.
+	addSubmitReportFlags(runsRerunCmd.Flags(), submitValues)
+	addResultPolicyFlags(runsRerunCmd.Flags(), submitValues)
+
+	runsRerunCmd.Flags().BoolVar(&cmd.values.isLocal, "local", false,
+		"set to true to run the test in a local JVM rather than in the ecosystem. "+
+			"The test run is still looked up in the result archive store of the ecosystem. "+
+			"A test run locally is always waited for.")
+	runsRerunCmd.Flags().StringVar(&cmd.values.localParams.RemoteMaven, "remoteMaven", "https://repo.maven.apache.org/maven2",
+		"the url of the remote maven where galasa bundles can be loaded from, with --local. Defaults to maven central.")
+	runsRerunCmd.Flags().StringVar(&cmd.values.localParams.LocalMaven, "localMaven", "",
+		"the url of a local maven repository where galasa bundles can be loaded from, with --local. Defaults to your home .m2/repository file.")
+	currentGalasaVersion, _ := embedded.GetGalasaVersion()
+	runsRerunCmd.Flags().StringVar(&cmd.values.localParams.TargetGalasaVersion, "galasaVersion", currentGalasaVersion,
+		"the version of galasa you want to use to run the test, with --local. "+
+			"This should match the version of the galasa obr you built your test bundles against.")
+
+	runsRerunCmd.MarkFlagRequired("name")
+
+	runsCommand.CobraCommand().AddCommand(runsRerunCmd)
+
+	return runsRerunCmd, err
+}
+
+// validateFlags - A test run locally needs its obr, a test run in the ecosystem needs its stream,
+// and there are only results to report once the test run has been waited for.
+func (cmd *RunsRerunCommand) validateFlags() error {
+	var err error
+	if cmd.values.isLocal {
+		if cmd.values.obr == "" {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_RERUN_LOCAL_WITHOUT_OBR)
+		}
+		cmd.values.isWaiting = true
+	} else if cmd.values.stream == "" {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_RERUN_WITHOUT_STREAM)
+	}
+
+	if err == nil && !cmd.values.isWaiting && cmd.isReportingOrPolicyFlagUsed() {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_RERUN_REPORT_WITHOUT_WAIT)
+	}
+	return err
+}
+
+func (cmd *RunsRerunCommand) isReportingOrPolicyFlagUsed() bool {
+	submitValues := cmd.values.submitValues
+
+	reportFileNames := []string{
+		submitValues.ReportYamlFilename,
+		submitValues.ReportJsonFilename,
+		submitValues.ReportJunitFilename,
+		submitValues.ReportHtmlFilename,
+		submitValues.ReportTapFilename,
+		submitValues.ReportCtrfFilename,
+		submitValues.ReportMarkdownFilename,
+		submitValues.ResultPolicyFileName,
+	}
+	isUsed := submitValues.ReportJunitRunLogLines != 0 ||
+		submitValues.NoExitCodeOnTestFailures ||
+		len(submitValues.ExcusedResults) > 0 ||
+		submitValues.MinPassRate != 0 ||
+		len(submitValues.Quarantine) > 0
+
+	for _, fileName := range reportFileNames {
+		if fileName != "" {
+			isUsed = true
+		}
+	}
+	return isUsed
+}
+
+func (cmd *RunsRerunCommand) executeRerun(
+	factory spi.Factory,
+	commsFlagSetValues *CommsFlagSetValues,
+) error {
+
+	var err error
+
+	// Operations on the file system will all be relative to the current folder.
+	fileSystem := factory.GetFileSystem()
+
+	err = utils.CaptureLog(fileSystem, commsFlagSetValues.logFileName)
+	if err == nil {
+
+		commsFlagSetValues.isCapturingLogs = true
+
+		log.Println("Galasa CLI - Rerun a test run")
+
+		err = cmd.validateFlags()
+		if err == nil {
+
+			// Get the ability to query environment variables.
+			env := factory.GetEnvironment()
+
+			var galasaHome spi.GalasaHome
+			galasaHome, err = utils.NewGalasaHome(fileSystem, env, commsFlagSetValues.CmdParamGalasaHomePath)
+			if err == nil {
+
+				timeService := factory.GetTimeService()
+				commsRetrier := api.NewCommsRetrier(commsFlagSetValues.maxRetries, commsFlagSetValues.retryBackoffSeconds, timeService)
+
+				// Read the bootstrap properties.
+				var urlService *api.RealUrlResolutionService = new(api.RealUrlResolutionService)
+				var bootstrapData *api.BootstrapData
+				loadBootstrapWithRetriesFunc := func() error {
+					bootstrapData, err = api.LoadBootstrap(galasaHome, fileSystem, env, commsFlagSetValues.bootstrap, urlService)
+					return err
+				}
+
+				err = commsRetrier.ExecuteCommandWithRateLimitRetries(loadBootstrapWithRetriesFunc)
+				if err == nil {
+
+					apiServerUrl := bootstrapData.ApiServerURL
+					log.Printf("The API Server is at '%s'\n", apiServerUrl)
+
+					var apiClient *galasaapi.APIClient
+					authenticator := factory.GetAuthenticator(
+						apiServerUrl,
+						galasaHome,
+					)
+					apiClient, err = authenticator.GetAuthenticatedAPIClient()
+					if err == nil {
+
+						var run *galasaapi.Run
+						run, err = runs.GetRunToRerun(cmd.values.runName, timeService, apiClient)
+						if err == nil {
+
+							var portfolio *runs.Portfolio
+							portfolio, err = runs.NewRerunPortfolio(run, cmd.values.stream, cmd.values.obr)
+							if err == nil {
+								submitValues := cmd.values.submitValues
+								submitValues.GroupName = runs.GetRerunGroupName(run, submitValues.GroupName)
+
+								if cmd.values.isLocal {
+									err = cmd.rerunLocally(factory, galasaHome, bootstrapData, portfolio)
+								} else {
+									err = cmd.rerunRemotely(factory, galasaHome, apiServerUrl, apiClient, commsRetrier, portfolio)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	log.Printf("executeRerun returning %v\n", err)
+	return err
+}
+
+func (cmd *RunsRerunCommand) rerunRemotely(
+	factory spi.Factory,
+	galasaHome spi.GalasaHome,
+	apiServerUrl string,
+	apiClient *galasaapi.APIClient,
+	commsRetrier api.CommsRetrier,
+	portfolio *runs.Portfolio,
+) error {
+	var err error
+
+	timeService := factory.GetTimeService()
+	console := factory.GetStdOutConsole()
+	launcherInstance := launcher.NewRemoteLauncher(apiServerUrl, apiClient, commsRetrier)
+
+	submitter := runs.NewSubmitter(galasaHome, factory.GetFileSystem(), launcherInstance, timeService, utils.NewRealTimedSleeper(),
+		factory.GetEnvironment(), console, images.NewImageExpanderNullImpl())
+	submitter.SetPortfolio(portfolio)
+
+	if !cmd.values.isWaiting {
+		err = submitter.SubmitRunsWithoutWaiting(cmd.values.submitValues, runs.NewTestSelectionFlagValues())
+	} else {
+		submitter.SetRunCanceller(runs.NewRemoteRunCanceller(timeService, apiClient))
+		submitter.SetApiServerUrl(apiServerUrl)
+		submitter.SetRunLogFetcher(runs.NewRemoteRunLogFetcher(apiClient))
+		submitter.SetProgressReporter(runs.NewProgressReporter(console, utils.IsTerminal(console)))
+
+		// Ctrl-C stops the submission gracefully, rather than leaving the run behind in the ecosystem.
+		stopWatchingForInterrupts := watchForInterrupts(submitter)
+		err = submitter.ExecuteSubmitRuns(cmd.values.submitValues, runs.NewTestSelectionFlagValues())
+		stopWatchingForInterrupts()
+	}
+	return err
+}
+
+func (cmd *RunsRerunCommand) rerunLocally(
+	factory spi.Factory,
+	galasaHome spi.GalasaHome,
+	bootstrapData *api.BootstrapData,
+	portfolio *runs.Portfolio,
+) error {
+	var err error
+
+	fileSystem := factory.GetFileSystem()
+	timedSleeper := utils.NewRealTimedSleeper()
+	embeddedFileSystem := embedded.GetReadOnlyFileSystem()
+
+	var launcherInstance *launcher.JvmLauncher
+	launcherInstance, err = launcher.NewJVMLauncher(
+		factory,
+		bootstrapData.Properties, embeddedFileSystem,
+		cmd.values.localParams,
+		launcher.NewRealProcessFactory(), galasaHome, timedSleeper)
+
+	if err == nil {
+		console := factory.GetStdOutConsole()
+
+		renderer := images.NewImageRenderer(embeddedFileSystem)
+		expander := images.NewImageExpander(fileSystem, renderer, true)
+
+		submitter := runs.NewSubmitter(galasaHome, fileSystem, launcherInstance, utils.NewRealTimeService(), timedSleeper,
+			factory.GetEnvironment(), console, expander)
+		submitter.SetPortfolio(portfolio)
+		submitter.SetRunCanceller(launcherInstance)
+		submitter.SetProgressReporter(runs.NewProgressReporter(console, utils.IsTerminal(console)))
+
+		// Ctrl-C stops the submission gracefully, ending the local JVM rather than leaving it running.
+		stopWatchingForInterrupts := watchForInterrupts(submitter)
+		err = submitter.ExecuteSubmitRuns(cmd.values.submitValues, runs.NewTestSelectionFlagValues())
+		stopWatchingForInterrupts()
+		if err == nil {
+			reportOnExpandedImages(expander)
+		}
+	}
+	return err
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunsRerunCommandInCommandCollection(t *testing.T) {
+
+	factory := utils.NewMockFactory()
+	commands, _ := NewCommandCollection(factory)
+
+	runsRerunCommand, err := commands.GetCommand(COMMAND_NAME_RUNS_RERUN)
+	assert.Nil(t, err)
+
+	assert.Equal(t, COMMAND_NAME_RUNS_RERUN, runsRerunCommand.Name())
+	assert.NotNil(t, runsRerunCommand.Values())
+	assert.IsType(t, &RunsRerunCmdValues{}, runsRerunCommand.Values())
+	assert.NotNil(t, runsRerunCommand.CobraCommand())
+}
+
+func TestRunsRerunHelpFlagSetCorrectly(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "rerun", "--help"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("Displays the options for the 'runs rerun' command.", "", factory, t)
+}
+
+func TestRunsRerunNoFlagsReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "rerun"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: required flag(s) \"name\" not set", factory, t)
+}
+
+func TestRunsRerunNameFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_RERUN, factory, t)
+
+	var args []string = []string{"runs", "rerun", "--name", "U123"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	checkOutput("", "", factory, t)
+
+	values := cmd.Values().(*RunsRerunCmdValues)
+	assert.Equal(t, "U123", values.runName)
+	assert.False(t, values.isWaiting)
+	assert.False(t, values.isLocal)
+	assert.Equal(t, "CLI", values.submitValues.RequestType)
+}
+
+func TestRunsRerunAllFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_RERUN, factory, t)
+
+	var args []string = []string{"runs", "rerun", "--name", "U123", "--stream", "myStream", "--obr", "mvn:my.group/my.obr/0.0.1/obr",
+		"--override", "prop1=val1,prop2=val2", "--overridefile", "my.properties", "--group", "myGroup", "--trace",
+		"--wait", "--poll", "5", "--progress", "2", "--reportyaml", "report.yaml", "--reportjson", "report.json",
+		"--reportjunit", "report.xml", "--reporthtml", "report.html", "--reporttap", "report.tap", "--reportctrf", "report.ctrf.json",
+		"--reportmarkdown", "report.md", "--reportjunitlog", "50", "--noexitcodeontestfailures",
+		"--resultpolicy", "policy.yaml", "--excuseresults", "EnvFail", "--minpassrate", "90", "--quarantine", "myBundle/*",
+		"--local", "--remoteMaven", "https://my.maven", "--localMaven", "file:///my/.m2/repository", "--galasaVersion", "0.1.0"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	checkOutput("", "", factory, t)
+
+	values := cmd.Values().(*RunsRerunCmdValues)
+	assert.Equal(t, "myStream", values.stream)
+	assert.Equal(t, "mvn:my.group/my.obr/0.0.1/obr", values.obr)
+	assert.Equal(t, []string{"prop1=val1", "prop2=val2"}, values.submitValues.Overrides)
+	assert.Equal(t, "my.properties", values.submitValues.OverrideFilePath)
+	assert.Equal(t, "myGroup", values.submitValues.GroupName)
+	assert.True(t, values.submitValues.Trace)
+	assert.True(t, values.isWaiting)
+	assert.Equal(t, 5, values.submitValues.PollIntervalSeconds)
+	assert.Equal(t, 2, values.submitValues.ProgressReportIntervalMinutes)
+	assert.Equal(t, "report.yaml", values.submitValues.ReportYamlFilename)
+	assert.Equal(t, "report.json", values.submitValues.ReportJsonFilename)
+	assert.Equal(t, "report.xml", values.submitValues.ReportJunitFilename)
+	assert.Equal(t, "report.html", values.submitValues.ReportHtmlFilename)
+	assert.Equal(t, "report.tap", values.submitValues.ReportTapFilename)
+	assert.Equal(t, "report.ctrf.json", values.submitValues.ReportCtrfFilename)
+	assert.Equal(t, "report.md", values.submitValues.ReportMarkdownFilename)
+	assert.Equal(t, 50, values.submitValues.ReportJunitRunLogLines)
+	assert.True(t, values.submitValues.NoExitCodeOnTestFailures)
+	assert.Equal(t, "policy.yaml", values.submitValues.ResultPolicyFileName)
+	assert.Equal(t, []string{"EnvFail"}, values.submitValues.ExcusedResults)
+	assert.Equal(t, float64(90), values.submitValues.MinPassRate)
+	assert.Equal(t, []string{"myBundle/*"}, values.submitValues.Quarantine)
+	assert.True(t, values.isLocal)
+	assert.Equal(t, "https://my.maven", values.localParams.RemoteMaven)
+	assert.Equal(t, "file:///my/.m2/repository", values.localParams.LocalMaven)
+	assert.Equal(t, "0.1.0", values.localParams.TargetGalasaVersion)
+}
+
+func TestRunsRerunLocallyWithoutObrFails(t *testing.T) {
+	// Given...
+	cmd := &RunsRerunCommand{values: &RunsRerunCmdValues{
+		runName:      "U123",
+		isLocal:      true,
+		submitValues: &utils.RunsSubmitCmdValues{},
+	}}
+
+	// When...
+	err := cmd.validateFlags()
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1297E")
+}
+
+func TestRunsRerunLocallyIsAlwaysWaitedFor(t *testing.T) {
+	// Given...
+	cmd := &RunsRerunCommand{values: &RunsRerunCmdValues{
+		runName:      "U123",
+		obr:          "mvn:my.group/my.obr/0.0.1/obr",
+		isLocal:      true,
+		submitValues: &utils.RunsSubmitCmdValues{ReportYamlFilename: "report.yaml"},
+	}}
+
+	// When...
+	err := cmd.validateFlags()
+
+	// Then...
+	assert.Nil(t, err)
+	assert.True(t, cmd.values.isWaiting)
+}
+
+func TestRunsRerunReportWithoutWaitFails(t *testing.T) {
+	// Given...
+	cmd := &RunsRerunCommand{values: &RunsRerunCmdValues{
+		runName:      "U123",
+		stream:       "myStream",
+		submitValues: &utils.RunsSubmitCmdValues{ReportJunitFilename: "report.xml"},
+	}}
+
+	// When...
+	err := cmd.validateFlags()
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1298E")
+}
+
+func TestRunsRerunResultPolicyWithoutWaitFails(t *testing.T) {
+	// Given...
+	cmd := &RunsRerunCommand{values: &RunsRerunCmdValues{
+		runName:      "U123",
+		stream:       "myStream",
+		submitValues: &utils.RunsSubmitCmdValues{MinPassRate: 50},
+	}}
+
+	// When...
+	err := cmd.validateFlags()
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1298E")
+}
+
+func TestRunsRerunInTheEcosystemWithoutStreamFails(t *testing.T) {
+	// Given...
+	cmd := &RunsRerunCommand{values: &RunsRerunCmdValues{
+		runName:      "U123",
+		submitValues: &utils.RunsSubmitCmdValues{},
+	}}
+
+	// When...
+	err := cmd.validateFlags()
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1325E")
+}
+
+func TestRunsRerunInTheEcosystemWithStreamIsOk(t *testing.T) {
+	// Given...
+	cmd := &RunsRerunCommand{values: &RunsRerunCmdValues{
+		runName:      "U123",
+		stream:       "myStream",
+		submitValues: &utils.RunsSubmitCmdValues{},
+	}}
+
+	// When...
+	err := cmd.validateFlags()
+
+	// Then...
+	assert.Nil(t, err)
+	assert.False(t, cmd.values.isWaiting)
+}
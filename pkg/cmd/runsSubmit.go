@@ -87,9 +87,8 @@ func (cmd *RunsSubmitCommand) createRunsSubmitCobraCmd(factory spi.Factory,
 
 	runsSubmitCmd.Flags().StringVarP(&cmd.values.PortfolioFileName, "portfolio", "p", "", "portfolio containing the tests to run")
 
-	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.ReportYamlFilename, "reportyaml", "", "yaml file to record the final results in")
-	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.ReportJsonFilename, "reportjson", "", "json file to record the final results in")
-	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.ReportJunitFilename, "reportjunit", "", "junit xml file to record the final results in")
+	addSubmitReportFlags(runsSubmitCmd.PersistentFlags(), cmd.values)
+
 	runsSubmitCmd.PersistentFlags().StringVarP(&cmd.values.GroupName, "group", "g", "", "the group name to assign the test runs to, if not provided, a psuedo unique id will be generated")
 	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.RequestType, "requesttype", "CLI", "the type of request, used to allocate a run name. Defaults to CLI.")
 
@@ -123,13 +122,125 @@ func (cmd *RunsSubmitCommand) createRunsSubmitCobraCmd(factory spi.Factory,
 			"Each override is of the form 'name=value'. Multiple instances of this flag can be used. "+
 			"For example --override=prop1=val1 --override=prop2=val2")
 
+	runsSubmitCmd.PersistentFlags().BoolVar(&cmd.values.StrictEnvVars, "strict-env", false,
+		"fail if an override, from the command line, the --overridefile or the portfolio, refers to an environment variable "+
+			"which is not set and has no default. Overrides can refer to environment variables as ${NAME} or ${NAME:-default}. "+
+			"Without this flag, a reference to a variable which is not set is sent as it is.")
+
+	runsSubmitCmd.PersistentFlags().BoolVar(&cmd.values.PrintEffectiveOverrides, "print-effective-overrides", false,
+		"show the overrides each test will be submitted with, once those from the --overridefile, the command line, "+
+			"the portfolio and its matrix are merged and any environment variables are replaced. "+
+			"Values of overrides whose names suggest they are secrets, such as passwords and tokens, are masked.")
+
 	// The trace flag defaults to 'false' if you don't use it.
 	// If you say '--trace' on it's own, it defaults to 'true'
 	// If you say --trace=false or --trace=true you can set the value explicitly.
 	runsSubmitCmd.PersistentFlags().BoolVar(&cmd.values.Trace, "trace", false, "Trace to be enabled on the test runs")
 	runsSubmitCmd.PersistentFlags().Lookup("trace").NoOptDefVal = "true"
 
-	runsSubmitCmd.PersistentFlags().BoolVar(&(cmd.values.NoExitCodeOnTestFailures), "noexitcodeontestfailures", false, "set to true if you don't want an exit code to be returned from galasactl if a test fails")
+	addResultPolicyFlags(runsSubmitCmd.PersistentFlags(), cmd.values)
+
+	runsSubmitCmd.PersistentFlags().IntVar(&cmd.values.MaxAttempts, "maxattempts", runs.DEFAULT_MAX_ATTEMPTS,
+		"the maximum number of times each test class will be attempted. "+
+			"When a test run finishes with one of the results given by --retryresults, it is re-submitted until it has been attempted this many times. "+
+			"Only the result of the last attempt is used to decide whether the test class failed. "+
+			"Defaults to "+strconv.Itoa(runs.DEFAULT_MAX_ATTEMPTS)+", so test runs are not re-submitted.")
+
+	runsSubmitCmd.PersistentFlags().StringSliceVar(&cmd.values.RetryResults, "retryresults", runs.DEFAULT_RETRY_RESULTS,
+		"the test run results which cause a test class to be re-submitted, when --maxattempts allows it. Case insensitive. "+
+			"Value can be a single value or a comma-separated list. For example \"--retryresults EnvFail\"")
+
+	runsSubmitCmd.PersistentFlags().IntVar(&cmd.values.RetryBackoffSeconds, "retrybackoff", runs.DEFAULT_RETRY_BACKOFF_SECONDS,
+		"in seconds, how long to wait after a test run finishes before its next attempt is submitted. "+
+			"Defaults to "+strconv.Itoa(runs.DEFAULT_RETRY_BACKOFF_SECONDS)+", so the next attempt is submitted straight away.")
+
+	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.JournalFileName, "journal", "",
+		"a file where the state of the submission is recorded each time it changes. "+
+			"If galasactl is stopped before the tests finish, the submission can be picked up again later using --resume with this file. "+
+			"Optional. If not specified, no journal is written.")
+
+	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.ResumeJournalFileName, "resume", "",
+		"a journal file written by an earlier submission which did not complete. "+
+			"The submission re-attaches to the tests already running in its group, submits only those tests which were not yet submitted, "+
+			"and carries on recording its progress in the same journal file. "+
+			"Cannot be used with --portfolio or test selection flags.")
+
+	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.ControlFileName, "controlfile", "",
+		"a file to create which lets 'galasactl runs control' commands pause and resume the submission, change the throttle, "+
+			"list the state of the test runs, or cancel test runs, while the submission is running. "+
+			"Requests are only accepted from the local machine, and only with the access token held in the file. "+
+			"The file is created so that only the user running the submission can read it, and the submission does not start if that can't be done. "+
+			"The file is removed when the submission ends. "+
+			"Optional. If not specified, the submission can't be controlled in this way.")
+
+	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.EventsFileName, "events-file", "",
+		"a file to write each event of the submission to as it happens, as a line of json. "+
+			"Events are added to the end of the file if it is already there. "+
+			"An event is written when a test run is submitted, changes status, finishes with a result or is lost, "+
+			"when the throttle is changed, and when the submission completes. "+
+			"Optional. If not specified, no events file is written.")
+
+	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.WebhookUrl, "webhook", "",
+		"an http or https URL to post a json payload to when the submission completes, giving the final results. "+
+			"Optional. If not specified, no webhook is used.")
+
+	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.WebhookTemplateFile, "webhook-template", "",
+		"a file holding a go template which builds the json payload posted to the --webhook from the event, "+
+			"such as '{\"text\": \"Tests in group {{.Group}} finished. {{.Summary.Failed}} failed.\"}'. "+
+			"The template function 'json' writes a value as json. "+
+			"Optional. If not specified, the event itself is posted.")
+
+	runsSubmitCmd.PersistentFlags().BoolVar(&cmd.values.IsWebhookForEachRun, "webhook-each-run", false,
+		"set to true to also post to the --webhook each time a test run finishes.")
+
+	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.Shard, "shard", "",
+		"only submit one shard of the tests, given as the shard number and the number of shards, for example '2/5'. "+
+			"The test classes are shared out between the shards the same way each time, so that running every shard, "+
+			"for example on 5 different CI agents, runs each test class exactly once. "+
+			"Test classes which depend on each other are kept in the same shard. "+
+			"Optional. If not specified, all the tests are submitted.")
+
+	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.ShardStrategy, "shardstrategy", runs.DEFAULT_SHARD_STRATEGY,
+		"how --shard shares the test classes out between the shards. "+
+			"'count' gives each shard as close to the same number of test classes as possible. "+
+			"'duration' gives each shard as close to the same total run time as possible, using how long each test class took to run "+
+			"over the "+strconv.Itoa(runs.RUN_DURATION_HISTORY_DAYS)+" days before today, from the Galasa ecosystem. "+
+			"'duration' cannot be used when running tests locally. "+
+			"Defaults to '"+runs.DEFAULT_SHARD_STRATEGY+"'.")
+
+	runsSubmitCmd.PersistentFlags().StringVar(&cmd.values.Schedule, "schedule", runs.DEFAULT_SCHEDULE,
+		"the order to submit the test classes in. "+
+			"'portfolio' submits them in the order the portfolio lists them. "+
+			"'longest-first' submits the test classes which took longest to run over the "+strconv.Itoa(runs.RUN_DURATION_HISTORY_DAYS)+" days before today first, "+
+			"so that with a --throttle the slowest test classes don't start last and hold up the end of the submission. "+
+			"Test classes which haven't run before are submitted after the rest, in portfolio order. "+
+			"How long each test class took is fetched from the Galasa ecosystem at most once a day, and cached in the galasa home folder. "+
+			"'longest-first' cannot be used when running tests locally. "+
+			"Defaults to '"+runs.DEFAULT_SCHEDULE+"'.")
+
+	runsSubmitCmd.PersistentFlags().BoolVar(&cmd.values.CancelOnInterrupt, "cancelrunsoninterrupt", false,
+		"set to true if the test runs which are in progress should be cancelled when galasactl is interrupted (for example by pressing Ctrl-C). "+
+			"Test runs launched locally are cancelled by ending the JVMs running them. "+
+			"Whether or not they are cancelled, no more test runs are submitted, and the reports are written with the unfinished test runs marked as 'Interrupted'. "+
+			"Interrupting galasactl a second time makes it exit immediately.")
+
+	runsSubmitCmd.PersistentFlags().IntVar(&cmd.values.InterruptWaitSeconds, "interruptwait", runs.DEFAULT_INTERRUPT_WAIT_SECONDS,
+		"in seconds, how long to wait for cancelled test runs to finish after galasactl is interrupted, or stopped by --fail-fast or --timeout, "+
+			"before the reports are written. "+
+			"Defaults to "+strconv.Itoa(runs.DEFAULT_INTERRUPT_WAIT_SECONDS)+" seconds.")
+
+	runsSubmitCmd.PersistentFlags().BoolVar(&cmd.values.FailFast, "fail-fast", false,
+		"set to true to stop as soon as any test run finishes with a result other than 'Passed', "+
+			"unless it is quarantined or its result is excused by the result policy. "+
+			"No more test runs are submitted, the test runs in progress are cancelled, "+
+			"and the reports record the unfinished test runs as 'Cancelled' by 'fail-fast'. "+
+			"A test run which the retry policy re-submits does not count as failing until its last attempt fails.")
+
+	runsSubmitCmd.PersistentFlags().DurationVar(&cmd.values.Timeout, "timeout", 0,
+		"the longest the whole submission may take, for example '90m' or '2h'. "+
+			"When it passes, no more test runs are submitted, the test runs in progress are cancelled, "+
+			"and the reports record the unfinished test runs as 'Cancelled' by 'timeout'. "+
+			"Defaults to no time limit.")
 
 	runs.AddCommandFlags(runsSubmitCmd, submitSelectionFlags)
 
@@ -198,8 +309,18 @@ func (cmd *RunsSubmitCommand) executeSubmit(
 						var console = factory.GetStdOutConsole()
 
 						submitter := runs.NewSubmitter(galasaHome, fileSystem, launcherInstance, timeService, timedSleeper, env, console, images.NewImageExpanderNullImpl())
-
+						submitter.SetRunCanceller(runs.NewRemoteRunCanceller(timeService, apiClient))
+						submitter.SetApiServerUrl(apiServerUrl)
+						submitter.SetRunLogFetcher(runs.NewRemoteRunLogFetcher(apiClient))
+						submitter.SetWebhookSender(runs.NewHttpWebhookSender(commsRetrier))
+						submitter.SetRunDurationHistory(runs.NewCachedRunDurationHistory(fileSystem, galasaHome, timeService, apiServerUrl,
+							runs.NewRemoteRunDurationHistory(apiClient, timeService)))
+						submitter.SetProgressReporter(runs.NewProgressReporter(console, utils.IsTerminal(console)))
+
+						// Ctrl-C stops the submission gracefully, rather than leaving runs behind in the ecosystem.
+						stopWatchingForInterrupts := watchForInterrupts(submitter)
 						err = submitter.ExecuteSubmitRuns(cmd.values, cmd.values.TestSelectionFlagValues)
+						stopWatchingForInterrupts()
 					}
 				}
 			}
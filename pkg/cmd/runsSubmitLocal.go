@@ -199,7 +199,7 @@ func (cmd *RunsSubmitLocalCommand) executeSubmitLocal(
 			if err == nil {
 
 				// A launcher is needed to launch anythihng
-				var launcherInstance launcher.Launcher
+				var launcherInstance *launcher.JvmLauncher
 				launcherInstance, err = launcher.NewJVMLauncher(
 					factory,
 					bootstrapData.Properties, embeddedFileSystem,
@@ -223,11 +223,18 @@ func (cmd *RunsSubmitLocalCommand) executeSubmitLocal(
 						console,
 						expander,
 					)
+					submitter.SetRunCanceller(launcherInstance)
+					submitter.SetProgressReporter(runs.NewProgressReporter(console, utils.IsTerminal(console)))
+					submitter.SetWebhookSender(runs.NewHttpWebhookSender(
+						api.NewCommsRetrier(commsFlagSetValues.maxRetries, commsFlagSetValues.retryBackoffSeconds, timeService)))
 
+					// Ctrl-C stops the submission gracefully, rather than leaving test JVMs running.
+					stopWatchingForInterrupts := watchForInterrupts(submitter)
 					err = submitter.ExecuteSubmitRuns(
 						runsSubmitCmdValues,
 						cmd.values.submitLocalSelectionFlags,
 					)
+					stopWatchingForInterrupts()
 
 					if err == nil {
 						reportOnExpandedImages(expander)
@@ -7,6 +7,7 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/galasa-dev/cli/pkg/utils"
 	"github.com/stretchr/testify/assert"
@@ -114,6 +115,60 @@ func TestRunsSubmitLocalClassObrFlagReturnsOk(t *testing.T) {
 	assert.Contains(t, cmd.Values().(*RunsSubmitLocalCmdValues).runsSubmitLocalCmdParams.Obrs, "mvn:a.big.ol.obr")
 }
 
+func TestRunsSubmitLocalShardFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, _ := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT_LOCAL, factory, t)
+	runsSubmitCommand, err := commandCollection.GetCommand(COMMAND_NAME_RUNS_SUBMIT)
+	assert.Nil(t, err)
+
+	var args []string = []string{"runs", "submit", "local", "--class", "my.class", "--obr", "mvn:a.big.ol.obr", "--shard", "1/3"}
+
+	// When...
+	err = commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, "1/3", runsSubmitCommand.Values().(*utils.RunsSubmitCmdValues).Shard)
+}
+
+func TestRunsSubmitLocalSubmissionFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, _ := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT_LOCAL, factory, t)
+	runsSubmitCommand, err := commandCollection.GetCommand(COMMAND_NAME_RUNS_SUBMIT)
+	assert.Nil(t, err)
+
+	var args []string = []string{"runs", "submit", "local", "--class", "my.class", "--obr", "mvn:a.big.ol.obr",
+		"--schedule", "portfolio",
+		"--reportjunitlog", "50",
+		"--cancelrunsoninterrupt",
+		"--interruptwait", "30",
+		"--fail-fast",
+		"--timeout", "90m"}
+
+	// When...
+	err = commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	runsSubmitValues := runsSubmitCommand.Values().(*utils.RunsSubmitCmdValues)
+	assert.Equal(t, "portfolio", runsSubmitValues.Schedule)
+	assert.Equal(t, 50, runsSubmitValues.ReportJunitRunLogLines)
+	assert.True(t, runsSubmitValues.CancelOnInterrupt)
+	assert.Equal(t, 30, runsSubmitValues.InterruptWaitSeconds)
+	assert.True(t, runsSubmitValues.FailFast)
+	assert.Equal(t, 90*time.Minute, runsSubmitValues.Timeout)
+}
+
 func TestRunsSubmitLocalDebugFlagReturnsOk(t *testing.T) {
 	// Given...
 	factory := utils.NewMockFactory()
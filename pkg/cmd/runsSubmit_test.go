@@ -7,6 +7,7 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/galasa-dev/cli/pkg/utils"
 	"github.com/stretchr/testify/assert"
@@ -470,6 +471,137 @@ func TestRunsSubmitReportjunitFlagReturnsOk(t *testing.T) {
 	assert.Contains(t, cmd.Values().(*utils.RunsSubmitCmdValues).ReportJunitFilename, "afile.junit")
 }
 
+func TestRunsSubmitReporthtmlFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--reporthtml", "afile.html"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Contains(t, cmd.Values().(*utils.RunsSubmitCmdValues).ReportHtmlFilename, "afile.html")
+}
+
+func TestRunsSubmitReportjunitlogFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--reportjunit", "afile.xml", "--reportjunitlog", "100"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, 100, cmd.Values().(*utils.RunsSubmitCmdValues).ReportJunitRunLogLines)
+}
+
+func TestRunsSubmitReporttapReportctrfAndReportmarkdownFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--reporttap", "afile.tap", "--reportctrf", "afile.json", "--reportmarkdown", "afile.md"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	values := cmd.Values().(*utils.RunsSubmitCmdValues)
+	assert.Equal(t, "afile.tap", values.ReportTapFilename)
+	assert.Equal(t, "afile.json", values.ReportCtrfFilename)
+	assert.Equal(t, "afile.md", values.ReportMarkdownFilename)
+}
+
+func TestRunsSubmitResultPolicyFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--resultpolicy", "policy.yaml",
+		"--excuseresults", "EnvFail", "--excuseresults", "Ignored",
+		"--minpassrate", "90", "--quarantine", "myBundle/myClass1,myOtherBundle/*"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	values := cmd.Values().(*utils.RunsSubmitCmdValues)
+	assert.Equal(t, "policy.yaml", values.ResultPolicyFileName)
+	assert.Equal(t, []string{"EnvFail", "Ignored"}, values.ExcusedResults)
+	assert.Equal(t, float64(90), values.MinPassRate)
+	assert.Equal(t, []string{"myBundle/myClass1", "myOtherBundle/*"}, values.Quarantine)
+}
+
+func TestRunsSubmitEventsFileAndWebhookFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--events-file", "events.ndjson",
+		"--webhook", "https://example.com/hooks/galasa", "--webhook-template", "webhook.tmpl", "--webhook-each-run"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	values := cmd.Values().(*utils.RunsSubmitCmdValues)
+	assert.Equal(t, "events.ndjson", values.EventsFileName)
+	assert.Equal(t, "https://example.com/hooks/galasa", values.WebhookUrl)
+	assert.Equal(t, "webhook.tmpl", values.WebhookTemplateFile)
+	assert.True(t, values.IsWebhookForEachRun)
+}
+
+func TestRunsSubmitEnvVarOverrideFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--override", "zos.host=${HOST}", "--strict-env", "--print-effective-overrides"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	values := cmd.Values().(*utils.RunsSubmitCmdValues)
+	assert.Equal(t, []string{"zos.host=${HOST}"}, values.Overrides)
+	assert.True(t, values.StrictEnvVars)
+	assert.True(t, values.PrintEffectiveOverrides)
+}
+
 func TestRunsSubmitReportyamlFlagReturnsOk(t *testing.T) {
 	// Given...
 	factory := utils.NewMockFactory()
@@ -602,6 +734,284 @@ func TestRunsSubmitTraceFlagReturnsOk(t *testing.T) {
 	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).Trace, true)
 }
 
+func TestRunsSubmitMaxAttemptsFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--maxattempts", "3"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).MaxAttempts, 3)
+}
+
+func TestRunsSubmitMaxAttemptsFlagDefaultsToOne(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--portfolio", "portfolio.file"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).MaxAttempts, 1)
+}
+
+func TestRunsSubmitRetryResultsFlagCSListReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--retryresults", "EnvFail,Failed With Defects"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).RetryResults, []string{"EnvFail", "Failed With Defects"})
+}
+
+func TestRunsSubmitRetryBackoffFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--retrybackoff", "60"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).RetryBackoffSeconds, 60)
+}
+
+func TestRunsSubmitJournalFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--journal", "my.journal"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).JournalFileName, "my.journal")
+}
+
+func TestRunsSubmitResumeFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--resume", "my.journal"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).ResumeJournalFileName, "my.journal")
+}
+
+func TestRunsSubmitCancelRunsOnInterruptFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--cancelrunsoninterrupt"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).CancelOnInterrupt, true)
+}
+
+func TestRunsSubmitInterruptWaitFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--interruptwait", "10"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).InterruptWaitSeconds, 10)
+}
+
+func TestRunsSubmitFailFastFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--fail-fast"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).FailFast, true)
+}
+
+func TestRunsSubmitTimeoutFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--timeout", "90m"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).Timeout, time.Minute*90)
+}
+
+func TestRunsSubmitTimeoutFlagWithBadDurationReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, _ := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--timeout", "a while"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid argument \"a while\" for \"--timeout\" flag")
+}
+
+func TestRunsSubmitControlFileFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--controlfile", "my.control"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).ControlFileName, "my.control")
+}
+
+func TestRunsSubmitShardFlagsReturnOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--shard", "2/5", "--shardstrategy", "duration"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).Shard, "2/5")
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).ShardStrategy, "duration")
+}
+
+func TestRunsSubmitShardStrategyDefaultsToCount(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).ShardStrategy, "count")
+}
+
+func TestRunsSubmitScheduleFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_SUBMIT, factory, t)
+
+	var args []string = []string{"runs", "submit", "--schedule", "longest-first"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, cmd.Values().(*utils.RunsSubmitCmdValues).Schedule, "longest-first")
+}
+
 func TestRunsSubmitAllFlagsReturnsOk(t *testing.T) {
 	// Given...
 	factory := utils.NewMockFactory()
@@ -0,0 +1,206 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/galasa-dev/cli/pkg/api"
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/images"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/runs"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// Objective: Allow the user to do this:
+//    runs wait --group myGroup --reportjunit results.xml
+// And then galasactl waits for all the runs in the group to finish, and reports on them.
+
+type RunsWaitCommand struct {
+	values       *utils.RunsWaitCmdValues
+	cobraCommand *cobra.Command
+}
+
+// ------------------------------------------------------------------------------------------------
+// Constructors
+// ------------------------------------------------------------------------------------------------
+func NewRunsWaitCommand(factory spi.Factory, runsCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) (spi.GalasaCommand, error) {
+	cmd := new(RunsWaitCommand)
+	err := cmd.init(factory, runsCommand, commsFlagSet)
+	return cmd, err
+}
+
+// ------------------------------------------------------------------------------------------------
+// Public methods
+// ------------------------------------------------------------------------------------------------
+func (cmd *RunsWaitCommand) Name() string {
+	return COMMAND_NAME_RUNS_WAIT
+}
+
+func (cmd *RunsWaitCommand) CobraCommand() *cobra.Command {
+	return cmd.cobraCommand
+}
+
+func (cmd *RunsWaitCommand) Values() interface{} {
+	return cmd.values
+}
+
+// ------------------------------------------------------------------------------------------------
+// Private methods
+// ------------------------------------------------------------------------------------------------
+
+func (cmd *RunsWaitCommand) init(factory spi.Factory, runsCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
+	var err error
+	cmd.values = &utils.RunsWaitCmdValues{}
+	cmd.cobraCommand, err = cmd.createRunsWaitCobraCmd(
+		factory,
+		runsCommand,
+		commsFlagSet.Values().(*CommsFlagSetValues),
+	)
+	return err
+}
+
+func (cmd *RunsWaitCommand) createRunsWaitCobraCmd(factory spi.Factory,
+	runsCommand spi.GalasaCommand,
+	commsFlagSetValues *CommsFlagSetValues,
+) (*cobra.Command, error) {
+
+	var err error
+
+	runsWaitCmd := &cobra.Command{
+		Use:     "wait",
+		Short:   "wait for a group of test runs in the ecosystem to finish",
+		Long:    "Attach to a group of test runs which were submitted earlier, monitor them and wait for them to complete",
+		Args:    cobra.NoArgs,
+		Aliases: []string{"runs wait"},
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.executeWait(factory, commsFlagSetValues)
+		},
+	}
+
+	runsWaitCmd.PersistentFlags().StringVarP(&cmd.values.GroupName, "group", "g", "", "the name of the group of test runs to wait for")
+
+	runsWaitCmd.PersistentFlags().StringVar(&cmd.values.ReportYamlFilename, "reportyaml", "", "yaml file to record the final results in")
+	runsWaitCmd.PersistentFlags().StringVar(&cmd.values.ReportJsonFilename, "reportjson", "", "json file to record the final results in")
+	runsWaitCmd.PersistentFlags().StringVar(&cmd.values.ReportJunitFilename, "reportjunit", "", "junit xml file to record the final results in")
+	runsWaitCmd.PersistentFlags().StringVar(&cmd.values.ReportHtmlFilename, "reporthtml", "", "html file to record the final results in, as a single page which can be shared")
+	runsWaitCmd.PersistentFlags().StringVar(&cmd.values.ReportTapFilename, "reporttap", "", "TAP version 13 file to record the final results in")
+	runsWaitCmd.PersistentFlags().StringVar(&cmd.values.ReportCtrfFilename, "reportctrf", "", "Common Test Report Format (CTRF) json file to record the final results in")
+	runsWaitCmd.PersistentFlags().StringVar(&cmd.values.ReportMarkdownFilename, "reportmarkdown", "", "markdown file to record a summary of the final results in, "+
+		"suitable for a pull request comment or a CI job summary")
+	runsWaitCmd.PersistentFlags().IntVar(&cmd.values.ReportJunitRunLogLines, "reportjunitlog", 0,
+		"the number of lines from the end of the run log of each test run to include in the --reportjunit report, "+
+			"as the system-out of its test suite. The run logs are fetched from the Galasa ecosystem. "+
+			"Defaults to 0, which leaves the run logs out of the report.")
+
+	runsWaitCmd.PersistentFlags().IntVar(&cmd.values.PollIntervalSeconds, "poll", runs.DEFAULT_POLL_INTERVAL_SECONDS,
+		"Optional. The interval time in seconds between successive polls of the test runs status. "+
+			"Defaults to "+strconv.Itoa(runs.DEFAULT_POLL_INTERVAL_SECONDS)+" seconds. "+
+			"If less than 1, then default value is used.")
+
+	runsWaitCmd.PersistentFlags().IntVar(&cmd.values.ProgressReportIntervalMinutes, "progress", runs.DEFAULT_PROGRESS_REPORT_INTERVAL_MINUTES,
+		"in minutes, how often the cli will report the overall progress of the test runs. A value of 0 or less disables progress reporting.")
+
+	runsWaitCmd.PersistentFlags().DurationVar(&cmd.values.Timeout, "timeout", 0,
+		"Optional. The longest time to wait for the test runs to finish, for example '90m' or '2h'. "+
+			"Test runs which have not finished by then are reported as lost, and galasactl fails. "+
+			"If not specified, galasactl waits until all the test runs have finished.")
+
+	runsWaitCmd.PersistentFlags().BoolVar(&(cmd.values.NoExitCodeOnTestFailures), "noexitcodeontestfailures", false, "set to true if you don't want an exit code to be returned from galasactl if a test fails")
+
+	runsWaitCmd.PersistentFlags().StringVar(&cmd.values.ResultPolicyFileName, "resultpolicy", "",
+		"a yaml file holding the result policy, which decides whether the results of the test runs fail galasactl. "+
+			"The policy can list the results which don't count as failures, the minimum pass rate, and the test classes which are quarantined. "+
+			"The --excuseresults, --quarantine and --minpassrate flags add to the policy in the file.")
+	runsWaitCmd.PersistentFlags().StringSliceVar(&cmd.values.ExcusedResults, "excuseresults", nil,
+		"a comma-separated list of the results which don't count as failures, such as 'EnvFail'. "+
+			"Test runs with these results are reported as they are, but don't fail galasactl.")
+	runsWaitCmd.PersistentFlags().Float64Var(&cmd.values.MinPassRate, "minpassrate", 0,
+		"the lowest percentage of the test runs which must pass, from 0 to 100. "+
+			"Quarantined test runs, and test runs with results given by --excuseresults, are not counted. "+
+			"Defaults to 0, where any test run which fails makes galasactl fail.")
+	runsWaitCmd.PersistentFlags().StringSliceVar(&cmd.values.Quarantine, "quarantine", nil,
+		"a comma-separated list of bundle/class patterns of the test classes which are quarantined, such as 'myBundle/com.myco.MyFlakyTest' or 'myBundle/*'. "+
+			"Quarantined test classes are run and reported as usual, but their failures don't fail galasactl.")
+
+	runsWaitCmd.MarkPersistentFlagRequired("group")
+
+	runsCommand.CobraCommand().AddCommand(runsWaitCmd)
+
+	return runsWaitCmd, err
+}
+
+func (cmd *RunsWaitCommand) executeWait(
+	factory spi.Factory,
+	commsFlagSetValues *CommsFlagSetValues,
+) error {
+
+	var err error
+
+	// Operations on the file system will all be relative to the current folder.
+	fileSystem := factory.GetFileSystem()
+
+	err = utils.CaptureLog(fileSystem, commsFlagSetValues.logFileName)
+	if err == nil {
+
+		commsFlagSetValues.isCapturingLogs = true
+
+		log.Println("Galasa CLI - Wait for a group of test runs to finish")
+
+		// Get the ability to query environment variables.
+		env := factory.GetEnvironment()
+
+		var galasaHome spi.GalasaHome
+		galasaHome, err = utils.NewGalasaHome(fileSystem, env, commsFlagSetValues.CmdParamGalasaHomePath)
+		if err == nil {
+
+			commsRetrier := api.NewCommsRetrier(commsFlagSetValues.maxRetries, commsFlagSetValues.retryBackoffSeconds, factory.GetTimeService())
+
+			// Read the bootstrap properties.
+			var urlService *api.RealUrlResolutionService = new(api.RealUrlResolutionService)
+			var bootstrapData *api.BootstrapData
+			loadBootstrapWithRetriesFunc := func() error {
+				bootstrapData, err = api.LoadBootstrap(galasaHome, fileSystem, env, commsFlagSetValues.bootstrap, urlService)
+				return err
+			}
+
+			err = commsRetrier.ExecuteCommandWithRateLimitRetries(loadBootstrapWithRetriesFunc)
+			if err == nil {
+
+				timeService := factory.GetTimeService()
+				timedSleeper := utils.NewRealTimedSleeper()
+
+				apiServerUrl := bootstrapData.ApiServerURL
+
+				var apiClient *galasaapi.APIClient
+				authenticator := factory.GetAuthenticator(
+					apiServerUrl,
+					galasaHome,
+				)
+				apiClient, err = authenticator.GetAuthenticatedAPIClient()
+				if err == nil {
+					// The launcher we are going to use to monitor tests.
+					launcherInstance := launcher.NewRemoteLauncher(apiServerUrl, apiClient, commsRetrier)
+
+					var console = factory.GetStdOutConsole()
+
+					submitter := runs.NewSubmitter(galasaHome, fileSystem, launcherInstance, timeService, timedSleeper, env, console, images.NewImageExpanderNullImpl())
+					submitter.SetApiServerUrl(apiServerUrl)
+					submitter.SetRunLogFetcher(runs.NewRemoteRunLogFetcher(apiClient))
+
+					err = submitter.WaitForGroup(cmd.values)
+				}
+			}
+		}
+	}
+
+	return err
+}
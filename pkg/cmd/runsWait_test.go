@@ -0,0 +1,148 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunsWaitCommandInCommandCollection(t *testing.T) {
+
+	factory := utils.NewMockFactory()
+	commands, _ := NewCommandCollection(factory)
+
+	cmd, err := commands.GetCommand(COMMAND_NAME_RUNS_WAIT)
+	assert.Nil(t, err)
+
+	assert.Equal(t, COMMAND_NAME_RUNS_WAIT, cmd.Name())
+	assert.NotNil(t, cmd.Values())
+	assert.IsType(t, &utils.RunsWaitCmdValues{}, cmd.Values())
+	assert.NotNil(t, cmd.CobraCommand())
+}
+
+func TestRunsWaitHelpFlagSetCorrectly(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "wait", "--help"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("Displays the options for the 'runs wait' command.", "", factory, t)
+}
+
+func TestRunsWaitNoFlagsReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+
+	var args []string = []string{"runs", "wait"}
+
+	// When...
+	err := Execute(factory, args)
+
+	// Then...
+	assert.NotNil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "Error: required flag(s) \"group\" not set", factory, t)
+}
+
+func TestRunsWaitGroupFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_WAIT, factory, t)
+
+	var args []string = []string{"runs", "wait", "--group", "myGroup"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, "myGroup", cmd.Values().(*utils.RunsWaitCmdValues).GroupName)
+	assert.Equal(t, time.Duration(0), cmd.Values().(*utils.RunsWaitCmdValues).Timeout)
+}
+
+func TestRunsWaitBadTimeoutFlagReturnsError(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, _ := setupTestCommandCollection(COMMAND_NAME_RUNS_WAIT, factory, t)
+
+	var args []string = []string{"runs", "wait", "--group", "myGroup", "--timeout", "badparam"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid argument \"badparam\" for \"--timeout\" flag")
+}
+
+func TestRunsWaitAllFlagsReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_RUNS_WAIT, factory, t)
+
+	var args []string = []string{"runs", "wait",
+		"--group", "myGroup",
+		"--reportjson", "file.json",
+		"--reportjunit", "file.junit",
+		"--reportyaml", "file.yaml",
+		"--reporthtml", "file.html",
+		"--reportjunitlog", "50",
+		"--reporttap", "file.tap",
+		"--reportctrf", "file.ctrf.json",
+		"--reportmarkdown", "file.md",
+		"--resultpolicy", "policy.yaml",
+		"--excuseresults", "EnvFail,Ignored",
+		"--minpassrate", "95.5",
+		"--quarantine", "myBundle/myClass",
+		"--poll", "5",
+		"--progress", "2",
+		"--timeout", "1h30m",
+		"--noexitcodeontestfailures"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw is reasonable.
+	checkOutput("", "", factory, t)
+
+	values := cmd.Values().(*utils.RunsWaitCmdValues)
+	assert.Equal(t, "myGroup", values.GroupName)
+	assert.Equal(t, "file.json", values.ReportJsonFilename)
+	assert.Equal(t, "file.junit", values.ReportJunitFilename)
+	assert.Equal(t, "file.yaml", values.ReportYamlFilename)
+	assert.Equal(t, "file.html", values.ReportHtmlFilename)
+	assert.Equal(t, 50, values.ReportJunitRunLogLines)
+	assert.Equal(t, "file.tap", values.ReportTapFilename)
+	assert.Equal(t, "file.ctrf.json", values.ReportCtrfFilename)
+	assert.Equal(t, "file.md", values.ReportMarkdownFilename)
+	assert.Equal(t, "policy.yaml", values.ResultPolicyFileName)
+	assert.Equal(t, []string{"EnvFail", "Ignored"}, values.ExcusedResults)
+	assert.Equal(t, 95.5, values.MinPassRate)
+	assert.Equal(t, []string{"myBundle/myClass"}, values.Quarantine)
+	assert.Equal(t, 5, values.PollIntervalSeconds)
+	assert.Equal(t, 2, values.ProgressReportIntervalMinutes)
+	assert.Equal(t, 90*time.Minute, values.Timeout)
+	assert.True(t, values.NoExitCodeOnTestFailures)
+}
@@ -12,12 +12,14 @@ import (
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/secrets"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
 	"github.com/galasa-dev/cli/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 type SecretsGetCmdValues struct {
-	outputFormat string
+	outputFormat    string
+	tableFlagValues TableFlagValues
 }
 
 type SecretsGetCommand struct {
@@ -92,7 +94,9 @@ func (cmd *SecretsGetCommand) createCobraCmd(
     addSecretNameFlag(secretsGetCobraCmd, false, secretsCommandValues)
 
 	formatters := secrets.GetFormatterNamesAsString()
-	secretsGetCobraCmd.Flags().StringVar(&cmd.values.outputFormat, "format", "summary", "the output format of the returned secrets. Supported formats are: "+formatters+".")
+	secretsGetCobraCmd.Flags().StringVar(&cmd.values.outputFormat, "format", "summary", "the output format of the returned secrets. Supported formats are: "+formatters+". "+
+		templateformatter.TEMPLATE_FORMATS_HELP)
+	addTableFlags(secretsGetCobraCmd.Flags(), &cmd.values.tableFlagValues)
 
     secretsCommand.CobraCommand().AddCommand(secretsGetCobraCmd)
 
@@ -139,8 +143,14 @@ func (cmd *SecretsGetCommand) executeSecretsGet(
 
 			byteReader := factory.GetByteReader()
 
+			var outputFormat string
 			if err == nil {
-				err = secrets.GetSecrets(secretsCmdValues.name, cmd.values.outputFormat, console, apiClient, byteReader)
+				// A 'template-file=<path>' format is swapped for the go template held in that file.
+				outputFormat, err = templateformatter.ResolveTemplateFile(fileSystem, cmd.values.outputFormat)
+			}
+
+			if err == nil {
+				err = secrets.GetSecrets(secretsCmdValues.name, outputFormat, cmd.values.tableFlagValues.toTableOptions(), console, apiClient, byteReader)
 			}
 		}
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/galasa-dev/cli/pkg/api"
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
 	"github.com/galasa-dev/cli/pkg/users"
 	"github.com/galasa-dev/cli/pkg/utils"
 	"github.com/spf13/cobra"
@@ -19,7 +20,13 @@ import (
 // Objective: Allow user to do this:
 //
 //	users get
+type UsersGetCmdValues struct {
+	outputFormat    string
+	tableFlagValues TableFlagValues
+}
+
 type UsersGetCommand struct {
+	values       *UsersGetCmdValues
 	cobraCommand *cobra.Command
 }
 
@@ -50,8 +57,7 @@ func (cmd *UsersGetCommand) CobraCommand() *cobra.Command {
 }
 
 func (cmd *UsersGetCommand) Values() interface{} {
-	// There are no values.
-	return nil
+	return cmd.values
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -60,6 +66,7 @@ func (cmd *UsersGetCommand) Values() interface{} {
 func (cmd *UsersGetCommand) init(factory spi.Factory, usersCommand spi.GalasaCommand, commsFlagSet GalasaFlagSet) error {
 	var err error
 
+	cmd.values = &UsersGetCmdValues{}
 	cmd.cobraCommand, err = cmd.createCobraCmd(factory, usersCommand, commsFlagSet)
 
 	return err
@@ -91,6 +98,11 @@ func (cmd *UsersGetCommand) createCobraCmd(
 
 	addLoginIdFlag(usersGetCobraCmd, false, userCommandValues)
 
+	formatters := users.GetFormatterNamesAsString()
+	usersGetCobraCmd.Flags().StringVar(&cmd.values.outputFormat, "format", "summary", "the output format of the returned users. Supported formats are: "+formatters+". "+
+		templateformatter.TEMPLATE_FORMATS_HELP)
+	addTableFlags(usersGetCobraCmd.Flags(), &cmd.values.tableFlagValues)
+
 	usersCommand.CobraCommand().AddCommand(usersGetCobraCmd)
 
 	return usersGetCobraCmd, err
@@ -136,9 +148,15 @@ func (cmd *UsersGetCommand) executeUsersGet(
 			var apiClient *galasaapi.APIClient
 			apiClient, err = authenticator.GetAuthenticatedAPIClient()
 
+			var outputFormat string
+			if err == nil {
+				// A 'template-file=<path>' format is swapped for the go template held in that file.
+				outputFormat, err = templateformatter.ResolveTemplateFile(fileSystem, cmd.values.outputFormat)
+			}
+
 			if err == nil {
 				// Call to process the command in a unit-testable way.
-				err = users.GetUsers(userCmdValues.name, apiClient, console)
+				err = users.GetUsers(userCmdValues.name, outputFormat, cmd.values.tableFlagValues.toTableOptions(), apiClient, console)
 			}
 		}
 	}
@@ -55,3 +55,44 @@ func TestUsersGetNamespaceNameFlagsReturnsOk(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Contains(t, parentCmd.Values().(*UsersCmdValues).name, "me")
 }
+
+func TestUsersGetFormatFlagReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_USERS_GET, factory, t)
+
+	var args []string = []string{"users", "get", "--format", "template={{index . \"login-id\"}}"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw was reasonable
+	checkOutput("", "", factory, t)
+
+	assert.Equal(t, "template={{index . \"login-id\"}}", cmd.Values().(*UsersGetCmdValues).outputFormat)
+}
+
+func TestUsersGetTableFlagsReturnsOk(t *testing.T) {
+	// Given...
+	factory := utils.NewMockFactory()
+	commandCollection, cmd := setupTestCommandCollection(COMMAND_NAME_USERS_GET, factory, t)
+
+	var args []string = []string{"users", "get", "--columns", "login-id", "--sort-by", "login-id"}
+
+	// When...
+	err := commandCollection.Execute(args)
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Check what the user saw was reasonable
+	checkOutput("", "", factory, t)
+
+	tableFlagValues := cmd.Values().(*UsersGetCmdValues).tableFlagValues
+	assert.Equal(t, []string{"login-id"}, tableFlagValues.columns)
+	assert.Equal(t, "login-id", tableFlagValues.sortBy)
+	assert.False(t, tableFlagValues.isNoHeaders)
+}
@@ -241,7 +241,7 @@ var (
 	GALASA_ERROR_INVALID_FROM_OR_TO_PARAMETER             = NewMessageType("GAL1076E: Badly formed from or to value '%s' specified in the age parameter. The value could not be converted into an integer value.", 1076, STACK_TRACE_NOT_WANTED)
 	GALASA_ERROR_FROM_AGE_SMALLER_THAN_TO_AGE             = NewMessageType("GAL1077E: Invalid value '%s' detected for age parameter. The 'from' value must be greater than the 'to' value.", 1077, STACK_TRACE_NOT_WANTED)
 	GALASA_ERROR_INVALID_AGE_PARAMETER                    = NewMessageType("GAL1078E: Badly formed '--age' parameter value '%s' specified. Age of the test runs should be specified in the format '{FROM}{TIME-UNIT}:{TO}{TIME-UNIT}' or '{FROM}{TIME-UNIT}', where 'FROM' is a positive, non-zero integer, 'TO' is a non-negative integer, and 'TIME-UNIT' can be %s. 'FROM' must be greater than 'TO'. 'TO' defaults to 0 if not specified."+SEE_COMMAND_REFERENCE, 1078, STACK_TRACE_NOT_WANTED)
-	GALASA_ERROR_NO_TEST_RUN_IDENTIFIER_FLAG_SPECIFIED    = NewMessageType("GAL1079E: The --age, --name, or --group parameter must be used to identify which test run(s) you want see."+SEE_COMMAND_REFERENCE, 1079, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_NO_TEST_RUN_IDENTIFIER_FLAG_SPECIFIED    = NewMessageType("GAL1079E: The --age, --from, --name, or --group parameter must be used to identify which test run(s) you want see."+SEE_COMMAND_REFERENCE, 1079, STACK_TRACE_NOT_WANTED)
 	GALASA_ERROR_INVALID_FROM_AGE_SPECIFIED               = NewMessageType("GAL1080E: Invalid 'from' value '%s' in the '--age' parameter. Age of the test runs should be specified in the format '{FROM}{TIME-UNIT}:{TO}{TIME-UNIT}' or '{FROM}{TIME-UNIT}', where 'FROM' is a positive, non-zero integer, 'TO' is a non-negative integer, and 'TIME-UNIT' can be %s. 'FROM' must be greater than 'TO'. 'TO' defaults to 0 if not specified."+SEE_COMMAND_REFERENCE, 1080, STACK_TRACE_NOT_WANTED)
 	GALASA_ERROR_NEGATIVE_AGE_SPECIFIED                   = NewMessageType("GAL1081E: Unable use a negative value '%s' in the '--age' parameter. Age of the test runs should be specified in the format '{FROM}{TIME-UNIT}:{TO}{TIME-UNIT}' or '{FROM}{TIME-UNIT}', where 'FROM' is a positive, non-zero integer, 'TO' is a non-negative integer, and 'TIME-UNIT' can be %s. 'FROM' must be greater than 'TO'. 'TO' defaults to 0 if not specified."+SEE_COMMAND_REFERENCE, 1081, STACK_TRACE_NOT_WANTED)
 	GALASA_ERROR_BAD_TIME_UNIT_AGE_SPECIFIED              = NewMessageType("GAL1082E: Invalid time unit specified '%s' in the '--age' parameter. Age of the test runs should be specified in the format '{FROM}{TIME-UNIT}:{TO}{TIME-UNIT}' or '{FROM}{TIME-UNIT}', where 'FROM' is a positive, non-zero integer, 'TO' is a non-negative integer, and 'TIME-UNIT' can be %s. 'FROM' must be greater than 'TO'. 'TO' defaults to 0 if not specified."+SEE_COMMAND_REFERENCE, 1082, STACK_TRACE_NOT_WANTED)
@@ -403,6 +403,154 @@ var (
 	GALASA_ERROR_UPDATE_USER_SERVER_REPORTED_ERROR       = NewMessageType("GAL1216E: An attempt to update a user '%s' failed. Unexpected http status code %v received from the server. Error details from the server are: '%s'", 1216, STACK_TRACE_NOT_WANTED)
 	GALASA_ERROR_UPDATE_USER_EXPLANATION_NOT_JSON        = NewMessageType("GAL1217E: An attempt to update a user '%s' failed. Unexpected http status code %v received from the server. Error details from the server are not in the json format.", 1217, STACK_TRACE_NOT_WANTED)
 
+	// When journalling and resuming a runs submit command...
+	GALASA_ERROR_SUBMIT_JOURNAL_WRITE_FAILED      = NewMessageType("GAL1231E: Failed to write the submission journal file '%s'. Reason is %s", 1231, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_JOURNAL_OPEN_FAILED       = NewMessageType("GAL1232E: Failed to open the submission journal file '%s' for reading. Reason is %s", 1232, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_JOURNAL_BAD_FORMAT        = NewMessageType("GAL1233E: Failed to read the submission journal file '%s' because the content is in the wrong format. Reason is %s", 1233, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_JOURNAL_BAD_RESOURCE_KIND = NewMessageType("GAL1234E: Failed to read the submission journal file '%s' because the content is not a resource of type '%s' using format '%s'.", 1234, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_RESUME_MIXED_WITH_TESTS   = NewMessageType("GAL1235E: The submit command does not support the --resume flag together with a portfolio or test selection flags. The tests to run are read from the submission journal."+SEE_COMMAND_REFERENCE, 1235, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_RESUME_GROUP_MISMATCH     = NewMessageType("GAL1236E: The group name '%s' does not match the group name '%s' recorded in the submission journal file '%s'. Omit the --group flag when using --resume.", 1236, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_RESUME_GROUP_CHECK_FAILED = NewMessageType("GAL1237E: Failed to re-attach to the test runs in group '%s'. Reason is %s", 1237, STACK_TRACE_NOT_WANTED)
+
+	// When waiting for a group of test runs to finish...
+	GALASA_ERROR_WAIT_GROUP_FETCH_FAILED = NewMessageType("GAL1238E: Failed to get the test runs in group '%s'. Reason is %s", 1238, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_WAIT_GROUP_EMPTY        = NewMessageType("GAL1239E: The group '%s' does not contain any test runs. Check that the group name is correct.", 1239, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_WAIT_TIMED_OUT          = NewMessageType("GAL1240E: Timed out after %v waiting for the test runs in group '%s' to finish. %v test runs had not finished.", 1240, STACK_TRACE_NOT_WANTED)
+
+	// When a runs submit command is interrupted...
+	GALASA_ERROR_SUBMIT_INTERRUPTED = NewMessageType("GAL1241E: The submission of tests was interrupted. %v tests did not finish.", 1241, STACK_TRACE_NOT_WANTED)
+
+	// When a runs submit command is stopped by its --timeout...
+	GALASA_ERROR_SUBMIT_TIMED_OUT = NewMessageType("GAL1242E: The submission of tests timed out after %v. %v tests were cancelled or not submitted.", 1242, STACK_TRACE_NOT_WANTED)
+
+	// Controlling a runs submit command while it runs...
+	GALASA_ERROR_CONTROL_SERVER_START_FAILED = NewMessageType("GAL1243E: Failed to start listening for requests to control the submission of tests. Reason: %s", 1243, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_CONTROL_FILE_WRITE_FAILED   = NewMessageType("GAL1244E: Failed to write the control file '%s'. Reason: %s", 1244, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_CONTROL_FILE_READ_FAILED    = NewMessageType("GAL1245E: Failed to read the control file '%s'. Is the 'runs submit' command which wrote it still running? Reason: %s", 1245, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_CONTROL_FILE_INVALID        = NewMessageType("GAL1246E: The control file '%s' is not valid. It should have been written by a 'runs submit' command using the --controlfile flag.", 1246, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_CONTROL_CONTACT_FAILED      = NewMessageType("GAL1247E: Failed to contact the 'runs submit' command at address '%s'. Is it still running? Reason: %s", 1247, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_CONTROL_REQUEST_REJECTED    = NewMessageType("GAL1248E: The 'runs submit' command rejected the request. Status code: %v. Reason: %s", 1248, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_CONTROL_INVALID_THROTTLE    = NewMessageType("GAL1249E: Invalid throttle value %v. The throttle must be 1 or more.", 1249, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_CONTROL_CANCEL_UNKNOWN_RUN  = NewMessageType("GAL1250E: Can't cancel '%s'. It is neither the name of a test run in progress, nor a test class (bundle/class, followed by its [combination] if the portfolio has a matrix) waiting to be submitted.", 1250, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_CONTROL_RESPONSE_UNREADABLE = NewMessageType("GAL1251E: The response from the 'runs submit' command could not be understood. Reason: %s", 1251, STACK_TRACE_NOT_WANTED)
+
+	// When a portfolio has a matrix of override sets...
+	GALASA_ERROR_PORTFOLIO_MATRIX_AXIS_NO_NAME        = NewMessageType("GAL1252E: Failed to read portfolio file '%s' because axis number %v of its matrix has no name.", 1252, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PORTFOLIO_MATRIX_AXIS_NO_SETS        = NewMessageType("GAL1253E: Failed to read portfolio file '%s' because the '%s' axis of its matrix has no override sets.", 1253, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PORTFOLIO_MATRIX_SET_NO_NAME         = NewMessageType("GAL1254E: Failed to read portfolio file '%s' because override set number %v of the '%s' axis of its matrix has no name.", 1254, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PORTFOLIO_MATRIX_DUPLICATE_AXIS_NAME = NewMessageType("GAL1255E: Failed to read portfolio file '%s' because its matrix has more than one axis named '%s'.", 1255, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PORTFOLIO_MATRIX_DUPLICATE_SET_NAME  = NewMessageType("GAL1256E: Failed to read portfolio file '%s' because the '%s' axis of its matrix has more than one override set named '%s'.", 1256, STACK_TRACE_NOT_WANTED)
+
+	// When a portfolio asks for test classes to be scheduled in particular ways...
+	GALASA_ERROR_PORTFOLIO_FIELDS_NEED_V1BETA       = NewMessageType("GAL1257E: The portfolio '%s' gives class '%s' a priority, maximum attempts, timeout, labels or dependencies, which are only supported by 'v1beta' portfolios. Change the apiVersion of the portfolio to 'v1beta'.", 1257, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PORTFOLIO_CLASS_BAD_TIMEOUT        = NewMessageType("GAL1258E: The portfolio '%s' gives class '%s' an invalid timeout '%s'. The timeout must be a positive duration such as '90s', '30m' or '2h'.", 1258, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PORTFOLIO_CLASS_BAD_MAX_ATTEMPTS   = NewMessageType("GAL1259E: The portfolio '%s' gives class '%s' an invalid maximum number of attempts %v. It must be 1 or more.", 1259, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PORTFOLIO_CLASS_UNKNOWN_DEPENDENCY = NewMessageType("GAL1260E: The portfolio '%s' says class '%s' depends on '%s', which is not a class (bundle/class) in the portfolio.", 1260, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PORTFOLIO_CLASS_DEPENDENCY_CYCLE   = NewMessageType("GAL1261E: The portfolio '%s' has classes which depend on each other, so none of them could ever be submitted: %s", 1261, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PREPARE_BAD_PORTFOLIO_VERSION      = NewMessageType("GAL1262E: Unsupported portfolio version '%s'. Supported versions are 'v1alpha' and 'v1beta'.", 1262, STACK_TRACE_NOT_WANTED)
+
+	// When 'runs submit' only submits one shard of the tests...
+	GALASA_ERROR_SUBMIT_INVALID_SHARD              = NewMessageType("GAL1263E: Invalid --shard value '%s'. It must be the number of the shard to submit and the number of shards, such as '2/5', where the shard number is from 1 to the number of shards.", 1263, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_INVALID_SHARD_STRATEGY     = NewMessageType("GAL1264E: Invalid --shardstrategy value '%s'. Supported values are 'count' and 'duration'.", 1264, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_SHARD_DURATION_UNAVAILABLE = NewMessageType("GAL1265E: The 'duration' shard strategy needs the history of previous runs from the Galasa ecosystem, which is not available when running tests locally. Use the 'count' shard strategy instead.", 1265, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_QUERY_RUN_HISTORY_FAILED          = NewMessageType("GAL1266E: Failed to get the history of previous runs of test class '%s' from the Galasa ecosystem, to decide which shard it belongs in. Reason: %s", 1266, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_SHARD_MIXED_WITH_RESUME    = NewMessageType("GAL1267E: The --shard flag cannot be used with --resume. The journal being resumed already holds only the tests of its shard.", 1267, STACK_TRACE_NOT_WANTED)
+
+	// When 'runs submit' orders the tests by how long they took to run before...
+	GALASA_ERROR_SUBMIT_INVALID_SCHEDULE             = NewMessageType("GAL1268E: Invalid --schedule value '%s'. Supported values are 'portfolio' and 'longest-first'.", 1268, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_SCHEDULE_HISTORY_UNAVAILABLE = NewMessageType("GAL1269E: The 'longest-first' schedule needs the history of previous runs from the Galasa ecosystem, which is not available when running tests locally.", 1269, STACK_TRACE_NOT_WANTED)
+
+	// When writing an html report of the test runs...
+	GALASA_ERROR_SUBMIT_REPORT_HTML_WRITE_FAIL = NewMessageType("GAL1270E: Failed to write the html test report file '%s'. Reason is %s", 1270, STACK_TRACE_NOT_WANTED)
+
+	// When the junit test report includes the end of each run log...
+	GALASA_ERROR_QUERY_RUN_LOG_FAILED = NewMessageType("GAL1271E: Failed to get the run log of test run '%s' from the Galasa ecosystem. Reason: %s", 1271, STACK_TRACE_NOT_WANTED)
+
+	// When writing the TAP, CTRF and markdown reports of the test runs...
+	GALASA_ERROR_SUBMIT_REPORT_TAP_WRITE_FAIL      = NewMessageType("GAL1272E: Failed to write the TAP test report file '%s'. Reason is %s", 1272, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_REPORT_CTRF_MARSHAL        = NewMessageType("GAL1273E: Failed to prepare the CTRF test report for file '%s'. Reason is %s", 1273, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_REPORT_CTRF_WRITE_FAIL     = NewMessageType("GAL1274E: Failed to write the CTRF test report file '%s'. Reason is %s", 1274, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_SUBMIT_REPORT_MARKDOWN_WRITE_FAIL = NewMessageType("GAL1275E: Failed to write the markdown test report file '%s'. Reason is %s", 1275, STACK_TRACE_NOT_WANTED)
+
+	// When deciding whether the results of the test runs fail the command...
+	GALASA_ERROR_OPEN_RESULT_POLICY_FILE_FAILED  = NewMessageType("GAL1276E: Failed to open result policy file '%s' for reading. Reason is %s", 1276, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_RESULT_POLICY_BAD_FORMAT        = NewMessageType("GAL1277E: Failed to read result policy file '%s' because the content is in the wrong format. Reason is %s", 1277, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_RESULT_POLICY_BAD_RESOURCE_KIND = NewMessageType("GAL1278E: Failed to read result policy file '%s' because the content is not a resource with apiVersion '%s' and kind '%s'.", 1278, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_INVALID_MIN_PASS_RATE           = NewMessageType("GAL1279E: Invalid minimum pass rate '%v'. It must be a percentage from 0 to 100.", 1279, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_INVALID_QUARANTINE_PATTERN      = NewMessageType("GAL1280E: Invalid quarantine pattern '%s'. It must be of the form bundle/class, where a '*' matches any part of a name.", 1280, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_TESTS_BELOW_MIN_PASS_RATE       = NewMessageType("GAL1281E: Not enough runs passed. The pass rate was %.1f%%, below the minimum of %v%%. %v of %v counted runs passed.", 1281, STACK_TRACE_NOT_WANTED)
+
+	// When telling other tools what is happening to a submission...
+	GALASA_ERROR_EVENTS_FILE_CREATE_FAILED    = NewMessageType("GAL1282E: Failed to open the events file '%s'. Reason is %s", 1282, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_EVENTS_FILE_WRITE_FAILED     = NewMessageType("GAL1283E: Failed to write to the events file '%s'. Reason is %s", 1283, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_INVALID_WEBHOOK_URL          = NewMessageType("GAL1284E: Invalid webhook URL '%s'. It must start with 'http://' or 'https://'.", 1284, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_OPEN_WEBHOOK_TEMPLATE_FAILED = NewMessageType("GAL1285E: Failed to open webhook template file '%s' for reading. Reason is %s", 1285, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_WEBHOOK_TEMPLATE_BAD_FORMAT  = NewMessageType("GAL1286E: Failed to read webhook template file '%s' because it is not a valid template. Reason is %s", 1286, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_WEBHOOK_PAYLOAD_NOT_JSON     = NewMessageType("GAL1287E: Failed to build the webhook payload for the '%s' event. The webhook template did not produce valid json. Reason is %s", 1287, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_WEBHOOK_SEND_FAILED          = NewMessageType("GAL1288E: Failed to send the '%s' event to webhook '%s'. Reason is %s", 1288, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_WEBHOOK_REJECTED             = NewMessageType("GAL1289E: Webhook '%s' rejected the '%s' event. Status code %v. Response is: %s", 1289, STACK_TRACE_NOT_WANTED)
+
+	// When preparing a portfolio from a test report...
+	GALASA_ERROR_OPEN_TEST_REPORT_FAILED         = NewMessageType("GAL1290E: Failed to open test report file '%s' for reading. Reason is %s", 1290, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_TEST_REPORT_BAD_FORMAT          = NewMessageType("GAL1291E: Failed to read test report file '%s' because the content is not a yaml, json or junit test report. Reason is %s", 1291, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PREPARE_REPORT_MIXED_WITH_TESTS = NewMessageType("GAL1292E: The --from-report flag cannot be used with flags which select tests, such as --bundle, --class or --tag. The tests are taken from the test report instead."+SEE_COMMAND_REFERENCE, 1292, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PREPARE_RESULT_WITHOUT_REPORT   = NewMessageType("GAL1293E: The --result flag can only be used with the --from-report flag."+SEE_COMMAND_REFERENCE, 1293, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PREPARE_NO_RUNS_IN_REPORT       = NewMessageType("GAL1294E: No test runs in test report file '%s' have the results wanted. Results wanted: %s", 1294, STACK_TRACE_NOT_WANTED)
+
+	// runs rerun...
+	GALASA_ERROR_RERUN_RUN_NOT_FOUND       = NewMessageType("GAL1295E: The run named '%s' could not be found in the result archive store, so it cannot be rerun.", 1295, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_RERUN_NO_TEST_CLASS       = NewMessageType("GAL1296E: The run named '%s' cannot be rerun because the result archive store does not record the bundle and test class it ran. Gherkin test runs cannot be rerun.", 1296, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_RERUN_LOCAL_WITHOUT_OBR   = NewMessageType("GAL1297E: The --obr flag must be used with the --local flag, to say which obr refers to the test bundle. The result archive store doesn't record which obr the test run used."+SEE_COMMAND_REFERENCE, 1297, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_RERUN_REPORT_WITHOUT_WAIT = NewMessageType("GAL1298E: The report flags, such as --reportyaml and --reportjunit, and the result policy flags, such as --resultpolicy and --minpassrate, can only be used with the --wait flag, as there is no result to report until the test run has finished."+SEE_COMMAND_REFERENCE, 1298, STACK_TRACE_NOT_WANTED)
+
+	// runs portfolio...
+	GALASA_ERROR_PORTFOLIO_CLASS_NO_TEST   = NewMessageType("GAL1299E: Class number %v in portfolio '%s' does not have a bundle and class, or a gherkin URL.", 1299, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PORTFOLIO_DUPLICATE_CLASS = NewMessageType("GAL1300E: Class '%s' is in portfolio '%s' more than once, so it would be run more than once.", 1300, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PORTFOLIO_UNKNOWN_STREAM  = NewMessageType("GAL1301E: Class '%s' in portfolio '%s' uses stream '%s', which the Galasa ecosystem does not have. The streams it has are: %s", 1301, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PORTFOLIO_CLASS_BAD_OBR   = NewMessageType("GAL1302E: Class '%s' in portfolio '%s' has an obr which is not valid. %s", 1302, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_PORTFOLIO_NOT_VALID       = NewMessageType("GAL1303E: Portfolio '%s' is not valid. %v problems were found.", 1303, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_MERGE_BAD_CONFLICT_RULE   = NewMessageType("GAL1304E: Unsupported value '%s' for the --onconflict flag. Supported values are '%s'.", 1304, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_MERGE_MATRIX_CONFLICT     = NewMessageType("GAL1305E: Portfolio '%s' cannot be merged, because its matrix is different to the matrix of an earlier portfolio.", 1305, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_MERGE_CLASS_CONFLICT      = NewMessageType("GAL1306E: Portfolio '%s' cannot be merged, because class '%s' has %s '%s' in it, but '%s' in an earlier portfolio. Use the --onconflict flag to choose which is kept.", 1306, STACK_TRACE_NOT_WANTED)
+
+	// Environment variables in overrides...
+	GALASA_ERROR_ENV_VAR_NOT_SET       = NewMessageType("GAL1307E: The value of %s refers to environment variable '%s', which is not set. Set the environment variable, give the reference a default value with ':-', or don't use the --strict-env flag.", 1307, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_BAD_ENV_VAR_REFERENCE = NewMessageType("GAL1308E: The value of %s has '%s' in it, which is not a valid reference to an environment variable. A reference is written as ${NAME} or ${NAME:-default}. Use $${ for a '${' which is not a reference.", 1308, STACK_TRACE_NOT_WANTED)
+
+	// Template output formats...
+	GALASA_ERROR_BAD_OUTPUT_TEMPLATE           = NewMessageType("GAL1309E: The go template given with the --format flag is not valid. Reason: %s", 1309, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_OUTPUT_TEMPLATE_FILE_NOT_READ = NewMessageType("GAL1310E: The go template file '%s' given with the --format flag could not be read. Reason: %s", 1310, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_OUTPUT_TEMPLATE_FAILED        = NewMessageType("GAL1311E: The go template given with the --format flag failed while formatting the results. Reason: %s", 1311, STACK_TRACE_NOT_WANTED)
+
+	// Table layout options...
+	GALASA_ERROR_UNKNOWN_TABLE_COLUMN        = NewMessageType("GAL1312E: The column '%s' given with the %s flag is not one of the columns of the table. Possible columns are: %s", 1312, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_TABLE_OPTIONS_NOT_SUPPORTED = NewMessageType("GAL1313E: The --columns, --sort-by and --no-headers flags cannot be used with the '%s' format. Use them with the 'summary' or 'csv' formats.", 1313, STACK_TRACE_NOT_WANTED)
+
+	// Runs get filters...
+	GALASA_ERROR_INVALID_RUNS_TIME                         = NewMessageType("GAL1314E: Badly formed '--%s' parameter value '%s' specified. Give a date such as '2026-10-01', or a date and time such as '2026-10-01T09:30Z' or '2026-10-01T09:30:00+01:00'. Times without a time zone are in UTC."+SEE_COMMAND_REFERENCE, 1314, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_FROM_TIME_NOT_BEFORE_TO_TIME              = NewMessageType("GAL1315E: The '--from' time '%s' must be before the '--to' time '%s'."+SEE_COMMAND_REFERENCE, 1315, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_AGE_AND_TIME_RANGE_ARE_MUTUALLY_EXCLUSIVE = NewMessageType("GAL1316E: --age must not be used at the same time as --from or --to, they are mutually exclusive."+SEE_COMMAND_REFERENCE, 1316, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_INVALID_RUN_STATUS                        = NewMessageType("GAL1317E: Unsupported value '%s' for the '--status' parameter. Supported values are: %s"+SEE_COMMAND_REFERENCE, 1317, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_ACTIVE_AND_STATUS_ARE_MUTUALLY_EXCLUSIVE  = NewMessageType("GAL1318E: --active and --status must not be used at the same time, they are mutually exclusive."+SEE_COMMAND_REFERENCE, 1318, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_INVALID_RUNS_SORT                         = NewMessageType("GAL1319E: Unsupported value '%s' for the '--sort' parameter. Runs can be sorted by %s, followed by an optional ':asc' or ':desc'."+SEE_COMMAND_REFERENCE, 1319, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_INVALID_RUNS_LIMIT                        = NewMessageType("GAL1320E: Unsupported value '%v' for the '--limit' parameter. The limit must not be negative. Use 0 to get every run which matches."+SEE_COMMAND_REFERENCE, 1320, STACK_TRACE_NOT_WANTED)
+
+	// Streaming runs get...
+	GALASA_ERROR_STREAM_FORMAT_NOT_SUPPORTED = NewMessageType("GAL1321E: --stream cannot be used with the '%s' format. Formats which can be streamed are: %s"+SEE_COMMAND_REFERENCE, 1321, STACK_TRACE_NOT_WANTED)
+	GALASA_ERROR_STREAM_CANNOT_BE_SORTED     = NewMessageType("GAL1322E: --stream cannot be used with '%s', as the runs can only be sorted that way once every run has been got. Use '--sort submitted-time' to choose the order of streamed runs."+SEE_COMMAND_REFERENCE, 1322, STACK_TRACE_NOT_WANTED)
+
+	// Files which hold secrets...
+	GALASA_ERROR_FILE_NOT_PRIVATE = NewMessageType("GAL1323E: The file '%s' was not written, as it could not be made readable and writable by its owner only, and it would hold secrets which others could read. Reason: %s. Check that you own the file and the folder it is in, and try again.", 1323, STACK_TRACE_NOT_WANTED)
+
+	// Cancelling local test runs...
+	GALASA_ERROR_CANCEL_LOCAL_RUN_FAILED = NewMessageType("GAL1324E: Failed to cancel local test run '%s'. Reason is %s", 1324, STACK_TRACE_NOT_WANTED)
+
+	// runs rerun in the ecosystem...
+	GALASA_ERROR_RERUN_WITHOUT_STREAM = NewMessageType("GAL1325E: The --stream flag must be used when a test run is rerun in the Galasa ecosystem, to say which test stream holds the test class. The result archive store doesn't record which test stream the test run used."+SEE_COMMAND_REFERENCE, 1325, STACK_TRACE_NOT_WANTED)
+
+	// runs prepare --from-report with a junit report...
+	GALASA_ERROR_JUNIT_REPORT_HAS_COMBINATIONS = NewMessageType("GAL1326E: The junit test report '%s' cannot be used to prepare a portfolio, as its test runs used portfolio matrix combinations, and a junit report doesn't record the overrides of each combination. Use the yaml or json test report of the same tests instead."+SEE_COMMAND_REFERENCE, 1326, STACK_TRACE_NOT_WANTED)
+
 	// Warnings...
 	GALASA_WARNING_MAVEN_NO_GALASA_OBR_REPO = NewMessageType("GAL2000W: Warning: Maven configuration file settings.xml should contain a reference to a Galasa repository so that the galasa OBR can be resolved. The official release repository is '%s', and 'pre-release' repository is '%s'", 2000, STACK_TRACE_WANTED)
 
@@ -410,4 +558,5 @@ var (
 	GALASA_INFO_FOLDER_DOWNLOADED_TO = NewMessageType("GAL2501I: Downloaded %d artifacts to folder '%s'\n", 2501, STACK_TRACE_NOT_WANTED)
 	GALASA_INFO_RUNS_RESET_SUCCESS   = NewMessageType("GAL2503I: The request to reset run '%s' has been accepted by the server.\n", 2503, STACK_TRACE_NOT_WANTED)
 	GALASA_INFO_RUNS_CANCEL_SUCCESS  = NewMessageType("GAL2504I: The request to cancel run '%s' has been accepted by the server.\n", 2504, STACK_TRACE_NOT_WANTED)
+	GALASA_INFO_RUN_SUBMITTED        = NewMessageType("GAL2505I: Run '%s' was submitted to run test %s in group '%s'.\n", 2505, STACK_TRACE_NOT_WANTED)
 )
@@ -7,6 +7,7 @@ package files
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	pathUtils "path"
@@ -35,6 +36,11 @@ func TildaExpansion(fileSystem spi.FileSystem, path string) (string, error) {
 // The implementation of the real os-delegating variant of the FileSystem interface
 //------------------------------------------------------------------------------------
 
+const (
+	// Files which hold secrets, such as tokens, can only be read or written by their owner.
+	PRIVATE_FILE_PERMISSIONS os.FileMode = 0600
+)
+
 type OSFileSystem struct {
 }
 
@@ -53,6 +59,11 @@ func (osFS *OSFileSystem) Create(path string) (io.WriteCloser, error) {
 	return fileWriter, err
 }
 
+func (osFS *OSFileSystem) Append(path string) (io.WriteCloser, error) {
+	fileWriter, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	return fileWriter, err
+}
+
 func (osFS *OSFileSystem) GetFilePathSeparator() string {
 	return string(os.PathSeparator)
 }
@@ -79,6 +90,14 @@ func (osFS *OSFileSystem) DeleteFile(path string) {
 	os.Remove(path)
 }
 
+func (osFS *OSFileSystem) Rename(fromPath string, toPath string) error {
+	err := os.Rename(fromPath, toPath)
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_FAILED_TO_WRITE_FILE, toPath, err.Error())
+	}
+	return err
+}
+
 func (osFS *OSFileSystem) MkdirAll(targetFolderPath string) error {
 	err := os.MkdirAll(targetFolderPath, 0755)
 	if err != nil {
@@ -95,6 +114,49 @@ func (osFS *OSFileSystem) WriteBinaryFile(targetFilePath string, desiredContents
 	return err
 }
 
+func (osFS *OSFileSystem) WritePrivateBinaryFile(targetFilePath string, desiredContents []byte) error {
+	file, err := os.OpenFile(targetFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, PRIVATE_FILE_PERMISSIONS)
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_FAILED_TO_WRITE_FILE, targetFilePath, err.Error())
+	} else {
+		// A file which already existed keeps its permissions when it is opened, so set them
+		// before the contents are written.
+		err = file.Chmod(PRIVATE_FILE_PERMISSIONS)
+		if err == nil {
+			err = checkFileIsPrivate(file)
+		}
+
+		if err != nil {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_FILE_NOT_PRIVATE, targetFilePath, err.Error())
+		} else {
+			_, err = file.Write(desiredContents)
+			if err != nil {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_FAILED_TO_WRITE_FILE, targetFilePath, err.Error())
+			}
+		}
+
+		closeErr := file.Close()
+		if err == nil && closeErr != nil {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_FAILED_TO_WRITE_FILE, targetFilePath, closeErr.Error())
+		}
+	}
+	return err
+}
+
+// checkFileIsPrivate - Windows controls who can read a file with access control lists rather than
+// permission bits, so the check is only made on other systems.
+func checkFileIsPrivate(file *os.File) error {
+	var err error
+	if runtime.GOOS != "windows" {
+		var info os.FileInfo
+		info, err = file.Stat()
+		if err == nil && info.Mode().Perm()&^PRIVATE_FILE_PERMISSIONS != 0 {
+			err = fmt.Errorf("its permissions are %v", info.Mode().Perm())
+		}
+	}
+	return err
+}
+
 func (osFS *OSFileSystem) WriteTextFile(targetFilePath string, desiredContents string) error {
 	bytes := []byte(desiredContents)
 	err := osFS.WriteBinaryFile(targetFilePath, bytes)
@@ -23,8 +23,9 @@ import (
 // The implementation of the file system interface built on an in-memory map.
 // ------------------------------------------------------------------------------------
 type Node struct {
-	content []byte
-	isDir   bool
+	content   []byte
+	isDir     bool
+	isPrivate bool
 }
 
 type MockFileSystem struct {
@@ -46,19 +47,22 @@ type MockFileSystem struct {
 
 	// The mock struct contains methods which can be over-ridden on a per-test basis.
 	// The New
-	VirtualFunction_MkdirAll             func(targetFolderPath string) error
-	VirtualFunction_WriteTextFile        func(targetFilePath string, desiredContents string) error
-	VirtualFunction_ReadBinaryFile       func(filePath string) ([]byte, error)
-	VirtualFunction_ReadTextFile         func(filePath string) (string, error)
-	VirtualFunction_Exists               func(path string) (bool, error)
-	VirtualFunction_DirExists            func(path string) (bool, error)
-	VirtualFunction_GetUserHomeDirPath   func() (string, error)
-	VirtualFunction_WriteBinaryFile      func(targetFilePath string, desiredContents []byte) error
-	VirtualFunction_OutputWarningMessage func(string) error
-	VirtualFunction_MkTempDir            func() (string, error)
-	VirtualFunction_DeleteDir            func(path string)
-	VirtualFunction_DeleteFile           func(path string)
-	VirtualFunction_Create               func(path string) (io.WriteCloser, error)
+	VirtualFunction_MkdirAll               func(targetFolderPath string) error
+	VirtualFunction_WriteTextFile          func(targetFilePath string, desiredContents string) error
+	VirtualFunction_ReadBinaryFile         func(filePath string) ([]byte, error)
+	VirtualFunction_ReadTextFile           func(filePath string) (string, error)
+	VirtualFunction_Exists                 func(path string) (bool, error)
+	VirtualFunction_DirExists              func(path string) (bool, error)
+	VirtualFunction_GetUserHomeDirPath     func() (string, error)
+	VirtualFunction_WriteBinaryFile        func(targetFilePath string, desiredContents []byte) error
+	VirtualFunction_WritePrivateBinaryFile func(targetFilePath string, desiredContents []byte) error
+	VirtualFunction_OutputWarningMessage   func(string) error
+	VirtualFunction_MkTempDir              func() (string, error)
+	VirtualFunction_DeleteDir              func(path string)
+	VirtualFunction_DeleteFile             func(path string)
+	VirtualFunction_Rename                 func(fromPath string, toPath string) error
+	VirtualFunction_Create                 func(path string) (io.WriteCloser, error)
+	VirtualFunction_Append                 func(path string) (io.WriteCloser, error)
 }
 
 // NewMockFileSystem creates an implementation of the thin file system layer which delegates
@@ -93,6 +97,10 @@ func NewOverridableMockFileSystem() *MockFileSystem {
 		return mockFSCreate(mockFileSystem, path)
 	}
 
+	mockFileSystem.VirtualFunction_Append = func(path string) (io.WriteCloser, error) {
+		return mockFSAppend(mockFileSystem, path)
+	}
+
 	mockFileSystem.VirtualFunction_MkdirAll = func(targetFolderPath string) error {
 		return mockFSMkdirAll(mockFileSystem, targetFolderPath)
 	}
@@ -117,6 +125,9 @@ func NewOverridableMockFileSystem() *MockFileSystem {
 	mockFileSystem.VirtualFunction_WriteBinaryFile = func(path string, content []byte) error {
 		return mockFSWriteBinaryFile(mockFileSystem, path, content)
 	}
+	mockFileSystem.VirtualFunction_WritePrivateBinaryFile = func(path string, content []byte) error {
+		return mockFSWritePrivateBinaryFile(mockFileSystem, path, content)
+	}
 	mockFileSystem.VirtualFunction_OutputWarningMessage = func(message string) error {
 		return mockFSOutputWarningMessage(mockFileSystem, message)
 	}
@@ -133,6 +144,10 @@ func NewOverridableMockFileSystem() *MockFileSystem {
 		mockFSDeleteFile(mockFileSystem, pathToDelete)
 	}
 
+	mockFileSystem.VirtualFunction_Rename = func(fromPath string, toPath string) error {
+		return mockFSRename(mockFileSystem, fromPath, toPath)
+	}
+
 	randomSource := rand.NewSource(13)
 	mockFileSystem.random = rand.New(randomSource)
 
@@ -157,6 +172,10 @@ func (fs *MockFileSystem) Create(path string) (io.WriteCloser, error) {
 	return fs.VirtualFunction_Create(path)
 }
 
+func (fs *MockFileSystem) Append(path string) (io.WriteCloser, error) {
+	return fs.VirtualFunction_Append(path)
+}
+
 func (fs *MockFileSystem) GetFilePathSeparator() string {
 	return fs.filePathSeparator
 }
@@ -183,6 +202,12 @@ func (fs *MockFileSystem) DeleteFile(pathToDelete string) {
 	fs.VirtualFunction_DeleteFile(pathToDelete)
 }
 
+func (fs *MockFileSystem) Rename(fromPath string, toPath string) error {
+	fs.mutexLock.Lock()
+	defer fs.mutexLock.Unlock()
+	return fs.VirtualFunction_Rename(fromPath, toPath)
+}
+
 func (fs *MockFileSystem) MkTempDir() (string, error) {
 	// log.Printf("MkTempDir entered")
 	// defer log.Printf("MkTempDir exited")
@@ -207,6 +232,12 @@ func (fs *MockFileSystem) WriteBinaryFile(targetFilePath string, desiredContents
 	return fs.VirtualFunction_WriteBinaryFile(targetFilePath, desiredContents)
 }
 
+func (fs *MockFileSystem) WritePrivateBinaryFile(targetFilePath string, desiredContents []byte) error {
+	fs.mutexLock.Lock()
+	defer fs.mutexLock.Unlock()
+	return fs.VirtualFunction_WritePrivateBinaryFile(targetFilePath, desiredContents)
+}
+
 // WriteTextFile writes a string to a text file
 func (fs *MockFileSystem) WriteTextFile(targetFilePath string, desiredContents string) error {
 	// log.Printf("WriteTextFile entered")
@@ -276,6 +307,15 @@ func mockFSCreate(fs MockFileSystem, path string) (io.WriteCloser, error) {
 	return writer, nil
 }
 
+func mockFSAppend(fs MockFileSystem, path string) (io.WriteCloser, error) {
+	if fs.data[path] == nil {
+		nodeToAdd := Node{content: nil, isDir: false}
+		fs.data[path] = &nodeToAdd
+	}
+	writer := NewOverridableMockFile(&fs, path)
+	return writer, nil
+}
+
 func mockFSDeleteDir(fs MockFileSystem, pathToDelete string) {
 
 	// Figure out which entries we are going to delete.
@@ -296,6 +336,18 @@ func mockFSDeleteFile(fs MockFileSystem, pathToDelete string) {
 	delete(fs.data, pathToDelete)
 }
 
+func mockFSRename(fs MockFileSystem, fromPath string, toPath string) error {
+	var err error
+	node := fs.data[fromPath]
+	if node == nil {
+		err = os.ErrNotExist
+	} else {
+		fs.data[toPath] = node
+		delete(fs.data, fromPath)
+	}
+	return err
+}
+
 func mockFSMkTempDir(fs MockFileSystem) (string, error) {
 	tempFolderPath := "/tmp" + strconv.Itoa(fs.random.Intn(math.MaxInt))
 	err := fs.MkdirAll(tempFolderPath)
@@ -314,6 +366,12 @@ func mockFSWriteBinaryFile(fs MockFileSystem, targetFilePath string, desiredCont
 	return nil
 }
 
+func mockFSWritePrivateBinaryFile(fs MockFileSystem, targetFilePath string, desiredContents []byte) error {
+	nodeToAdd := Node{content: desiredContents, isDir: false, isPrivate: true}
+	fs.data[targetFilePath] = &nodeToAdd
+	return nil
+}
+
 func mockFSWriteTextFile(fs MockFileSystem, targetFilePath string, desiredContents string) error {
 	nodeToAdd := Node{content: []byte(desiredContents), isDir: false}
 	fs.data[targetFilePath] = &nodeToAdd
@@ -386,6 +444,14 @@ func (fs MockFileSystem) GetAllWarningMessages() string {
 	return messages
 }
 
+// IsPrivateFile - Was the file written so only its owner can read it ?
+func (fs *MockFileSystem) IsPrivateFile(path string) bool {
+	fs.mutexLock.Lock()
+	defer fs.mutexLock.Unlock()
+	node := fs.data[path]
+	return node != nil && node.isPrivate
+}
+
 func (fs *MockFileSystem) GetAllFilePaths(rootPath string) ([]string, error) {
 	// log.Printf("GetAllFilePaths entered")
 	// defer log.Printf("GetAllFilePaths exited")
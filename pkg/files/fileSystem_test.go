@@ -6,6 +6,7 @@
 package files
 
 import (
+	"os"
 	"runtime"
 	"strings"
 	"testing"
@@ -178,3 +179,66 @@ func TestCanGetFilePathsFromDeepFolder(t *testing.T) {
 	assert.Equal(t, textFilePath1, collectedPaths[0])
 	assert.Equal(t, textFilePath2, collectedPaths[1])
 }
+
+func TestWritePrivateBinaryFileCanOnlyBeReadByItsOwner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows controls who can read a file with access control lists rather than permission bits.")
+	}
+	fs := NewOSFileSystem()
+	tempFolderPath, _ := fs.MkTempDir()
+	defer func() {
+		fs.DeleteDir(tempFolderPath)
+	}()
+	filePath := tempFolderPath + fs.GetFilePathSeparator() + "secret.txt"
+
+	// A file which already exists keeps its old permissions unless they are changed.
+	err := fs.WriteTextFile(filePath, "old")
+	assert.Nil(t, err)
+
+	err = fs.WritePrivateBinaryFile(filePath, []byte("my-token"))
+	assert.Nil(t, err)
+
+	info, err := os.Stat(filePath)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	textGotBack, _ := fs.ReadTextFile(filePath)
+	assert.Equal(t, "my-token", textGotBack)
+}
+
+func TestRenameReplacesFileAlreadyThere(t *testing.T) {
+	fs := NewOSFileSystem()
+	tempFolderPath, _ := fs.MkTempDir()
+	defer func() {
+		fs.DeleteDir(tempFolderPath)
+	}()
+	oldFilePath := tempFolderPath + fs.GetFilePathSeparator() + "file.txt"
+	newFilePath := oldFilePath + ".tmp"
+	fs.WriteTextFile(oldFilePath, "old")
+	fs.WriteTextFile(newFilePath, "new")
+
+	err := fs.Rename(newFilePath, oldFilePath)
+	assert.Nil(t, err)
+
+	textGotBack, _ := fs.ReadTextFile(oldFilePath)
+	assert.Equal(t, "new", textGotBack)
+	isExists, _ := fs.Exists(newFilePath)
+	assert.False(t, isExists)
+}
+
+func TestAppendAddsToTheEndOfFileAlreadyThere(t *testing.T) {
+	fs := NewOSFileSystem()
+	tempFolderPath, _ := fs.MkTempDir()
+	defer func() {
+		fs.DeleteDir(tempFolderPath)
+	}()
+	filePath := tempFolderPath + fs.GetFilePathSeparator() + "file.txt"
+	fs.WriteTextFile(filePath, "old\n")
+
+	writer, err := fs.Append(filePath)
+	assert.Nil(t, err)
+	writer.Write([]byte("new\n"))
+	writer.Close()
+
+	textGotBack, _ := fs.ReadTextFile(filePath)
+	assert.Equal(t, "old\nnew\n", textGotBack)
+}
@@ -37,6 +37,11 @@ type TestResultsSummary struct {
 
 // JvmLauncher can act as a launcher, it's given test cases which need to
 // be executed, and it launches them within a local JVM.
+const (
+	// The result of a local test run whose JVM was killed because it was cancelled.
+	LOCAL_RUN_RESULT_CANCELLED = "Cancelled"
+)
+
 type JvmLauncher struct {
 	// The fully-qualified path to JAVA_HOME where we can find the bin/java command.
 	javaHome string
@@ -490,6 +495,13 @@ func (launcher *JvmLauncher) GetRunsByGroup(groupName string) (*galasaapi.TestRu
 			testRun = createSimulatedTestRun(testName)
 		}
 
+		if localTest.isCancelled && testRun.GetStatus() != "finished" {
+			cancelledRun := *testRun
+			cancelledRun.SetStatus("finished")
+			cancelledRun.SetResult(LOCAL_RUN_RESULT_CANCELLED)
+			testRun = &cancelledRun
+		}
+
 		testRuns.Runs = append(testRuns.Runs, *testRun)
 	}
 
@@ -500,6 +512,29 @@ func (launcher *JvmLauncher) GetRunsByGroup(groupName string) (*galasaapi.TestRu
 	return &testRuns, nil
 }
 
+// CancelRun kills the JVM running a test which this launcher launched.
+func (launcher *JvmLauncher) CancelRun(runName string) error {
+	log.Printf("JvmLauncher: CancelRun(runName=%s) entered.", runName)
+
+	var err error
+	var isFound bool
+
+	for _, localTest := range launcher.localTests {
+		if localTest.runId == runName {
+			isFound = true
+			err = localTest.cancel()
+			if err != nil {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_CANCEL_LOCAL_RUN_FAILED, runName, err.Error())
+			}
+		}
+	}
+
+	if !isFound {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_CANCEL_LOCAL_RUN_FAILED, runName, "it was not launched by this command")
+	}
+	return err
+}
+
 // GetRunsById gets the Run information for the run with a specific run identifier
 func (launcher *JvmLauncher) GetRunsById(runId string) (*galasaapi.Run, error) {
 	log.Printf("JvmLauncher: GetRunsById entered. runId=%s", runId)
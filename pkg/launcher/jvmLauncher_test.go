@@ -1705,3 +1705,76 @@ func TestGetRunsByIdReturnsOk(t *testing.T) {
 	assert.Equal(t, "Passed", run.TestStructure.GetResult())
 	assert.Equal(t, "simpleSampleTest", run.GetTestStructure().Methods[0].GetMethodName())
 }
+
+func TestCancelRunKillsTheJvmAndReportsTheRunAsCancelled(t *testing.T) {
+	// Given...
+	bootstrapProps, env, fs, embeddedReadOnlyFS,
+		jvmLaunchParams, timeService, timedSleeper, _, galasaHome := NewMockLauncherParams()
+	mockProcess := NewMockProcess()
+
+	mockFactory := &utils.MockFactory{
+		Env:         env,
+		FileSystem:  fs,
+		TimeService: timeService,
+	}
+
+	launcher, err := NewJVMLauncher(
+		mockFactory,
+		bootstrapProps, embeddedReadOnlyFS,
+		jvmLaunchParams, NewMockProcessFactory(mockProcess), galasaHome, timedSleeper,
+	)
+	assert.Nil(t, err)
+
+	testRuns, err := launcher.SubmitTestRun(
+		"myGroup",
+		"galasa.dev.example.banking.account/galasa.dev.example.banking.account.TestAccount",
+		"myRequestType-UnitTest",
+		"myRequestor",
+		"unitTestStream",
+		"mvn:myGroup/myArtifact/myClassifier/obr",
+		false,
+		"", // No Gherkin URL supplied
+		"", // No Gherkin Feature supplied
+		make(map[string]interface{}),
+	)
+	assert.Nil(t, err)
+	runName := testRuns.Runs[0].GetName()
+
+	// When...
+	err = launcher.CancelRun(runName)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.True(t, mockProcess.isKilled)
+
+	groupRuns, err := launcher.GetRunsByGroup("myGroup")
+	assert.Nil(t, err)
+	assert.Equal(t, "finished", groupRuns.Runs[0].GetStatus())
+	assert.Equal(t, LOCAL_RUN_RESULT_CANCELLED, groupRuns.Runs[0].GetResult())
+}
+
+func TestCancelRunWhichWasNotLaunchedFails(t *testing.T) {
+	// Given...
+	bootstrapProps, env, fs, embeddedReadOnlyFS,
+		jvmLaunchParams, timeService, timedSleeper, mockProcessFactory, galasaHome := NewMockLauncherParams()
+
+	mockFactory := &utils.MockFactory{
+		Env:         env,
+		FileSystem:  fs,
+		TimeService: timeService,
+	}
+
+	launcher, err := NewJVMLauncher(
+		mockFactory,
+		bootstrapProps, embeddedReadOnlyFS,
+		jvmLaunchParams, mockProcessFactory, galasaHome, timedSleeper,
+	)
+	assert.Nil(t, err)
+
+	// When...
+	err = launcher.CancelRun("L999")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1324E: Failed to cancel local test run 'L999'")
+}
@@ -31,6 +31,10 @@ type MockLauncher struct {
 	allTestRuns *galasaapi.TestRuns
 	nextRunId   int
 	launches    []LaunchParameters
+
+	// The results which each submission of a test class will finish with, in the order
+	// they are submitted. Keyed by the bundle/class name.
+	plannedResults map[string][]string
 }
 
 func NewMockLauncher() *MockLauncher {
@@ -38,9 +42,34 @@ func NewMockLauncher() *MockLauncher {
 	launcher.allTestRuns = newEmptyTestRun()
 	launcher.allTestRuns.Runs = make([]galasaapi.TestRun, 0)
 	launcher.nextRunId = 100
+	launcher.plannedResults = make(map[string][]string)
 	return launcher
 }
 
+// SetPlannedResults sets the results which successive submissions of the named bundle/class
+// will finish with. Once the planned results are used up, runs finish with a "Passed" result.
+func (launcher *MockLauncher) SetPlannedResults(className string, results ...string) {
+	launcher.plannedResults[className] = results
+}
+
+// SetRunStatus changes the status which the named run is reported as having.
+func (launcher *MockLauncher) SetRunStatus(runName string, status string) {
+	for index, run := range launcher.allTestRuns.Runs {
+		if run.GetName() == runName {
+			launcher.allTestRuns.Runs[index].SetStatus(status)
+		}
+	}
+}
+
+// SetRunResult changes the result which the named run is reported as having.
+func (launcher *MockLauncher) SetRunResult(runName string, result string) {
+	for index, run := range launcher.allTestRuns.Runs {
+		if run.GetName() == runName {
+			launcher.allTestRuns.Runs[index].SetResult(result)
+		}
+	}
+}
+
 //-------------------------------------------------------------------
 // Implementation of the launcher interface.
 //-------------------------------------------------------------------
@@ -103,10 +132,16 @@ func (launcher *MockLauncher) SubmitTestRun(
 
 	newTestRun.SetStream(stream)
 	newTestRun.SetName(name)
-	launcher.allTestRuns.Runs = append(launcher.allTestRuns.Runs, *newTestRun)
+
+	result := "Passed"
+	plannedResults := launcher.plannedResults[className]
+	if len(plannedResults) > 0 {
+		result = plannedResults[0]
+		launcher.plannedResults[className] = plannedResults[1:]
+	}
 
 	newTestRun.SetStatus("finished")
-	newTestRun.SetResult("Passed")
+	newTestRun.SetResult(result)
 
 	// Add the new test run to an empty list, so the caller can read things off it.
 	testRunList := newEmptyTestRun()
@@ -115,7 +150,7 @@ func (launcher *MockLauncher) SubmitTestRun(
 	// Add the new test run to our list so we can return details when asked about it later.
 	launcher.allTestRuns.Runs = append(launcher.allTestRuns.Runs, *newTestRun)
 
-	return launcher.allTestRuns, nil
+	return testRunList, nil
 }
 
 // GetRunsById gets the Run information for the run with a specific run identifier
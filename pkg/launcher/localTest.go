@@ -51,6 +51,9 @@ type LocalTest struct {
 
 	// Something which can create new processes in the operating system
 	processFactory ProcessFactory
+
+	// Set once the JVM has been killed because the test run was cancelled.
+	isCancelled bool
 }
 
 // A structure which tells us all we know about a JVM process we launched.
@@ -266,3 +269,11 @@ func (localTest *LocalTest) isCompleted() bool {
 	}
 	return isComplete
 }
+
+// cancel - Kills the JVM running the test. It can't record its own result once it has been killed,
+// so it is reported as cancelled from then on.
+func (localTest *LocalTest) cancel() error {
+	log.Printf("Killing the JVM running test run %s\n", localTest.runId)
+	localTest.isCancelled = true
+	return localTest.process.Kill()
+}
@@ -30,6 +30,9 @@ type Process interface {
 
 	// Wait for the process to complete. This is a blocking call.
 	Wait() error
+
+	// Kill the process straight away, without waiting for it to exit.
+	Kill() error
 }
 
 //----------------------------------------------------------------------------------
@@ -73,6 +76,12 @@ func (proc *realProcess) Wait() error {
 	return err
 }
 
+// Kill the process.
+func (proc *realProcess) Kill() error {
+	err := proc.process.Process.Kill()
+	return err
+}
+
 // ----------------------------------------------------------------------------------
 // A mock implementation which creates mock processes for use in unit testing.
 // ----------------------------------------------------------------------------------
@@ -93,6 +102,8 @@ type mockProcess struct {
 	stdErr io.Writer
 	cmd    string
 	args   []string
+
+	isKilled bool
 }
 
 // Create a new mock process.
@@ -114,6 +125,12 @@ func (mockProcess *mockProcess) Wait() error {
 	return nil
 }
 
+// Kill the mock process. The mock only records that it was killed.
+func (mockProcess *mockProcess) Kill() error {
+	mockProcess.isKilled = true
+	return nil
+}
+
 func (mockProcess *mockProcess) Start(cmd string, args []string, stdOut io.Writer, stdErr io.Writer) error {
 
 	// Store the values received by the mock so they can be examined.
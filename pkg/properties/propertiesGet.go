@@ -19,6 +19,8 @@ import (
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/propertiesformatter"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
+	"github.com/galasa-dev/cli/pkg/utils"
 )
 
 var (
@@ -36,6 +38,7 @@ func GetProperties(
 	infix string,
 	apiClient *galasaapi.APIClient,
 	propertiesOutputFormat string,
+	tableOptions *utils.TableOptions,
 	console spi.Console,
 ) error {
 	var err error
@@ -48,6 +51,9 @@ func GetProperties(
 			var chosenFormatter propertiesformatter.PropertyFormatter
 
 			chosenFormatter, err = validateOutputFormatFlagValue(propertiesOutputFormat, validPropertyFormatters)
+			if err == nil {
+				err = utils.ApplyTableOptions(chosenFormatter, tableOptions)
+			}
 			if err == nil {
 				var cpsProperty []galasaapi.GalasaProperty
 				cpsProperty, err = getCpsPropertiesFromRestApi(namespace, name, prefix, suffix, infix, apiClient)
@@ -130,8 +136,11 @@ func CreateFormatters(hasYamlFormat bool) map[string]propertiesformatter.Propert
 	summaryFormatter := propertiesformatter.NewPropertySummaryFormatter()
 	rawFormatter := propertiesformatter.NewPropertyRawFormatter()
 
+	csvFormatter := propertiesformatter.NewPropertyCsvFormatter()
+
 	validFormatters[summaryFormatter.GetName()] = summaryFormatter
 	validFormatters[rawFormatter.GetName()] = rawFormatter
+	validFormatters[csvFormatter.GetName()] = csvFormatter
 
 	if hasYamlFormat {
 		yamlFormatter := propertiesformatter.NewPropertyYamlFormatter()
@@ -145,10 +154,17 @@ func CreateFormatters(hasYamlFormat bool) map[string]propertiesformatter.Propert
 func validateOutputFormatFlagValue(propertiesOutputFormat string, validFormatters map[string]propertiesformatter.PropertyFormatter) (propertiesformatter.PropertyFormatter, error) {
 	var err error
 
-	chosenFormatter, isPresent := validFormatters[propertiesOutputFormat]
+	var chosenFormatter propertiesformatter.PropertyFormatter
 
-	if !isPresent {
-		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_OUTPUT_FORMAT, propertiesOutputFormat, GetFormatterNamesString(validFormatters))
+	if templateformatter.IsTemplateFormat(propertiesOutputFormat) {
+		chosenFormatter, err = propertiesformatter.NewPropertyTemplateFormatter(propertiesOutputFormat)
+	} else {
+		var isPresent bool
+		chosenFormatter, isPresent = validFormatters[propertiesOutputFormat]
+
+		if !isPresent {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_OUTPUT_FORMAT, propertiesOutputFormat, GetFormatterNamesString(validFormatters))
+		}
 	}
 
 	return chosenFormatter, err
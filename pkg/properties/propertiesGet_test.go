@@ -325,7 +325,7 @@ func TestInvalidNamepsaceReturnsError(t *testing.T) {
 	apiClient := api.InitialiseAPI(apiServerUrl)
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Error(t, err)
@@ -359,7 +359,7 @@ Total:4
 `
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -386,7 +386,7 @@ func TestEmptyNamespaceReturnsEmpty(t *testing.T) {
 	expectedOutput := `Total:0
 `
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -417,7 +417,7 @@ Total:1
 `
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -448,7 +448,7 @@ Total:1
 `
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -479,7 +479,7 @@ Total:1
 `
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -507,7 +507,7 @@ func TestValidNamespaceWithNoMatchingPrefixAndSuffixReturnsEmpty(t *testing.T) {
 `
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -535,7 +535,7 @@ func TestValidNamespaceAndNoMatchingPrefixReturnsEmpty(t *testing.T) {
 `
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -563,7 +563,7 @@ func TestValidNamespaceAndNoMatchingSuffixReturnsEmpty(t *testing.T) {
 `
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -593,7 +593,7 @@ validnamespace extra.anInfix.extra infixVal
 Total:1
 `
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -623,7 +623,7 @@ validnamespace extra.anInfix.extra infixVal
 Total:1
 `
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -650,7 +650,7 @@ func TestValidNamespaceWithNoMatchingInfixReturnsEmpty(t *testing.T) {
 	expectedOutput := `Total:0
 `
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -681,7 +681,7 @@ Total:1
 `
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -712,7 +712,7 @@ Total:1
 `
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -743,7 +743,7 @@ Total:1
 `
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -773,7 +773,7 @@ validnamespace property0 value0
 Total:1
 `
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -804,7 +804,7 @@ Total:1
 `
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -832,7 +832,7 @@ func TestInvalidPropertyNameReturnsEmpty(t *testing.T) {
 `
 
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -862,7 +862,7 @@ validnamespace|property2|value2
 validnamespace|property3|value3
 `
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -888,7 +888,7 @@ func TestEmptyNamespaceRawFormatReturnsOk(t *testing.T) {
 
 	expectedOutput := ``
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -945,7 +945,7 @@ data:
     value: value3
 `
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
@@ -971,13 +971,55 @@ func TestEmptyNamespaceYamlFormatReturnsOk(t *testing.T) {
 
 	expectedOutput := ``
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.Nil(t, err)
 	assert.Equal(t, expectedOutput, mockConsole.ReadText())
 }
 
+func TestValidNamespaceTemplateFormatReturnsOk(t *testing.T) {
+	//Given...
+	namespace := "validnamespace"
+	name := ""
+	prefix := ""
+	suffix := ""
+	infix := ""
+	propertiesOutputFormat := "template={{.metadata.namespace}}.{{.metadata.name}}={{.data.value}}"
+
+	server := NewPropertiesServletMock(t)
+	apiServerUrl := server.URL
+	defer server.Close()
+
+	mockConsole := utils.NewMockConsole()
+
+	apiClient := api.InitialiseAPI(apiServerUrl)
+
+	expectedOutput := "validnamespace.property0=value0\n" +
+		"validnamespace.property1=value1\n" +
+		"validnamespace.property2=value2\n" +
+		"validnamespace.property3=value3\n"
+	//When
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOutput, mockConsole.ReadText())
+}
+
+func TestCreateFormattersBadTemplateReturnsError(t *testing.T) {
+	//Given
+	hasYamlFormat := false
+
+	//When
+	validFormatters := CreateFormatters(hasYamlFormat)
+	_, err := validateOutputFormatFlagValue("template={{.metadata.name", validFormatters)
+
+	//Then
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1309E")
+}
+
 func TestCreateFormattersSummaryReturnsOk(t *testing.T) {
 	//Given
 	hasYamlFormat := false
@@ -1052,7 +1094,7 @@ func TestInvalidNamespaceFormatWithCapitalLettersReturnsError(t *testing.T) {
 
 	expectedOutput := ``
 	//When
-	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, mockConsole)
+	err := GetProperties(namespace, name, prefix, suffix, infix, apiClient, propertiesOutputFormat, nil, mockConsole)
 
 	//Then
 	assert.NotNil(t, err)
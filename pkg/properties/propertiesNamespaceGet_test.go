@@ -144,3 +144,24 @@ func TestEmptyNamespacesRawFormatReturnsOk(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expectedOutput, console.ReadText())
 }
+
+func TestMultipleNamespacesTemplateFormatReturnsOk(t *testing.T) {
+	//Given...
+	namespaceOutputFormat := "template={{padRight .name 11}}{{.type}}"
+	serverState := "populated"
+	server := NewPropertiesNamespaceServletMock(t, serverState)
+	apiClient := api.InitialiseAPI(server.URL)
+	defer server.Close()
+
+	console := utils.NewMockConsole()
+	expectedOutput := "framework  normal\n" +
+		"secure     secure\n" +
+		"anamespace normal\n"
+
+	//When
+	err := GetPropertiesNamespaces(apiClient, namespaceOutputFormat, console)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOutput, console.ReadText())
+}
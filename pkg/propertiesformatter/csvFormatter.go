@@ -0,0 +1,45 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package propertiesformatter
+
+import (
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// -----------------------------------------------------
+// CSV format. The table the summary format writes, as comma-separated values,
+// without the totals. Property values are not cropped.
+type PropertyCsvFormatter struct {
+	utils.TableLayout
+}
+
+func NewPropertyCsvFormatter() PropertyFormatter {
+	return new(PropertyCsvFormatter)
+}
+
+func (*PropertyCsvFormatter) GetName() string {
+	return utils.CSV_FORMATTER_NAME
+}
+
+func (formatter *PropertyCsvFormatter) FormatProperties(cpsProperties []galasaapi.GalasaProperty) (string, error) {
+	buff := strings.Builder{}
+
+	isCroppingValues := false
+	err := formatter.WriteCsvTable(getPropertiesTable(cpsProperties, isCroppingValues), &buff)
+
+	return buff.String(), err
+}
+
+func (formatter *PropertyCsvFormatter) FormatNamespaces(namespaces []galasaapi.Namespace) (string, error) {
+	buff := strings.Builder{}
+
+	err := formatter.WriteCsvTable(getNamespacesTable(namespaces), &buff)
+
+	return buff.String(), err
+}
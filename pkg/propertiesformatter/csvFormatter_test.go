@@ -0,0 +1,61 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package propertiesformatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropertiesCsvFormatterNoDataReturnsHeadersOnly(t *testing.T) {
+	// Given...
+	formatter := NewPropertyCsvFormatter()
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatProperties(make([]galasaapi.GalasaProperty, 0))
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "namespace,name,value\n", actualFormattedOutput)
+}
+
+func TestPropertiesCsvFormatterDoesNotCropLongValues(t *testing.T) {
+	// Given...
+	formatter := NewPropertyCsvFormatter()
+	longValue := strings.Repeat("x", PROPERTY_VALUE_MAX_VISIBLE_LENGTH+10)
+	properties := []galasaapi.GalasaProperty{
+		*CreateMockGalasaProperty("namespace", "name1", longValue),
+		*CreateMockGalasaProperty("namespace", "name2", "value2, with a comma"),
+	}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatProperties(properties)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "namespace,name,value\n"+
+		"namespace,name1,"+longValue+"\n"+
+		"namespace,name2,\"value2, with a comma\"\n", actualFormattedOutput)
+}
+
+func TestPropertiesCsvFormatterFormatsNamespaces(t *testing.T) {
+	// Given...
+	formatter := NewPropertyCsvFormatter()
+	namespaces := []galasaapi.Namespace{
+		*CreateNamespace("framework", "normal", "/cps/framework"),
+		*CreateNamespace("secure", "secure", "/cps/secure"),
+	}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatNamespaces(namespaces)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "namespace,type\nframework,normal\nsecure,secure\n", actualFormattedOutput)
+}
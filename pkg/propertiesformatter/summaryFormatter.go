@@ -20,6 +20,7 @@ const (
 )
 
 type PropertySummaryFormatter struct {
+	utils.TableLayout
 }
 
 func NewPropertySummaryFormatter() PropertyFormatter {
@@ -30,68 +31,86 @@ func (*PropertySummaryFormatter) GetName() string {
 	return SUMMARY_FORMATTER_NAME
 }
 
-func (*PropertySummaryFormatter) FormatProperties(cpsProperties []galasaapi.GalasaProperty) (string, error) {
+func (formatter *PropertySummaryFormatter) FormatProperties(cpsProperties []galasaapi.GalasaProperty) (string, error) {
 	var result string
 	var err error
 	buff := strings.Builder{}
 	totalProperties := len(cpsProperties)
 
 	if totalProperties > 0 {
-		var table [][]string
-
-		var headers = []string{HEADER_PROPERTY_NAMESPACE, HEADER_PROPERTY_NAME, HEADER_PROPERTY_VALUE}
-
-		table = append(table, headers)
-		for _, property := range cpsProperties {
-			var line []string
-			namespace := *property.Metadata.Namespace
-			name := *property.Metadata.Name
-			value := *property.Data.Value
-
-			line = append(line, namespace)
-			line = append(line, name, cropExtraLongValue(substituteNewLines(value)))
-			table = append(table, line)
-		}
-
-		columnLengths := utils.CalculateMaxLengthOfEachColumn(table)
-		utils.WriteFormattedTableToStringBuilder(table, &buff, columnLengths)
+		isCroppingValues := true
+		err = formatter.WriteTable(getPropertiesTable(cpsProperties, isCroppingValues), &buff)
 
 		buff.WriteString("\n")
 
 	}
 	buff.WriteString("Total:" + strconv.Itoa(totalProperties) + "\n")
 
-	result = buff.String()
+	if err == nil {
+		result = buff.String()
+	}
 	return result, err
 }
 
-func (*PropertySummaryFormatter) FormatNamespaces(namespaces []galasaapi.Namespace) (string, error) {
+func (formatter *PropertySummaryFormatter) FormatNamespaces(namespaces []galasaapi.Namespace) (string, error) {
 	var result string
 	var err error
 	buff := strings.Builder{}
 	totalNamespaces := len(namespaces)
 
 	if totalNamespaces > 0 {
-		var table [][]string
+		err = formatter.WriteTable(getNamespacesTable(namespaces), &buff)
+
+		buff.WriteString("\n")
+
+	}
+	buff.WriteString("Total:" + strconv.Itoa(totalNamespaces) + "\n")
+
+	if err == nil {
+		result = buff.String()
+	}
+	return result, err
+}
 
-		var headers = []string{HEADER_NAMESPACE, HEADER_NAMESPACE_TYPE}
+// getPropertiesTable - The table of properties which the summary and csv formats write.
+// The summary format crops long values, so they fit on a line.
+func getPropertiesTable(cpsProperties []galasaapi.GalasaProperty, isCroppingValues bool) [][]string {
+	var table [][]string
 
-		table = append(table, headers)
-		for _, namespace := range namespaces {
-			var line []string
+	var headers = []string{HEADER_PROPERTY_NAMESPACE, HEADER_PROPERTY_NAME, HEADER_PROPERTY_VALUE}
 
-			line = append(line, *namespace.Name, *namespace.Type)
-			table = append(table, line)
+	table = append(table, headers)
+	for _, property := range cpsProperties {
+		var line []string
+		namespace := *property.Metadata.Namespace
+		name := *property.Metadata.Name
+		value := *property.Data.Value
+
+		if isCroppingValues {
+			value = cropExtraLongValue(substituteNewLines(value))
 		}
 
-		columnLengths := utils.CalculateMaxLengthOfEachColumn(table)
-		utils.WriteFormattedTableToStringBuilder(table, &buff, columnLengths)
+		line = append(line, namespace)
+		line = append(line, name, value)
+		table = append(table, line)
+	}
 
-		buff.WriteString("\n")
+	return table
+}
+
+// getNamespacesTable - The table of namespaces which the summary and csv formats write.
+func getNamespacesTable(namespaces []galasaapi.Namespace) [][]string {
+	var table [][]string
+
+	var headers = []string{HEADER_NAMESPACE, HEADER_NAMESPACE_TYPE}
 
+	table = append(table, headers)
+	for _, namespace := range namespaces {
+		var line []string
+
+		line = append(line, *namespace.Name, *namespace.Type)
+		table = append(table, line)
 	}
-	buff.WriteString("Total:" + strconv.Itoa(totalNamespaces) + "\n")
 
-	result = buff.String()
-	return result, err
+	return table
 }
@@ -0,0 +1,51 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package propertiesformatter
+
+import (
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
+)
+
+// -----------------------------------------------------
+// Go template format. The template is run against each property
+// or namespace in turn.
+type PropertyTemplateFormatter struct {
+	formatter *templateformatter.TemplateFormatter
+}
+
+func NewPropertyTemplateFormatter(format string) (PropertyFormatter, error) {
+	var propertyFormatter PropertyFormatter
+
+	formatter, err := templateformatter.NewTemplateFormatter(format)
+	if err == nil {
+		templateFormatter := new(PropertyTemplateFormatter)
+		templateFormatter.formatter = formatter
+		propertyFormatter = templateFormatter
+	}
+
+	return propertyFormatter, err
+}
+
+func (templateFormatter *PropertyTemplateFormatter) GetName() string {
+	return templateFormatter.formatter.GetName()
+}
+
+func (templateFormatter *PropertyTemplateFormatter) FormatProperties(cpsProperties []galasaapi.GalasaProperty) (string, error) {
+	items := make([]interface{}, 0)
+	for _, property := range cpsProperties {
+		items = append(items, property)
+	}
+	return templateFormatter.formatter.FormatItems(items)
+}
+
+func (templateFormatter *PropertyTemplateFormatter) FormatNamespaces(namespaces []galasaapi.Namespace) (string, error) {
+	items := make([]interface{}, 0)
+	for _, namespace := range namespaces {
+		items = append(items, namespace)
+	}
+	return templateFormatter.formatter.FormatItems(items)
+}
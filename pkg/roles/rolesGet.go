@@ -17,6 +17,8 @@ import (
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/rolesformatter"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
+	"github.com/galasa-dev/cli/pkg/utils"
 )
 
 var (
@@ -26,6 +28,7 @@ var (
 func GetRoles(
 	roleName string,
 	format string,
+	tableOptions *utils.TableOptions,
 	console spi.Console,
 	apiClient *galasaapi.APIClient,
 	byteReader spi.ByteReader,
@@ -35,6 +38,9 @@ func GetRoles(
 	roles := make([]galasaapi.RBACRole, 0)
 
 	chosenFormatter, err = validateFormatFlag(format)
+	if err == nil {
+		err = utils.ApplyTableOptions(chosenFormatter, tableOptions)
+	}
 	if err == nil {
 		log.Printf("formatter flag is valid.\n")
 		if roleName != "" {
@@ -177,9 +183,11 @@ func createFormatters() map[string]rolesformatter.RolesFormatter {
 	formatters := make(map[string]rolesformatter.RolesFormatter, 0)
 	summaryFormatter := rolesformatter.NewRolesSummaryFormatter()
 	yamlFormatter := rolesformatter.NewRolesYamlFormatter()
+	csvFormatter := rolesformatter.NewRolesCsvFormatter()
 
 	formatters[summaryFormatter.GetName()] = summaryFormatter
 	formatters[yamlFormatter.GetName()] = yamlFormatter
+	formatters[csvFormatter.GetName()] = csvFormatter
 
 	return formatters
 }
@@ -206,10 +214,17 @@ func GetFormatterNamesAsString() string {
 func validateFormatFlag(outputFormatString string) (rolesformatter.RolesFormatter, error) {
 	var err error
 
-	chosenFormatter, isPresent := formatters[outputFormatString]
+	var chosenFormatter rolesformatter.RolesFormatter
 
-	if !isPresent {
-		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_OUTPUT_FORMAT, outputFormatString, GetFormatterNamesAsString())
+	if templateformatter.IsTemplateFormat(outputFormatString) {
+		chosenFormatter, err = rolesformatter.NewRoleTemplateFormatter(outputFormatString)
+	} else {
+		var isPresent bool
+		chosenFormatter, isPresent = formatters[outputFormatString]
+
+		if !isPresent {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_OUTPUT_FORMAT, outputFormatString, GetFormatterNamesAsString())
+		}
 	}
 
 	return chosenFormatter, err
@@ -85,6 +85,7 @@ func TestGetANamedRoleWhenRoleExistsFindsItOkSummaryFormat(t *testing.T) {
 	err := GetRoles(
 		roleName,
 		outputFormat,
+		nil,
 		console,
 		apiClient,
 		mockByteReader)
@@ -140,6 +141,7 @@ func TestCanGetAllRolesOkSummaryFormat(t *testing.T) {
 	err := GetRoles(
 		roleNameToLookFor,
 		outputFormat,
+		nil,
 		console,
 		apiClient,
 		mockByteReader)
@@ -194,6 +196,7 @@ func TestCanGetARoleByIdWhenRoleExistsFindsItOkInYamlFormat(t *testing.T) {
 	err := GetRoles(
 		roleName,
 		outputFormat,
+		nil,
 		console,
 		apiClient,
 		mockByteReader)
@@ -248,6 +251,7 @@ func TestCanGetARoleByIdWhenRoleDoesNotExistCausesError(t *testing.T) {
 	err := GetRoles(
 		"NotTheCorrectName",
 		outputFormat,
+		nil,
 		console,
 		apiClient,
 		mockByteReader)
@@ -273,6 +277,7 @@ func TestTryGettingAnythingWithAnInvalidFormatterNameFailsImmediately(t *testing
 	err := GetRoles(
 		"validRoleName",
 		"unknownOutputFormatInvalid",
+		nil,
 		console,
 		apiClient,
 		mockByteReader)
@@ -311,6 +316,7 @@ func TestCanGetARoleByIdWhenRoleExistsFindsItOkSummaryFormat(t *testing.T) {
 	err := GetRoles(
 		roleName,
 		outputFormat,
+		nil,
 		console,
 		apiClient,
 		mockByteReader)
@@ -319,3 +325,51 @@ func TestCanGetARoleByIdWhenRoleExistsFindsItOkSummaryFormat(t *testing.T) {
 	assert.NotNil(t, err, "GetRoles returned an no error when one was expected")
 	assert.Contains(t, err.Error(), "GAL1206E")
 }
+
+func TestCanGetARoleByIdWhenRoleExistsFindsItOkInTemplateFormat(t *testing.T) {
+	// Given...
+	roleId := "role1"
+	roleName := "role1Name"
+	description := "role1Description"
+	outputFormat := "template={{.metadata.name}}: {{join .data.actions \", \"}}"
+
+	// Create the test role array to return
+	role := createTestGalasaRole(roleId, roleName, description)
+	roles := make([]galasaapi.RBACRole, 0)
+	roles = append(roles, role)
+	rolesBytes, _ := json.Marshal(roles)
+	rolesJson := string(rolesBytes)
+
+	// Create the expected HTTP interactions with the API server.
+	getRoleInteraction := utils.NewHttpInteraction("/rbac/roles", http.MethodGet)
+	getRoleInteraction.WriteHttpResponseFunc = func(writer http.ResponseWriter, req *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		writer.Write([]byte(rolesJson))
+	}
+
+	interactions := []utils.HttpInteraction{
+		getRoleInteraction,
+	}
+
+	server := utils.NewMockHttpServer(t, interactions)
+	defer server.Server.Close()
+
+	console := utils.NewMockConsole()
+	apiServerUrl := server.Server.URL
+	apiClient := api.InitialiseAPI(apiServerUrl)
+	mockByteReader := utils.NewMockByteReader()
+
+	// When...
+	err := GetRoles(
+		roleName,
+		outputFormat,
+		nil,
+		console,
+		apiClient,
+		mockByteReader)
+
+	// Then...
+	assert.Nil(t, err, "GetRoles returned an unexpected error")
+	assert.Equal(t, "role1Name: action1, action2\n", console.ReadText())
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package rolesformatter
+
+import (
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// -----------------------------------------------------
+// CSV format. The table the summary format writes, as comma-separated values,
+// without the totals.
+type RolesCsvFormatter struct {
+	utils.TableLayout
+}
+
+func NewRolesCsvFormatter() RolesFormatter {
+	return new(RolesCsvFormatter)
+}
+
+func (*RolesCsvFormatter) GetName() string {
+	return utils.CSV_FORMATTER_NAME
+}
+
+func (formatter *RolesCsvFormatter) FormatRoles(roles []galasaapi.RBACRole) (string, error) {
+	buff := strings.Builder{}
+
+	err := formatter.WriteCsvTable(getRolesTable(roles), &buff)
+
+	return buff.String(), err
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package rolesformatter
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolesCsvFormatterHasCorrectName(t *testing.T) {
+	formatter := NewRolesCsvFormatter()
+	assert.Equal(t, utils.CSV_FORMATTER_NAME, formatter.GetName())
+}
+
+func TestRolesCsvFormatterWritesRolesWithoutTotals(t *testing.T) {
+	// Given...
+	formatter := NewRolesCsvFormatter()
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRoles(createTestRoles())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "name,description\nrole1Name,role1Description\nrole2Name,role2Description\n", actualFormattedOutput)
+}
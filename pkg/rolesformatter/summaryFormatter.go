@@ -20,6 +20,7 @@ const (
 )
 
 type RolesSummaryFormatter struct {
+	utils.TableLayout
 }
 
 func NewRolesSummaryFormatter() RolesFormatter {
@@ -30,37 +31,43 @@ func (*RolesSummaryFormatter) GetName() string {
 	return SUMMARY_FORMATTER_NAME
 }
 
-func (*RolesSummaryFormatter) FormatRoles(roles []galasaapi.RBACRole) (string, error) {
+func (formatter *RolesSummaryFormatter) FormatRoles(roles []galasaapi.RBACRole) (string, error) {
 	var result string
 	var err error = nil
 	buff := strings.Builder{}
 	total := len(roles)
 
 	if total > 0 {
-		var table [][]string
-
-		var headers = []string{
-			HEADER_ROLE_NAME,
-			HEADER_ROLE_DESCRIPTION,
-		}
-
-		table = append(table, headers)
-		for _, role := range roles {
-			var line []string
-			name := role.Metadata.GetName()
-			description := role.Metadata.GetDescription()
-			line = append(line, name, description)
-			table = append(table, line)
-		}
-
-		columnLengths := utils.CalculateMaxLengthOfEachColumn(table)
-		utils.WriteFormattedTableToStringBuilder(table, &buff, columnLengths)
+		err = formatter.WriteTable(getRolesTable(roles), &buff)
 
 		buff.WriteString("\n")
 
 	}
 	buff.WriteString("Total:" + strconv.Itoa(total) + "\n")
 
-	result = buff.String()
+	if err == nil {
+		result = buff.String()
+	}
 	return result, err
 }
+
+// getRolesTable - The table of roles which the summary and csv formats write.
+func getRolesTable(roles []galasaapi.RBACRole) [][]string {
+	var table [][]string
+
+	var headers = []string{
+		HEADER_ROLE_NAME,
+		HEADER_ROLE_DESCRIPTION,
+	}
+
+	table = append(table, headers)
+	for _, role := range roles {
+		var line []string
+		name := role.Metadata.GetName()
+		description := role.Metadata.GetDescription()
+		line = append(line, name, description)
+		table = append(table, line)
+	}
+
+	return table
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package rolesformatter
+
+import (
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
+)
+
+// -----------------------------------------------------
+// Go template format. The template is run against each role in turn.
+type RoleTemplateFormatter struct {
+	formatter *templateformatter.TemplateFormatter
+}
+
+func NewRoleTemplateFormatter(format string) (RolesFormatter, error) {
+	var chosenFormatter RolesFormatter
+
+	formatter, err := templateformatter.NewTemplateFormatter(format)
+	if err == nil {
+		templateFormatter := new(RoleTemplateFormatter)
+		templateFormatter.formatter = formatter
+		chosenFormatter = templateFormatter
+	}
+
+	return chosenFormatter, err
+}
+
+func (templateFormatter *RoleTemplateFormatter) GetName() string {
+	return templateFormatter.formatter.GetName()
+}
+
+func (templateFormatter *RoleTemplateFormatter) FormatRoles(roles []galasaapi.RBACRole) (string, error) {
+	items := make([]interface{}, 0)
+	for _, role := range roles {
+		items = append(items, role)
+	}
+	return templateFormatter.formatter.FormatItems(items)
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+
+	randomGenerator "github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+const (
+	CONTROL_PATH_STATE    = "/submission/state"
+	CONTROL_PATH_PAUSE    = "/submission/pause"
+	CONTROL_PATH_RESUME   = "/submission/resume"
+	CONTROL_PATH_THROTTLE = "/submission/throttle"
+	CONTROL_PATH_CANCEL   = "/submission/cancel"
+)
+
+// ControlFile - Tells a 'runs control' command where to find the submission it is controlling.
+// The token stops anyone who can't read the file from controlling the submission,
+// and only the user who started the submission can read the file.
+type ControlFile struct {
+	Address string `yaml:"address"`
+	Token   string `yaml:"token"`
+}
+
+// ThrottleRequest - The body of a request to change the throttle.
+type ThrottleRequest struct {
+	Throttle int `json:"throttle"`
+}
+
+// CancelRequest - The body of a request to cancel test runs in progress, or tests waiting to be submitted.
+type CancelRequest struct {
+	Runs []string `json:"runs"`
+}
+
+// ControlServer - Listens on the local machine only for requests to control a running submission.
+type ControlServer struct {
+	fileSystem      spi.FileSystem
+	controlFileName string
+	controls        *SubmissionControls
+	token           string
+	listener        net.Listener
+	server          *http.Server
+}
+
+// StartControlServer - Starts listening for requests on a free port, and writes the control file
+// which announces where to send them.
+func StartControlServer(fileSystem spi.FileSystem, controlFileName string, controls *SubmissionControls) (*ControlServer, error) {
+	var err error
+
+	server := new(ControlServer)
+	server.fileSystem = fileSystem
+	server.controlFileName = controlFileName
+	server.controls = controls
+	server.token = randomGenerator.NewString()
+
+	server.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_CONTROL_SERVER_START_FAILED, err.Error())
+	} else {
+		err = server.writeControlFile()
+		if err != nil {
+			server.listener.Close()
+		} else {
+			server.server = &http.Server{Handler: server.createHandler()}
+			go server.server.Serve(server.listener)
+			log.Printf("Listening for requests to control the submission on %v\n", server.GetAddress())
+		}
+	}
+
+	return server, err
+}
+
+// GetAddress - The host and port requests are listened for on.
+func (server *ControlServer) GetAddress() string {
+	return server.listener.Addr().String()
+}
+
+// Stop - Stops listening for requests, and removes the control file as it is no longer any use.
+func (server *ControlServer) Stop() {
+	server.server.Close()
+	server.fileSystem.DeleteFile(server.controlFileName)
+	log.Printf("Stopped listening for requests to control the submission\n")
+}
+
+func (server *ControlServer) writeControlFile() error {
+	controlFile := ControlFile{Address: server.GetAddress(), Token: server.token}
+
+	bytes, err := yaml.Marshal(&controlFile)
+	if err == nil {
+		// Anyone who can read the token can control the submission, so only its owner may read the file.
+		err = server.fileSystem.WritePrivateBinaryFile(server.controlFileName, bytes)
+	}
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_CONTROL_FILE_WRITE_FAILED, server.controlFileName, err.Error())
+	}
+	return err
+}
+
+func (server *ControlServer) createHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(CONTROL_PATH_STATE, server.handle(http.MethodGet, server.getState))
+	mux.HandleFunc(CONTROL_PATH_PAUSE, server.handle(http.MethodPost, server.pause))
+	mux.HandleFunc(CONTROL_PATH_RESUME, server.handle(http.MethodPost, server.resume))
+	mux.HandleFunc(CONTROL_PATH_THROTTLE, server.handle(http.MethodPut, server.setThrottle))
+	mux.HandleFunc(CONTROL_PATH_CANCEL, server.handle(http.MethodPost, server.cancel))
+	return mux
+}
+
+// handle - Checks the request is allowed before acting on it, then responds with the state of the submission.
+func (server *ControlServer) handle(method string, action func(request *http.Request) error) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if !server.isAuthorized(request) {
+			http.Error(writer, "The control token is missing or incorrect.", http.StatusUnauthorized)
+		} else if request.Method != method {
+			http.Error(writer, "Method "+request.Method+" is not allowed.", http.StatusMethodNotAllowed)
+		} else {
+			err := action(request)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+			} else {
+				writer.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(writer).Encode(server.controls.GetState())
+			}
+		}
+	}
+}
+
+// isAuthorized - Does the request carry the token ? Compared in constant time, so the time
+// taken to reject a guess gives nothing away about the token.
+func (server *ControlServer) isAuthorized(request *http.Request) bool {
+	expected := []byte("Bearer " + server.token)
+	actual := []byte(request.Header.Get("Authorization"))
+	return subtle.ConstantTimeCompare(actual, expected) == 1
+}
+
+func (server *ControlServer) getState(request *http.Request) error {
+	return nil
+}
+
+func (server *ControlServer) pause(request *http.Request) error {
+	log.Printf("Control request received: pause\n")
+	server.controls.Pause()
+	return nil
+}
+
+func (server *ControlServer) resume(request *http.Request) error {
+	log.Printf("Control request received: resume\n")
+	server.controls.Resume()
+	return nil
+}
+
+func (server *ControlServer) setThrottle(request *http.Request) error {
+	var throttleRequest ThrottleRequest
+	err := json.NewDecoder(request.Body).Decode(&throttleRequest)
+	if err == nil {
+		log.Printf("Control request received: throttle %v\n", throttleRequest.Throttle)
+		err = server.controls.SetThrottle(throttleRequest.Throttle)
+	}
+	return err
+}
+
+func (server *ControlServer) cancel(request *http.Request) error {
+	var cancelRequest CancelRequest
+	err := json.NewDecoder(request.Body).Decode(&cancelRequest)
+	if err == nil {
+		log.Printf("Control request received: cancel %v\n", cancelRequest.Runs)
+		err = server.controls.RequestCancel(cancelRequest.Runs)
+	}
+	return err
+}
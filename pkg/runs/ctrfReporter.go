@@ -0,0 +1,192 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+// The Common Test Report Format (CTRF). See https://ctrf.io
+const (
+	CTRF_TOOL_NAME = "galasa"
+
+	CTRF_STATUS_PASSED  = "passed"
+	CTRF_STATUS_FAILED  = "failed"
+	CTRF_STATUS_SKIPPED = "skipped"
+	CTRF_STATUS_OTHER   = "other"
+)
+
+type CtrfReport struct {
+	Results CtrfResults `json:"results"`
+}
+
+type CtrfResults struct {
+	Tool    CtrfTool    `json:"tool"`
+	Summary CtrfSummary `json:"summary"`
+	Tests   []CtrfTest  `json:"tests"`
+}
+
+type CtrfTool struct {
+	Name string `json:"name"`
+}
+
+// CtrfSummary - Start and Stop are milliseconds since the epoch, or 0 if not known.
+type CtrfSummary struct {
+	Tests   int   `json:"tests"`
+	Passed  int   `json:"passed"`
+	Failed  int   `json:"failed"`
+	Pending int   `json:"pending"`
+	Skipped int   `json:"skipped"`
+	Other   int   `json:"other"`
+	Start   int64 `json:"start"`
+	Stop    int64 `json:"stop"`
+}
+
+// CtrfTest - Duration is in milliseconds. Start and Stop are milliseconds since the epoch.
+type CtrfTest struct {
+	Name      string         `json:"name"`
+	Status    string         `json:"status"`
+	Duration  int64          `json:"duration"`
+	Start     int64          `json:"start,omitempty"`
+	Stop      int64          `json:"stop,omitempty"`
+	Suite     string         `json:"suite,omitempty"`
+	Message   string         `json:"message,omitempty"`
+	RawStatus string         `json:"rawStatus,omitempty"`
+	Extra     *CtrfTestExtra `json:"extra,omitempty"`
+}
+
+// CtrfTestExtra - Where to find the test in Galasa.
+type CtrfTestExtra struct {
+	RunName string `json:"runName,omitempty"`
+	Bundle  string `json:"bundle,omitempty"`
+	Class   string `json:"class,omitempty"`
+}
+
+// ReportCtrf - Writes each test method as a CTRF test, in a suite named after its test class.
+// A test run without any test methods, such as one which was lost, is written as a single test
+// named after its test class.
+func ReportCtrf(
+	fileSystem spi.FileSystem,
+	reportCtrfFilename string,
+	finishedRuns map[string]*TestRun,
+	lostRuns map[string]*TestRun) error {
+
+	var report CtrfReport
+	report.Results.Tool.Name = CTRF_TOOL_NAME
+	report.Results.Tests = make([]CtrfTest, 0)
+
+	for _, run := range getRunsInNameOrder(finishedRuns) {
+		report.Results.Tests = append(report.Results.Tests, getCtrfTestsOfRun(run, run.Result)...)
+	}
+	for _, run := range getRunsInNameOrder(lostRuns) {
+		report.Results.Tests = append(report.Results.Tests, getCtrfTestsOfRun(run, getLostRunResult(run))...)
+	}
+
+	report.Results.Summary = getCtrfSummary(report.Results.Tests)
+
+	data, err := json.MarshalIndent(&report, "", "  ")
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_REPORT_CTRF_MARSHAL, reportCtrfFilename, err.Error())
+	} else {
+		err = fileSystem.WriteBinaryFile(reportCtrfFilename, data)
+		if err != nil {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_REPORT_CTRF_WRITE_FAIL, reportCtrfFilename, err.Error())
+		} else {
+			log.Printf("CTRF test report written to %v\n", reportCtrfFilename)
+		}
+	}
+	return err
+}
+
+func getCtrfTestsOfRun(run *TestRun, result string) []CtrfTest {
+	tests := make([]CtrfTest, 0)
+	suite := run.Stream + "/" + run.getTestKey()
+	extra := &CtrfTestExtra{RunName: run.Name, Bundle: run.Bundle, Class: run.Class}
+
+	if len(run.Tests) == 0 {
+		test := newCtrfTest(run.Class, result, run.StartTimeUTC, run.EndTimeUTC)
+		test.Suite = suite
+		test.Extra = extra
+		if run.CancelledBy != "" {
+			test.Message = "Cancelled by: " + run.CancelledBy
+		}
+		tests = append(tests, test)
+	} else {
+		for _, method := range run.Tests {
+			test := newCtrfTest(method.Method, method.Result, method.StartTimeUTC, method.EndTimeUTC)
+			test.Suite = suite
+			test.Extra = extra
+			tests = append(tests, test)
+		}
+	}
+	return tests
+}
+
+func newCtrfTest(name string, result string, startTimeUTC string, endTimeUTC string) CtrfTest {
+	test := CtrfTest{
+		Name:      name,
+		Status:    getCtrfStatus(result),
+		RawStatus: result,
+	}
+	if test.Status != CTRF_STATUS_PASSED && test.Status != CTRF_STATUS_SKIPPED {
+		test.Message = "Finished with result: " + result
+	}
+
+	startTime, startErr := time.Parse(time.RFC3339, startTimeUTC)
+	endTime, endErr := time.Parse(time.RFC3339, endTimeUTC)
+	if startErr == nil && endErr == nil && !endTime.Before(startTime) {
+		test.Start = startTime.UnixNano() / int64(time.Millisecond)
+		test.Stop = endTime.UnixNano() / int64(time.Millisecond)
+		test.Duration = test.Stop - test.Start
+	}
+	return test
+}
+
+// getCtrfStatus - CTRF has no status for a broken test environment, so an EnvFail is 'other'.
+func getCtrfStatus(result string) string {
+	var status string
+	switch getReportOutcome(result) {
+	case REPORT_OUTCOME_PASSED:
+		status = CTRF_STATUS_PASSED
+	case REPORT_OUTCOME_SKIPPED:
+		status = CTRF_STATUS_SKIPPED
+	case REPORT_OUTCOME_ERROR:
+		status = CTRF_STATUS_OTHER
+	default:
+		status = CTRF_STATUS_FAILED
+	}
+	return status
+}
+
+func getCtrfSummary(tests []CtrfTest) CtrfSummary {
+	var summary CtrfSummary
+	summary.Tests = len(tests)
+	for _, test := range tests {
+		switch test.Status {
+		case CTRF_STATUS_PASSED:
+			summary.Passed++
+		case CTRF_STATUS_FAILED:
+			summary.Failed++
+		case CTRF_STATUS_SKIPPED:
+			summary.Skipped++
+		default:
+			summary.Other++
+		}
+
+		if test.Start != 0 && (summary.Start == 0 || test.Start < summary.Start) {
+			summary.Start = test.Start
+		}
+		if test.Stop > summary.Stop {
+			summary.Stop = test.Stop
+		}
+	}
+	return summary
+}
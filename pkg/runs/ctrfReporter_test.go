@@ -0,0 +1,106 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/stretchr/testify/assert"
+)
+
+func readCtrfReport(t *testing.T, fileSystem spi.FileSystem, filename string) CtrfReport {
+	var report CtrfReport
+	contents, err := fileSystem.ReadTextFile(filename)
+	assert.Nil(t, err)
+	err = json.Unmarshal([]byte(contents), &report)
+	assert.Nil(t, err)
+	return report
+}
+
+func TestReportCtrfWritesATestForEachMethod(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	finishedRuns := map[string]*TestRun{
+		"U100": {Name: "U100", Stream: "myStream", Bundle: "myBundle", Class: "myClass1", Result: "Failed",
+			Tests: []TestMethod{
+				{Method: "testOne", Result: "Passed", StartTimeUTC: "2024-03-01T10:00:00Z", EndTimeUTC: "2024-03-01T10:00:01.5Z"},
+				{Method: "testTwo", Result: "Failed", StartTimeUTC: "2024-03-01T10:00:02Z", EndTimeUTC: "2024-03-01T10:00:03Z"},
+				{Method: "testThree", Result: "EnvFail"},
+				{Method: "testFour", Result: "Disabled"},
+			}},
+	}
+
+	// When...
+	err := ReportCtrf(mockFileSystem, "report.json", finishedRuns, map[string]*TestRun{})
+
+	// Then...
+	assert.Nil(t, err)
+	report := readCtrfReport(t, mockFileSystem, "report.json")
+	assert.Equal(t, "galasa", report.Results.Tool.Name)
+	assert.Equal(t, CtrfSummary{Tests: 4, Passed: 1, Failed: 1, Skipped: 1, Other: 1, Start: 1709287200000, Stop: 1709287203000}, report.Results.Summary)
+
+	assert.Equal(t, 4, len(report.Results.Tests))
+	assert.Equal(t, CtrfTest{
+		Name:      "testOne",
+		Status:    "passed",
+		Duration:  1500,
+		Start:     1709287200000,
+		Stop:      1709287201500,
+		Suite:     "myStream/myBundle/myClass1",
+		RawStatus: "Passed",
+		Extra:     &CtrfTestExtra{RunName: "U100", Bundle: "myBundle", Class: "myClass1"},
+	}, report.Results.Tests[0])
+	assert.Equal(t, "failed", report.Results.Tests[1].Status)
+	assert.Equal(t, "Finished with result: Failed", report.Results.Tests[1].Message)
+	assert.Equal(t, "other", report.Results.Tests[2].Status)
+	assert.Equal(t, "EnvFail", report.Results.Tests[2].RawStatus)
+	assert.Equal(t, "skipped", report.Results.Tests[3].Status)
+	assert.Empty(t, report.Results.Tests[3].Message)
+}
+
+func TestReportCtrfWritesARunWithoutMethodsAsOneTest(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	finishedRuns := map[string]*TestRun{
+		"U100": {Name: "U100", Stream: "myStream", Bundle: "myBundle", Class: "myClass1", Result: RESULT_CANCELLED, CancelledBy: STOP_REASON_FAIL_FAST},
+	}
+	lostRuns := map[string]*TestRun{
+		"U101": {Name: "U101", Stream: "myStream", Bundle: "myBundle", Class: "myClass2"},
+	}
+
+	// When...
+	err := ReportCtrf(mockFileSystem, "report.json", finishedRuns, lostRuns)
+
+	// Then...
+	assert.Nil(t, err)
+	report := readCtrfReport(t, mockFileSystem, "report.json")
+	assert.Equal(t, 2, report.Results.Summary.Tests)
+	assert.Equal(t, 2, report.Results.Summary.Failed)
+	assert.Equal(t, "myClass1", report.Results.Tests[0].Name)
+	assert.Equal(t, "Cancelled by: fail-fast", report.Results.Tests[0].Message)
+	assert.Equal(t, "myClass2", report.Results.Tests[1].Name)
+	assert.Equal(t, "Lost", report.Results.Tests[1].RawStatus)
+}
+
+func TestReportCtrfGivesARunWhichDidNotFinishItsOwnResult(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	lostRuns := map[string]*TestRun{
+		"U100": {Name: "U100", Stream: "myStream", Bundle: "myBundle", Class: "myClass1", Result: RESULT_INTERRUPTED},
+	}
+
+	// When...
+	err := ReportCtrf(mockFileSystem, "report.json", map[string]*TestRun{}, lostRuns)
+
+	// Then...
+	assert.Nil(t, err)
+	report := readCtrfReport(t, mockFileSystem, "report.json")
+	assert.Equal(t, 1, report.Results.Summary.Failed)
+	assert.Equal(t, RESULT_INTERRUPTED, report.Results.Tests[0].RawStatus)
+}
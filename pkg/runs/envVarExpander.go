@@ -0,0 +1,187 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+const (
+	// The value an override which looks like it holds a secret is shown as.
+	MASKED_OVERRIDE_VALUE = "********"
+)
+
+var envVarNamePattern = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+// Parts of an override name which suggest its value is a secret, so should not be shown.
+var secretOverrideNameParts = []string{"password", "passwd", "secret", "token", "apikey", "api.key", "privatekey", "private.key"}
+
+// EnvVarExpander - Replaces references to environment variables in override values, so the same
+// overrides and portfolios can be used against different environments. A reference is written as
+// ${NAME}, or ${NAME:-default} to use a default value when the variable is not set or is empty.
+// $${ is not a reference, and is replaced by ${.
+type EnvVarExpander struct {
+	env spi.Environment
+
+	// Fail when a variable with no default is not set, rather than leaving the reference as it is.
+	isStrict bool
+}
+
+func NewEnvVarExpander(env spi.Environment, isStrict bool) *EnvVarExpander {
+	expander := new(EnvVarExpander)
+	expander.env = env
+	expander.isStrict = isStrict
+	return expander
+}
+
+// ExpandOverrides - Replaces the references in each value of the overrides, in name order.
+// The source says where the overrides came from when one of them can't be expanded.
+func (expander *EnvVarExpander) ExpandOverrides(overrides map[string]string, source string) error {
+	var err error
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		overrides[name], err = expander.ExpandValue(overrides[name], "override '"+name+"' "+source)
+		if err != nil {
+			break
+		}
+	}
+	return err
+}
+
+// ExpandValue - Replaces the references in a single value. The description names the value when it can't be expanded.
+func (expander *EnvVarExpander) ExpandValue(value string, description string) (string, error) {
+	var err error
+	buff := strings.Builder{}
+
+	remaining := value
+	for err == nil && remaining != "" {
+		start := strings.Index(remaining, "${")
+		if start < 0 {
+			buff.WriteString(remaining)
+			break
+		}
+
+		if start > 0 && remaining[start-1] == '$' {
+			// An escaped reference, which is kept with one less '$'.
+			buff.WriteString(remaining[:start-1] + "${")
+			remaining = remaining[start+2:]
+			continue
+		}
+
+		buff.WriteString(remaining[:start])
+
+		length := strings.Index(remaining[start:], "}")
+		if length < 0 {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_BAD_ENV_VAR_REFERENCE, description, remaining[start:])
+		} else {
+			reference := remaining[start : start+length+1]
+			var expanded string
+			expanded, err = expander.expandReference(reference, description)
+			buff.WriteString(expanded)
+			remaining = remaining[start+length+1:]
+		}
+	}
+	return buff.String(), err
+}
+
+// expandReference - The value of a single ${NAME} or ${NAME:-default} reference.
+func (expander *EnvVarExpander) expandReference(reference string, description string) (string, error) {
+	var err error
+	expanded := reference
+
+	name := reference[2 : len(reference)-1]
+	defaultValue := ""
+	hasDefault := false
+	if separator := strings.Index(name, ":-"); separator >= 0 {
+		defaultValue = name[separator+2:]
+		name = name[:separator]
+		hasDefault = true
+	}
+
+	if !envVarNamePattern.MatchString(name) {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_BAD_ENV_VAR_REFERENCE, description, reference)
+	} else {
+		envValue := expander.env.GetEnv(name)
+		if envValue != "" {
+			expanded = envValue
+		} else if hasDefault {
+			expanded = defaultValue
+		} else if expander.isStrict {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_ENV_VAR_NOT_SET, description, name)
+		} else {
+			log.Printf("Environment variable %v used by %v is not set, so %v is not replaced\n", name, description, reference)
+		}
+	}
+	return expanded, err
+}
+
+// isSecretOverride - Whether the name of an override suggests its value is a secret.
+func isSecretOverride(name string) bool {
+	isSecret := false
+	lowerName := strings.ToLower(name)
+	for _, part := range secretOverrideNameParts {
+		if strings.Contains(lowerName, part) {
+			isSecret = true
+			break
+		}
+	}
+	return isSecret
+}
+
+// maskSecretOverrides - A copy of the overrides, with the values which look like secrets masked.
+func maskSecretOverrides(overrides map[string]string) map[string]string {
+	var maskedOverrides map[string]string
+	if overrides != nil {
+		maskedOverrides = make(map[string]string, len(overrides))
+		for name, value := range overrides {
+			if isSecretOverride(name) {
+				value = MASKED_OVERRIDE_VALUE
+			}
+			maskedOverrides[name] = value
+		}
+	}
+	return maskedOverrides
+}
+
+// formatEffectiveOverrides - The overrides each test run will be submitted with, in name order,
+// with the values which look like secrets masked.
+func formatEffectiveOverrides(runs []TestRun) string {
+	buff := strings.Builder{}
+	for _, run := range runs {
+		testName := run.getTestKey()
+		if run.GherkinUrl != "" {
+			testName = run.GherkinFeature + getCombinationSuffix(&run)
+		}
+		buff.WriteString("Overrides for " + testName + ":\n")
+
+		names := make([]string, 0, len(run.Overrides))
+		for name := range run.Overrides {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		if len(names) == 0 {
+			buff.WriteString("  (none)\n")
+		}
+		maskedOverrides := maskSecretOverrides(run.Overrides)
+		for _, name := range names {
+			buff.WriteString("  " + name + "=" + maskedOverrides[name] + "\n")
+		}
+	}
+	return buff.String()
+}
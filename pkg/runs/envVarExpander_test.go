@@ -0,0 +1,170 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEnvVarExpanderForTests(isStrict bool) *EnvVarExpander {
+	env := utils.NewMockEnv()
+	env.SetEnv("HOST", "myhost.example.com")
+	env.SetEnv("PORT", "8080")
+	return NewEnvVarExpander(env, isStrict)
+}
+
+func TestExpandValueReplacesEachReference(t *testing.T) {
+	// Given...
+	expander := newEnvVarExpanderForTests(false)
+
+	// When...
+	value, err := expander.ExpandValue("https://${HOST}:${PORT}/api", "override 'url'")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "https://myhost.example.com:8080/api", value)
+}
+
+func TestExpandValueWithoutReferencesIsUnchanged(t *testing.T) {
+	// Given...
+	expander := newEnvVarExpanderForTests(true)
+
+	// When...
+	value, err := expander.ExpandValue("$HOST costs $5 {each}", "override 'text'")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "$HOST costs $5 {each}", value)
+}
+
+func TestExpandValueUsesDefaultWhenVariableNotSet(t *testing.T) {
+	// Given...
+	expander := newEnvVarExpanderForTests(true)
+
+	// When...
+	value, err := expander.ExpandValue("${REGION:-CICSA}/${HOST:-otherhost}/${EMPTY:-}", "override 'region'")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "CICSA/myhost.example.com/", value)
+}
+
+func TestExpandValueKeepsReferenceToVariableNotSetWhenNotStrict(t *testing.T) {
+	// Given...
+	expander := newEnvVarExpanderForTests(false)
+
+	// When...
+	value, err := expander.ExpandValue("${REGION}", "override 'region'")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "${REGION}", value)
+}
+
+func TestExpandValueFailsOnVariableNotSetWhenStrict(t *testing.T) {
+	// Given...
+	expander := newEnvVarExpanderForTests(true)
+
+	// When...
+	_, err := expander.ExpandValue("${REGION}", "override 'region'")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1307E: The value of override 'region' refers to environment variable 'REGION', which is not set.")
+}
+
+func TestExpandValueKeepsEscapedReference(t *testing.T) {
+	// Given...
+	expander := newEnvVarExpanderForTests(true)
+
+	// When...
+	value, err := expander.ExpandValue("$${HOST} is ${HOST}", "override 'text'")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "${HOST} is myhost.example.com", value)
+}
+
+func TestExpandValueFailsOnBadReferences(t *testing.T) {
+	// Given...
+	expander := newEnvVarExpanderForTests(false)
+
+	for _, value := range []string{"${HOST", "${}", "${1HOST}", "${MY-HOST}"} {
+		// When...
+		_, err := expander.ExpandValue(value, "override 'host'")
+
+		// Then...
+		assert.NotNil(t, err, value)
+		assert.Contains(t, err.Error(), "GAL1308E: The value of override 'host' has '"+value)
+	}
+}
+
+func TestExpandOverridesSaysWhereTheOverrideCameFrom(t *testing.T) {
+	// Given...
+	expander := newEnvVarExpanderForTests(true)
+	overrides := map[string]string{"url": "https://${HOST}", "region": "${REGION}"}
+
+	// When...
+	err := expander.ExpandOverrides(overrides, "of class 'myBundle/myClass' in portfolio 'my.yaml'")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1307E: The value of override 'region' of class 'myBundle/myClass' in portfolio 'my.yaml'")
+}
+
+func TestReadPortfolioExpandingEnvVarsExpandsClassAndMatrixOverrides(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	portfolio := NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes, PortfolioClass{Bundle: "myBundle", Class: "myClass",
+		Overrides: map[string]string{"url": "https://${HOST}"}})
+	portfolio.Matrix = []PortfolioMatrixAxis{{
+		Name:         "port",
+		OverrideSets: []PortfolioOverrideSet{{Name: "default", Overrides: map[string]string{"port": "${PORT}"}}},
+	}}
+	err := WritePortfolio(fs, "my.yaml", portfolio)
+	assert.Nil(t, err)
+
+	// When...
+	expanded, err := ReadPortfolioExpandingEnvVars(fs, "my.yaml", newEnvVarExpanderForTests(true))
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "https://myhost.example.com", expanded.Classes[0].Overrides["url"])
+	assert.Equal(t, "8080", expanded.Matrix[0].OverrideSets[0].Overrides["port"])
+
+	// The portfolio file still has the references.
+	unexpanded, err := ReadPortfolio(fs, "my.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://${HOST}", unexpanded.Classes[0].Overrides["url"])
+}
+
+func TestFormatEffectiveOverridesMasksSecrets(t *testing.T) {
+	// Given...
+	runs := []TestRun{
+		{Bundle: "myBundle", Class: "myClass", Combination: "image=zos1",
+			Overrides: map[string]string{"zos.image": "MV1A", "my.Password": "pa55", "api.token": "abc", "zos.credentials": "MYCREDS"}},
+		{GherkinUrl: "file:///my.feature", GherkinFeature: "my", Overrides: map[string]string{}},
+	}
+
+	// When...
+	text := formatEffectiveOverrides(runs)
+
+	// Then...
+	assert.Equal(t,
+		"Overrides for myBundle/myClass[image=zos1]:\n"+
+			"  api.token=********\n"+
+			"  my.Password=********\n"+
+			"  zos.credentials=MYCREDS\n"+
+			"  zos.image=MV1A\n"+
+			"Overrides for my:\n"+
+			"  (none)\n",
+		text)
+}
@@ -6,6 +6,7 @@
 package runs
 
 import (
+	"sort"
 	"strings"
 
 	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
@@ -21,3 +22,37 @@ func validateGroupname(groupName string) (string, error) {
 	}
 	return trimmedName, err
 }
+
+// getRunGroupName - The group a test run is submitted to. Each combination of a portfolio matrix has a
+// group of its own, named after the group of the submission and the combination, so that the runs of
+// each combination can be listed with 'runs get --group'.
+func getRunGroupName(groupName string, run *TestRun) string {
+	runGroupName := groupName
+	if run.Combination != "" {
+		// Combination names hold '=' and ',', which can't be used in a group name.
+		var buff strings.Builder
+		for _, character := range run.Combination {
+			if utils.IsCharacterAlphanumeric(character) || character == '-' || character == '_' {
+				buff.WriteRune(character)
+			} else {
+				buff.WriteRune('_')
+			}
+		}
+		runGroupName = groupName + "-" + buff.String()
+	}
+	return runGroupName
+}
+
+// getDistinctGroupNames - Each of the group names once, in alphabetical order.
+func getDistinctGroupNames(groupNames []string) []string {
+	isGroupNameFound := make(map[string]bool)
+	distinctGroupNames := make([]string, 0, 1)
+	for _, groupName := range groupNames {
+		if !isGroupNameFound[groupName] {
+			isGroupNameFound[groupName] = true
+			distinctGroupNames = append(distinctGroupNames, groupName)
+		}
+	}
+	sort.Strings(distinctGroupNames)
+	return distinctGroupNames
+}
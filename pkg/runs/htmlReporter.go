@@ -0,0 +1,45 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"log"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/runsformatter"
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+// ReportHtml - Writes the results of the test runs as a single html page, using the same html
+// formatter as 'runs get'. The runs link to the ecosystem when apiServerUrl is given.
+func ReportHtml(
+	fileSystem spi.FileSystem,
+	reportHtmlFilename string,
+	apiServerUrl string,
+	finishedRuns map[string]*TestRun,
+	lostRuns map[string]*TestRun) error {
+
+	var err error
+	var html string
+
+	formattableTests := FormattableTestFromTestRun(finishedRuns, lostRuns)
+	for index := range formattableTests {
+		formattableTests[index].ApiServerUrl = apiServerUrl
+	}
+
+	html, err = runsformatter.NewHtmlFormatter().FormatRuns(formattableTests)
+	if err == nil {
+		err = fileSystem.WriteTextFile(reportHtmlFilename, html)
+	}
+
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_REPORT_HTML_WRITE_FAIL, reportHtmlFilename, err.Error())
+	} else {
+		log.Printf("Html test report written to %v\n", reportHtmlFilename)
+	}
+
+	return err
+}
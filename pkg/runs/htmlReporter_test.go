@@ -0,0 +1,95 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportHtmlWritesRunsMethodsAndLinksToTheEcosystem(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	finishedRuns := map[string]*TestRun{
+		"U123": {
+			Name:          "U123",
+			Bundle:        "myBundle",
+			Class:         "myClass",
+			Stream:        "myStream",
+			Status:        "finished",
+			Result:        "Failed",
+			QueuedTimeUTC: "2023-05-04T10:45:29Z",
+			RasRunId:      "cdb-123",
+			StartTimeUTC:  "2023-05-04T10:50:00Z",
+			EndTimeUTC:    "2023-05-04T10:52:00Z",
+			Tests: []TestMethod{
+				{Method: "testOne", Result: "Passed", Type: "test", Status: "finished"},
+				{Method: "testTwo", Result: "Failed", Type: "test", Status: "finished"},
+			},
+		},
+	}
+	lostRuns := map[string]*TestRun{
+		"U124": {Name: "U124", Bundle: "myBundle", Class: "myLostClass", Stream: "myStream", Status: "running"},
+	}
+
+	// When...
+	err := ReportHtml(mockFileSystem, "report.html", "https://my-ecosystem/api", finishedRuns, lostRuns)
+
+	// Then...
+	assert.Nil(t, err)
+	html, err := mockFileSystem.ReadTextFile("report.html")
+	assert.Nil(t, err)
+	assert.Contains(t, html, "<span>Total: 2</span>")
+	assert.Contains(t, html, `<a href="https://my-ecosystem/api/ras/runs/cdb-123">U123</a>`)
+	assert.Contains(t, html, "<td>myStream/myBundle/myClass</td>")
+	assert.Contains(t, html, "<td>120000</td>")
+	assert.Contains(t, html, "<td>testTwo</td><td>test</td><td>finished</td><td class=\"result-failed\">Failed</td>")
+	assert.Contains(t, html, `<td class="result-lost">Lost</td>`)
+}
+
+func TestReportHtmlOfLocalRunsHasNoLinks(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	finishedRuns := map[string]*TestRun{
+		"L123": {Name: "L123", Bundle: "myBundle", Class: "myClass", Stream: "myStream", Status: "finished", Result: "Passed"},
+	}
+
+	// When...
+	err := ReportHtml(mockFileSystem, "report.html", "", finishedRuns, map[string]*TestRun{})
+
+	// Then...
+	assert.Nil(t, err)
+	html, err := mockFileSystem.ReadTextFile("report.html")
+	assert.Nil(t, err)
+	assert.Contains(t, html, "<td>L123</td>")
+	assert.NotContains(t, html, "<a href")
+}
+
+func TestSubmitWithReportHtmlWritesTheHtmlReport(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportHtmlFilename: "report.html",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	html, err := mockFileSystem.ReadTextFile("report.html")
+	assert.Nil(t, err)
+	assert.Contains(t, html, "<span>Total: 2</span>")
+}
@@ -21,15 +21,28 @@ const (
 	RESULT_FAILED_WITH_DEFECTS = "Failed With Defects"
 	RESULT_LOST                = "Lost"
 	RESULT_ENVFAIL             = "EnvFail"
+
+	// Given to tests which had not finished when the submission of tests was interrupted.
+	RESULT_INTERRUPTED = "Interrupted"
+
+	// Given to tests which had not finished when the submission of tests was stopped by --fail-fast or --timeout.
+	RESULT_CANCELLED = "Cancelled"
 )
 
+// CountTotalFailedRuns - Runs which were quarantined or excused by the result policy are not counted.
 func CountTotalFailedRuns(finishedRuns map[string]*TestRun, lostRuns map[string]*TestRun) int {
 
-	totalFailed := len(lostRuns)
+	totalFailed := 0
+
+	for _, run := range lostRuns {
+		if run.ExcusedBy == "" {
+			totalFailed = totalFailed + 1
+		}
+	}
 
 	for _, run := range finishedRuns {
 		// Anything which didn't pass failed by definition.
-		if !strings.HasPrefix(run.Result, RESULT_PASSED) {
+		if !strings.HasPrefix(run.Result, RESULT_PASSED) && run.ExcusedBy == "" {
 			totalFailed = totalFailed + 1
 		}
 	}
@@ -37,6 +50,52 @@ func CountTotalFailedRuns(finishedRuns map[string]*TestRun, lostRuns map[string]
 	return totalFailed
 }
 
+// CountTotalQuarantinedRuns - How many runs did not pass, but are in the quarantine list of the result policy.
+func CountTotalQuarantinedRuns(finishedRuns map[string]*TestRun, lostRuns map[string]*TestRun) int {
+	return countRunsExcusedBy(EXCUSED_BY_QUARANTINE, finishedRuns, lostRuns)
+}
+
+// CountTotalExcusedRuns - How many runs did not pass, but had a result which the result policy does not count as a failure.
+func CountTotalExcusedRuns(finishedRuns map[string]*TestRun, lostRuns map[string]*TestRun) int {
+	return countRunsExcusedBy(EXCUSED_BY_POLICY, finishedRuns, lostRuns)
+}
+
+func countRunsExcusedBy(excusedBy string, finishedRuns map[string]*TestRun, lostRuns map[string]*TestRun) int {
+	total := 0
+	for _, runs := range []map[string]*TestRun{finishedRuns, lostRuns} {
+		for _, run := range runs {
+			if run.ExcusedBy == excusedBy {
+				total = total + 1
+			}
+		}
+	}
+	return total
+}
+
+// CountTotalInterruptedRuns - How many of the lost runs didn't finish because the submission was interrupted.
+func CountTotalInterruptedRuns(lostRuns map[string]*TestRun) int {
+	totalInterrupted := 0
+	for _, run := range lostRuns {
+		if run.Result == RESULT_INTERRUPTED {
+			totalInterrupted = totalInterrupted + 1
+		}
+	}
+	return totalInterrupted
+}
+
+// CountTotalCancelledRuns - How many runs were cancelled, or never submitted, because the submission stopped early.
+func CountTotalCancelledRuns(finishedRuns map[string]*TestRun, lostRuns map[string]*TestRun) int {
+	totalCancelled := 0
+	for _, runs := range []map[string]*TestRun{finishedRuns, lostRuns} {
+		for _, run := range runs {
+			if run.CancelledBy != "" {
+				totalCancelled = totalCancelled + 1
+			}
+		}
+	}
+	return totalCancelled
+}
+
 // FinalHumanReadableReport - Creates a human readable report of how it went.
 func FinalHumanReadableReport(finishedRuns map[string]*TestRun, lostRuns map[string]*TestRun) {
 	report := FinalHumanReadableReportAsString(finishedRuns, lostRuns)
@@ -78,7 +137,7 @@ func FinalHumanReadableReportAsString(finishedRuns map[string]*TestRun, lostRuns
 	found := false
 	for runName, run := range finishedRuns {
 		if strings.HasPrefix(run.Result, RESULT_PASSED) && !strings.HasPrefix(run.Result, RESULT_PASSED_WITH_DEFECTS) {
-			fmt.Fprintf(&buff, "***     Run %v - %v/%v/%v\n", runName, run.Stream, run.Bundle, run.Class)
+			fmt.Fprintf(&buff, "***     Run %v - %v/%v/%v%v\n", runName, run.Stream, run.Bundle, run.Class, getCombinationSuffix(run))
 			found = true
 		}
 	}
@@ -91,7 +150,7 @@ func FinalHumanReadableReportAsString(finishedRuns map[string]*TestRun, lostRuns
 	found = false
 	for runName, run := range finishedRuns {
 		if strings.HasPrefix(run.Result, RESULT_FAILED) && !strings.HasPrefix(run.Result, RESULT_FAILED_WITH_DEFECTS) {
-			fmt.Fprintf(&buff, "***     Run %v - %v/%v/%v\n", runName, run.Stream, run.Bundle, run.Class)
+			fmt.Fprintf(&buff, "***     Run %v - %v/%v/%v%v\n", runName, run.Stream, run.Bundle, run.Class, getCombinationSuffix(run))
 			found = true
 		}
 	}
@@ -104,7 +163,7 @@ func FinalHumanReadableReportAsString(finishedRuns map[string]*TestRun, lostRuns
 	found = false
 	for runName, run := range finishedRuns {
 		if strings.HasPrefix(run.Result, RESULT_PASSED_WITH_DEFECTS) {
-			fmt.Fprintf(&buff, "***     Run %v - %v/%v/%v\n", runName, run.Stream, run.Bundle, run.Class)
+			fmt.Fprintf(&buff, "***     Run %v - %v/%v/%v%v\n", runName, run.Stream, run.Bundle, run.Class, getCombinationSuffix(run))
 			found = true
 		}
 	}
@@ -117,7 +176,7 @@ func FinalHumanReadableReportAsString(finishedRuns map[string]*TestRun, lostRuns
 	found = false
 	for runName, run := range finishedRuns {
 		if strings.HasPrefix(run.Result, RESULT_FAILED_WITH_DEFECTS) {
-			log.Printf("***     Run %v - %v/%v/%v\n", runName, run.Stream, run.Bundle, run.Class)
+			log.Printf("***     Run %v - %v/%v/%v%v\n", runName, run.Stream, run.Bundle, run.Class, getCombinationSuffix(run))
 			found = true
 		}
 	}
@@ -130,7 +189,7 @@ func FinalHumanReadableReportAsString(finishedRuns map[string]*TestRun, lostRuns
 	found = false
 	for runName, run := range finishedRuns {
 		if !strings.HasPrefix(run.Result, RESULT_PASSED) && !strings.HasPrefix(run.Result, RESULT_FAILED) {
-			fmt.Fprintf(&buff, "***     Run %v(%v) - %v/%v/%v\n", runName, run.Result, run.Stream, run.Bundle, run.Class)
+			fmt.Fprintf(&buff, "***     Run %v(%v) - %v/%v/%v%v\n", runName, run.Result, run.Stream, run.Bundle, run.Class, getCombinationSuffix(run))
 			found = true
 		}
 	}
@@ -198,7 +257,7 @@ func InterrimProgressReportAsString(
 	fmt.Fprintln(&buff, "*** Progress report")
 	fmt.Fprintln(&buff, "*** ---------------")
 	for runName, run := range submittedRuns {
-		log.Printf("***     Run %v is currently %v - %v/%v/%v\n", runName, run.Status, run.Stream, run.Bundle, run.Class)
+		log.Printf("***     Run %v is currently %v - %v/%v/%v%v\n", runName, run.Status, run.Stream, run.Bundle, run.Class, getCombinationSuffix(run))
 	}
 	fmt.Fprintln(&buff, "*** ----------------------------------------------------------------------------")
 	fmt.Fprintf(&buff, "*** run status, ready=%v, submitted=%v, finished=%v, lost=%v\n", ready, submitted, finished, lost)
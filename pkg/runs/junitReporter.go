@@ -8,8 +8,11 @@ package runs
 import (
 	"encoding/xml"
 	"log"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
 	"github.com/galasa-dev/cli/pkg/spi"
@@ -21,24 +24,41 @@ type JunitTestSuites struct {
 	Name      string           `xml:"name,attr"`
 	Tests     int              `xml:"tests,attr"`
 	Failures  int              `xml:"failures,attr"`
-	Time      int              `xml:"time,attr"`
+	Errors    int              `xml:"errors,attr,omitempty"`
+	Skipped   int              `xml:"skipped,attr,omitempty"`
+	Time      string           `xml:"time,attr"`
 	Testsuite []JunitTestSuite `xml:"testsuite"`
 }
 
 type JunitTestSuite struct {
-	ID       string          `xml:"id,attr"`
-	Name     string          `xml:"name,attr"`
-	Tests    int             `xml:"tests,attr"`
-	Failures int             `xml:"failures,attr"`
-	Time     int             `xml:"time,attr"`
-	TestCase []JunitTestCase `xml:"testcase"`
+	ID         string           `xml:"id,attr"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr,omitempty"`
+	Skipped    int              `xml:"skipped,attr,omitempty"`
+	Time       string           `xml:"time,attr"`
+	Properties *JunitProperties `xml:"properties,omitempty"`
+	TestCase   []JunitTestCase  `xml:"testcase"`
+	SystemOut  *JunitSystemOut  `xml:"system-out,omitempty"`
+}
+
+type JunitProperties struct {
+	Property []JunitProperty `xml:"property"`
+}
+
+type JunitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
 }
 
 type JunitTestCase struct {
 	ID      string        `xml:"id,attr"`
 	Name    string        `xml:"name,attr"`
-	Time    int           `xml:"time,attr"`
+	Time    string        `xml:"time,attr"`
 	Failure *JunitFailure `xml:"failure"`
+	Error   *JunitFailure `xml:"error"`
+	Skipped *JunitSkipped `xml:"skipped"`
 }
 
 type JunitFailure struct {
@@ -46,20 +66,35 @@ type JunitFailure struct {
 	Type    string `xml:"type,attr"`
 }
 
+// JunitSystemOut - Kept as CDATA so that the lines of a run log stay readable in the report file.
+type JunitSystemOut struct {
+	Text string `xml:",cdata"`
+}
+
+type JunitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// ReportJunit - Writes each finished run as a test suite, with each of its test methods as a test case.
+// If runLogLineCount is more than zero, the last few lines of the run log of each run are fetched
+// and included in the test suite, so that the reason a test failed can be seen without looking it up.
+// Each lost run is written as a test suite with a single failed test case, so that it can be read back.
 func ReportJunit(
 	fileSystem spi.FileSystem,
 	reportJunitFilename string,
 	groupName string,
 	finishedRuns map[string]*TestRun,
-	lostRuns map[string]*TestRun) error {
+	lostRuns map[string]*TestRun,
+	runLogFetcher RunLogFetcher,
+	runLogLineCount int) error {
 
 	var testSuites JunitTestSuites
 	testSuites.ID = groupName
 	testSuites.Name = "Galasa test run"
 	testSuites.Tests = 0
 	testSuites.Failures = 0
-	testSuites.Time = 0
 	testSuites.Testsuite = make([]JunitTestSuite, 0)
+	var totalSeconds float64
 
 	//sort the key values of the finishedRun tests in alphabetical order
 	sortedFinishedRunsKeys := sortFinishedRunsKeys(finishedRuns)
@@ -70,36 +105,51 @@ func ReportJunit(
 		var testSuite JunitTestSuite
 
 		testSuite.ID = run.Name
-		testSuite.Name = run.Stream + "/" + run.Bundle + "/" + run.Class
+		testSuite.Name = run.Stream + "/" + run.Bundle + "/" + run.Class + getCombinationSuffix(run)
 		testSuite.TestCase = make([]JunitTestCase, 0)
+		testSuite.Properties = getJunitRunProperties(run)
 
+		var methodsSeconds float64
 		for _, method := range run.Tests {
-			var testCase JunitTestCase
-			testCase.ID = method.Method
-			testCase.Name = method.Method
+			testCase := getJunitTestCase(method)
+			methodsSeconds += getDurationSeconds(method.StartTimeUTC, method.EndTimeUTC)
 
-			testSuites.Tests = testSuites.Tests + 1
 			testSuite.Tests = testSuite.Tests + 1
-			if !strings.HasPrefix(method.Result, "Passed") {
-				testSuites.Failures = testSuites.Failures + 1
+			if testCase.Failure != nil {
 				testSuite.Failures = testSuite.Failures + 1
-
-				var failure JunitFailure
-				failure.Message = "Failure messages are unavailable at this time"
-				failure.Type = "Unknown"
-
-				testCase.Failure = &failure
+			} else if testCase.Error != nil {
+				testSuite.Errors = testSuite.Errors + 1
+			} else if testCase.Skipped != nil {
+				testSuite.Skipped = testSuite.Skipped + 1
 			}
 
 			testSuite.TestCase = append(testSuite.TestCase, testCase)
 		}
 
+		// The run takes longer than its test methods, as it has to provision its environment first.
+		suiteSeconds := methodsSeconds
+		if run.StartTimeUTC != "" && run.EndTimeUTC != "" {
+			suiteSeconds = getDurationSeconds(run.StartTimeUTC, run.EndTimeUTC)
+		}
+		testSuite.Time = formatJunitSeconds(suiteSeconds)
+		totalSeconds += suiteSeconds
+
+		if runLogFetcher != nil && runLogLineCount > 0 && run.RasRunId != "" {
+			testSuite.SystemOut = getJunitRunLogTail(runLogFetcher, run, runLogLineCount)
+		}
+
+		testSuites.Tests = testSuites.Tests + testSuite.Tests
+		testSuites.Failures = testSuites.Failures + testSuite.Failures
+		testSuites.Errors = testSuites.Errors + testSuite.Errors
+		testSuites.Skipped = testSuites.Skipped + testSuite.Skipped
 		testSuites.Testsuite = append(testSuites.Testsuite, testSuite)
 	}
+	testSuites.Time = formatJunitSeconds(totalSeconds)
 
-	for range lostRuns {
+	for _, run := range getRunsInNameOrder(lostRuns) {
 		testSuites.Tests = testSuites.Tests + 1
 		testSuites.Failures = testSuites.Failures + 1
+		testSuites.Testsuite = append(testSuites.Testsuite, getJunitLostRunTestSuite(run))
 	}
 
 	data, err := xml.MarshalIndent(&testSuites, "", "    ")
@@ -119,6 +169,152 @@ func ReportJunit(
 	return err
 }
 
+// getJunitLostRunTestSuite - A lost run has no test methods, so its test class is the only test case.
+// Its result is recorded, as for a finished run, so that 'runs prepare --from-report' knows it was lost.
+func getJunitLostRunTestSuite(run *TestRun) JunitTestSuite {
+	lostRun := *run
+	lostRun.Result = getLostRunResult(run)
+
+	message := "Test run " + run.Name + " did not finish. Result: " + lostRun.Result
+	if run.CancelledBy != "" {
+		message += ". Cancelled by: " + run.CancelledBy
+	}
+
+	return JunitTestSuite{
+		ID:         run.Name,
+		Name:       run.Stream + "/" + run.Bundle + "/" + run.Class + getCombinationSuffix(run),
+		Tests:      1,
+		Failures:   1,
+		Time:       formatJunitSeconds(0),
+		Properties: getJunitRunProperties(&lostRun),
+		TestCase: []JunitTestCase{{
+			ID:      run.Class,
+			Name:    run.Class,
+			Time:    formatJunitSeconds(0),
+			Failure: &JunitFailure{Message: message, Type: lostRun.Result},
+		}},
+	}
+}
+
+// getJunitTestCase - Maps the result of a Galasa test method onto the junit test case.
+func getJunitTestCase(method TestMethod) JunitTestCase {
+	var testCase JunitTestCase
+	testCase.ID = method.Method
+	testCase.Name = method.Method
+	testCase.Time = formatJunitSeconds(getDurationSeconds(method.StartTimeUTC, method.EndTimeUTC))
+
+	message := "Test method " + method.Method + " finished with result: " + method.Result
+	switch getReportOutcome(method.Result) {
+	case REPORT_OUTCOME_FAILED:
+		testCase.Failure = &JunitFailure{Message: message, Type: method.Result}
+	case REPORT_OUTCOME_ERROR:
+		testCase.Error = &JunitFailure{Message: message, Type: method.Result}
+	case REPORT_OUTCOME_SKIPPED:
+		testCase.Skipped = &JunitSkipped{Message: message}
+	}
+	return testCase
+}
+
+// getDurationSeconds - Zero if either time is missing or can't be understood.
+func getDurationSeconds(startTimeUTC string, endTimeUTC string) float64 {
+	var seconds float64
+	startTime, startErr := time.Parse(time.RFC3339, startTimeUTC)
+	endTime, endErr := time.Parse(time.RFC3339, endTimeUTC)
+	if startErr == nil && endErr == nil && !endTime.Before(startTime) {
+		seconds = endTime.Sub(startTime).Seconds()
+	}
+	return seconds
+}
+
+// formatJunitSeconds - To the millisecond, without an exponent, which junit readers can't parse.
+func formatJunitSeconds(seconds float64) string {
+	return strconv.FormatFloat(math.Round(seconds*1000)/1000, 'f', -1, 64)
+}
+
+// getJunitRunLogTail - The report is still written if a run log can't be fetched, just without that run log.
+func getJunitRunLogTail(runLogFetcher RunLogFetcher, run *TestRun, lineCount int) *JunitSystemOut {
+	var systemOut *JunitSystemOut
+	runLog, err := runLogFetcher.GetRunLog(run.RasRunId)
+	if err != nil {
+		log.Printf("Could not include the run log of test run %v in the junit report. Reason: %v\n", run.Name, err.Error())
+	} else {
+		systemOut = &JunitSystemOut{Text: getRunLogTail(runLog, lineCount)}
+	}
+	return systemOut
+}
+
+// getJunitRunProperties - When a test was re-submitted, record each of the earlier attempts
+// as properties of its test suite. The test suite itself holds the results of the last attempt.
+// When a test was cancelled because the submission stopped early, record why.
+// When a test was run as part of a portfolio matrix, record which combination it used.
+// When the portfolio gave the test class labels, record them.
+// When the result policy doesn't count a failed test as a failure, record why.
+// The result, stream, bundle, obr, requestor and group of the run are recorded when they are known,
+// so that 'runs prepare --from-report' can read the test runs back from the report.
+func getJunitRunProperties(run *TestRun) *JunitProperties {
+	properties := new(JunitProperties)
+
+	for _, property := range []JunitProperty{
+		{Name: "result", Value: run.Result},
+		{Name: "stream", Value: run.Stream},
+		{Name: "bundle", Value: run.Bundle},
+		{Name: "obr", Value: run.Obr},
+		{Name: "requestor", Value: run.Requestor},
+		{Name: "group", Value: run.Group},
+	} {
+		if property.Value != "" {
+			properties.Property = append(properties.Property, property)
+		}
+	}
+
+	if len(run.Labels) > 0 {
+		properties.Property = append(properties.Property, JunitProperty{
+			Name:  "labels",
+			Value: strings.Join(run.Labels, ","),
+		})
+	}
+
+	if run.Combination != "" {
+		properties.Property = append(properties.Property, JunitProperty{
+			Name:  "combination",
+			Value: run.Combination,
+		})
+	}
+
+	if len(run.PreviousAttempts) > 0 {
+		properties.Property = append(properties.Property, JunitProperty{
+			Name:  "attempts",
+			Value: strconv.Itoa(len(run.PreviousAttempts) + 1),
+		})
+
+		for index, attempt := range run.PreviousAttempts {
+			properties.Property = append(properties.Property, JunitProperty{
+				Name:  "attempt-" + strconv.Itoa(index+1),
+				Value: attempt.Name + " " + attempt.Result,
+			})
+		}
+	}
+
+	if run.CancelledBy != "" {
+		properties.Property = append(properties.Property, JunitProperty{
+			Name:  "cancelled-by",
+			Value: run.CancelledBy,
+		})
+	}
+
+	if run.ExcusedBy != "" {
+		properties.Property = append(properties.Property, JunitProperty{
+			Name:  "excused-by",
+			Value: run.ExcusedBy,
+		})
+	}
+
+	if len(properties.Property) == 0 {
+		properties = nil
+	}
+	return properties
+}
+
 func sortFinishedRunsKeys(finishedRuns map[string]*TestRun) []string {
 
 	var finishedRunsKeys = make([]string, 0)
@@ -6,6 +6,7 @@
 package runs
 
 import (
+	"errors"
 	"strconv"
 	"strings"
 	"testing"
@@ -21,7 +22,7 @@ func submitFinishedRunsAndReturnJunitReport(t *testing.T, finishedRunsMap map[st
 		mockFileSystem,
 		"myReportJunitFilename",
 		"myGroup",
-		finishedRunsMap, lostRunsMap)
+		finishedRunsMap, lostRunsMap, nil, 0)
 
 	// Then...
 	if err != nil {
@@ -83,6 +84,11 @@ func TestJunitReportPassedRunWith2PassedTestsWorks(t *testing.T) {
 	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
 	<testsuites id="myGroup" name="Galasa test run" tests="2" failures="0" time="0">
 		<testsuite id="myTestRun" name="myStream/myBundle/com.myco.MyClass" tests="2" failures="0" time="0">
+			<properties>
+				<property name="result" value="PASSED"></property>
+				<property name="stream" value="myStream"></property>
+				<property name="bundle" value="myBundle"></property>
+			</properties>
 			<testcase id="method1" name="method1" time="0"></testcase>
 			<testcase id="method2" name="method2" time="0"></testcase>
 		</testsuite>
@@ -111,6 +117,11 @@ func TestJunitReportFailedRunWith2PassedTestsWorks(t *testing.T) {
 	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
 	<testsuites id="myGroup" name="Galasa test run" tests="2" failures="0" time="0">
 		<testsuite id="myTestRun" name="myStream/myBundle/com.myco.MyClass" tests="2" failures="0" time="0">
+			<properties>
+				<property name="result" value="PASSED"></property>
+				<property name="stream" value="myStream"></property>
+				<property name="bundle" value="myBundle"></property>
+			</properties>
 			<testcase id="method1" name="method1" time="0"></testcase>
 			<testcase id="method2" name="method2" time="0"></testcase>
 		</testsuite>
@@ -138,7 +149,13 @@ func TestJunitReportWith0TestsWorks(t *testing.T) {
 	// We expect a report like this:
 	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
 	<testsuites id="myGroup" name="Galasa test run" tests="0" failures="0" time="0">
-		<testsuite id="myTestRun" name="myStream/myBundle/com.myco.MyClass" tests="0" failures="0" time="0"></testsuite>
+		<testsuite id="myTestRun" name="myStream/myBundle/com.myco.MyClass" tests="0" failures="0" time="0">
+			<properties>
+				<property name="result" value="FAILED"></property>
+				<property name="stream" value="myStream"></property>
+				<property name="bundle" value="myBundle"></property>
+			</properties>
+		</testsuite>
 	</testsuites>`
 
 	// When...
@@ -166,9 +183,14 @@ func TestJunitReportWith1FailedTestWorks(t *testing.T) {
 	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
 	<testsuites id="myGroup" name="Galasa test run" tests="2" failures="1" time="0">
 		<testsuite id="myTestRun" name="myStream/myBundle/com.myco.MyClass" tests="2" failures="1" time="0">
+			<properties>
+				<property name="result" value="FAILED"></property>
+				<property name="stream" value="myStream"></property>
+				<property name="bundle" value="myBundle"></property>
+			</properties>
 			<testcase id="method1" name="method1" time="0"></testcase>
 			<testcase id="method2" name="method2" time="0">
-				<failure message="Failure messages are unavailable at this time" type="Unknown"></failure>
+				<failure message="Test method method2 finished with result: failed" type="failed"></failure>
 			</testcase>
 		</testsuite>
 	</testsuites>`
@@ -207,12 +229,22 @@ func TestJunitReportWith2RunsAndMixedResultTestsReturnsOk(t *testing.T) {
 	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
 	<testsuites id="myGroup" name="Galasa test run" tests="4" failures="1" time="0">
 		<testsuite id="myTestRun1" name="myStream1/myBundle1/com.myco.MyClass1" tests="2" failures="1" time="0">
+			<properties>
+				<property name="result" value="FAILED"></property>
+				<property name="stream" value="myStream1"></property>
+				<property name="bundle" value="myBundle1"></property>
+			</properties>
 			<testcase id="method1.1" name="method1.1" time="0"></testcase>
 			<testcase id="method1.2" name="method1.2" time="0">
-				<failure message="Failure messages are unavailable at this time" type="Unknown"></failure>
+				<failure message="Test method method1.2 finished with result: failed" type="failed"></failure>
 			</testcase>
 		</testsuite>
 		<testsuite id="myTestRun2" name="myStream2/myBundle2/com.myco.MyClass2" tests="2" failures="0" time="0">
+			<properties>
+				<property name="result" value="PASSED"></property>
+				<property name="stream" value="myStream2"></property>
+				<property name="bundle" value="myBundle2"></property>
+			</properties>
 			<testcase id="method2.1" name="method2.1" time="0"></testcase>
 			<testcase id="method2.2" name="method2.2" time="0"></testcase>
 		</testsuite>
@@ -263,16 +295,31 @@ func TestJunitReportWithManyRunsAndMixedResultTestsReturnsAlphabeticallyOk(t *te
 	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
 	<testsuites id="myGroup" name="Galasa test run" tests="5" failures="1" time="0">
 		<testsuite id="eagle" name="myStream2/myBundle2/com.myco.MyClass2" tests="1" failures="0" time="0">
+			<properties>
+				<property name="result" value="PASSED"></property>
+				<property name="stream" value="myStream2"></property>
+				<property name="bundle" value="myBundle2"></property>
+			</properties>
 			<testcase id="method3.1" name="method3.1" time="0"></testcase>
 		</testsuite>	
 		<testsuite id="myTestRun2" name="myStream2/myBundle2/com.myco.MyClass2" tests="2" failures="0" time="0">
+			<properties>
+				<property name="result" value="PASSED"></property>
+				<property name="stream" value="myStream2"></property>
+				<property name="bundle" value="myBundle2"></property>
+			</properties>
 			<testcase id="method2.1" name="method2.1" time="0"></testcase>
 			<testcase id="method2.2" name="method2.2" time="0"></testcase>
 		</testsuite>
 		<testsuite id="zoo" name="myStream1/myBundle1/com.myco.MyClass1" tests="2" failures="1" time="0">
+			<properties>
+				<property name="result" value="FAILED"></property>
+				<property name="stream" value="myStream1"></property>
+				<property name="bundle" value="myBundle1"></property>
+			</properties>
 			<testcase id="method1.1" name="method1.1" time="0"></testcase>
 			<testcase id="method1.2" name="method1.2" time="0">
-				<failure message="Failure messages are unavailable at this time" type="Unknown"></failure>
+				<failure message="Test method method1.2 finished with result: failed" type="failed"></failure>
 			</testcase>
 		</testsuite>
 	</testsuites>`
@@ -311,15 +358,338 @@ func TestJunitReportPassedRunWithLostRunsWorks(t *testing.T) {
 	lostRunsMap["myLostRun1"] = &lostRuns
 	lostRunsMap["myLostRun2"] = &lostRuns
 
+	// Each lost run is a test suite with a single failed test case, so it can be read back from the report.
+	lostTestSuite := `
+		<testsuite id="myLostRun" name="myStream/myBundle/com.myco.MyClass" tests="1" failures="1" time="0">
+			<properties>
+				<property name="result" value="UNKNOWN"></property>
+				<property name="stream" value="myStream"></property>
+				<property name="bundle" value="myBundle"></property>
+			</properties>
+			<testcase id="com.myco.MyClass" name="com.myco.MyClass" time="0">
+				<failure message="Test run myLostRun did not finish. Result: UNKNOWN" type="UNKNOWN"></failure>
+			</testcase>
+		</testsuite>`
+
 	// We expect a report like this:
 	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
 	<testsuites id="myGroup" name="Galasa test run" tests="5" failures="3" time="0">
 		<testsuite id="myTestRun" name="myStream/myBundle/com.myco.MyClass" tests="2" failures="0" time="0">
+			<properties>
+				<property name="result" value="PASSED"></property>
+				<property name="stream" value="myStream"></property>
+				<property name="bundle" value="myBundle"></property>
+			</properties>
 			<testcase id="method1" name="method1" time="0"></testcase>
 			<testcase id="method2" name="method2" time="0"></testcase>
+		</testsuite>` + strings.Repeat(lostTestSuite, 3) + `
+	</testsuites>`
+
+	// When...
+	submitFinishedRunsAndReturnJunitReport(t, finishedRunsMap, lostRunsMap, expectedReport)
+}
+
+func TestJunitReportRecordsPreviousAttemptsAsProperties(t *testing.T) {
+	// Given...
+	finishedRuns := TestRun{
+		Name:      "U102",
+		Bundle:    "myBundle",
+		Class:     "com.myco.MyClass",
+		Stream:    "myStream",
+		Status:    "finished",
+		Result:    "Passed",
+		Overrides: make(map[string]string, 1),
+		Tests:     []TestMethod{{Method: "method1", Result: "Passed"}},
+		PreviousAttempts: []TestRunAttempt{
+			{Name: "U100", Status: "finished", Result: "EnvFail"},
+			{Name: "U101", Status: "finished", Result: "EnvFail"},
+		},
+	}
+
+	finishedRunsMap := make(map[string]*TestRun, 1)
+	finishedRunsMap["U102"] = &finishedRuns
+
+	lostRunsMap := make(map[string]*TestRun, 0)
+
+	// We expect a report like this:
+	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
+	<testsuites id="myGroup" name="Galasa test run" tests="1" failures="0" time="0">
+		<testsuite id="U102" name="myStream/myBundle/com.myco.MyClass" tests="1" failures="0" time="0">
+			<properties>
+				<property name="result" value="Passed"></property>
+				<property name="stream" value="myStream"></property>
+				<property name="bundle" value="myBundle"></property>
+				<property name="attempts" value="3"></property>
+				<property name="attempt-1" value="U100 EnvFail"></property>
+				<property name="attempt-2" value="U101 EnvFail"></property>
+			</properties>
+			<testcase id="method1" name="method1" time="0"></testcase>
+		</testsuite>
+	</testsuites>`
+
+	// When...
+	submitFinishedRunsAndReturnJunitReport(t, finishedRunsMap, lostRunsMap, expectedReport)
+}
+
+func TestJunitReportRecordsWhyARunWasCancelledAsAProperty(t *testing.T) {
+	// Given...
+	finishedRuns := TestRun{
+		Name:        "U100",
+		Bundle:      "myBundle",
+		Class:       "com.myco.MyClass",
+		Stream:      "myStream",
+		Status:      "finished",
+		Result:      "cancelled",
+		Overrides:   make(map[string]string, 1),
+		Tests:       []TestMethod{},
+		CancelledBy: STOP_REASON_FAIL_FAST,
+	}
+
+	finishedRunsMap := make(map[string]*TestRun, 1)
+	finishedRunsMap["U100"] = &finishedRuns
+
+	lostRunsMap := make(map[string]*TestRun, 0)
+
+	// We expect a report like this:
+	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
+	<testsuites id="myGroup" name="Galasa test run" tests="0" failures="0" time="0">
+		<testsuite id="U100" name="myStream/myBundle/com.myco.MyClass" tests="0" failures="0" time="0">
+			<properties>
+				<property name="result" value="cancelled"></property>
+				<property name="stream" value="myStream"></property>
+				<property name="bundle" value="myBundle"></property>
+				<property name="cancelled-by" value="fail-fast"></property>
+			</properties>
+		</testsuite>
+	</testsuites>`
+
+	// When...
+	submitFinishedRunsAndReturnJunitReport(t, finishedRunsMap, lostRunsMap, expectedReport)
+}
+
+func TestJunitReportRecordsTheMatrixCombinationOfARun(t *testing.T) {
+	// Given...
+	finishedRuns := TestRun{
+		Name:        "U100",
+		Bundle:      "myBundle",
+		Class:       "com.myco.MyClass",
+		Stream:      "myStream",
+		Status:      "finished",
+		Result:      "Passed",
+		Overrides:   make(map[string]string, 1),
+		Tests:       []TestMethod{},
+		Combination: "image=zos1",
+	}
+
+	finishedRunsMap := make(map[string]*TestRun, 1)
+	finishedRunsMap["U100"] = &finishedRuns
+
+	lostRunsMap := make(map[string]*TestRun, 0)
+
+	// We expect a report like this:
+	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
+	<testsuites id="myGroup" name="Galasa test run" tests="0" failures="0" time="0">
+		<testsuite id="U100" name="myStream/myBundle/com.myco.MyClass[image=zos1]" tests="0" failures="0" time="0">
+			<properties>
+				<property name="result" value="Passed"></property>
+				<property name="stream" value="myStream"></property>
+				<property name="bundle" value="myBundle"></property>
+				<property name="combination" value="image=zos1"></property>
+			</properties>
+		</testsuite>
+	</testsuites>`
+
+	// When...
+	submitFinishedRunsAndReturnJunitReport(t, finishedRunsMap, lostRunsMap, expectedReport)
+}
+
+func TestJunitReportRecordsTheLabelsOfARun(t *testing.T) {
+	// Given...
+	finishedRuns := TestRun{
+		Name:      "U100",
+		Bundle:    "myBundle",
+		Class:     "com.myco.MyClass",
+		Stream:    "myStream",
+		Status:    "finished",
+		Result:    "Passed",
+		Overrides: make(map[string]string, 1),
+		Tests:     []TestMethod{},
+		Labels:    []string{"smoke", "cics"},
+	}
+
+	finishedRunsMap := make(map[string]*TestRun, 1)
+	finishedRunsMap["U100"] = &finishedRuns
+
+	lostRunsMap := make(map[string]*TestRun, 0)
+
+	// We expect a report like this:
+	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
+	<testsuites id="myGroup" name="Galasa test run" tests="0" failures="0" time="0">
+		<testsuite id="U100" name="myStream/myBundle/com.myco.MyClass" tests="0" failures="0" time="0">
+			<properties>
+				<property name="result" value="Passed"></property>
+				<property name="stream" value="myStream"></property>
+				<property name="bundle" value="myBundle"></property>
+				<property name="labels" value="smoke,cics"></property>
+			</properties>
 		</testsuite>
 	</testsuites>`
 
 	// When...
 	submitFinishedRunsAndReturnJunitReport(t, finishedRunsMap, lostRunsMap, expectedReport)
 }
+
+func TestJunitReportMapsMethodResultsAndTimings(t *testing.T) {
+	// Given...
+	finishedRuns := TestRun{
+		Name:         "U100",
+		Bundle:       "myBundle",
+		Class:        "com.myco.MyClass",
+		Stream:       "myStream",
+		Status:       "finished",
+		Result:       "Failed",
+		Requestor:    "myRequestor",
+		Group:        "myGroup",
+		StartTimeUTC: "2024-03-01T10:00:00Z",
+		EndTimeUTC:   "2024-03-01T10:01:30Z",
+		Overrides:    make(map[string]string, 1),
+		Tests: []TestMethod{
+			{Method: "method1", Result: "Passed", StartTimeUTC: "2024-03-01T10:00:10Z", EndTimeUTC: "2024-03-01T10:00:12.5Z"},
+			{Method: "method2", Result: "Failed", StartTimeUTC: "2024-03-01T10:00:12.5Z", EndTimeUTC: "2024-03-01T10:00:20Z"},
+			{Method: "method3", Result: "EnvFail", StartTimeUTC: "2024-03-01T10:00:20Z", EndTimeUTC: "2024-03-01T10:00:21Z"},
+			{Method: "method4", Result: "Ignored"},
+			{Method: "method5", Result: "Disabled"},
+		},
+	}
+
+	finishedRunsMap := make(map[string]*TestRun, 1)
+	finishedRunsMap["U100"] = &finishedRuns
+
+	// We expect a report like this:
+	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
+	<testsuites id="myGroup" name="Galasa test run" tests="5" failures="1" errors="1" skipped="2" time="90">
+		<testsuite id="U100" name="myStream/myBundle/com.myco.MyClass" tests="5" failures="1" errors="1" skipped="2" time="90">
+			<properties>
+				<property name="result" value="Failed"></property>
+				<property name="stream" value="myStream"></property>
+				<property name="bundle" value="myBundle"></property>
+				<property name="requestor" value="myRequestor"></property>
+				<property name="group" value="myGroup"></property>
+			</properties>
+			<testcase id="method1" name="method1" time="2.5"></testcase>
+			<testcase id="method2" name="method2" time="7.5">
+				<failure message="Test method method2 finished with result: Failed" type="Failed"></failure>
+			</testcase>
+			<testcase id="method3" name="method3" time="1">
+				<error message="Test method method3 finished with result: EnvFail" type="EnvFail"></error>
+			</testcase>
+			<testcase id="method4" name="method4" time="0">
+				<skipped message="Test method method4 finished with result: Ignored"></skipped>
+			</testcase>
+			<testcase id="method5" name="method5" time="0">
+				<skipped message="Test method method5 finished with result: Disabled"></skipped>
+			</testcase>
+		</testsuite>
+	</testsuites>`
+
+	// When...
+	submitFinishedRunsAndReturnJunitReport(t, finishedRunsMap, nil, expectedReport)
+}
+
+func TestJunitReportTimeOfARunWithoutTimesIsTheTimeOfItsMethods(t *testing.T) {
+	// Given...
+	finishedRuns := TestRun{
+		Name:      "U100",
+		Bundle:    "myBundle",
+		Class:     "com.myco.MyClass",
+		Stream:    "myStream",
+		Status:    "finished",
+		Result:    "Passed",
+		Overrides: make(map[string]string, 1),
+		Tests: []TestMethod{
+			{Method: "method1", Result: "Passed", StartTimeUTC: "2024-03-01T10:00:10Z", EndTimeUTC: "2024-03-01T10:00:12.5Z"},
+			{Method: "method2", Result: "Passed", StartTimeUTC: "2024-03-01T10:00:12.5Z", EndTimeUTC: "2024-03-01T10:00:13.001Z"},
+		},
+	}
+
+	finishedRunsMap := make(map[string]*TestRun, 1)
+	finishedRunsMap["U100"] = &finishedRuns
+
+	// We expect a report like this:
+	expectedReport := `<?xml version="1.0" encoding="UTF-8" ?>
+	<testsuites id="myGroup" name="Galasa test run" tests="2" failures="0" time="3.001">
+		<testsuite id="U100" name="myStream/myBundle/com.myco.MyClass" tests="2" failures="0" time="3.001">
+			<properties>
+				<property name="result" value="Passed"></property>
+				<property name="stream" value="myStream"></property>
+				<property name="bundle" value="myBundle"></property>
+			</properties>
+			<testcase id="method1" name="method1" time="2.5"></testcase>
+			<testcase id="method2" name="method2" time="0.501"></testcase>
+		</testsuite>
+	</testsuites>`
+
+	// When...
+	submitFinishedRunsAndReturnJunitReport(t, finishedRunsMap, nil, expectedReport)
+}
+
+type mockRunLogFetcher struct {
+	runLogs       map[string]string
+	fetchedRunIds []string
+}
+
+func (fetcher *mockRunLogFetcher) GetRunLog(rasRunId string) (string, error) {
+	var err error
+	fetcher.fetchedRunIds = append(fetcher.fetchedRunIds, rasRunId)
+	runLog, isKnown := fetcher.runLogs[rasRunId]
+	if !isKnown {
+		err = errors.New("run log not found")
+	}
+	return runLog, err
+}
+
+func TestJunitReportIncludesTheEndOfEachRunLog(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	runLogFetcher := &mockRunLogFetcher{runLogs: map[string]string{
+		"cdb-100": "line 1\nline 2\nline 3\nline <4>\n",
+	}}
+
+	finishedRunsMap := map[string]*TestRun{
+		"U100": {Name: "U100", Bundle: "myBundle", Class: "com.myco.MyClass1", Result: "Failed", RasRunId: "cdb-100"},
+		"U101": {Name: "U101", Bundle: "myBundle", Class: "com.myco.MyClass2", Result: "Passed", RasRunId: "cdb-101"},
+		"U102": {Name: "U102", Bundle: "myBundle", Class: "com.myco.MyClass3", Result: "Passed"},
+	}
+
+	// When...
+	err := ReportJunit(mockFileSystem, "myReport.xml", "myGroup", finishedRunsMap, nil, runLogFetcher, 2)
+
+	// Then...
+	assert.Nil(t, err)
+	contents, err := mockFileSystem.ReadTextFile("myReport.xml")
+	assert.Nil(t, err)
+	assert.Contains(t, contents, "<system-out><![CDATA[line 3\nline <4>]]></system-out>")
+	assert.Equal(t, 1, strings.Count(contents, "<system-out>"), "A run log which could not be fetched should be left out.")
+
+	// Runs without a run id have no run log to fetch.
+	assert.Equal(t, []string{"cdb-100", "cdb-101"}, runLogFetcher.fetchedRunIds)
+}
+
+func TestJunitReportLeavesRunLogsOutWhenNoLinesAreWanted(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	runLogFetcher := &mockRunLogFetcher{runLogs: map[string]string{"cdb-100": "line 1\n"}}
+
+	finishedRunsMap := map[string]*TestRun{
+		"U100": {Name: "U100", Bundle: "myBundle", Class: "com.myco.MyClass1", Result: "Failed", RasRunId: "cdb-100"},
+	}
+
+	// When...
+	err := ReportJunit(mockFileSystem, "myReport.xml", "myGroup", finishedRunsMap, nil, runLogFetcher, 0)
+
+	// Then...
+	assert.Nil(t, err)
+	contents, _ := mockFileSystem.ReadTextFile("myReport.xml")
+	assert.NotContains(t, contents, "<system-out>")
+	assert.Empty(t, runLogFetcher.fetchedRunIds)
+}
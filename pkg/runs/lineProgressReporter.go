@@ -0,0 +1,77 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+// LineProgressReporter - Writes a line each time a run changes status, and each time the number
+// of runs in each state changes. Suits consoles which aren't terminals, such as build logs,
+// where a dashboard can't be redrawn in place.
+type LineProgressReporter struct {
+	console    spi.Console
+	startTimes runStartTimes
+
+	// What was last reported, so that only changes are written.
+	reportedStatuses map[string]string
+	reportedCounters string
+}
+
+func NewLineProgressReporter(console spi.Console) *LineProgressReporter {
+	reporter := new(LineProgressReporter)
+	reporter.console = console
+	reporter.startTimes = make(runStartTimes)
+	reporter.reportedStatuses = make(map[string]string)
+	return reporter
+}
+
+func (reporter *LineProgressReporter) ReportProgress(progress SubmissionProgress) {
+	buff := strings.Builder{}
+
+	for _, runName := range getSortedRunNames(progress.SubmittedRuns) {
+		run := progress.SubmittedRuns[runName]
+		elapsed := reporter.startTimes.getElapsedTime(runName, run, progress.Now)
+		if reporter.reportedStatuses[runName] != run.Status {
+			reporter.reportedStatuses[runName] = run.Status
+			buff.WriteString(fmt.Sprintf("Run %v is now %v - %v (%v)\n", runName, run.Status, getRunClassName(run), elapsed))
+		}
+	}
+
+	for _, runName := range getSortedRunNames(progress.FinishedRuns) {
+		run := progress.FinishedRuns[runName]
+		if reporter.reportedStatuses[runName] != run.Status {
+			reporter.reportedStatuses[runName] = run.Status
+			elapsed := reporter.startTimes.getElapsedTime(runName, run, progress.Now)
+			buff.WriteString(fmt.Sprintf("Run %v has finished(%v) - %v (%v)\n", runName, run.Result, getRunClassName(run), elapsed))
+		}
+	}
+
+	for _, runName := range getSortedRunNames(progress.LostRuns) {
+		run := progress.LostRuns[runName]
+		if reporter.reportedStatuses[runName] != RESULT_LOST {
+			reporter.reportedStatuses[runName] = RESULT_LOST
+			buff.WriteString(fmt.Sprintf("Run %v was lost - %v\n", runName, getRunClassName(run)))
+		}
+	}
+
+	counters := formatProgressCounters(progress)
+	if counters != reporter.reportedCounters {
+		reporter.reportedCounters = counters
+		buff.WriteString(counters)
+	}
+
+	if buff.Len() > 0 {
+		reporter.console.WriteString(buff.String())
+	}
+}
+
+func (reporter *LineProgressReporter) ReportMessage(message string) {
+	reporter.console.WriteString(message)
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineProgressWritesEachRunAndTheCounters(t *testing.T) {
+	// Given...
+	console := utils.NewMockConsole()
+	reporter := NewLineProgressReporter(console)
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	submittedRuns := map[string]*TestRun{
+		"U100": {Name: "U100", Bundle: "myBundle", Class: "myClass1", Status: "running"},
+	}
+
+	// When...
+	reporter.ReportProgress(newProgressForTests(now, submittedRuns))
+
+	// Then...
+	assert.Equal(t,
+		"Run U100 is now running - myBundle/myClass1 (0s)\n"+
+			"Ready=1, Submitted=1, Finished=0, Lost=0, Throttle=2\n",
+		console.ReadText())
+}
+
+func TestLineProgressOnlyWritesChanges(t *testing.T) {
+	// Given...
+	console := utils.NewMockConsole()
+	reporter := NewLineProgressReporter(console)
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	submittedRuns := map[string]*TestRun{
+		"U100": {Name: "U100", Bundle: "myBundle", Class: "myClass1", Status: "running"},
+		"U101": {Name: "U101", Bundle: "myBundle", Class: "myClass2", Status: "queued"},
+	}
+	reporter.ReportProgress(newProgressForTests(now, submittedRuns))
+	firstOutput := console.ReadText()
+
+	// When...
+	reporter.ReportProgress(newProgressForTests(now.Add(time.Minute), submittedRuns))
+	submittedRuns["U101"].Status = "building"
+	reporter.ReportProgress(newProgressForTests(now.Add(time.Minute*2), submittedRuns))
+
+	// Then...
+	assert.Equal(t, "Run U101 is now building - myBundle/myClass2 (2m0s)\n", console.ReadText()[len(firstOutput):])
+}
+
+func TestLineProgressWritesFinishedAndLostRuns(t *testing.T) {
+	// Given...
+	console := utils.NewMockConsole()
+	reporter := NewLineProgressReporter(console)
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	run := &TestRun{Name: "U100", Bundle: "myBundle", Class: "myClass1", Status: "running"}
+	progress := newProgressForTests(now, map[string]*TestRun{"U100": run})
+	reporter.ReportProgress(progress)
+	firstOutput := console.ReadText()
+
+	// When...
+	run.Status = "finished"
+	run.Result = RESULT_PASSED
+	progress = newProgressForTests(now.Add(time.Minute*3), make(map[string]*TestRun))
+	progress.FinishedRuns["U100"] = run
+	progress.LostRuns["U101"] = &TestRun{Name: "U101", Bundle: "myBundle", Class: "myClass2"}
+	reporter.ReportProgress(progress)
+
+	// Then...
+	assert.Equal(t,
+		"Run U100 has finished(Passed) - myBundle/myClass1 (3m0s)\n"+
+			"Run U101 was lost - myBundle/myClass2\n"+
+			"Ready=1, Submitted=0, Finished=1, Lost=1, Throttle=2\n",
+		console.ReadText()[len(firstOutput):])
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/runsformatter"
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+// ReportMarkdown - Writes a summary of the test runs in markdown, to post as a pull request comment
+// or to a CI job summary file. The totals of each result come first, then a table of the test runs,
+// then the test methods which did not pass. The runs link to the ecosystem when apiServerUrl is given.
+func ReportMarkdown(
+	fileSystem spi.FileSystem,
+	reportMarkdownFilename string,
+	apiServerUrl string,
+	finishedRuns map[string]*TestRun,
+	lostRuns map[string]*TestRun) error {
+
+	buff := strings.Builder{}
+
+	buff.WriteString("## Galasa test results\n\n")
+	buff.WriteString(getMarkdownTotals(finishedRuns, lostRuns) + "\n")
+
+	if len(finishedRuns)+len(lostRuns) > 0 {
+		buff.WriteString("\n| Run | Test | Result | Duration |\n")
+		buff.WriteString("| --- | --- | --- | --- |\n")
+		for _, run := range getRunsInNameOrder(finishedRuns) {
+			writeMarkdownRunRow(&buff, run, run.Result, apiServerUrl)
+		}
+		for _, run := range getRunsInNameOrder(lostRuns) {
+			writeMarkdownRunRow(&buff, run, getLostRunResult(run), apiServerUrl)
+		}
+	}
+
+	writeMarkdownMethodsNotPassed(&buff, finishedRuns)
+
+	err := fileSystem.WriteTextFile(reportMarkdownFilename, buff.String())
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_REPORT_MARKDOWN_WRITE_FAIL, reportMarkdownFilename, err.Error())
+	} else {
+		log.Printf("Markdown test report written to %v\n", reportMarkdownFilename)
+	}
+	return err
+}
+
+// getMarkdownTotals - In the same order as the final report 'runs submit' shows.
+func getMarkdownTotals(finishedRuns map[string]*TestRun, lostRuns map[string]*TestRun) string {
+	resultCounts := make(map[string]int)
+	for _, run := range finishedRuns {
+		resultCounts[run.Result] += 1
+	}
+	resultCounts[RESULT_LOST] = len(lostRuns)
+
+	totals := "**Total: " + strconv.Itoa(len(finishedRuns)+len(lostRuns)) + "**"
+	for _, result := range orderResultLabelKeys(resultCounts) {
+		if resultCounts[result] > 0 {
+			totals += ", " + escapeMarkdown(result) + ": " + strconv.Itoa(resultCounts[result])
+		}
+	}
+	return totals
+}
+
+func writeMarkdownRunRow(buff *strings.Builder, run *TestRun, result string, apiServerUrl string) {
+	runName := escapeMarkdown(run.Name)
+	if apiServerUrl != "" && run.RasRunId != "" {
+		runName = "[" + runName + "](" + apiServerUrl + runsformatter.RAS_RUNS_URL + run.RasRunId + ")"
+	}
+
+	buff.WriteString("| " + runName +
+		" | " + escapeMarkdown(run.Stream+"/"+run.getTestKey()) +
+		" | " + getMarkdownResultIcon(result) + " " + escapeMarkdown(result) + getMarkdownExcusedBy(run) +
+		" | " + getMarkdownDuration(run.StartTimeUTC, run.EndTimeUTC) +
+		" |\n")
+}
+
+func writeMarkdownMethodsNotPassed(buff *strings.Builder, finishedRuns map[string]*TestRun) {
+	isHeadingWritten := false
+	for _, run := range getRunsInNameOrder(finishedRuns) {
+		for _, method := range run.Tests {
+			if getReportOutcome(method.Result) != REPORT_OUTCOME_PASSED {
+				if !isHeadingWritten {
+					buff.WriteString("\n### Test methods which did not pass\n\n")
+					isHeadingWritten = true
+				}
+				buff.WriteString("- " + getMarkdownResultIcon(method.Result) + " " + escapeMarkdown(run.Name) +
+					" " + escapeMarkdown(run.getTestKey()) + " `" + strings.ReplaceAll(method.Method, "`", "'") + "`: " +
+					escapeMarkdown(method.Result) + "\n")
+			}
+		}
+	}
+}
+
+func getMarkdownExcusedBy(run *TestRun) string {
+	var excusedBy string
+	if run.ExcusedBy != "" {
+		excusedBy = " (excused by " + run.ExcusedBy + ")"
+	}
+	return excusedBy
+}
+
+func getMarkdownResultIcon(result string) string {
+	var icon string
+	switch getReportOutcome(result) {
+	case REPORT_OUTCOME_PASSED:
+		icon = ":white_check_mark:"
+	case REPORT_OUTCOME_SKIPPED:
+		icon = ":fast_forward:"
+	case REPORT_OUTCOME_ERROR:
+		icon = ":warning:"
+	default:
+		icon = ":x:"
+	}
+	return icon
+}
+
+// getMarkdownDuration - To the nearest second, such as '1m30s'. Empty if the times are not known.
+func getMarkdownDuration(startTimeUTC string, endTimeUTC string) string {
+	var duration string
+	startTime, startErr := time.Parse(time.RFC3339, startTimeUTC)
+	endTime, endErr := time.Parse(time.RFC3339, endTimeUTC)
+	if startErr == nil && endErr == nil && !endTime.Before(startTime) {
+		duration = endTime.Sub(startTime).Round(time.Second).String()
+	}
+	return duration
+}
+
+// escapeMarkdown - Stops text from the test runs being taken as markdown, or breaking the table it is in.
+func escapeMarkdown(text string) string {
+	replacer := strings.NewReplacer(
+		"\\", "\\\\",
+		"|", "\\|",
+		"*", "\\*",
+		"_", "\\_",
+		"[", "\\[",
+		"]", "\\]",
+		"<", "&lt;",
+		">", "&gt;",
+		"\n", " ",
+	)
+	return replacer.Replace(text)
+}
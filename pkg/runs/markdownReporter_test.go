@@ -0,0 +1,66 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportMarkdownWritesTotalsRunsAndMethodsWhichDidNotPass(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	finishedRuns := map[string]*TestRun{
+		"U100": {Name: "U100", Stream: "myStream", Bundle: "myBundle", Class: "myClass1", Result: "Passed",
+			RasRunId: "cdb-100", StartTimeUTC: "2024-03-01T10:00:00Z", EndTimeUTC: "2024-03-01T10:01:30.4Z"},
+		"U101": {Name: "U101", Stream: "myStream", Bundle: "myBundle", Class: "my_Class2", Result: "Failed",
+			Tests: []TestMethod{{Method: "testOne", Result: "Passed"}, {Method: "testTwo", Result: "Failed"}}},
+	}
+	lostRuns := map[string]*TestRun{
+		"U102": {Name: "U102", Stream: "myStream", Bundle: "myBundle", Class: "myClass3"},
+	}
+
+	// When...
+	err := ReportMarkdown(mockFileSystem, "report.md", "https://my-ecosystem/api", finishedRuns, lostRuns)
+
+	// Then...
+	assert.Nil(t, err)
+	markdown, err := mockFileSystem.ReadTextFile("report.md")
+	assert.Nil(t, err)
+	assert.Equal(t, `## Galasa test results
+
+**Total: 3**, Passed: 1, Failed: 1, Lost: 1
+
+| Run | Test | Result | Duration |
+| --- | --- | --- | --- |
+| [U100](https://my-ecosystem/api/ras/runs/cdb-100) | myStream/myBundle/myClass1 | :white_check_mark: Passed | 1m30s |
+| U101 | myStream/myBundle/my\_Class2 | :x: Failed |  |
+| U102 | myStream/myBundle/myClass3 | :x: Lost |  |
+
+### Test methods which did not pass
+
+- :x: U101 myBundle/my\_Class2 `+"`testTwo`"+`: Failed
+`, markdown)
+}
+
+func TestReportMarkdownOfNoRunsHasOnlyTheTotal(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+
+	// When...
+	err := ReportMarkdown(mockFileSystem, "report.md", "", map[string]*TestRun{}, map[string]*TestRun{})
+
+	// Then...
+	assert.Nil(t, err)
+	markdown, _ := mockFileSystem.ReadTextFile("report.md")
+	assert.Equal(t, "## Galasa test results\n\n**Total: 0**\n", markdown)
+}
+
+func TestEscapeMarkdownStopsTextBreakingATable(t *testing.T) {
+	assert.Equal(t, `a\|b \*c\* &lt;d&gt;`, escapeMarkdown("a|b *c* <d>"))
+}
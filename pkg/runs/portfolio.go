@@ -6,6 +6,8 @@
 package runs
 
 import (
+	"strings"
+
 	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
 	"github.com/galasa-dev/cli/pkg/spi"
 	"gopkg.in/yaml.v3"
@@ -17,6 +19,9 @@ type Portfolio struct {
 	Metadata   PortfolioMetadata `yaml:"metadata"`
 
 	Classes []PortfolioClass `yaml:"classes"`
+
+	// Every class is run once for each combination of one override set from each axis.
+	Matrix []PortfolioMatrixAxis `yaml:"matrix,omitempty"`
 }
 
 type PortfolioMetadata struct {
@@ -30,11 +35,51 @@ type PortfolioClass struct {
 	Obr        string            `yaml:"obr"`
 	Overrides  map[string]string `yaml:"overrides"`
 	GherkinUrl string            `yaml:"gherkin"`
+
+	// The fields below are only allowed in v1beta portfolios.
+	PortfolioClassScheduling `yaml:",inline"`
+}
+
+// PortfolioClassScheduling - How a v1beta portfolio asks for a test class to be scheduled.
+type PortfolioClassScheduling struct {
+	// Classes with a higher priority are submitted before those with a lower one.
+	Priority int `yaml:"priority,omitempty"`
+
+	// The most times the class is attempted, overriding the --maxattempts flag of 'runs submit'.
+	MaxAttempts int `yaml:"maxAttempts,omitempty"`
+
+	// How long each attempt may run for before it is cancelled, such as "30m".
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// Labels which are carried into the reports, so that results can be picked out by them.
+	Labels []string `yaml:"labels,omitempty"`
+
+	// The bundle/class names of other classes in the portfolio which must pass before this one is submitted.
+	DependsOn []string `yaml:"dependsOn,omitempty"`
+}
+
+// PortfolioMatrixAxis - Something the tests are run against several variations of, such as the
+// z/OS image or CICS region to use, with the overrides which select each of those variations.
+type PortfolioMatrixAxis struct {
+	Name         string                 `yaml:"name"`
+	OverrideSets []PortfolioOverrideSet `yaml:"overrideSets"`
+}
+
+type PortfolioOverrideSet struct {
+	Name      string            `yaml:"name"`
+	Overrides map[string]string `yaml:"overrides"`
+}
+
+// MatrixCombination - One override set from each axis of a portfolio matrix.
+type MatrixCombination struct {
+	// Names each of the override sets in the combination, for example "image=zos1,region=cicsA".
+	Name      string
+	Overrides map[string]string
 }
 
 func NewPortfolio() *Portfolio {
 	portfolio := Portfolio{
-		APIVersion: "v1alpha",
+		APIVersion: PORTOLIO_DECLARED_FORMAT_VERSION,
 		Kind:       "galasa.dev/testPortfolio",
 		Metadata:   PortfolioMetadata{Name: "adhoc"},
 	}
@@ -48,6 +93,9 @@ const (
 	// Inside the portfolio file, it must carry a format field with this value inside.
 	PORTOLIO_DECLARED_FORMAT_VERSION = "v1alpha"
 
+	// ... or this value, if it uses per-class scheduling.
+	PORTFOLIO_DECLARED_FORMAT_VERSION_V1BETA = "v1beta"
+
 	// Inside the portfolio file, it should claim to be a resource of this kind.
 	PORTFOLIO_DECLARED_RESOURCE_KIND = "galasa.dev/testPortfolio"
 )
@@ -91,9 +139,9 @@ func ReadPortfolio(fileSystem spi.FileSystem, filename string) (*Portfolio, erro
 		return nil, err
 	}
 
-	// Check the portfolio file claims to be the correct format version.
-	if portfolio.APIVersion != PORTOLIO_DECLARED_FORMAT_VERSION {
-		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_BAD_FORMAT_VERSION, filename, PORTOLIO_DECLARED_FORMAT_VERSION)
+	// Check the portfolio file claims to be one of the format versions we understand.
+	if !IsSupportedPortfolioVersion(portfolio.APIVersion) {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_BAD_FORMAT_VERSION, filename, getSupportedPortfolioVersionsAsString())
 		return nil, err
 	}
 
@@ -102,5 +150,126 @@ func ReadPortfolio(fileSystem spi.FileSystem, filename string) (*Portfolio, erro
 		return nil, err
 	}
 
+	err = validatePortfolioMatrix(portfolio.Matrix, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidatePortfolioClasses(&portfolio, filename)
+	if err != nil {
+		return nil, err
+	}
+
 	return &portfolio, nil
 }
+
+// ReadPortfolioExpandingEnvVars - Reads a portfolio to submit its tests, replacing the references to
+// environment variables in the overrides of its classes and matrix. The portfolio file keeps the
+// references, so 'runs prepare' never writes the values of the variables into it.
+func ReadPortfolioExpandingEnvVars(fileSystem spi.FileSystem, filename string, expander *EnvVarExpander) (*Portfolio, error) {
+	portfolio, err := ReadPortfolio(fileSystem, filename)
+	if err == nil {
+		for _, portfolioClass := range portfolio.Classes {
+			err = expander.ExpandOverrides(portfolioClass.Overrides, "of class '"+portfolioClass.getClassKey()+"' in portfolio '"+filename+"'")
+			if err != nil {
+				break
+			}
+		}
+
+		for _, axis := range portfolio.Matrix {
+			for _, overrideSet := range axis.OverrideSets {
+				if err == nil {
+					err = expander.ExpandOverrides(overrideSet.Overrides,
+						"of override set '"+overrideSet.Name+"' of matrix axis '"+axis.Name+"' in portfolio '"+filename+"'")
+				}
+			}
+		}
+
+		if err != nil {
+			portfolio = nil
+		}
+	}
+	return portfolio, err
+}
+
+func IsSupportedPortfolioVersion(version string) bool {
+	return version == PORTOLIO_DECLARED_FORMAT_VERSION || version == PORTFOLIO_DECLARED_FORMAT_VERSION_V1BETA
+}
+
+func getSupportedPortfolioVersionsAsString() string {
+	return PORTOLIO_DECLARED_FORMAT_VERSION + "' or '" + PORTFOLIO_DECLARED_FORMAT_VERSION_V1BETA
+}
+
+func validatePortfolioMatrix(matrix []PortfolioMatrixAxis, filename string) error {
+	var err error
+
+	axisNames := make(map[string]bool)
+	for axisIndex, axis := range matrix {
+		if axis.Name == "" {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_MATRIX_AXIS_NO_NAME, filename, axisIndex+1)
+		} else if axisNames[axis.Name] {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_MATRIX_DUPLICATE_AXIS_NAME, filename, axis.Name)
+		} else if len(axis.OverrideSets) < 1 {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_MATRIX_AXIS_NO_SETS, filename, axis.Name)
+		} else {
+			axisNames[axis.Name] = true
+
+			setNames := make(map[string]bool)
+			for setIndex, overrideSet := range axis.OverrideSets {
+				if overrideSet.Name == "" {
+					err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_MATRIX_SET_NO_NAME, filename, setIndex+1, axis.Name)
+				} else if setNames[overrideSet.Name] {
+					err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_MATRIX_DUPLICATE_SET_NAME, filename, axis.Name, overrideSet.Name)
+				}
+				if err != nil {
+					break
+				}
+				setNames[overrideSet.Name] = true
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+	return err
+}
+
+// GetMatrixCombinations - Every combination of one override set from each axis of the matrix, in the
+// order the axes and override sets are listed. A portfolio without a matrix has a single combination,
+// with no name and no overrides, so each class is run once as normal.
+func (portfolio *Portfolio) GetMatrixCombinations() []MatrixCombination {
+	combinations := []MatrixCombination{{Name: "", Overrides: make(map[string]string)}}
+
+	for _, axis := range portfolio.Matrix {
+		expandedCombinations := make([]MatrixCombination, 0, len(combinations)*len(axis.OverrideSets))
+		for _, combination := range combinations {
+			for _, overrideSet := range axis.OverrideSets {
+				expandedCombinations = append(expandedCombinations, combination.with(axis.Name, overrideSet))
+			}
+		}
+		combinations = expandedCombinations
+	}
+
+	return combinations
+}
+
+// with - A copy of the combination, with another axis added. Where two axes set the same override,
+// the later axis wins.
+func (combination MatrixCombination) with(axisName string, overrideSet PortfolioOverrideSet) MatrixCombination {
+	names := make([]string, 0, 2)
+	if combination.Name != "" {
+		names = append(names, combination.Name)
+	}
+	names = append(names, axisName+"="+overrideSet.Name)
+
+	overrides := make(map[string]string, len(combination.Overrides)+len(overrideSet.Overrides))
+	for key, value := range combination.Overrides {
+		overrides[key] = value
+	}
+	for key, value := range overrideSet.Overrides {
+		overrides[key] = value
+	}
+
+	return MatrixCombination{Name: strings.Join(names, ","), Overrides: overrides}
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+// DiffPortfolios - Writes the differences between two portfolios to the console, class by class.
+// A class only in the first portfolio is shown with a '-', a class only in the second with a '+',
+// and each setting of a class which changed with a '~'.
+func DiffPortfolios(fileSystem spi.FileSystem, fromFilename string, toFilename string, console spi.Console) error {
+	fromPortfolio, err := ReadPortfolio(fileSystem, fromFilename)
+	if err == nil {
+		var toPortfolio *Portfolio
+		toPortfolio, err = ReadPortfolio(fileSystem, toFilename)
+		if err == nil {
+			differences := getPortfolioDifferences(fromPortfolio, toPortfolio)
+			if len(differences) == 0 {
+				err = console.WriteString(fmt.Sprintf("Portfolios '%s' and '%s' have the same classes.\n", fromFilename, toFilename))
+			} else {
+				err = console.WriteString(strings.Join(differences, "\n") + "\n")
+			}
+		}
+	}
+	return err
+}
+
+// getPortfolioDifferences - The removed and changed classes in the order of the first portfolio,
+// followed by the added classes in the order of the second.
+func getPortfolioDifferences(fromPortfolio *Portfolio, toPortfolio *Portfolio) []string {
+	differences := make([]string, 0)
+
+	if fromPortfolio.APIVersion != toPortfolio.APIVersion {
+		differences = append(differences, formatPortfolioChange("", "apiVersion", fromPortfolio.APIVersion, toPortfolio.APIVersion))
+	}
+	if !reflect.DeepEqual(fromPortfolio.GetMatrixCombinations(), toPortfolio.GetMatrixCombinations()) {
+		differences = append(differences, formatPortfolioChange("", "matrix combinations",
+			getMatrixCombinationNames(fromPortfolio), getMatrixCombinationNames(toPortfolio)))
+	}
+
+	for _, fromClass := range fromPortfolio.Classes {
+		classKey := fromClass.getClassKey()
+		toClass := findPortfolioClass(toPortfolio, classKey)
+		if toClass == nil {
+			differences = append(differences, "- "+classKey)
+		} else {
+			differences = append(differences, getPortfolioClassDifferences(classKey, &fromClass, toClass)...)
+		}
+	}
+
+	for _, toClass := range toPortfolio.Classes {
+		classKey := toClass.getClassKey()
+		if findPortfolioClass(fromPortfolio, classKey) == nil {
+			differences = append(differences, "+ "+classKey)
+		}
+	}
+	return differences
+}
+
+func getPortfolioClassDifferences(classKey string, fromClass *PortfolioClass, toClass *PortfolioClass) []string {
+	differences := make([]string, 0)
+
+	addIfChanged := func(settingName string, fromValue string, toValue string) {
+		if fromValue != toValue {
+			differences = append(differences, formatPortfolioChange(classKey, settingName, fromValue, toValue))
+		}
+	}
+
+	addIfChanged("stream", fromClass.Stream, toClass.Stream)
+	addIfChanged("obr", fromClass.Obr, toClass.Obr)
+
+	overrideKeys := make(map[string]bool)
+	for key := range fromClass.Overrides {
+		overrideKeys[key] = true
+	}
+	for key := range toClass.Overrides {
+		overrideKeys[key] = true
+	}
+	sortedOverrideKeys := make([]string, 0, len(overrideKeys))
+	for key := range overrideKeys {
+		sortedOverrideKeys = append(sortedOverrideKeys, key)
+	}
+	sort.Strings(sortedOverrideKeys)
+	for _, key := range sortedOverrideKeys {
+		addIfChanged("override '"+key+"'", fromClass.Overrides[key], toClass.Overrides[key])
+	}
+
+	fromScheduling := fromClass.PortfolioClassScheduling
+	toScheduling := toClass.PortfolioClassScheduling
+	addIfChanged("priority", strconv.Itoa(fromScheduling.Priority), strconv.Itoa(toScheduling.Priority))
+	addIfChanged("maxAttempts", formatMaxAttempts(fromScheduling.MaxAttempts), formatMaxAttempts(toScheduling.MaxAttempts))
+	addIfChanged("timeout", fromScheduling.Timeout, toScheduling.Timeout)
+	addIfChanged("labels", strings.Join(fromScheduling.Labels, ","), strings.Join(toScheduling.Labels, ","))
+	addIfChanged("dependsOn", strings.Join(fromScheduling.DependsOn, ","), strings.Join(toScheduling.DependsOn, ","))
+
+	return differences
+}
+
+func formatPortfolioChange(classKey string, settingName string, fromValue string, toValue string) string {
+	prefix := "~ "
+	if classKey != "" {
+		prefix += classKey + " "
+	}
+	return fmt.Sprintf("%s%s: '%s' -> '%s'", prefix, settingName, fromValue, toValue)
+}
+
+func getMatrixCombinationNames(portfolio *Portfolio) string {
+	names := make([]string, 0)
+	for _, combination := range portfolio.GetMatrixCombinations() {
+		if combination.Name != "" {
+			names = append(names, "["+combination.Name+"]")
+		}
+	}
+	return strings.Join(names, " ")
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffOfTheSamePortfolioHasNoDifferences(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	writeTestPortfolio(t, fs, "a.yaml", PortfolioClass{Bundle: "myBundle", Class: "myClass", Overrides: map[string]string{"prop1": "val1"}})
+	writeTestPortfolio(t, fs, "b.yaml", PortfolioClass{Bundle: "myBundle", Class: "myClass", Overrides: map[string]string{"prop1": "val1"}})
+	console := utils.NewMockConsole()
+
+	// When...
+	err := DiffPortfolios(fs, "a.yaml", "b.yaml", console)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "Portfolios 'a.yaml' and 'b.yaml' have the same classes.\n", console.ReadText())
+}
+
+func TestDiffShowsRemovedChangedAndAddedClasses(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	writeTestPortfolio(t, fs, "a.yaml",
+		PortfolioClass{Bundle: "myBundle", Class: "removedClass"},
+		PortfolioClass{Bundle: "myBundle", Class: "changedClass", Stream: "stream1", Overrides: map[string]string{"prop1": "val1", "prop2": "val2"}},
+	)
+	writeTestPortfolio(t, fs, "b.yaml",
+		PortfolioClass{GherkinUrl: "file:///added.feature"},
+		PortfolioClass{Bundle: "myBundle", Class: "changedClass", Stream: "stream2", Overrides: map[string]string{"prop1": "val1", "prop3": "val3"}},
+	)
+	console := utils.NewMockConsole()
+
+	// When...
+	err := DiffPortfolios(fs, "a.yaml", "b.yaml", console)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t,
+		"- myBundle/removedClass\n"+
+			"~ myBundle/changedClass stream: 'stream1' -> 'stream2'\n"+
+			"~ myBundle/changedClass override 'prop2': 'val2' -> ''\n"+
+			"~ myBundle/changedClass override 'prop3': '' -> 'val3'\n"+
+			"+ file:///added.feature\n",
+		console.ReadText())
+}
+
+func TestDiffShowsChangesToTheApiVersionAndMatrix(t *testing.T) {
+	// Given...
+	fromPortfolio := NewPortfolio()
+	toPortfolio := createMatrixPortfolio()
+	toPortfolio.Classes = fromPortfolio.Classes
+	toPortfolio.APIVersion = PORTFOLIO_DECLARED_FORMAT_VERSION_V1BETA
+
+	// When...
+	differences := getPortfolioDifferences(fromPortfolio, toPortfolio)
+
+	// Then...
+	assert.Equal(t, []string{
+		"~ apiVersion: 'v1alpha' -> 'v1beta'",
+		"~ matrix combinations: '' -> '[image=zos1,region=cicsA] [image=zos1,region=cicsB] [image=zos2,region=cicsA] [image=zos2,region=cicsB]'",
+	}, differences)
+}
+
+func TestDiffWithMissingPortfolioFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	writeTestPortfolio(t, fs, "a.yaml", PortfolioClass{Bundle: "myBundle", Class: "myClass"})
+
+	// When...
+	err := DiffPortfolios(fs, "a.yaml", "missing.yaml", utils.NewMockConsole())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1021E")
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+// What to do when the same class is in more than one of the portfolios being merged,
+// with a different stream, obr, scheduling or value for an override.
+const (
+	MERGE_CONFLICT_ERROR = "error"
+	MERGE_CONFLICT_FIRST = "first"
+	MERGE_CONFLICT_LAST  = "last"
+
+	DEFAULT_MERGE_CONFLICT_RULE = MERGE_CONFLICT_ERROR
+)
+
+var mergeConflictRules = []string{MERGE_CONFLICT_ERROR, MERGE_CONFLICT_FIRST, MERGE_CONFLICT_LAST}
+
+// MergePortfolios - Reads each portfolio in turn, and writes a portfolio holding all of their classes.
+// A class which is in more than one of them is only listed once, with the overrides from each of them.
+func MergePortfolios(
+	fileSystem spi.FileSystem,
+	portfolioFilenames []string,
+	mergedFilename string,
+	conflictRule string,
+	console spi.Console,
+) error {
+	var err error
+
+	if !isValidMergeConflictRule(conflictRule) {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_MERGE_BAD_CONFLICT_RULE, conflictRule, strings.Join(mergeConflictRules, "', '"))
+	}
+
+	var merged *Portfolio
+	for index, portfolioFilename := range portfolioFilenames {
+		if err != nil {
+			break
+		}
+
+		var portfolio *Portfolio
+		portfolio, err = ReadPortfolio(fileSystem, portfolioFilename)
+		if err == nil {
+			if index == 0 {
+				merged = NewPortfolio()
+				merged.Metadata.Name = portfolio.Metadata.Name
+			}
+			err = mergePortfolio(merged, portfolio, portfolioFilename, conflictRule)
+		}
+	}
+
+	if err == nil {
+		err = WritePortfolio(fileSystem, mergedFilename, merged)
+		if err == nil {
+			log.Printf("Merged portfolio written to %v\n", mergedFilename)
+			err = console.WriteString(fmt.Sprintf("Merged %v classes from %v portfolios into '%s'.\n",
+				len(merged.Classes), len(portfolioFilenames), mergedFilename))
+		}
+	}
+	return err
+}
+
+func isValidMergeConflictRule(conflictRule string) bool {
+	isValid := false
+	for _, validRule := range mergeConflictRules {
+		if conflictRule == validRule {
+			isValid = true
+			break
+		}
+	}
+	return isValid
+}
+
+// mergePortfolio - Adds the classes of the portfolio to the merged portfolio. The merged portfolio is a
+// v1beta portfolio if any of the portfolios are. Portfolios with different matrices can't be merged,
+// as each class would then be run against a different set of combinations.
+func mergePortfolio(merged *Portfolio, portfolio *Portfolio, portfolioFilename string, conflictRule string) error {
+	var err error
+
+	if portfolio.APIVersion == PORTFOLIO_DECLARED_FORMAT_VERSION_V1BETA {
+		merged.APIVersion = PORTFOLIO_DECLARED_FORMAT_VERSION_V1BETA
+	}
+
+	if len(portfolio.Matrix) > 0 {
+		if len(merged.Matrix) == 0 {
+			merged.Matrix = portfolio.Matrix
+		} else if !reflect.DeepEqual(merged.Matrix, portfolio.Matrix) {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_MERGE_MATRIX_CONFLICT, portfolioFilename)
+		}
+	}
+
+	for _, portfolioClass := range portfolio.Classes {
+		if err != nil {
+			break
+		}
+
+		existingClass := findPortfolioClass(merged, portfolioClass.getClassKey())
+		if existingClass == nil {
+			newClass := portfolioClass
+			newClass.Overrides = make(map[string]string)
+			for key, value := range portfolioClass.Overrides {
+				newClass.Overrides[key] = value
+			}
+			merged.Classes = append(merged.Classes, newClass)
+		} else {
+			err = mergePortfolioClass(existingClass, portfolioClass, portfolioFilename, conflictRule)
+		}
+	}
+	return err
+}
+
+func findPortfolioClass(portfolio *Portfolio, classKey string) *PortfolioClass {
+	var found *PortfolioClass
+	for index := range portfolio.Classes {
+		if portfolio.Classes[index].getClassKey() == classKey {
+			found = &portfolio.Classes[index]
+			break
+		}
+	}
+	return found
+}
+
+// mergePortfolioClass - Merges a class into the same class from an earlier portfolio. Where only one of them
+// sets something, that is used. Where both set it differently, the conflict rule decides which is used.
+func mergePortfolioClass(existingClass *PortfolioClass, portfolioClass PortfolioClass, portfolioFilename string, conflictRule string) error {
+	var err error
+	classKey := existingClass.getClassKey()
+
+	existingClass.Stream, err = mergePortfolioSetting(existingClass.Stream, portfolioClass.Stream, "stream", classKey, portfolioFilename, conflictRule)
+	if err == nil {
+		existingClass.Obr, err = mergePortfolioSetting(existingClass.Obr, portfolioClass.Obr, "obr", classKey, portfolioFilename, conflictRule)
+	}
+
+	if err == nil && portfolioClass.PortfolioClassScheduling.isUsed() {
+		if !existingClass.PortfolioClassScheduling.isUsed() {
+			existingClass.PortfolioClassScheduling = portfolioClass.PortfolioClassScheduling
+		} else if !reflect.DeepEqual(existingClass.PortfolioClassScheduling, portfolioClass.PortfolioClassScheduling) {
+			if conflictRule == MERGE_CONFLICT_ERROR {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_MERGE_CLASS_CONFLICT, portfolioFilename, classKey, "scheduling",
+					fmt.Sprintf("%+v", portfolioClass.PortfolioClassScheduling), fmt.Sprintf("%+v", existingClass.PortfolioClassScheduling))
+			} else if conflictRule == MERGE_CONFLICT_LAST {
+				existingClass.PortfolioClassScheduling = portfolioClass.PortfolioClassScheduling
+			}
+		}
+	}
+
+	for key, value := range portfolioClass.Overrides {
+		if err != nil {
+			break
+		}
+		existingClass.Overrides[key], err = mergePortfolioSetting(existingClass.Overrides[key], value, "override '"+key+"'", classKey, portfolioFilename, conflictRule)
+	}
+	return err
+}
+
+func mergePortfolioSetting(existingValue string, value string, settingName string, classKey string, portfolioFilename string, conflictRule string) (string, error) {
+	var err error
+	mergedValue := existingValue
+
+	if existingValue == "" {
+		mergedValue = value
+	} else if value != "" && value != existingValue {
+		switch conflictRule {
+		case MERGE_CONFLICT_ERROR:
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_MERGE_CLASS_CONFLICT, portfolioFilename, classKey, settingName, value, existingValue)
+		case MERGE_CONFLICT_LAST:
+			mergedValue = value
+		}
+		log.Printf("Class %v has %v '%v' in %v, but '%v' in an earlier portfolio\n", classKey, settingName, value, portfolioFilename, existingValue)
+	}
+	return mergedValue, err
+}
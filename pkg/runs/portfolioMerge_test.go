@@ -0,0 +1,144 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestPortfolio(t *testing.T, fs spi.FileSystem, filename string, classes ...PortfolioClass) *Portfolio {
+	portfolio := NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes, classes...)
+	err := WritePortfolio(fs, filename, portfolio)
+	assert.Nil(t, err)
+	return portfolio
+}
+
+func TestMergePortfoliosCombinesTheirClasses(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	writeTestPortfolio(t, fs, "a.yaml",
+		PortfolioClass{Bundle: "myBundle", Class: "myClass", Stream: "myStream", Overrides: map[string]string{"prop1": "val1"}},
+		PortfolioClass{Bundle: "myBundle", Class: "myOtherClass"},
+	)
+	writeTestPortfolio(t, fs, "b.yaml",
+		PortfolioClass{Bundle: "myBundle", Class: "myClass", Obr: VALID_TEST_OBR, Overrides: map[string]string{"prop2": "val2"}},
+		PortfolioClass{GherkinUrl: "file:///my.feature"},
+	)
+	console := utils.NewMockConsole()
+
+	// When...
+	err := MergePortfolios(fs, []string{"a.yaml", "b.yaml"}, "merged.yaml", DEFAULT_MERGE_CONFLICT_RULE, console)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "Merged 3 classes from 2 portfolios into 'merged.yaml'.\n", console.ReadText())
+
+	merged, err := ReadPortfolio(fs, "merged.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(merged.Classes))
+	assert.Equal(t, "myClass", merged.Classes[0].Class)
+	assert.Equal(t, "myStream", merged.Classes[0].Stream)
+	assert.Equal(t, VALID_TEST_OBR, merged.Classes[0].Obr)
+	assert.Equal(t, map[string]string{"prop1": "val1", "prop2": "val2"}, merged.Classes[0].Overrides)
+	assert.Equal(t, "myOtherClass", merged.Classes[1].Class)
+	assert.Equal(t, "file:///my.feature", merged.Classes[2].GherkinUrl)
+}
+
+func TestMergePortfoliosWithConflictingOverrideFailsByDefault(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	writeTestPortfolio(t, fs, "a.yaml", PortfolioClass{Bundle: "myBundle", Class: "myClass", Overrides: map[string]string{"prop1": "val1"}})
+	writeTestPortfolio(t, fs, "b.yaml", PortfolioClass{Bundle: "myBundle", Class: "myClass", Overrides: map[string]string{"prop1": "val2"}})
+	console := utils.NewMockConsole()
+
+	// When...
+	err := MergePortfolios(fs, []string{"a.yaml", "b.yaml"}, "merged.yaml", MERGE_CONFLICT_ERROR, console)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1306E: Portfolio 'b.yaml' cannot be merged, because class 'myBundle/myClass' has override 'prop1' 'val2' in it, but 'val1' in an earlier portfolio.")
+	isMergedWritten, _ := fs.Exists("merged.yaml")
+	assert.False(t, isMergedWritten)
+}
+
+func TestMergePortfoliosWithConflictingStreamCanKeepTheFirstOrLast(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	writeTestPortfolio(t, fs, "a.yaml", PortfolioClass{Bundle: "myBundle", Class: "myClass", Stream: "stream1"})
+	writeTestPortfolio(t, fs, "b.yaml", PortfolioClass{Bundle: "myBundle", Class: "myClass", Stream: "stream2"})
+
+	// When...
+	errFirst := MergePortfolios(fs, []string{"a.yaml", "b.yaml"}, "first.yaml", MERGE_CONFLICT_FIRST, utils.NewMockConsole())
+	errLast := MergePortfolios(fs, []string{"a.yaml", "b.yaml"}, "last.yaml", MERGE_CONFLICT_LAST, utils.NewMockConsole())
+
+	// Then...
+	assert.Nil(t, errFirst)
+	assert.Nil(t, errLast)
+
+	first, _ := ReadPortfolio(fs, "first.yaml")
+	assert.Equal(t, "stream1", first.Classes[0].Stream)
+	last, _ := ReadPortfolio(fs, "last.yaml")
+	assert.Equal(t, "stream2", last.Classes[0].Stream)
+}
+
+func TestMergePortfoliosWithBadConflictRuleFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	writeTestPortfolio(t, fs, "a.yaml", PortfolioClass{Bundle: "myBundle", Class: "myClass"})
+
+	// When...
+	err := MergePortfolios(fs, []string{"a.yaml"}, "merged.yaml", "newest", utils.NewMockConsole())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1304E: Unsupported value 'newest' for the --onconflict flag. Supported values are 'error', 'first', 'last'.")
+}
+
+func TestMergePortfoliosWithDifferentMatricesFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	portfolio := createMatrixPortfolio()
+	err := WritePortfolio(fs, "a.yaml", portfolio)
+	assert.Nil(t, err)
+	portfolio.Matrix = portfolio.Matrix[:1]
+	err = WritePortfolio(fs, "b.yaml", portfolio)
+	assert.Nil(t, err)
+
+	// When...
+	err = MergePortfolios(fs, []string{"a.yaml", "b.yaml"}, "merged.yaml", DEFAULT_MERGE_CONFLICT_RULE, utils.NewMockConsole())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1305E: Portfolio 'b.yaml' cannot be merged")
+}
+
+func TestMergeV1alphaAndV1betaPortfoliosWritesV1beta(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	writeTestPortfolio(t, fs, "a.yaml", PortfolioClass{Bundle: "myBundle", Class: "myClass"})
+	portfolio := NewPortfolio()
+	portfolio.APIVersion = PORTFOLIO_DECLARED_FORMAT_VERSION_V1BETA
+	portfolio.Classes = append(portfolio.Classes, PortfolioClass{Bundle: "myBundle", Class: "myClass",
+		PortfolioClassScheduling: PortfolioClassScheduling{Priority: 3}})
+	err := WritePortfolio(fs, "b.yaml", portfolio)
+	assert.Nil(t, err)
+
+	// When...
+	err = MergePortfolios(fs, []string{"a.yaml", "b.yaml"}, "merged.yaml", DEFAULT_MERGE_CONFLICT_RULE, utils.NewMockConsole())
+
+	// Then...
+	assert.Nil(t, err)
+	merged, err := ReadPortfolio(fs, "merged.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, PORTFOLIO_DECLARED_FORMAT_VERSION_V1BETA, merged.APIVersion)
+	assert.Equal(t, 3, merged.Classes[0].Priority)
+}
@@ -0,0 +1,249 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+)
+
+// The states a test can be in, as far as the tests which depend on it are concerned.
+const (
+	DEPENDENCY_PASSED  = "passed"
+	DEPENDENCY_PENDING = "pending"
+	DEPENDENCY_FAILED  = "failed"
+)
+
+// The orders 'runs submit' can submit the classes of a portfolio in.
+const (
+	// The order the portfolio lists them in.
+	SCHEDULE_PORTFOLIO_ORDER = "portfolio"
+
+	// The classes which took longest to run in the past go first, so that the slowest class doesn't
+	// start last and hold up the end of the submission. Classes with no history go after the rest,
+	// in the order the portfolio lists them.
+	SCHEDULE_LONGEST_FIRST = "longest-first"
+
+	DEFAULT_SCHEDULE = SCHEDULE_PORTFOLIO_ORDER
+)
+
+func IsValidSchedule(schedule string) bool {
+	return schedule == SCHEDULE_PORTFOLIO_ORDER || schedule == SCHEDULE_LONGEST_FIRST
+}
+
+// ValidatePortfolioClasses - Checks the scheduling fields of each class in the portfolio make sense,
+// and are only used by a portfolio version which supports them.
+func ValidatePortfolioClasses(portfolio *Portfolio, filename string) error {
+	var err error
+
+	classNames := make(map[string]bool)
+	for _, portfolioClass := range portfolio.Classes {
+		classNames[portfolioClass.getClassName()] = true
+	}
+
+	for _, portfolioClass := range portfolio.Classes {
+		className := portfolioClass.getClassName()
+		scheduling := portfolioClass.PortfolioClassScheduling
+
+		if portfolio.APIVersion != PORTFOLIO_DECLARED_FORMAT_VERSION_V1BETA && scheduling.isUsed() {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_FIELDS_NEED_V1BETA, filename, className)
+		} else if scheduling.MaxAttempts < 0 {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_CLASS_BAD_MAX_ATTEMPTS, filename, className, scheduling.MaxAttempts)
+		} else if scheduling.Timeout != "" {
+			timeout, parseErr := time.ParseDuration(scheduling.Timeout)
+			if parseErr != nil || timeout <= 0 {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_CLASS_BAD_TIMEOUT, filename, className, scheduling.Timeout)
+			}
+		}
+
+		if err == nil {
+			for _, dependency := range scheduling.DependsOn {
+				if !classNames[dependency] {
+					err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_CLASS_UNKNOWN_DEPENDENCY, filename, className, dependency)
+					break
+				}
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	if err == nil {
+		cycle := findDependencyCycle(portfolio.Classes)
+		if cycle != nil {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_CLASS_DEPENDENCY_CYCLE, filename, strings.Join(cycle, " -> "))
+		}
+	}
+
+	return err
+}
+
+func (portfolioClass *PortfolioClass) getClassName() string {
+	return portfolioClass.Bundle + "/" + portfolioClass.Class
+}
+
+func (scheduling *PortfolioClassScheduling) isUsed() bool {
+	return scheduling.Priority != 0 ||
+		scheduling.MaxAttempts != 0 ||
+		scheduling.Timeout != "" ||
+		len(scheduling.Labels) > 0 ||
+		len(scheduling.DependsOn) > 0
+}
+
+// findDependencyCycle - The classes which depend on each other in a loop, starting and ending with
+// the same class, or nil if there is no loop.
+func findDependencyCycle(classes []PortfolioClass) []string {
+	dependencies := make(map[string][]string)
+	for _, portfolioClass := range classes {
+		className := portfolioClass.getClassName()
+		dependencies[className] = append(dependencies[className], portfolioClass.DependsOn...)
+	}
+
+	// Classes are visited depth-first. A class which is met again while it is still on the path
+	// being followed closes a loop.
+	var cycle []string
+	isFinished := make(map[string]bool)
+	path := make([]string, 0)
+
+	var visit func(className string)
+	visit = func(className string) {
+		for index, classOnPath := range path {
+			if classOnPath == className {
+				cycle = append(append([]string{}, path[index:]...), className)
+				return
+			}
+		}
+		if isFinished[className] {
+			return
+		}
+
+		path = append(path, className)
+		for _, dependency := range dependencies[className] {
+			visit(dependency)
+			if cycle != nil {
+				return
+			}
+		}
+		path = path[:len(path)-1]
+		isFinished[className] = true
+	}
+
+	for _, portfolioClass := range classes {
+		visit(portfolioClass.getClassName())
+		if cycle != nil {
+			break
+		}
+	}
+	return cycle
+}
+
+// getClassesInPriorityOrder - The classes of the portfolio, highest priority first. Classes with the
+// same priority stay in the order the portfolio lists them.
+func getClassesInPriorityOrder(classes []PortfolioClass) []PortfolioClass {
+	orderedClasses := make([]PortfolioClass, len(classes))
+	copy(orderedClasses, classes)
+	sort.SliceStable(orderedClasses, func(i int, j int) bool {
+		return orderedClasses[i].Priority > orderedClasses[j].Priority
+	})
+	return orderedClasses
+}
+
+// getClassesLongestFirst - The classes of the portfolio, ordered by how long they took to run in the past,
+// longest first. Classes with no history stay in the order the portfolio lists them, after those with history.
+// Priorities are applied afterwards, so longest-first only orders classes of the same priority.
+func getClassesLongestFirst(classes []PortfolioClass, durationHistory RunDurationHistory) ([]PortfolioClass, error) {
+	var err error
+	var orderedClasses []PortfolioClass
+
+	if durationHistory == nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_SCHEDULE_HISTORY_UNAVAILABLE)
+	} else {
+		durations := make(map[string]time.Duration)
+		for _, portfolioClass := range classes {
+			className := portfolioClass.getClassName()
+			if _, isLookedUp := durations[className]; !isLookedUp {
+				var duration time.Duration
+				var isKnown bool
+				duration, isKnown, err = durationHistory.GetAverageRunDuration(portfolioClass.Bundle, portfolioClass.Class)
+				if err != nil {
+					break
+				}
+				if !isKnown {
+					// Less than any class which has run before, so it goes after all of them.
+					duration = -1
+				}
+				durations[className] = duration
+			}
+		}
+
+		if err == nil {
+			orderedClasses = make([]PortfolioClass, len(classes))
+			copy(orderedClasses, classes)
+			sort.SliceStable(orderedClasses, func(i int, j int) bool {
+				return durations[orderedClasses[i].getClassName()] > durations[orderedClasses[j].getClassName()]
+			})
+		}
+	}
+	return orderedClasses, err
+}
+
+// getDependencyState - Whether the test a run depends on has passed, failed, or hasn't finished yet.
+// In a portfolio with a matrix, a run depends on the test of the same combination.
+func getDependencyState(
+	dependencyKey string,
+	readyRuns []TestRun,
+	submittedRuns map[string]*TestRun,
+	rerunRuns map[string]*TestRun,
+	finishedRuns map[string]*TestRun,
+	lostRuns map[string]*TestRun,
+) string {
+	// If the test is nowhere to be found, it can never pass.
+	state := DEPENDENCY_FAILED
+
+	for _, run := range finishedRuns {
+		if run.getTestKey() == dependencyKey {
+			if strings.HasPrefix(run.Result, RESULT_PASSED) {
+				state = DEPENDENCY_PASSED
+			}
+			return state
+		}
+	}
+
+	for _, run := range lostRuns {
+		if run.getTestKey() == dependencyKey {
+			return DEPENDENCY_FAILED
+		}
+	}
+
+	for index := range readyRuns {
+		if readyRuns[index].getTestKey() == dependencyKey {
+			return DEPENDENCY_PENDING
+		}
+	}
+	for _, runs := range []map[string]*TestRun{submittedRuns, rerunRuns} {
+		for _, run := range runs {
+			if run.getTestKey() == dependencyKey {
+				return DEPENDENCY_PENDING
+			}
+		}
+	}
+
+	return state
+}
+
+// getDependencyKeys - The keys of the tests which a run depends on.
+func getDependencyKeys(run *TestRun) []string {
+	keys := make([]string, 0, len(run.DependsOn))
+	for _, dependency := range run.DependsOn {
+		keys = append(keys, dependency+getCombinationSuffix(run))
+	}
+	return keys
+}
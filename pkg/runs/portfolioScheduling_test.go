@@ -0,0 +1,205 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/stretchr/testify/assert"
+)
+
+func newV1betaPortfolio(classes ...PortfolioClass) *Portfolio {
+	portfolio := NewPortfolio()
+	portfolio.APIVersion = PORTFOLIO_DECLARED_FORMAT_VERSION_V1BETA
+	portfolio.Classes = append(portfolio.Classes, classes...)
+	return portfolio
+}
+
+func newScheduledClass(className string, scheduling PortfolioClassScheduling) PortfolioClass {
+	return PortfolioClass{Bundle: "myBundle", Class: className, Obr: "myobr", PortfolioClassScheduling: scheduling}
+}
+
+func TestCanWriteAndReadAV1betaPortfolio(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	portfolio := newV1betaPortfolio(
+		newScheduledClass("mySetup", PortfolioClassScheduling{Priority: 10}),
+		newScheduledClass("myTest", PortfolioClassScheduling{
+			MaxAttempts: 2,
+			Timeout:     "30m",
+			Labels:      []string{"smoke"},
+			DependsOn:   []string{"myBundle/mySetup"},
+		}),
+	)
+	err := WritePortfolio(fs, "my.portfolio", portfolio)
+	assert.Nil(t, err)
+
+	// When...
+	portfolioGotBack, err := ReadPortfolio(fs, "my.portfolio")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "v1beta", portfolioGotBack.APIVersion)
+	assert.Equal(t, 10, portfolioGotBack.Classes[0].Priority)
+	assert.Equal(t, 2, portfolioGotBack.Classes[1].MaxAttempts)
+	assert.Equal(t, "30m", portfolioGotBack.Classes[1].Timeout)
+	assert.Equal(t, []string{"smoke"}, portfolioGotBack.Classes[1].Labels)
+	assert.Equal(t, []string{"myBundle/mySetup"}, portfolioGotBack.Classes[1].DependsOn)
+}
+
+func TestV1alphaPortfolioIsWrittenWithoutSchedulingFields(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	portfolio := NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes, PortfolioClass{Bundle: "myBundle", Class: "myClass"})
+
+	// When...
+	err := WritePortfolio(fs, "my.portfolio", portfolio)
+
+	// Then...
+	assert.Nil(t, err)
+	contents, _ := fs.ReadTextFile("my.portfolio")
+	assert.NotContains(t, contents, "priority")
+	assert.NotContains(t, contents, "dependsOn")
+}
+
+func TestValidatePortfolioClassesInV1alphaPortfolioWithSchedulingFails(t *testing.T) {
+	// Given...
+	portfolio := newV1betaPortfolio(newScheduledClass("myTest", PortfolioClassScheduling{Labels: []string{"smoke"}}))
+	portfolio.APIVersion = PORTOLIO_DECLARED_FORMAT_VERSION
+
+	// When...
+	err := ValidatePortfolioClasses(portfolio, "my.portfolio")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1257E")
+	assert.Contains(t, err.Error(), "'myBundle/myTest'")
+}
+
+func TestValidatePortfolioClassesWithBadTimeoutFails(t *testing.T) {
+	// Given...
+	portfolio := newV1betaPortfolio(newScheduledClass("myTest", PortfolioClassScheduling{Timeout: "ten minutes"}))
+
+	// When...
+	err := ValidatePortfolioClasses(portfolio, "my.portfolio")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1258E")
+	assert.Contains(t, err.Error(), "'ten minutes'")
+}
+
+func TestValidatePortfolioClassesWithNegativeMaxAttemptsFails(t *testing.T) {
+	// Given...
+	portfolio := newV1betaPortfolio(newScheduledClass("myTest", PortfolioClassScheduling{MaxAttempts: -1}))
+
+	// When...
+	err := ValidatePortfolioClasses(portfolio, "my.portfolio")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1259E")
+}
+
+func TestValidatePortfolioClassesWithUnknownDependencyFails(t *testing.T) {
+	// Given...
+	portfolio := newV1betaPortfolio(newScheduledClass("myTest", PortfolioClassScheduling{DependsOn: []string{"myBundle/myMissing"}}))
+
+	// When...
+	err := ValidatePortfolioClasses(portfolio, "my.portfolio")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1260E")
+	assert.Contains(t, err.Error(), "'myBundle/myMissing'")
+}
+
+func TestValidatePortfolioClassesWithDependencyCycleFails(t *testing.T) {
+	// Given...
+	portfolio := newV1betaPortfolio(
+		newScheduledClass("myClass1", PortfolioClassScheduling{}),
+		newScheduledClass("myClass2", PortfolioClassScheduling{DependsOn: []string{"myBundle/myClass3"}}),
+		newScheduledClass("myClass3", PortfolioClassScheduling{DependsOn: []string{"myBundle/myClass1", "myBundle/myClass2"}}),
+	)
+
+	// When...
+	err := ValidatePortfolioClasses(portfolio, "my.portfolio")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1261E")
+	assert.Contains(t, err.Error(), "myBundle/myClass2 -> myBundle/myClass3 -> myBundle/myClass2")
+}
+
+func TestReadPortfolioWithUnsupportedVersionFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	portfolio := NewPortfolio()
+	portfolio.APIVersion = "v2"
+	err := WritePortfolio(fs, "my.portfolio", portfolio)
+	assert.Nil(t, err)
+
+	// When...
+	_, err = ReadPortfolio(fs, "my.portfolio")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1023E")
+	assert.Contains(t, err.Error(), "'v1alpha' or 'v1beta'")
+}
+
+func TestClassesInPriorityOrderKeepsPortfolioOrderForEqualPriorities(t *testing.T) {
+	// Given...
+	classes := []PortfolioClass{
+		newScheduledClass("myClass1", PortfolioClassScheduling{}),
+		newScheduledClass("myClass2", PortfolioClassScheduling{Priority: 5}),
+		newScheduledClass("myClass3", PortfolioClassScheduling{Priority: -1}),
+		newScheduledClass("myClass4", PortfolioClassScheduling{Priority: 5}),
+	}
+
+	// When...
+	orderedClasses := getClassesInPriorityOrder(classes)
+
+	// Then...
+	orderedNames := make([]string, 0)
+	for _, portfolioClass := range orderedClasses {
+		orderedNames = append(orderedNames, portfolioClass.Class)
+	}
+	assert.Equal(t, []string{"myClass2", "myClass4", "myClass1", "myClass3"}, orderedNames)
+	assert.Equal(t, "myClass1", classes[0].Class)
+}
+
+func TestClassesLongestFirstPutsClassesWithNoHistoryLastInPortfolioOrder(t *testing.T) {
+	// Given...
+	classes := newPortfolioOfClasses("myNewClass1", "myQuickClass", "myNewClass2", "mySlowClass").Classes
+	history := mockRunDurationHistory{
+		"myBundle/myQuickClass": time.Minute * 5,
+		"myBundle/mySlowClass":  time.Minute * 90,
+	}
+
+	// When...
+	orderedClasses, err := getClassesLongestFirst(classes, history)
+
+	// Then...
+	assert.Nil(t, err)
+	orderedClassNames := make([]string, 0)
+	for _, portfolioClass := range orderedClasses {
+		orderedClassNames = append(orderedClassNames, portfolioClass.Class)
+	}
+	assert.Equal(t, []string{"mySlowClass", "myQuickClass", "myNewClass1", "myNewClass2"}, orderedClassNames)
+}
+
+func TestClassesLongestFirstWithoutHistoryFails(t *testing.T) {
+	classes := newPortfolioOfClasses("myClass1").Classes
+
+	_, err := getClassesLongestFirst(classes, nil)
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1269E")
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+const (
+	PORTFOLIO_HEADER_BUNDLE       = "bundle"
+	PORTFOLIO_HEADER_CLASS        = "class"
+	PORTFOLIO_HEADER_STREAM       = "stream"
+	PORTFOLIO_HEADER_OBR          = "obr"
+	PORTFOLIO_HEADER_OVERRIDES    = "overrides"
+	PORTFOLIO_HEADER_PRIORITY     = "priority"
+	PORTFOLIO_HEADER_MAX_ATTEMPTS = "max-attempts"
+	PORTFOLIO_HEADER_TIMEOUT      = "timeout"
+	PORTFOLIO_HEADER_LABELS       = "labels"
+	PORTFOLIO_HEADER_DEPENDS_ON   = "depends-on"
+)
+
+// ShowPortfolio - Writes the classes in the portfolio to the console as a table, one row per class.
+func ShowPortfolio(fileSystem spi.FileSystem, portfolioFilename string, console spi.Console) error {
+	portfolio, err := ReadPortfolio(fileSystem, portfolioFilename)
+	if err == nil {
+		err = console.WriteString(formatPortfolio(portfolio))
+	}
+	return err
+}
+
+// formatPortfolio - The scheduling columns are only shown for a v1beta portfolio, as no other portfolio can use them.
+func formatPortfolio(portfolio *Portfolio) string {
+	buff := strings.Builder{}
+	buff.WriteString(fmt.Sprintf("Portfolio '%s' (%s)\n\n", portfolio.Metadata.Name, portfolio.APIVersion))
+
+	isV1beta := portfolio.APIVersion == PORTFOLIO_DECLARED_FORMAT_VERSION_V1BETA
+
+	headers := []string{PORTFOLIO_HEADER_BUNDLE, PORTFOLIO_HEADER_CLASS, PORTFOLIO_HEADER_STREAM, PORTFOLIO_HEADER_OBR, PORTFOLIO_HEADER_OVERRIDES}
+	if isV1beta {
+		headers = append(headers, PORTFOLIO_HEADER_PRIORITY, PORTFOLIO_HEADER_MAX_ATTEMPTS, PORTFOLIO_HEADER_TIMEOUT,
+			PORTFOLIO_HEADER_LABELS, PORTFOLIO_HEADER_DEPENDS_ON)
+	}
+
+	table := [][]string{headers}
+	for _, portfolioClass := range portfolio.Classes {
+		className := portfolioClass.Class
+		if portfolioClass.GherkinUrl != "" {
+			className = portfolioClass.GherkinUrl
+		}
+
+		row := []string{portfolioClass.Bundle, className, portfolioClass.Stream, portfolioClass.Obr, formatOverrides(portfolioClass.Overrides)}
+		if isV1beta {
+			scheduling := portfolioClass.PortfolioClassScheduling
+			row = append(row,
+				strconv.Itoa(scheduling.Priority),
+				formatMaxAttempts(scheduling.MaxAttempts),
+				scheduling.Timeout,
+				strings.Join(scheduling.Labels, ","),
+				strings.Join(scheduling.DependsOn, ","),
+			)
+		}
+		table = append(table, row)
+	}
+
+	columnLengths := utils.CalculateMaxLengthOfEachColumn(table)
+	utils.WriteFormattedTableToStringBuilder(table, &buff, columnLengths)
+	buff.WriteString("\n")
+
+	buff.WriteString(fmt.Sprintf("Total classes: %v\n", len(portfolio.Classes)))
+	if len(portfolio.Matrix) > 0 {
+		combinations := portfolio.GetMatrixCombinations()
+		buff.WriteString(fmt.Sprintf("Matrix combinations: %v, so each class is run %v times\n", len(combinations), len(combinations)))
+	}
+	return buff.String()
+}
+
+// formatOverrides - The overrides as name=value pairs, in name order, separated by commas.
+func formatOverrides(overrides map[string]string) string {
+	pairs := make([]string, 0, len(overrides))
+	for key, value := range overrides {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func formatMaxAttempts(maxAttempts int) string {
+	text := ""
+	if maxAttempts > 0 {
+		text = strconv.Itoa(maxAttempts)
+	}
+	return text
+}
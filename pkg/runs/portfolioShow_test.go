@@ -0,0 +1,79 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShowPortfolioWritesATableOfTheClasses(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	portfolio := NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes,
+		PortfolioClass{Bundle: "myBundle", Class: "myClass", Stream: "myStream", Obr: "myObr",
+			Overrides: map[string]string{"zos.image": "MV1A", "cics.region": "A"}},
+		PortfolioClass{GherkinUrl: "file:///my.feature"},
+	)
+	err := WritePortfolio(fs, "my.yaml", portfolio)
+	assert.Nil(t, err)
+	console := utils.NewMockConsole()
+
+	// When...
+	err = ShowPortfolio(fs, "my.yaml", console)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t,
+		"Portfolio 'adhoc' (v1alpha)\n"+
+			"\n"+
+			"bundle   class              stream   obr   overrides\n"+
+			"myBundle myClass            myStream myObr cics.region=A,zos.image=MV1A\n"+
+			"         file:///my.feature                \n"+
+			"\n"+
+			"Total classes: 2\n",
+		console.ReadText())
+}
+
+func TestShowV1betaPortfolioWithMatrixShowsSchedulingAndCombinations(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	portfolio := createMatrixPortfolio()
+	portfolio.APIVersion = PORTFOLIO_DECLARED_FORMAT_VERSION_V1BETA
+	portfolio.Classes[0].PortfolioClassScheduling = PortfolioClassScheduling{Priority: 5, MaxAttempts: 2, Timeout: "30m"}
+	err := WritePortfolio(fs, "my.yaml", portfolio)
+	assert.Nil(t, err)
+	console := utils.NewMockConsole()
+
+	// When...
+	err = ShowPortfolio(fs, "my.yaml", console)
+
+	// Then...
+	assert.Nil(t, err)
+	text := console.ReadText()
+	assert.Contains(t, text, "Portfolio 'adhoc' (v1beta)")
+	assert.Contains(t, text, "priority max-attempts timeout labels depends-on")
+	assert.Contains(t, text, "5        2            30m")
+	assert.Contains(t, text, "Total classes: 1\n")
+	assert.Contains(t, text, "Matrix combinations: 4, so each class is run 4 times\n")
+}
+
+func TestShowMissingPortfolioFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	console := utils.NewMockConsole()
+
+	// When...
+	err := ShowPortfolio(fs, "missing.yaml", console)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1021E")
+}
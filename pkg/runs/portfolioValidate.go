@@ -0,0 +1,99 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// ValidatePortfolioFile - Reads the portfolio, which checks its structure, apiVersion and kind, then looks
+// for the problems which would stop its tests running as expected. Each problem found is written to the
+// console. The streams the classes use are only checked if a launcher is given.
+func ValidatePortfolioFile(
+	fileSystem spi.FileSystem,
+	portfolioFilename string,
+	launcherInstance launcher.Launcher,
+	console spi.Console,
+) error {
+	portfolio, err := ReadPortfolio(fileSystem, portfolioFilename)
+	if err == nil {
+		var streams []string
+		if launcherInstance != nil {
+			streams, err = launcherInstance.GetStreams()
+		} else {
+			log.Println("The streams used by the portfolio are not being checked")
+		}
+
+		if err == nil {
+			problems := getPortfolioProblems(portfolio, portfolioFilename, streams, launcherInstance != nil)
+			for _, problem := range problems {
+				console.WriteString(problem.Error() + "\n")
+			}
+
+			if len(problems) > 0 {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_NOT_VALID, portfolioFilename, len(problems))
+			} else {
+				err = console.WriteString(fmt.Sprintf("Portfolio '%s' is valid. It has %v classes.\n", portfolioFilename, len(portfolio.Classes)))
+			}
+		}
+	}
+	return err
+}
+
+// getPortfolioProblems - Every problem with the classes of the portfolio, in the order the classes are listed.
+func getPortfolioProblems(portfolio *Portfolio, portfolioFilename string, streams []string, isCheckingStreams bool) []error {
+	problems := make([]error, 0)
+
+	knownStreams := make(map[string]bool)
+	for _, stream := range streams {
+		knownStreams[stream] = true
+	}
+
+	classKeys := make(map[string]bool)
+	for index, portfolioClass := range portfolio.Classes {
+		classKey := portfolioClass.getClassKey()
+
+		if portfolioClass.GherkinUrl == "" && (portfolioClass.Bundle == "" || portfolioClass.Class == "") {
+			problems = append(problems, galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_CLASS_NO_TEST, index+1, portfolioFilename))
+			continue
+		}
+
+		if classKeys[classKey] {
+			problems = append(problems, galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_DUPLICATE_CLASS, classKey, portfolioFilename))
+		}
+		classKeys[classKey] = true
+
+		if isCheckingStreams && portfolioClass.Stream != "" && !knownStreams[portfolioClass.Stream] {
+			problems = append(problems, galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_UNKNOWN_STREAM,
+				classKey, portfolioFilename, portfolioClass.Stream, strings.Join(streams, ", ")))
+		}
+
+		if portfolioClass.Obr != "" {
+			_, obrErr := utils.ValidateObr(portfolioClass.Obr)
+			if obrErr != nil {
+				problems = append(problems, galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_PORTFOLIO_CLASS_BAD_OBR,
+					classKey, portfolioFilename, obrErr.Error()))
+			}
+		}
+	}
+	return problems
+}
+
+// getClassKey - What identifies the class in the portfolio. A gherkin test is identified by its feature URL.
+func (portfolioClass *PortfolioClass) getClassKey() string {
+	classKey := portfolioClass.getClassName()
+	if portfolioClass.GherkinUrl != "" {
+		classKey = portfolioClass.GherkinUrl
+	}
+	return classKey
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const VALID_TEST_OBR = "mvn:my.group/my.obr/0.0.1/obr"
+
+func TestValidPortfolioIsReportedAsValid(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	createTestPortfolioFile(t, fs, "my.yaml", "myBundle", "myClass", "", VALID_TEST_OBR)
+	console := utils.NewMockConsole()
+
+	// When...
+	err := ValidatePortfolioFile(fs, "my.yaml", nil, console)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "Portfolio 'my.yaml' is valid. It has 1 classes.\n", console.ReadText())
+}
+
+func TestValidatePortfolioWithBadApiVersionFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	portfolio := NewPortfolio()
+	portfolio.APIVersion = "v0"
+	err := WritePortfolio(fs, "my.yaml", portfolio)
+	assert.Nil(t, err)
+	console := utils.NewMockConsole()
+
+	// When...
+	err = ValidatePortfolioFile(fs, "my.yaml", nil, console)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1023E")
+}
+
+func TestValidatePortfolioReportsEveryProblem(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	portfolio := NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes,
+		PortfolioClass{Bundle: "myBundle", Class: "myClass", Obr: VALID_TEST_OBR},
+		PortfolioClass{Bundle: "myBundle"},
+		PortfolioClass{Bundle: "myBundle", Class: "myClass", Obr: VALID_TEST_OBR},
+		PortfolioClass{Bundle: "myBundle", Class: "myOtherClass", Obr: "my.obr"},
+	)
+	err := WritePortfolio(fs, "my.yaml", portfolio)
+	assert.Nil(t, err)
+	console := utils.NewMockConsole()
+
+	// When...
+	err = ValidatePortfolioFile(fs, "my.yaml", nil, console)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1303E: Portfolio 'my.yaml' is not valid. 3 problems were found.")
+
+	text := console.ReadText()
+	assert.Contains(t, text, "GAL1299E: Class number 2 in portfolio 'my.yaml'")
+	assert.Contains(t, text, "GAL1300E: Class 'myBundle/myClass' is in portfolio 'my.yaml' more than once")
+	assert.Contains(t, text, "GAL1302E: Class 'myBundle/myOtherClass' in portfolio 'my.yaml' has an obr which is not valid.")
+}
+
+func TestValidatePortfolioChecksStreamsWithTheLauncher(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	createTestPortfolioFile(t, fs, "my.yaml", "myBundle", "myClass", "myStream", VALID_TEST_OBR)
+	console := utils.NewMockConsole()
+
+	// When...
+	err := ValidatePortfolioFile(fs, "my.yaml", launcher.NewMockLauncher(), console)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1303E")
+	assert.Contains(t, console.ReadText(), "GAL1301E: Class 'myBundle/myClass' in portfolio 'my.yaml' uses stream 'myStream'")
+}
+
+func TestPortfolioProblemsIgnoreStreamsWhichExist(t *testing.T) {
+	// Given...
+	portfolio := NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes,
+		PortfolioClass{Bundle: "myBundle", Class: "myClass", Stream: "myStream"},
+		PortfolioClass{GherkinUrl: "file:///my.feature", Stream: "myOtherStream"},
+	)
+
+	// When...
+	problems := getPortfolioProblems(portfolio, "my.yaml", []string{"myStream", "myOtherStream"}, true)
+
+	// Then...
+	assert.Empty(t, problems)
+}
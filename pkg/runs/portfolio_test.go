@@ -49,3 +49,125 @@ func TestCanWriteAndReadAPortfolio(t *testing.T) {
 	assert.Equal(t, "myStream", portfolioGotBack.Classes[0].Stream)
 	assert.Equal(t, "myObr", portfolioGotBack.Classes[0].Obr)
 }
+
+func createMatrixPortfolio() *Portfolio {
+	portfolio := NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes, PortfolioClass{Bundle: "myBundle", Class: "myClass", Obr: "myObr"})
+	portfolio.Matrix = []PortfolioMatrixAxis{
+		{
+			Name: "image",
+			OverrideSets: []PortfolioOverrideSet{
+				{Name: "zos1", Overrides: map[string]string{"zos.image": "MV1A", "zos.tag": "PRIMARY"}},
+				{Name: "zos2", Overrides: map[string]string{"zos.image": "MV2A"}},
+			},
+		},
+		{
+			Name: "region",
+			OverrideSets: []PortfolioOverrideSet{
+				{Name: "cicsA", Overrides: map[string]string{"cics.region": "CICSA", "zos.tag": "SECONDARY"}},
+				{Name: "cicsB", Overrides: map[string]string{"cics.region": "CICSB"}},
+			},
+		},
+	}
+	return portfolio
+}
+
+func TestPortfolioWithoutMatrixHasOneUnnamedCombination(t *testing.T) {
+	// Given...
+	portfolio := NewPortfolio()
+
+	// When...
+	combinations := portfolio.GetMatrixCombinations()
+
+	// Then...
+	assert.Equal(t, 1, len(combinations))
+	assert.Equal(t, "", combinations[0].Name)
+	assert.Empty(t, combinations[0].Overrides)
+}
+
+func TestPortfolioMatrixExpandsIntoEveryCombinationOfOverrideSets(t *testing.T) {
+	// Given...
+	portfolio := createMatrixPortfolio()
+
+	// When...
+	combinations := portfolio.GetMatrixCombinations()
+
+	// Then...
+	assert.Equal(t, 4, len(combinations))
+	assert.Equal(t, "image=zos1,region=cicsA", combinations[0].Name)
+	assert.Equal(t, "image=zos1,region=cicsB", combinations[1].Name)
+	assert.Equal(t, "image=zos2,region=cicsA", combinations[2].Name)
+	assert.Equal(t, "image=zos2,region=cicsB", combinations[3].Name)
+
+	// The later axis wins when two axes set the same override.
+	assert.Equal(t, map[string]string{"zos.image": "MV1A", "zos.tag": "SECONDARY", "cics.region": "CICSA"}, combinations[0].Overrides)
+	assert.Equal(t, map[string]string{"zos.image": "MV2A", "cics.region": "CICSB"}, combinations[3].Overrides)
+}
+
+func TestCanWriteAndReadAPortfolioWithAMatrix(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	err := WritePortfolio(fs, "my.portfolio", createMatrixPortfolio())
+	assert.Nil(t, err)
+
+	// When...
+	portfolioGotBack, err := ReadPortfolio(fs, "my.portfolio")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(portfolioGotBack.Matrix))
+	assert.Equal(t, "region", portfolioGotBack.Matrix[1].Name)
+	assert.Equal(t, "cicsB", portfolioGotBack.Matrix[1].OverrideSets[1].Name)
+	assert.Equal(t, "CICSB", portfolioGotBack.Matrix[1].OverrideSets[1].Overrides["cics.region"])
+}
+
+func TestReadPortfolioWithMatrixAxisWithoutOverrideSetsFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	portfolio := createMatrixPortfolio()
+	portfolio.Matrix[1].OverrideSets = nil
+	err := WritePortfolio(fs, "my.portfolio", portfolio)
+	assert.Nil(t, err)
+
+	// When...
+	_, err = ReadPortfolio(fs, "my.portfolio")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1253E")
+	assert.Contains(t, err.Error(), "'region'")
+}
+
+func TestReadPortfolioWithMatrixAxisWithoutANameFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	portfolio := createMatrixPortfolio()
+	portfolio.Matrix[1].Name = ""
+	err := WritePortfolio(fs, "my.portfolio", portfolio)
+	assert.Nil(t, err)
+
+	// When...
+	_, err = ReadPortfolio(fs, "my.portfolio")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1252E")
+	assert.Contains(t, err.Error(), "axis number 2")
+}
+
+func TestReadPortfolioWithDuplicateOverrideSetNamesFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	portfolio := createMatrixPortfolio()
+	portfolio.Matrix[0].OverrideSets[1].Name = "zos1"
+	err := WritePortfolio(fs, "my.portfolio", portfolio)
+	assert.Nil(t, err)
+
+	// When...
+	_, err = ReadPortfolio(fs, "my.portfolio")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1256E")
+	assert.Contains(t, err.Error(), "'zos1'")
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+// ProgressReporter - Shows the user how a submission of tests is progressing, each time
+// the submitter has checked on the status of the runs.
+type ProgressReporter interface {
+	ReportProgress(progress SubmissionProgress)
+
+	// ReportMessage - Shows the user a message, such as an error, while the submission is in progress,
+	// without it being drawn over by the progress which is reported after it.
+	ReportMessage(message string)
+}
+
+// SubmissionProgress - The status data the submitter has collected so far. The runs are the
+// submitter's own, so a progress reporter must only read them, and not keep them.
+type SubmissionProgress struct {
+	Now           time.Time
+	ReadyRuns     []TestRun
+	SubmittedRuns map[string]*TestRun
+	FinishedRuns  map[string]*TestRun
+	LostRuns      map[string]*TestRun
+	Throttle      int
+}
+
+// NewProgressReporter - Creates a dashboard which refreshes in place when the console is a terminal,
+// and a reporter which writes a line for each change otherwise, such as when the output is captured
+// by a build pipeline.
+func NewProgressReporter(console spi.Console, isTerminal bool) ProgressReporter {
+	var reporter ProgressReporter
+	if isTerminal {
+		reporter = NewTerminalProgressReporter(console)
+	} else {
+		reporter = NewLineProgressReporter(console)
+	}
+	return reporter
+}
+
+// runStartTimes - Remembers when each run whose submitted time isn't known was first seen in progress,
+// so the time every run has been running for can be shown.
+type runStartTimes map[string]time.Time
+
+// getElapsedTime - How long it is since the run was submitted.
+func (startTimes runStartTimes) getElapsedTime(runName string, run *TestRun, now time.Time) time.Duration {
+	startTime := run.SubmittedTime
+	if startTime.IsZero() {
+		var isKnown bool
+		startTime, isKnown = startTimes[runName]
+		if !isKnown {
+			startTime = now
+			startTimes[runName] = startTime
+		}
+	}
+	return now.Sub(startTime).Round(time.Second)
+}
+
+// getSortedRunNames - The names of the runs, in alphabetical order, so they are always shown in the same order.
+func getSortedRunNames(runs map[string]*TestRun) []string {
+	runNames := make([]string, 0, len(runs))
+	for runName := range runs {
+		runNames = append(runNames, runName)
+	}
+	sort.Strings(runNames)
+	return runNames
+}
+
+// formatProgressCounters - How many runs are in each state, as a line of text.
+func formatProgressCounters(progress SubmissionProgress) string {
+	return formatRunCounters(len(progress.ReadyRuns), len(progress.SubmittedRuns), len(progress.FinishedRuns), len(progress.LostRuns), progress.Throttle)
+}
+
+func formatRunCounters(ready int, submitted int, finished int, lost int, throttle int) string {
+	return fmt.Sprintf("Ready=%v, Submitted=%v, Finished=%v, Lost=%v, Throttle=%v\n", ready, submitted, finished, lost, throttle)
+}
+
+func getRunClassName(run *TestRun) string {
+	className := run.Bundle + "/" + run.Class
+	if run.GherkinUrl != "" {
+		className = run.GherkinFeature
+	}
+	return className + getCombinationSuffix(run)
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"log"
+	"strings"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"gopkg.in/yaml.v3"
+)
+
+// ReadTestReport - Reads the test runs back from a yaml, json or junit report written by 'runs submit'
+// or 'runs wait'. The format is worked out from the content of the file.
+// A junit report doesn't hold the overrides of its test runs, so one whose test runs used portfolio
+// matrix combinations is rejected, rather than running every combination with the same overrides.
+func ReadTestReport(fileSystem spi.FileSystem, reportFilename string) (*TestReport, error) {
+	var report *TestReport
+
+	text, err := fileSystem.ReadTextFile(reportFilename)
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_OPEN_TEST_REPORT_FAILED, reportFilename, err.Error())
+	} else {
+		trimmedText := strings.TrimSpace(text)
+		report = new(TestReport)
+		isJunit := strings.HasPrefix(trimmedText, "<")
+		if isJunit {
+			report.Tests, err = readJunitTestRuns(trimmedText)
+		} else if strings.HasPrefix(trimmedText, "{") {
+			err = json.Unmarshal([]byte(trimmedText), report)
+		} else {
+			err = yaml.Unmarshal([]byte(trimmedText), report)
+		}
+
+		if err != nil {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_TEST_REPORT_BAD_FORMAT, reportFilename, err.Error())
+		} else if isJunit && hasMatrixCombinations(report.Tests) {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_JUNIT_REPORT_HAS_COMBINATIONS, reportFilename)
+		} else {
+			log.Printf("Read %v test runs from test report %v\n", len(report.Tests), reportFilename)
+		}
+	}
+	return report, err
+}
+
+func hasMatrixCombinations(testRuns []TestRun) bool {
+	isFound := false
+	for _, run := range testRuns {
+		if run.Combination != "" {
+			isFound = true
+			break
+		}
+	}
+	return isFound
+}
+
+// readJunitTestRuns - Each test suite is a test run, named stream/bundle/class, with a
+// [combination] suffix if it used a portfolio matrix combination. A lost test run has the
+// result it was given when it was cancelled, or 'Lost'.
+func readJunitTestRuns(text string) ([]TestRun, error) {
+	var testSuites JunitTestSuites
+	testRuns := make([]TestRun, 0)
+
+	err := xml.Unmarshal([]byte(text), &testSuites)
+	if err == nil {
+		for _, testSuite := range testSuites.Testsuite {
+			testRuns = append(testRuns, getTestRunFromJunitTestSuite(testSuite))
+		}
+	}
+	return testRuns, err
+}
+
+func getTestRunFromJunitTestSuite(testSuite JunitTestSuite) TestRun {
+	run := TestRun{Name: testSuite.ID}
+
+	properties := make(map[string]string)
+	if testSuite.Properties != nil {
+		for _, property := range testSuite.Properties.Property {
+			properties[property.Name] = property.Value
+		}
+	}
+	run.Result = properties["result"]
+	run.Obr = properties["obr"]
+	run.Requestor = properties["requestor"]
+	run.Group = properties["group"]
+	run.Combination = properties["combination"]
+
+	nameParts := strings.SplitN(strings.TrimSuffix(testSuite.Name, getCombinationSuffix(&run)), "/", 3)
+	if len(nameParts) == 3 {
+		run.Stream = nameParts[0]
+		run.Bundle = nameParts[1]
+		run.Class = nameParts[2]
+	}
+
+	if run.Result == "" {
+		// Reports written before the result was recorded can only say how the test methods went.
+		run.Result = getResultFromJunitTestCases(testSuite.TestCase)
+	}
+	return run
+}
+
+func getResultFromJunitTestCases(testCases []JunitTestCase) string {
+	result := RESULT_PASSED
+	for _, testCase := range testCases {
+		if testCase.Failure != nil {
+			result = RESULT_FAILED
+			break
+		} else if testCase.Error != nil {
+			result = RESULT_ENVFAIL
+		}
+	}
+	return result
+}
+
+// AddReportedRunsToPortfolio - Adds a class to the portfolio for each test run in the report with one of
+// the results, to run it again. If no results are given, each test run which failed or had an EnvFail
+// is added. A test run which was lost, so has no result, has the result 'Lost'. The overrides are those
+// the test run used, with testOverrides added on top. Returns how many classes were added.
+func AddReportedRunsToPortfolio(report *TestReport, results []string, testOverrides map[string]string, portfolio *Portfolio) int {
+	count := 0
+	for _, run := range report.Tests {
+		if isReportedRunWanted(run, results) {
+			overrides := make(map[string]string)
+			for key, value := range run.Overrides {
+				overrides[key] = value
+			}
+			for key, value := range testOverrides {
+				overrides[key] = value
+			}
+
+			portfolio.Classes = append(portfolio.Classes, PortfolioClass{
+				Bundle:     run.Bundle,
+				Class:      run.Class,
+				Stream:     run.Stream,
+				Obr:        run.Obr,
+				Overrides:  overrides,
+				GherkinUrl: run.GherkinUrl,
+			})
+			count++
+		}
+	}
+	return count
+}
+
+func isReportedRunWanted(run TestRun, results []string) bool {
+	result := run.Result
+	if result == "" {
+		result = RESULT_LOST
+	}
+
+	isWanted := false
+	if len(results) == 0 {
+		outcome := getReportOutcome(result)
+		isWanted = outcome == REPORT_OUTCOME_FAILED || outcome == REPORT_OUTCOME_ERROR
+	} else {
+		for _, wantedResult := range results {
+			if strings.EqualFold(wantedResult, result) {
+				isWanted = true
+				break
+			}
+		}
+	}
+	return isWanted
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReportedRunsForRerunTests() (map[string]*TestRun, map[string]*TestRun) {
+	finishedRuns := map[string]*TestRun{
+		"U1": {Name: "U1", Stream: "myStream", Bundle: "myBundle", Class: "myClass1", Obr: "myObr", Result: RESULT_PASSED, Group: "myGroup"},
+		"U2": {Name: "U2", Stream: "myStream", Bundle: "myBundle", Class: "myClass2", Obr: "myObr", Result: RESULT_FAILED, Group: "myGroup",
+			Overrides: map[string]string{"myOverride": "myValue"}},
+		"U3": {Name: "U3", Stream: "myStream", Bundle: "myBundle", Class: "myClass3", Obr: "myObr", Result: RESULT_ENVFAIL, Group: "myGroup"},
+	}
+	lostRuns := map[string]*TestRun{
+		"myClass4": {Stream: "myStream", Bundle: "myBundle", Class: "myClass4", Obr: "myObr", Group: "myGroup"},
+	}
+	return finishedRuns, lostRuns
+}
+
+func getPortfolioClassNames(portfolio *Portfolio) []string {
+	classNames := make([]string, 0)
+	for _, portfolioClass := range portfolio.Classes {
+		classNames = append(classNames, portfolioClass.Class)
+	}
+	return classNames
+}
+
+func readRerunPortfolioFromReport(t *testing.T, fileSystem spi.FileSystem, results []string) *Portfolio {
+	report, err := ReadTestReport(fileSystem, "my.report")
+	assert.Nil(t, err)
+
+	portfolio := NewPortfolio()
+	AddReportedRunsToPortfolio(report, results, nil, portfolio)
+	return portfolio
+}
+
+func TestReadYamlReportAddsFailedEnvFailAndLostRunsByDefault(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	finishedRuns, lostRuns := newReportedRunsForRerunTests()
+	err := ReportYaml(fileSystem, "my.report", finishedRuns, lostRuns)
+	assert.Nil(t, err)
+
+	// When...
+	portfolio := readRerunPortfolioFromReport(t, fileSystem, nil)
+
+	// Then...
+	assert.ElementsMatch(t, []string{"myClass2", "myClass3", "myClass4"}, getPortfolioClassNames(portfolio))
+	for _, portfolioClass := range portfolio.Classes {
+		assert.Equal(t, "myStream", portfolioClass.Stream)
+		assert.Equal(t, "myBundle", portfolioClass.Bundle)
+		assert.Equal(t, "myObr", portfolioClass.Obr)
+	}
+}
+
+func TestReadJsonReportAddsOnlyRunsWithTheResultsAskedFor(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	finishedRuns, lostRuns := newReportedRunsForRerunTests()
+	err := ReportJSON(fileSystem, "my.report", finishedRuns, lostRuns)
+	assert.Nil(t, err)
+
+	// When...
+	portfolio := readRerunPortfolioFromReport(t, fileSystem, []string{"passed", "lost"})
+
+	// Then...
+	assert.ElementsMatch(t, []string{"myClass1", "myClass4"}, getPortfolioClassNames(portfolio))
+}
+
+func TestReadJunitReportAddsFailedEnvFailAndLostRuns(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	finishedRuns, lostRuns := newReportedRunsForRerunTests()
+	err := ReportJunit(fileSystem, "my.report", "myGroup", finishedRuns, lostRuns, nil, 0)
+	assert.Nil(t, err)
+
+	// When...
+	portfolio := readRerunPortfolioFromReport(t, fileSystem, nil)
+
+	// Then...
+	assert.ElementsMatch(t, []string{"myClass2", "myClass3", "myClass4"}, getPortfolioClassNames(portfolio))
+	for _, portfolioClass := range portfolio.Classes {
+		assert.Equal(t, "myStream", portfolioClass.Stream)
+		assert.Equal(t, "myBundle", portfolioClass.Bundle)
+		assert.Equal(t, "myObr", portfolioClass.Obr)
+	}
+}
+
+func TestReadJunitReportAddsOnlyLostRunsWhenAskedFor(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	finishedRuns, lostRuns := newReportedRunsForRerunTests()
+	lostRuns["myClass5"] = &TestRun{Name: "U5", Stream: "myStream", Bundle: "myBundle", Class: "myClass5", Obr: "myObr",
+		Result: RESULT_CANCELLED, CancelledBy: STOP_REASON_TIMEOUT}
+	err := ReportJunit(fileSystem, "my.report", "myGroup", finishedRuns, lostRuns, nil, 0)
+	assert.Nil(t, err)
+
+	// When...
+	portfolio := readRerunPortfolioFromReport(t, fileSystem, []string{"lost"})
+
+	// Then...
+	// The run which was cancelled keeps its own result, so isn't taken as lost.
+	assert.ElementsMatch(t, []string{"myClass4"}, getPortfolioClassNames(portfolio))
+}
+
+func TestReadJunitReportOfMatrixCombinationsFails(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	finishedRuns := map[string]*TestRun{
+		"U1": {Name: "U1", Stream: "myStream", Bundle: "myBundle", Class: "myClass1", Result: RESULT_FAILED, Combination: "image=zos1",
+			Overrides: map[string]string{"zos.image": "MV1A"}},
+	}
+	err := ReportJunit(fileSystem, "my.report", "myGroup", finishedRuns, nil, nil, 0)
+	assert.Nil(t, err)
+
+	// When...
+	_, err = ReadTestReport(fileSystem, "my.report")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1326E")
+}
+
+func TestReadJunitReportWithoutResultPropertyTakesResultFromTestCases(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	fileSystem.WriteTextFile("my.report", `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites id="myGroup" name="myGroup" tests="2" failures="1" time="0">
+    <testsuite id="U1" name="myStream/myBundle/myClass1" tests="1" failures="0" time="0">
+        <testcase id="myMethod" name="myMethod" time="0"></testcase>
+    </testsuite>
+    <testsuite id="U2" name="myStream/myBundle/myClass2" tests="1" failures="1" time="0">
+        <testcase id="myMethod" name="myMethod" time="0">
+            <failure message="Failed" type="Unknown"></failure>
+        </testcase>
+    </testsuite>
+</testsuites>`)
+
+	// When...
+	report, err := ReadTestReport(fileSystem, "my.report")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Len(t, report.Tests, 2)
+	assert.Equal(t, RESULT_PASSED, report.Tests[0].Result)
+	assert.Equal(t, RESULT_FAILED, report.Tests[1].Result)
+	assert.Equal(t, "myClass2", report.Tests[1].Class)
+}
+
+func TestAddReportedRunsKeepsTheirOverridesUnderTheOnesGiven(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	finishedRuns, lostRuns := newReportedRunsForRerunTests()
+	err := ReportYaml(fileSystem, "my.report", finishedRuns, lostRuns)
+	assert.Nil(t, err)
+
+	report, err := ReadTestReport(fileSystem, "my.report")
+	assert.Nil(t, err)
+	portfolio := NewPortfolio()
+
+	// When...
+	count := AddReportedRunsToPortfolio(report, []string{RESULT_FAILED}, map[string]string{"myOtherOverride": "myOtherValue"}, portfolio)
+
+	// Then...
+	assert.Equal(t, 1, count)
+	assert.Equal(t, map[string]string{"myOverride": "myValue", "myOtherOverride": "myOtherValue"}, portfolio.Classes[0].Overrides)
+}
+
+func TestReadMissingReportFails(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+
+	// When...
+	_, err := ReadTestReport(fileSystem, "my.report")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1290E")
+}
+
+func TestReadReportInBadFormatFails(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	fileSystem.WriteTextFile("my.report", "{ this is not json")
+
+	// When...
+	_, err := ReadTestReport(fileSystem, "my.report")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1291E")
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"log"
+	"path"
+	"strings"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/runsformatter"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	RESULT_POLICY_API_VERSION            = "galasa-dev/v1alpha1"
+	RESULT_POLICY_DECLARED_RESOURCE_KIND = "galasa.dev/testResultPolicy"
+)
+
+// Given to test runs which did not pass, but which are not counted as failures.
+const (
+	// The test class is in the quarantine list of the result policy.
+	EXCUSED_BY_QUARANTINE = runsformatter.RUN_EXCUSED_BY_QUARANTINE
+
+	// The result is one which the result policy does not count as a failure.
+	EXCUSED_BY_POLICY = "policy"
+)
+
+// ResultPolicy - Decides whether the results of the test runs fail the command.
+//
+// For example:
+//
+//	apiVersion: galasa-dev/v1alpha1
+//	kind: galasa.dev/testResultPolicy
+//	excusedResults:
+//	- EnvFail
+//	minPassRate: 95
+//	quarantine:
+//	- myBundle/com.myco.MyFlakyTest
+//	- myOtherBundle/*
+type ResultPolicy struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+
+	// Results which are not counted as failures, such as EnvFail.
+	ExcusedResults []string `yaml:"excusedResults,omitempty"`
+
+	// The lowest percentage of the counted test runs which must pass. 0 if any failure fails the command.
+	// Quarantined and excused test runs are not counted.
+	MinPassRate float64 `yaml:"minPassRate,omitempty"`
+
+	// bundle/class patterns of test classes whose failures are reported, but don't fail the command.
+	// A '*' matches any part of a bundle or class name.
+	Quarantine []string `yaml:"quarantine,omitempty"`
+}
+
+// NewResultPolicy - The policy in the --resultpolicy file, if there is one, with the results and
+// quarantine patterns from the command-line flags added to it. A --minpassrate flag overrides
+// the pass rate in the file.
+func NewResultPolicy(fileSystem spi.FileSystem, params utils.RunsSubmitCmdValues) (*ResultPolicy, error) {
+	var err error
+	policy := new(ResultPolicy)
+
+	if params.ResultPolicyFileName != "" {
+		policy, err = ReadResultPolicy(fileSystem, params.ResultPolicyFileName)
+	}
+
+	if err == nil {
+		policy.ExcusedResults = append(policy.ExcusedResults, params.ExcusedResults...)
+		policy.Quarantine = append(policy.Quarantine, params.Quarantine...)
+		if params.MinPassRate != 0 {
+			policy.MinPassRate = params.MinPassRate
+		}
+		err = policy.validate()
+	}
+	return policy, err
+}
+
+// ReadResultPolicy - Reads a result policy from a yaml file.
+func ReadResultPolicy(fileSystem spi.FileSystem, filename string) (*ResultPolicy, error) {
+	var policy *ResultPolicy
+
+	text, err := fileSystem.ReadTextFile(filename)
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_OPEN_RESULT_POLICY_FILE_FAILED, filename, err.Error())
+	} else {
+		policy = new(ResultPolicy)
+		err = yaml.Unmarshal([]byte(text), policy)
+		if err != nil {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_RESULT_POLICY_BAD_FORMAT, filename, err.Error())
+		} else if policy.APIVersion != RESULT_POLICY_API_VERSION || policy.Kind != RESULT_POLICY_DECLARED_RESOURCE_KIND {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_RESULT_POLICY_BAD_RESOURCE_KIND, filename,
+				RESULT_POLICY_API_VERSION, RESULT_POLICY_DECLARED_RESOURCE_KIND)
+		}
+	}
+	return policy, err
+}
+
+func (policy *ResultPolicy) validate() error {
+	var err error
+	if policy.MinPassRate < 0 || policy.MinPassRate > 100 {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_MIN_PASS_RATE, policy.MinPassRate)
+	}
+
+	for _, pattern := range policy.Quarantine {
+		if err == nil {
+			_, err = path.Match(pattern, "")
+			if err != nil || len(strings.Split(pattern, "/")) != 2 {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_QUARANTINE_PATTERN, pattern)
+			}
+		}
+	}
+	return err
+}
+
+// ExcuseRuns - Marks the test runs which did not pass, but which are not to be counted as failures.
+// A test run which is quarantined is not also marked as excused by the policy.
+func (policy *ResultPolicy) ExcuseRuns(finishedRuns map[string]*TestRun, lostRuns map[string]*TestRun) {
+	for _, run := range finishedRuns {
+		policy.excuseRun(run, run.Result)
+	}
+	for _, run := range lostRuns {
+		policy.excuseRun(run, RESULT_LOST)
+	}
+}
+
+func (policy *ResultPolicy) excuseRun(run *TestRun, result string) {
+	run.ExcusedBy = ""
+	if !strings.HasPrefix(result, RESULT_PASSED) {
+		if policy.isQuarantined(run) {
+			run.ExcusedBy = EXCUSED_BY_QUARANTINE
+		} else if policy.isExcusedResult(result) {
+			run.ExcusedBy = EXCUSED_BY_POLICY
+		}
+
+		if run.ExcusedBy != "" {
+			log.Printf("Test run %v - %v/%v with result %v is excused by the %v\n", run.Name, run.Bundle, run.Class, result, run.ExcusedBy)
+		}
+	}
+}
+
+// isCountedAsFailure - Does a run which finished with this result count as a failure ?
+// Not if it passed, or if it is quarantined or has a result the policy excuses.
+func (policy *ResultPolicy) isCountedAsFailure(run *TestRun, result string) bool {
+	return !strings.HasPrefix(result, RESULT_PASSED) && !policy.isQuarantined(run) && !policy.isExcusedResult(result)
+}
+
+func (policy *ResultPolicy) isQuarantined(run *TestRun) bool {
+	isQuarantined := false
+	for _, pattern := range policy.Quarantine {
+		isMatch, _ := path.Match(pattern, run.Bundle+"/"+run.Class)
+		if isMatch {
+			isQuarantined = true
+			break
+		}
+	}
+	return isQuarantined
+}
+
+func (policy *ResultPolicy) isExcusedResult(result string) bool {
+	isExcused := false
+	for _, excusedResult := range policy.ExcusedResults {
+		if strings.EqualFold(excusedResult, result) {
+			isExcused = true
+			break
+		}
+	}
+	return isExcused
+}
+
+// CheckResults - Fails if too many of the test runs failed. Call ExcuseRuns first.
+// Without a minimum pass rate, any failure which is not excused is too many.
+func (policy *ResultPolicy) CheckResults(finishedRuns map[string]*TestRun, lostRuns map[string]*TestRun) error {
+	var err error
+
+	failureCount := CountTotalFailedRuns(finishedRuns, lostRuns)
+	if failureCount > 0 {
+		if policy.MinPassRate == 0 {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_TESTS_FAILED, failureCount)
+		} else {
+			passedCount, countedRuns, passRate := getPassRate(finishedRuns, lostRuns)
+			log.Printf("%v of %v counted test runs passed. Pass rate %v%%. Minimum pass rate %v%%\n", passedCount, countedRuns, passRate, policy.MinPassRate)
+			if passRate < policy.MinPassRate {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_TESTS_BELOW_MIN_PASS_RATE, passRate, policy.MinPassRate, passedCount, countedRuns)
+			}
+		}
+	}
+	return err
+}
+
+// isPassing - Whether CheckResults would pass the test runs. Call ExcuseRuns first.
+func (policy *ResultPolicy) isPassing(finishedRuns map[string]*TestRun, lostRuns map[string]*TestRun) bool {
+	isPassing := CountTotalFailedRuns(finishedRuns, lostRuns) == 0
+	if !isPassing && policy.MinPassRate != 0 {
+		_, _, passRate := getPassRate(finishedRuns, lostRuns)
+		isPassing = passRate >= policy.MinPassRate
+	}
+	return isPassing
+}
+
+// getPassRate - How many of the counted test runs passed, how many were counted, and the percentage which passed.
+// Quarantined and excused test runs are not counted.
+func getPassRate(finishedRuns map[string]*TestRun, lostRuns map[string]*TestRun) (int, int, float64) {
+	countedRuns := len(finishedRuns) + len(lostRuns) - CountTotalQuarantinedRuns(finishedRuns, lostRuns) - CountTotalExcusedRuns(finishedRuns, lostRuns)
+	passedCount := countedRuns - CountTotalFailedRuns(finishedRuns, lostRuns)
+	passRate := float64(passedCount) * 100 / float64(countedRuns)
+	return passedCount, countedRuns, passRate
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRunForResultPolicyTests(name string, className string, result string) *TestRun {
+	return &TestRun{
+		Name:   name,
+		Bundle: "myBundle",
+		Class:  className,
+		Stream: "myStream",
+		Status: "finished",
+		Result: result,
+	}
+}
+
+func TestNewResultPolicyReadsFileAndAddsFlags(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockFileSystem.WriteTextFile("policy.yaml", `apiVersion: galasa-dev/v1alpha1
+kind: galasa.dev/testResultPolicy
+excusedResults:
+- EnvFail
+minPassRate: 90
+quarantine:
+- myBundle/myFlakyClass
+`)
+	params := utils.RunsSubmitCmdValues{
+		ResultPolicyFileName: "policy.yaml",
+		ExcusedResults:       []string{"Ignored"},
+		Quarantine:           []string{"myOtherBundle/*"},
+		MinPassRate:          75,
+	}
+
+	// When...
+	policy, err := NewResultPolicy(mockFileSystem, params)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"EnvFail", "Ignored"}, policy.ExcusedResults)
+	assert.Equal(t, []string{"myBundle/myFlakyClass", "myOtherBundle/*"}, policy.Quarantine)
+	assert.Equal(t, float64(75), policy.MinPassRate)
+}
+
+func TestNewResultPolicyWithoutAFileUsesTheFlags(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	params := utils.RunsSubmitCmdValues{
+		Quarantine: []string{"myBundle/myClass*"},
+	}
+
+	// When...
+	policy, err := NewResultPolicy(mockFileSystem, params)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Empty(t, policy.ExcusedResults)
+	assert.Equal(t, []string{"myBundle/myClass*"}, policy.Quarantine)
+	assert.Equal(t, float64(0), policy.MinPassRate)
+}
+
+func TestNewResultPolicyWithMissingFileFails(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	params := utils.RunsSubmitCmdValues{ResultPolicyFileName: "missing.yaml"}
+
+	// When...
+	_, err := NewResultPolicy(mockFileSystem, params)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1276E")
+}
+
+func TestNewResultPolicyWithWrongKindFails(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockFileSystem.WriteTextFile("policy.yaml", `apiVersion: galasa-dev/v1alpha1
+kind: galasa.dev/testPortfolio
+`)
+	params := utils.RunsSubmitCmdValues{ResultPolicyFileName: "policy.yaml"}
+
+	// When...
+	_, err := NewResultPolicy(mockFileSystem, params)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1278E")
+}
+
+func TestNewResultPolicyWithBadMinPassRateFails(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	params := utils.RunsSubmitCmdValues{MinPassRate: 101}
+
+	// When...
+	_, err := NewResultPolicy(mockFileSystem, params)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1279E")
+}
+
+func TestNewResultPolicyWithQuarantinePatternWithoutBundleFails(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	params := utils.RunsSubmitCmdValues{Quarantine: []string{"myClass"}}
+
+	// When...
+	_, err := NewResultPolicy(mockFileSystem, params)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1280E: Invalid quarantine pattern 'myClass'.")
+}
+
+func TestExcuseRunsPrefersQuarantineToExcusedResults(t *testing.T) {
+	// Given...
+	policy := &ResultPolicy{
+		ExcusedResults: []string{"envfail"},
+		Quarantine:     []string{"myBundle/myFlaky*"},
+	}
+	finishedRuns := map[string]*TestRun{
+		"U1": newRunForResultPolicyTests("U1", "myFlakyClass", RESULT_ENVFAIL),
+		"U2": newRunForResultPolicyTests("U2", "myClass", RESULT_ENVFAIL),
+		"U3": newRunForResultPolicyTests("U3", "myClass", RESULT_FAILED),
+		"U4": newRunForResultPolicyTests("U4", "myFlakyClass2", RESULT_PASSED),
+	}
+	lostRuns := map[string]*TestRun{
+		"U5": newRunForResultPolicyTests("U5", "myFlakyClass3", ""),
+	}
+
+	// When...
+	policy.ExcuseRuns(finishedRuns, lostRuns)
+
+	// Then...
+	assert.Equal(t, EXCUSED_BY_QUARANTINE, finishedRuns["U1"].ExcusedBy)
+	assert.Equal(t, EXCUSED_BY_POLICY, finishedRuns["U2"].ExcusedBy)
+	assert.Empty(t, finishedRuns["U3"].ExcusedBy)
+	assert.Empty(t, finishedRuns["U4"].ExcusedBy)
+	assert.Equal(t, EXCUSED_BY_QUARANTINE, lostRuns["U5"].ExcusedBy)
+	assert.Equal(t, 1, CountTotalFailedRuns(finishedRuns, lostRuns))
+}
+
+func TestCheckResultsWithoutMinPassRateFailsOnAnyFailure(t *testing.T) {
+	// Given...
+	policy := new(ResultPolicy)
+	finishedRuns := map[string]*TestRun{
+		"U1": newRunForResultPolicyTests("U1", "myClass1", RESULT_PASSED),
+		"U2": newRunForResultPolicyTests("U2", "myClass2", RESULT_FAILED),
+	}
+	lostRuns := make(map[string]*TestRun)
+	policy.ExcuseRuns(finishedRuns, lostRuns)
+
+	// When...
+	err := policy.CheckResults(finishedRuns, lostRuns)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1017E: Not all runs passed. 1 failed.")
+}
+
+func TestCheckResultsBelowMinPassRateFails(t *testing.T) {
+	// Given...
+	policy := &ResultPolicy{MinPassRate: 75, Quarantine: []string{"myBundle/myClass4"}}
+	finishedRuns := map[string]*TestRun{
+		"U1": newRunForResultPolicyTests("U1", "myClass1", RESULT_PASSED),
+		"U2": newRunForResultPolicyTests("U2", "myClass2", RESULT_PASSED),
+		"U3": newRunForResultPolicyTests("U3", "myClass3", RESULT_FAILED),
+		"U4": newRunForResultPolicyTests("U4", "myClass4", RESULT_FAILED),
+	}
+	lostRuns := make(map[string]*TestRun)
+	policy.ExcuseRuns(finishedRuns, lostRuns)
+
+	// When...
+	err := policy.CheckResults(finishedRuns, lostRuns)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1281E: Not enough runs passed. The pass rate was 66.7%, below the minimum of 75%. 2 of 3 counted runs passed.")
+}
+
+func TestCheckResultsAtMinPassRatePasses(t *testing.T) {
+	// Given...
+	policy := &ResultPolicy{MinPassRate: 75}
+	finishedRuns := map[string]*TestRun{
+		"U1": newRunForResultPolicyTests("U1", "myClass1", RESULT_PASSED),
+		"U2": newRunForResultPolicyTests("U2", "myClass2", RESULT_PASSED),
+		"U3": newRunForResultPolicyTests("U3", "myClass3", RESULT_PASSED),
+		"U4": newRunForResultPolicyTests("U4", "myClass4", RESULT_FAILED),
+	}
+	lostRuns := make(map[string]*TestRun)
+	policy.ExcuseRuns(finishedRuns, lostRuns)
+
+	// When...
+	err := policy.CheckResults(finishedRuns, lostRuns)
+
+	// Then...
+	assert.Nil(t, err)
+}
+
+func TestSubmitWithFailedQuarantinedClassPasses(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createThreeClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass2", RESULT_FAILED)
+	submitter, _ := newSubmitterForStopPolicyTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+		Throttle:           3,
+		Quarantine:         []string{"myBundle/myClass2"},
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+
+	report := readTestReport(t, mockFileSystem)
+	quarantinedTest := getReportedTestByClass(report, "myClass2")
+	assert.Equal(t, RESULT_FAILED, quarantinedTest.Result)
+	assert.Equal(t, EXCUSED_BY_QUARANTINE, quarantinedTest.ExcusedBy)
+	assert.Empty(t, getReportedTestByClass(report, "myClass1").ExcusedBy)
+}
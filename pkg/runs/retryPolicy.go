@@ -0,0 +1,103 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"strings"
+	"time"
+)
+
+// RetryPolicy - Decides whether a test which has finished should be submitted again.
+// A test class is attempted at most maxAttempts times, and is only re-submitted
+// if the last attempt finished with one of the retryable results.
+type RetryPolicy struct {
+	maxAttempts      int
+	retryableResults map[string]struct{}
+	backoff          time.Duration
+}
+
+func NewRetryPolicy(maxAttempts int, retryableResults []string, backoffSeconds int) *RetryPolicy {
+	policy := new(RetryPolicy)
+
+	if maxAttempts < 1 {
+		maxAttempts = DEFAULT_MAX_ATTEMPTS
+	}
+	policy.maxAttempts = maxAttempts
+
+	// Results are matched without regard to case, so 'envfail' matches 'EnvFail'
+	policy.retryableResults = make(map[string]struct{})
+	for _, result := range retryableResults {
+		result = strings.TrimSpace(result)
+		if result != "" {
+			policy.retryableResults[strings.ToLower(result)] = struct{}{}
+		}
+	}
+
+	if backoffSeconds < 0 {
+		backoffSeconds = DEFAULT_RETRY_BACKOFF_SECONDS
+	}
+	policy.backoff = time.Second * time.Duration(backoffSeconds)
+
+	return policy
+}
+
+// IsRetryNeeded - Should the finished test run be submitted again ?
+func (policy *RetryPolicy) IsRetryNeeded(run *TestRun) bool {
+	isRetryNeeded := false
+
+	attemptsSoFar := len(run.PreviousAttempts) + 1
+	if attemptsSoFar < policy.GetMaxAttemptsOf(run) {
+		_, isRetryNeeded = policy.retryableResults[strings.ToLower(run.Result)]
+	}
+	return isRetryNeeded
+}
+
+// GetMaxAttempts - The most times any test class will be attempted, unless its portfolio class says otherwise.
+func (policy *RetryPolicy) GetMaxAttempts() int {
+	return policy.maxAttempts
+}
+
+// GetMaxAttemptsOf - The most times a particular test will be attempted. The portfolio can set this
+// for each class, in place of the maximum for all of them.
+func (policy *RetryPolicy) GetMaxAttemptsOf(run *TestRun) int {
+	maxAttempts := policy.maxAttempts
+	if run.MaxAttempts > 0 {
+		maxAttempts = run.MaxAttempts
+	}
+	return maxAttempts
+}
+
+// GetBackoff - How long to wait after an attempt finishes before submitting the next one.
+func (policy *RetryPolicy) GetBackoff() time.Duration {
+	return policy.backoff
+}
+
+// newRetryAttempt - Creates the next attempt of a finished test run, ready to be submitted.
+// The finished run is added to the history of previous attempts which the new run carries with it.
+func newRetryAttempt(finishedRun *TestRun, queuedTime time.Time, notBefore time.Time) TestRun {
+	nextRun := *finishedRun
+
+	nextRun.PreviousAttempts = make([]TestRunAttempt, 0, len(finishedRun.PreviousAttempts)+1)
+	nextRun.PreviousAttempts = append(nextRun.PreviousAttempts, finishedRun.PreviousAttempts...)
+	nextRun.PreviousAttempts = append(nextRun.PreviousAttempts, TestRunAttempt{
+		Name:          finishedRun.Name,
+		Status:        finishedRun.Status,
+		QueuedTimeUTC: finishedRun.QueuedTimeUTC,
+		Result:        finishedRun.Result,
+		Tests:         finishedRun.Tests,
+	})
+
+	nextRun.Name = ""
+	nextRun.Status = "queued"
+	nextRun.Result = ""
+	nextRun.Tests = nil
+	nextRun.QueuedTimeUTC = queuedTime.String()
+	nextRun.NextAttemptNotBefore = notBefore
+	nextRun.SubmittedTime = time.Time{}
+	nextRun.CancelledBy = ""
+
+	return nextRun
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyWithDefaultsNeverRetries(t *testing.T) {
+	// Given...
+	policy := NewRetryPolicy(DEFAULT_MAX_ATTEMPTS, DEFAULT_RETRY_RESULTS, DEFAULT_RETRY_BACKOFF_SECONDS)
+	run := &TestRun{Name: "U100", Result: RESULT_ENVFAIL}
+
+	// When...
+	isRetryNeeded := policy.IsRetryNeeded(run)
+
+	// Then...
+	assert.False(t, isRetryNeeded)
+}
+
+func TestRetryPolicyRetriesRetryableResultWhenAttemptsRemain(t *testing.T) {
+	// Given...
+	policy := NewRetryPolicy(3, []string{RESULT_ENVFAIL}, 0)
+	run := &TestRun{Name: "U100", Result: RESULT_ENVFAIL}
+
+	// When...
+	isRetryNeeded := policy.IsRetryNeeded(run)
+
+	// Then...
+	assert.True(t, isRetryNeeded)
+}
+
+func TestRetryPolicyMatchesResultsIgnoringCase(t *testing.T) {
+	// Given...
+	policy := NewRetryPolicy(2, []string{" envfail "}, 0)
+	run := &TestRun{Name: "U100", Result: "EnvFail"}
+
+	// When...
+	isRetryNeeded := policy.IsRetryNeeded(run)
+
+	// Then...
+	assert.True(t, isRetryNeeded)
+}
+
+func TestRetryPolicyDoesNotRetryNonRetryableResult(t *testing.T) {
+	// Given...
+	policy := NewRetryPolicy(3, []string{RESULT_ENVFAIL}, 0)
+	run := &TestRun{Name: "U100", Result: RESULT_FAILED}
+
+	// When...
+	isRetryNeeded := policy.IsRetryNeeded(run)
+
+	// Then...
+	assert.False(t, isRetryNeeded)
+}
+
+func TestRetryPolicyDoesNotRetryOnceMaxAttemptsUsedUp(t *testing.T) {
+	// Given...
+	policy := NewRetryPolicy(2, []string{RESULT_ENVFAIL}, 0)
+	run := &TestRun{
+		Name:             "U101",
+		Result:           RESULT_ENVFAIL,
+		PreviousAttempts: []TestRunAttempt{{Name: "U100", Result: RESULT_ENVFAIL}},
+	}
+
+	// When...
+	isRetryNeeded := policy.IsRetryNeeded(run)
+
+	// Then...
+	assert.False(t, isRetryNeeded)
+}
+
+func TestRetryPolicyCorrectsInvalidValues(t *testing.T) {
+	// Given...
+	// When...
+	policy := NewRetryPolicy(0, nil, -5)
+
+	// Then...
+	assert.Equal(t, DEFAULT_MAX_ATTEMPTS, policy.GetMaxAttempts())
+	assert.Equal(t, time.Duration(0), policy.GetBackoff())
+}
+
+func TestNewRetryAttemptRecordsTheFinishedAttempt(t *testing.T) {
+	// Given...
+	finishedRun := &TestRun{
+		Name:          "U100",
+		Bundle:        "myBundle",
+		Class:         "myBundle/myClass",
+		Stream:        "myStream",
+		Status:        "finished",
+		Result:        RESULT_ENVFAIL,
+		QueuedTimeUTC: "2024-01-01 10:00:00",
+		Tests:         []TestMethod{{Method: "testA", Result: RESULT_ENVFAIL}},
+	}
+	queuedTime := time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC)
+	notBefore := queuedTime.Add(time.Minute)
+
+	// When...
+	nextRun := newRetryAttempt(finishedRun, queuedTime, notBefore)
+
+	// Then...
+	assert.Equal(t, "", nextRun.Name)
+	assert.Equal(t, "queued", nextRun.Status)
+	assert.Equal(t, "", nextRun.Result)
+	assert.Nil(t, nextRun.Tests)
+	assert.Equal(t, "myBundle/myClass", nextRun.Class)
+	assert.Equal(t, "myStream", nextRun.Stream)
+	assert.Equal(t, notBefore, nextRun.NextAttemptNotBefore)
+
+	assert.Equal(t, 1, len(nextRun.PreviousAttempts))
+	assert.Equal(t, "U100", nextRun.PreviousAttempts[0].Name)
+	assert.Equal(t, RESULT_ENVFAIL, nextRun.PreviousAttempts[0].Result)
+	assert.Equal(t, "2024-01-01 10:00:00", nextRun.PreviousAttempts[0].QueuedTimeUTC)
+	assert.Equal(t, 1, len(nextRun.PreviousAttempts[0].Tests))
+
+	// The finished run is left alone.
+	assert.Empty(t, finishedRun.PreviousAttempts)
+}
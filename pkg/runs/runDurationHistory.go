@@ -0,0 +1,242 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/embedded"
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+const (
+	// How far back the history of a test class is looked at, to see how long it takes to run.
+	RUN_DURATION_HISTORY_DAYS = 30
+
+	// The most recent runs of a test class which are looked at, to see how long it takes to run.
+	RUN_DURATION_HISTORY_MAX_RUNS = 20
+)
+
+// RunDurationHistory - How long test classes have taken to run in the past.
+type RunDurationHistory interface {
+	// GetAverageRunDuration - The average time the test class took to run.
+	// isKnown is false if the test class has no finished runs to go by.
+	GetAverageRunDuration(bundle string, className string) (duration time.Duration, isKnown bool, err error)
+}
+
+// RemoteRunDurationHistory - Gets the history of test classes from the result archive store
+// of the Galasa ecosystem.
+type RemoteRunDurationHistory struct {
+	apiClient   *galasaapi.APIClient
+	timeService spi.TimeService
+}
+
+func NewRemoteRunDurationHistory(apiClient *galasaapi.APIClient, timeService spi.TimeService) RunDurationHistory {
+	instance := new(RemoteRunDurationHistory)
+	instance.apiClient = apiClient
+	instance.timeService = timeService
+	return instance
+}
+
+// GetAverageRunDuration - Averages the time the most recent runs of the test class took,
+// between the start and end of each run.
+//
+// Only runs which finished before midnight (UTC) at the start of today are looked at, so that
+// every invocation of 'runs submit' on the same day sees the same history, even while some of
+// them are running tests of their own.
+func (history *RemoteRunDurationHistory) GetAverageRunDuration(bundle string, className string) (time.Duration, bool, error) {
+	var err error
+	var averageDuration time.Duration
+	var isKnown bool
+	var restApiVersion string
+	var runData *galasaapi.RunResults
+	var httpResponse *http.Response
+	var context context.Context = nil
+
+	now := history.timeService.Now().UTC()
+	toTime := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	fromTime := toTime.AddDate(0, 0, -RUN_DURATION_HISTORY_DAYS)
+
+	restApiVersion, err = embedded.GetGalasactlRestApiVersion()
+	if err == nil {
+		runData, httpResponse, err = history.apiClient.ResultArchiveStoreAPIApi.GetRasSearchRuns(context).
+			ClientApiVersion(restApiVersion).
+			Bundle(bundle).
+			Testname(className).
+			Status("finished").
+			From(fromTime).
+			To(toTime).
+			Sort("to:desc").
+			Size(strconv.Itoa(RUN_DURATION_HISTORY_MAX_RUNS)).
+			Execute()
+
+		var statusCode int
+		if httpResponse != nil {
+			defer httpResponse.Body.Close()
+			statusCode = httpResponse.StatusCode
+		}
+
+		if err != nil {
+			err = galasaErrors.NewGalasaErrorWithHttpStatusCode(statusCode, galasaErrors.GALASA_ERROR_QUERY_RUN_HISTORY_FAILED, bundle+"/"+className, err.Error())
+		} else if statusCode != http.StatusOK {
+			err = galasaErrors.NewGalasaErrorWithHttpStatusCode(statusCode, galasaErrors.GALASA_ERROR_QUERY_RUN_HISTORY_FAILED, bundle+"/"+className,
+				"http response status code: "+strconv.Itoa(statusCode))
+		} else {
+			averageDuration, isKnown = getAverageDurationOfRuns(runData.GetRuns())
+			log.Printf("Test class %v/%v has taken %v on average. Known: %v\n", bundle, className, averageDuration, isKnown)
+		}
+	}
+
+	return averageDuration, isKnown, err
+}
+
+// getAverageDurationOfRuns - Runs without both a start and an end time are left out.
+func getAverageDurationOfRuns(runs []galasaapi.Run) (time.Duration, bool) {
+	var totalDuration time.Duration
+	runCount := 0
+
+	for _, run := range runs {
+		testStructure := run.GetTestStructure()
+		startTime, startErr := time.Parse(time.RFC3339, testStructure.GetStartTime())
+		endTime, endErr := time.Parse(time.RFC3339, testStructure.GetEndTime())
+		if startErr == nil && endErr == nil && !endTime.Before(startTime) {
+			totalDuration += endTime.Sub(startTime)
+			runCount++
+		}
+	}
+
+	var averageDuration time.Duration
+	if runCount > 0 {
+		averageDuration = totalDuration / time.Duration(runCount)
+	}
+	return averageDuration, runCount > 0
+}
+
+// RunDurationCacheEntry - How long a test class took to run on average, as recorded in the cache.
+type RunDurationCacheEntry struct {
+	DurationMillis int64  `json:"durationMillis"`
+	IsKnown        bool   `json:"known"`
+	FetchedTimeUTC string `json:"fetched"`
+}
+
+// CachedRunDurationHistory - Keeps the history of each test class in a file under the galasa home
+// folder, so that it is only fetched from the Galasa ecosystem once a day.
+type CachedRunDurationHistory struct {
+	fileSystem  spi.FileSystem
+	timeService spi.TimeService
+	history     RunDurationHistory
+
+	cacheFilePath string
+	entries       map[string]RunDurationCacheEntry
+}
+
+// NewCachedRunDurationHistory - Each Galasa ecosystem has a cache file of its own, as the same test
+// class can take different times to run on different ecosystems.
+func NewCachedRunDurationHistory(
+	fileSystem spi.FileSystem,
+	galasaHome spi.GalasaHome,
+	timeService spi.TimeService,
+	apiServerUrl string,
+	history RunDurationHistory,
+) RunDurationHistory {
+	instance := new(CachedRunDurationHistory)
+	instance.fileSystem = fileSystem
+	instance.timeService = timeService
+	instance.history = history
+
+	cacheFileName := url.QueryEscape(strings.TrimPrefix(strings.TrimPrefix(apiServerUrl, "https://"), "http://")) + ".json"
+	instance.cacheFilePath = filepath.Join(getRunDurationCacheFolderPath(galasaHome), cacheFileName)
+	return instance
+}
+
+func getRunDurationCacheFolderPath(galasaHome spi.GalasaHome) string {
+	return filepath.Join(galasaHome.GetNativeFolderPath(), "cache", "run-durations")
+}
+
+// GetAverageRunDuration - Uses the cached history of the test class if it was fetched today,
+// otherwise fetches it again and caches it. A cache file which can't be read or written is
+// ignored, as the history can always be fetched again.
+func (cache *CachedRunDurationHistory) GetAverageRunDuration(bundle string, className string) (time.Duration, bool, error) {
+	var err error
+	var duration time.Duration
+	var isKnown bool
+
+	if cache.entries == nil {
+		cache.entries = cache.readCacheFile()
+	}
+
+	key := bundle + "/" + className
+	entry, isCached := cache.entries[key]
+	if isCached && cache.isFetchedToday(entry) {
+		duration = time.Duration(entry.DurationMillis) * time.Millisecond
+		isKnown = entry.IsKnown
+	} else {
+		duration, isKnown, err = cache.history.GetAverageRunDuration(bundle, className)
+		if err == nil {
+			cache.entries[key] = RunDurationCacheEntry{
+				DurationMillis: duration.Milliseconds(),
+				IsKnown:        isKnown,
+				FetchedTimeUTC: cache.timeService.Now().UTC().Format(time.RFC3339),
+			}
+			cache.writeCacheFile()
+		}
+	}
+	return duration, isKnown, err
+}
+
+// isFetchedToday - The history fetched from the ecosystem only changes at midnight (UTC).
+func (cache *CachedRunDurationHistory) isFetchedToday(entry RunDurationCacheEntry) bool {
+	isFetchedToday := false
+	fetchedTime, err := time.Parse(time.RFC3339, entry.FetchedTimeUTC)
+	if err == nil {
+		now := cache.timeService.Now().UTC()
+		fetchedTime = fetchedTime.UTC()
+		isFetchedToday = fetchedTime.Year() == now.Year() && fetchedTime.YearDay() == now.YearDay()
+	}
+	return isFetchedToday
+}
+
+func (cache *CachedRunDurationHistory) readCacheFile() map[string]RunDurationCacheEntry {
+	entries := make(map[string]RunDurationCacheEntry)
+
+	isExists, err := cache.fileSystem.Exists(cache.cacheFilePath)
+	if err == nil && isExists {
+		var contents string
+		contents, err = cache.fileSystem.ReadTextFile(cache.cacheFilePath)
+		if err == nil {
+			err = json.Unmarshal([]byte(contents), &entries)
+		}
+		if err != nil {
+			log.Printf("Could not read the run duration cache file '%s'. Ignoring it. Reason: %s\n", cache.cacheFilePath, err.Error())
+			entries = make(map[string]RunDurationCacheEntry)
+		}
+	}
+	return entries
+}
+
+func (cache *CachedRunDurationHistory) writeCacheFile() {
+	err := cache.fileSystem.MkdirAll(filepath.Dir(cache.cacheFilePath))
+	if err == nil {
+		var contents []byte
+		contents, err = json.MarshalIndent(cache.entries, "", "  ")
+		if err == nil {
+			err = cache.fileSystem.WriteBinaryFile(cache.cacheFilePath, contents)
+		}
+	}
+	if err != nil {
+		log.Printf("Could not write the run duration cache file '%s'. Reason: %s\n", cache.cacheFilePath, err.Error())
+	}
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/api"
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRunHistoryServletMock(t *testing.T, status int, runsJson string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ras/runs", r.URL.Path)
+
+		values := r.URL.Query()
+		assert.Equal(t, "myBundle", values.Get("bundle"))
+		assert.Equal(t, "myClass", values.Get("testname"))
+
+		// The history stops at the midnight before the time the mock time service gives,
+		// so that every shard asking on the same day gets the same answer.
+		assert.Contains(t, values.Get("from"), "2024-05-01T00:00:00")
+		assert.Contains(t, values.Get("to"), "2024-05-31T00:00:00")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{ "pageSize": 20, "amountOfRuns": 2, "runs": [` + runsJson + `] }`))
+	}))
+	return server
+}
+
+func newRunHistoryTimeService() *utils.MockTimeService {
+	return utils.NewMockTimeServiceAsMock(time.Date(2024, time.May, 31, 15, 30, 0, 0, time.UTC))
+}
+
+func TestRunDurationHistoryAveragesTheDurationOfPreviousRuns(t *testing.T) {
+	// Given...
+	server := newRunHistoryServletMock(t, http.StatusOK, `
+		{ "runId": "run1", "testStructure": { "startTime": "2024-05-10T06:00:00Z", "endTime": "2024-05-10T06:10:00Z" } },
+		{ "runId": "run2", "testStructure": { "startTime": "2024-05-11T06:00:00Z", "endTime": "2024-05-11T06:30:00Z" } },
+		{ "runId": "run3", "testStructure": { "startTime": "2024-05-12T06:00:00Z" } }`)
+	defer server.Close()
+
+	history := NewRemoteRunDurationHistory(api.InitialiseAPI(server.URL), newRunHistoryTimeService())
+
+	// When...
+	duration, isKnown, err := history.GetAverageRunDuration("myBundle", "myClass")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.True(t, isKnown)
+	assert.Equal(t, time.Minute*20, duration)
+}
+
+func TestRunDurationHistoryOfClassWithNoPreviousRunsIsNotKnown(t *testing.T) {
+	// Given...
+	server := newRunHistoryServletMock(t, http.StatusOK, "")
+	defer server.Close()
+
+	history := NewRemoteRunDurationHistory(api.InitialiseAPI(server.URL), newRunHistoryTimeService())
+
+	// When...
+	_, isKnown, err := history.GetAverageRunDuration("myBundle", "myClass")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.False(t, isKnown)
+}
+
+func TestRunDurationHistoryWhenServerFailsReturnsError(t *testing.T) {
+	// Given...
+	server := newRunHistoryServletMock(t, http.StatusInternalServerError, "")
+	defer server.Close()
+
+	history := NewRemoteRunDurationHistory(api.InitialiseAPI(server.URL), newRunHistoryTimeService())
+
+	// When...
+	_, _, err := history.GetAverageRunDuration("myBundle", "myClass")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1266E")
+	assert.Contains(t, err.Error(), "myBundle/myClass")
+}
+
+// A run duration history which counts how many times it is asked.
+type countingRunDurationHistory struct {
+	mockRunDurationHistory
+	lookups int
+}
+
+func (history *countingRunDurationHistory) GetAverageRunDuration(bundle string, className string) (time.Duration, bool, error) {
+	history.lookups++
+	return history.mockRunDurationHistory.GetAverageRunDuration(bundle, className)
+}
+
+func newRunDurationCacheForTests(t *testing.T, fileSystem spi.FileSystem, timeService spi.TimeService, history RunDurationHistory) RunDurationHistory {
+	galasaHome, err := utils.NewGalasaHome(fileSystem, utils.NewMockEnv(), "")
+	assert.Nil(t, err)
+	return NewCachedRunDurationHistory(fileSystem, galasaHome, timeService, "https://my.ecosystem/api", history)
+}
+
+func TestCachedRunDurationHistoryOnlyFetchesEachClassOnceADay(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	timeService := newRunHistoryTimeService()
+	remoteHistory := &countingRunDurationHistory{mockRunDurationHistory: mockRunDurationHistory{"myBundle/myClass": time.Minute * 7}}
+
+	firstDuration, _, err := newRunDurationCacheForTests(t, fileSystem, timeService, remoteHistory).GetAverageRunDuration("myBundle", "myClass")
+	assert.Nil(t, err)
+
+	// When...
+	timeService.AdvanceClock(time.Hour * 3)
+	secondDuration, isKnown, err := newRunDurationCacheForTests(t, fileSystem, timeService, remoteHistory).GetAverageRunDuration("myBundle", "myClass")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.True(t, isKnown)
+	assert.Equal(t, time.Minute*7, firstDuration)
+	assert.Equal(t, time.Minute*7, secondDuration)
+	assert.Equal(t, 1, remoteHistory.lookups)
+}
+
+func TestCachedRunDurationHistoryFetchesAgainTheNextDay(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	timeService := newRunHistoryTimeService()
+	remoteHistory := &countingRunDurationHistory{mockRunDurationHistory: mockRunDurationHistory{"myBundle/myClass": time.Minute * 7}}
+
+	_, _, err := newRunDurationCacheForTests(t, fileSystem, timeService, remoteHistory).GetAverageRunDuration("myBundle", "myClass")
+	assert.Nil(t, err)
+
+	// When...
+	timeService.AdvanceClock(time.Hour * 12)
+	_, _, err = newRunDurationCacheForTests(t, fileSystem, timeService, remoteHistory).GetAverageRunDuration("myBundle", "myClass")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 2, remoteHistory.lookups)
+}
+
+func TestCachedRunDurationHistoryIgnoresACorruptCacheFile(t *testing.T) {
+	// Given...
+	fileSystem := files.NewMockFileSystem()
+	timeService := newRunHistoryTimeService()
+	remoteHistory := &countingRunDurationHistory{mockRunDurationHistory: mockRunDurationHistory{}}
+	cache := newRunDurationCacheForTests(t, fileSystem, timeService, remoteHistory)
+
+	err := fileSystem.WriteTextFile(cache.(*CachedRunDurationHistory).cacheFilePath, "not json")
+	assert.Nil(t, err)
+
+	// When...
+	_, isKnown, err := cache.GetAverageRunDuration("myBundle", "myClass")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.False(t, isKnown)
+	assert.Equal(t, 1, remoteHistory.lookups)
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/embedded"
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+)
+
+// RunLogFetcher - Gets the run log of a test run from the result archive store.
+type RunLogFetcher interface {
+	GetRunLog(rasRunId string) (string, error)
+}
+
+type remoteRunLogFetcher struct {
+	apiClient *galasaapi.APIClient
+}
+
+// NewRemoteRunLogFetcher - Gets run logs from the result archive store of the Galasa ecosystem.
+func NewRemoteRunLogFetcher(apiClient *galasaapi.APIClient) RunLogFetcher {
+	instance := new(remoteRunLogFetcher)
+	instance.apiClient = apiClient
+	return instance
+}
+
+func (fetcher *remoteRunLogFetcher) GetRunLog(rasRunId string) (string, error) {
+	var err error
+	var runLog string
+	var restApiVersion string
+	var httpResponse *http.Response
+	var context context.Context = nil
+
+	restApiVersion, err = embedded.GetGalasactlRestApiVersion()
+	if err == nil {
+		runLog, httpResponse, err = fetcher.apiClient.ResultArchiveStoreAPIApi.GetRasRunLog(context, rasRunId).
+			ClientApiVersion(restApiVersion).
+			Execute()
+
+		var statusCode int
+		if httpResponse != nil {
+			defer httpResponse.Body.Close()
+			statusCode = httpResponse.StatusCode
+		}
+
+		if err != nil {
+			err = galasaErrors.NewGalasaErrorWithHttpStatusCode(statusCode, galasaErrors.GALASA_ERROR_QUERY_RUN_LOG_FAILED, rasRunId, err.Error())
+		} else if statusCode != http.StatusOK {
+			err = galasaErrors.NewGalasaErrorWithHttpStatusCode(statusCode, galasaErrors.GALASA_ERROR_QUERY_RUN_LOG_FAILED, rasRunId,
+				"http response status code: "+strconv.Itoa(statusCode))
+		}
+	}
+	return runLog, err
+}
+
+// getRunLogTail - The last few lines of a run log, which is usually where the reason a test failed can be found.
+func getRunLogTail(runLog string, lineCount int) string {
+	lines := strings.Split(strings.TrimRight(runLog, "\n"), "\n")
+	if len(lines) > lineCount {
+		lines = lines[len(lines)-lineCount:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -5,6 +5,8 @@
  */
 package runs
 
+import "time"
+
 type TestRun struct {
 	Name           string            `yaml:"name" json:"name"`
 	Bundle         string            `yaml:"bundle" json:"bundle"`
@@ -20,11 +22,72 @@ type TestRun struct {
 	GherkinUrl     string            `yaml:"gherkin"`
 	GherkinFeature string            `yaml:"feature"`
 	Group          string            `yaml:"group" json:"group"`
+
+	// Where the run can be found in the result archive store, and when it ran, when the reports asked for them.
+	RasRunId     string `yaml:"runId,omitempty" json:"runId,omitempty"`
+	StartTimeUTC string `yaml:"startTime,omitempty" json:"startTime,omitempty"`
+	EndTimeUTC   string `yaml:"endTime,omitempty" json:"endTime,omitempty"`
+
+	// The portfolio matrix combination whose overrides this test run uses, when the portfolio has a matrix.
+	Combination string `yaml:"combination,omitempty" json:"combination,omitempty"`
+
+	// Earlier attempts at running this test, oldest first, when the retry policy caused it to be re-submitted.
+	PreviousAttempts []TestRunAttempt `yaml:"previousAttempts,omitempty" json:"previousAttempts,omitempty"`
+
+	// Why the test run was cancelled, or never submitted. One of the STOP_REASON_ values when the
+	// submission stopped early, or one of the CANCELLED_BY_ values.
+	CancelledBy string `yaml:"cancelledBy,omitempty" json:"cancelledBy,omitempty"`
+
+	// Why the test run is not counted as a failure, although it did not pass. One of the EXCUSED_BY_ values, or empty.
+	ExcusedBy string `yaml:"excusedBy,omitempty" json:"excusedBy,omitempty"`
+
+	// Labels which the portfolio gave the test class.
+	Labels []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	// Scheduling asked for by a v1beta portfolio. See PortfolioClassScheduling.
+	MaxAttempts int      `yaml:"maxAttempts,omitempty" json:"maxAttempts,omitempty"`
+	Timeout     string   `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	DependsOn   []string `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty"`
+
+	// When the retry policy delays a re-submission, the earliest time the next attempt may be submitted.
+	// Kept in the journal, so a resumed submission still waits for it.
+	NextAttemptNotBefore time.Time `yaml:"nextAttemptNotBefore,omitempty" json:"-"`
+
+	// When this attempt was submitted, or first seen after resuming a submission, to check it against its timeout.
+	// Kept in the journal, so a resumed submission doesn't restart the clock.
+	SubmittedTime time.Time `yaml:"submittedTime,omitempty" json:"-"`
+}
+
+// TestRunAttempt - The outcome of one attempt at running a test, which was later re-submitted.
+type TestRunAttempt struct {
+	Name          string       `yaml:"name" json:"name"`
+	Status        string       `yaml:"status" json:"status"`
+	QueuedTimeUTC string       `yaml:"queued" json:"queued"`
+	Result        string       `yaml:"result" json:"result"`
+	Tests         []TestMethod `yaml:"tests" json:"tests"`
 }
 
 type TestMethod struct {
-	Method string `yaml:"name" json:"name"`
-	Result string `yaml:"result" json:"result"`
+	Method       string `yaml:"name" json:"name"`
+	Result       string `yaml:"result" json:"result"`
+	Type         string `yaml:"type,omitempty" json:"type,omitempty"`
+	Status       string `yaml:"status,omitempty" json:"status,omitempty"`
+	StartTimeUTC string `yaml:"startTime,omitempty" json:"startTime,omitempty"`
+	EndTimeUTC   string `yaml:"endTime,omitempty" json:"endTime,omitempty"`
+}
+
+// getTestKey - Identifies a test which may not have a run name yet, as bundle/class. A class is in a
+// portfolio with a matrix more than once, so the combination is added in square brackets.
+func (run *TestRun) getTestKey() string {
+	return run.Bundle + "/" + run.Class + getCombinationSuffix(run)
+}
+
+func getCombinationSuffix(run *TestRun) string {
+	suffix := ""
+	if run.Combination != "" {
+		suffix = "[" + run.Combination + "]"
+	}
+	return suffix
 }
 
 func DeepClone(original map[string]*TestRun) map[string]*TestRun {
@@ -41,4 +104,25 @@ const (
 	MAX_INT                                  int = int(^uint(0) >> 1)
 	DEFAULT_PROGRESS_REPORT_INTERVAL_MINUTES int = 5
 	DEFAULT_THROTTLE_TESTS_AT_ONCE           int = 3
+	DEFAULT_MAX_ATTEMPTS                     int = 1
+	DEFAULT_RETRY_BACKOFF_SECONDS            int = 0
+	DEFAULT_INTERRUPT_WAIT_SECONDS           int = 60
+)
+
+// Reasons for stopping a submission before all its tests have finished.
+const (
+	STOP_REASON_INTERRUPT = "interrupt"
+	STOP_REASON_FAIL_FAST = "fail-fast"
+	STOP_REASON_TIMEOUT   = "timeout"
 )
+
+// Given to test runs which a 'runs control cancel' command cancelled.
+const CANCELLED_BY_RUNS_CONTROL = "runs control"
+
+// Given to test runs which ran for longer than the portfolio allowed their class.
+const CANCELLED_BY_CLASS_TIMEOUT = "class timeout"
+
+// Given to tests which were never submitted because a test they depend on did not pass.
+const CANCELLED_BY_DEPENDENCY = "dependency"
+
+var DEFAULT_RETRY_RESULTS = []string{RESULT_ENVFAIL, RESULT_FAILED}
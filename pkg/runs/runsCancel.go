@@ -23,6 +23,33 @@ var (
 	CANCEL_RESULT = "cancelled"
 )
 
+// RunCanceller - Something which can cancel a test run which is in progress.
+type RunCanceller interface {
+	CancelRun(runName string) error
+}
+
+type remoteRunCanceller struct {
+	timeService spi.TimeService
+	apiClient   *galasaapi.APIClient
+}
+
+// NewRemoteRunCanceller - Cancels runs in an ecosystem, in the same way as the 'runs cancel' command does.
+func NewRemoteRunCanceller(timeService spi.TimeService, apiClient *galasaapi.APIClient) RunCanceller {
+	canceller := new(remoteRunCanceller)
+	canceller.timeService = timeService
+	canceller.apiClient = apiClient
+	return canceller
+}
+
+func (canceller *remoteRunCanceller) CancelRun(runName string) error {
+	runId, err := getRunIdFromRunName(runName, canceller.timeService, canceller.apiClient)
+	if err == nil {
+		updateRunStatusRequest := createUpdateRunStatusRequest(CANCEL_STATUS, CANCEL_RESULT)
+		err = cancelRun(runName, runId, updateRunStatusRequest, canceller.apiClient)
+	}
+	return err
+}
+
 func CancelRun(
 	runName string,
 	timeService spi.TimeService,
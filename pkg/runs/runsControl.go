@@ -0,0 +1,176 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/runsformatter"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+const CONTROL_REQUEST_TIMEOUT = time.Second * 30
+
+// PauseSubmission - Stops a running 'runs submit' command submitting any more tests until it is resumed.
+func PauseSubmission(controlFileName string, fileSystem spi.FileSystem, console spi.Console) error {
+	_, err := sendControlRequest(controlFileName, fileSystem, http.MethodPost, CONTROL_PATH_PAUSE, nil)
+	if err == nil {
+		console.WriteString("Submission paused. No more tests will be submitted until it is resumed.\n")
+	}
+	return err
+}
+
+// ResumeSubmission - Lets a paused 'runs submit' command carry on submitting tests.
+func ResumeSubmission(controlFileName string, fileSystem spi.FileSystem, console spi.Console) error {
+	_, err := sendControlRequest(controlFileName, fileSystem, http.MethodPost, CONTROL_PATH_RESUME, nil)
+	if err == nil {
+		console.WriteString("Submission resumed.\n")
+	}
+	return err
+}
+
+// SetSubmissionThrottle - Changes how many tests a running 'runs submit' command lets run at once.
+func SetSubmissionThrottle(controlFileName string, throttle int, fileSystem spi.FileSystem, console spi.Console) error {
+	_, err := sendControlRequest(controlFileName, fileSystem, http.MethodPut, CONTROL_PATH_THROTTLE, ThrottleRequest{Throttle: throttle})
+	if err == nil {
+		console.WriteString(fmt.Sprintf("Throttle changed to %v.\n", throttle))
+	}
+	return err
+}
+
+// CancelSubmissionRuns - Asks a running 'runs submit' command to cancel some of the test runs in progress,
+// given their names, or not to submit some of the tests waiting to be submitted, given their bundle/class names.
+func CancelSubmissionRuns(controlFileName string, runNames []string, fileSystem spi.FileSystem, console spi.Console) error {
+	_, err := sendControlRequest(controlFileName, fileSystem, http.MethodPost, CONTROL_PATH_CANCEL, CancelRequest{Runs: runNames})
+	if err == nil {
+		console.WriteString(fmt.Sprintf("Cancelling %v.\n", strings.Join(runNames, ", ")))
+	}
+	return err
+}
+
+// ListSubmission - Shows what a running 'runs submit' command is doing.
+func ListSubmission(controlFileName string, fileSystem spi.FileSystem, console spi.Console) error {
+	state, err := sendControlRequest(controlFileName, fileSystem, http.MethodGet, CONTROL_PATH_STATE, nil)
+	if err == nil {
+		console.WriteString(formatSubmissionState(state))
+	}
+	return err
+}
+
+func formatSubmissionState(state *SubmissionState) string {
+	buff := strings.Builder{}
+
+	table := [][]string{{runsformatter.HEADER_RUNNAME, runsformatter.HEADER_TEST_NAME, runsformatter.HEADER_STATUS, runsformatter.HEADER_RESULT}}
+	for _, run := range state.Submitted {
+		table = append(table, []string{run.Name, getRunClassName(&run), run.Status, run.Result})
+	}
+	for _, run := range state.Ready {
+		table = append(table, []string{"", getRunClassName(&run), "ready", ""})
+	}
+	for _, run := range state.Finished {
+		table = append(table, []string{run.Name, getRunClassName(&run), run.Status, run.Result})
+	}
+	for _, run := range state.Lost {
+		table = append(table, []string{run.Name, getRunClassName(&run), "lost", run.Result})
+	}
+
+	if len(table) > 1 {
+		columnLengths := utils.CalculateMaxLengthOfEachColumn(table)
+		utils.WriteFormattedTableToStringBuilder(table, &buff, columnLengths)
+		buff.WriteString("\n")
+	}
+
+	buff.WriteString(formatRunCounters(len(state.Ready), len(state.Submitted), len(state.Finished), len(state.Lost), state.Throttle))
+	if state.Paused {
+		buff.WriteString("Submission is paused.\n")
+	}
+	return buff.String()
+}
+
+func readControlFile(controlFileName string, fileSystem spi.FileSystem) (*ControlFile, error) {
+	var controlFile ControlFile
+
+	contents, err := fileSystem.ReadTextFile(controlFileName)
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_CONTROL_FILE_READ_FAILED, controlFileName, err.Error())
+	} else {
+		err = yaml.Unmarshal([]byte(contents), &controlFile)
+		if err != nil || controlFile.Address == "" || controlFile.Token == "" {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_CONTROL_FILE_INVALID, controlFileName)
+		}
+	}
+	return &controlFile, err
+}
+
+// sendControlRequest - Sends a request to the submission named in the control file, returning the
+// state of the submission once it has been acted on.
+func sendControlRequest(
+	controlFileName string,
+	fileSystem spi.FileSystem,
+	method string,
+	path string,
+	body interface{},
+) (*SubmissionState, error) {
+
+	var state *SubmissionState
+
+	controlFile, err := readControlFile(controlFileName, fileSystem)
+	if err == nil {
+		var requestBody []byte
+		if body != nil {
+			requestBody, err = json.Marshal(body)
+		}
+
+		var request *http.Request
+		if err == nil {
+			request, err = http.NewRequest(method, "http://"+controlFile.Address+path, bytes.NewReader(requestBody))
+		}
+
+		var response *http.Response
+		if err == nil {
+			request.Header.Set("Authorization", "Bearer "+controlFile.Token)
+			request.Header.Set("Content-Type", "application/json")
+
+			log.Printf("Sending control request %v %v\n", method, path)
+			client := &http.Client{Timeout: CONTROL_REQUEST_TIMEOUT}
+			response, err = client.Do(request)
+		}
+
+		if err != nil {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_CONTROL_CONTACT_FAILED, controlFile.Address, err.Error())
+		} else {
+			defer response.Body.Close()
+
+			var responseBody []byte
+			responseBody, err = ioutil.ReadAll(response.Body)
+			if err != nil {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_CONTROL_RESPONSE_UNREADABLE, err.Error())
+			} else if response.StatusCode != http.StatusOK {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_CONTROL_REQUEST_REJECTED,
+					response.StatusCode, strings.TrimSpace(string(responseBody)))
+			} else {
+				state = new(SubmissionState)
+				err = json.Unmarshal(responseBody, state)
+				if err != nil {
+					err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_CONTROL_RESPONSE_UNREADABLE, err.Error())
+				}
+			}
+		}
+	}
+
+	return state, err
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func startControlServerForTests(t *testing.T, mockFileSystem spi.FileSystem) (*ControlServer, *SubmissionControls) {
+	controls := newControlsWithOneRunOfEachKind()
+	server, err := StartControlServer(mockFileSystem, "my.control", controls)
+	assert.Nil(t, err)
+	return server, controls
+}
+
+func TestControlServerWritesControlFileAndRemovesItWhenStopped(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	server, _ := startControlServerForTests(t, mockFileSystem)
+
+	controlFile, err := readControlFile("my.control", mockFileSystem)
+	assert.Nil(t, err)
+	assert.Equal(t, server.GetAddress(), controlFile.Address)
+	assert.NotEmpty(t, controlFile.Token)
+
+	// When...
+	server.Stop()
+
+	// Then...
+	isExists, _ := mockFileSystem.Exists("my.control")
+	assert.False(t, isExists)
+}
+
+func TestControlServerWritesControlFileOnlyItsOwnerCanRead(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewOverridableMockFileSystem()
+
+	// When...
+	server, _ := startControlServerForTests(t, mockFileSystem)
+	defer server.Stop()
+
+	// Then...
+	assert.True(t, mockFileSystem.IsPrivateFile("my.control"))
+}
+
+func TestControlServerDoesNotStartIfControlFileCantBeMadePrivate(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewOverridableMockFileSystem()
+	mockFileSystem.VirtualFunction_WritePrivateBinaryFile = func(targetFilePath string, desiredContents []byte) error {
+		return galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_FILE_NOT_PRIVATE, targetFilePath, "its permissions are -rw-r--r--")
+	}
+
+	// When...
+	_, err := StartControlServer(mockFileSystem, "my.control", NewSubmissionControls())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1323E")
+	isExists, _ := mockFileSystem.Exists("my.control")
+	assert.False(t, isExists)
+}
+
+func TestRunsControlPausesAndResumesSubmission(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	server, controls := startControlServerForTests(t, mockFileSystem)
+	defer server.Stop()
+	console := utils.NewMockConsole()
+
+	// When...
+	err := PauseSubmission("my.control", mockFileSystem, console)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.True(t, controls.isSubmissionPaused())
+
+	// When...
+	err = ResumeSubmission("my.control", mockFileSystem, console)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.False(t, controls.isSubmissionPaused())
+	assert.Equal(t, "Submission paused. No more tests will be submitted until it is resumed.\nSubmission resumed.\n", console.ReadText())
+}
+
+func TestRunsControlChangesThrottle(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	server, controls := startControlServerForTests(t, mockFileSystem)
+	defer server.Stop()
+	console := utils.NewMockConsole()
+
+	// When...
+	err := SetSubmissionThrottle("my.control", 4, mockFileSystem, console)
+
+	// Then...
+	assert.Nil(t, err)
+	throttle, isChanged := controls.takeThrottleRequest()
+	assert.True(t, isChanged)
+	assert.Equal(t, 4, throttle)
+	assert.Equal(t, "Throttle changed to 4.\n", console.ReadText())
+}
+
+func TestRunsControlInvalidThrottleIsRejected(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	server, _ := startControlServerForTests(t, mockFileSystem)
+	defer server.Stop()
+	console := utils.NewMockConsole()
+
+	// When...
+	err := SetSubmissionThrottle("my.control", -1, mockFileSystem, console)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1248E: The 'runs submit' command rejected the request. Status code: 400. Reason: GAL1249E:")
+	assert.Empty(t, console.ReadText())
+}
+
+func TestRunsControlCancelsRuns(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	server, controls := startControlServerForTests(t, mockFileSystem)
+	defer server.Stop()
+	console := utils.NewMockConsole()
+
+	// When...
+	err := CancelSubmissionRuns("my.control", []string{"U100", "myBundle/myClass2"}, mockFileSystem, console)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"U100", "myBundle/myClass2"}, controls.takeCancelRequests())
+	assert.Equal(t, "Cancelling U100, myBundle/myClass2.\n", console.ReadText())
+}
+
+func TestRunsControlListsTheStateOfTheSubmission(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	server, controls := startControlServerForTests(t, mockFileSystem)
+	defer server.Stop()
+	controls.Pause()
+	console := utils.NewMockConsole()
+
+	// When...
+	err := ListSubmission("my.control", mockFileSystem, console)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t,
+		"name test-name         status   result\n"+
+			"U100 myBundle/myClass1 running  \n"+
+			"     myBundle/myClass2 ready    \n"+
+			"U99  myBundle/myClass0 finished Passed\n"+
+			"\n"+
+			"Ready=1, Submitted=1, Finished=1, Lost=0, Throttle=1\n"+
+			"Submission is paused.\n",
+		console.ReadText())
+}
+
+func TestRunsControlWithWrongTokenIsRejected(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	server, controls := startControlServerForTests(t, mockFileSystem)
+	defer server.Stop()
+	mockFileSystem.WriteTextFile("my.control", "address: "+server.GetAddress()+"\ntoken: not-the-token\n")
+
+	// When...
+	err := PauseSubmission("my.control", mockFileSystem, utils.NewMockConsole())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1248E: The 'runs submit' command rejected the request. Status code: 401.")
+	assert.False(t, controls.isSubmissionPaused())
+}
+
+func TestRunsControlWithMissingControlFileReturnsError(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+
+	// When...
+	err := PauseSubmission("my.control", mockFileSystem, utils.NewMockConsole())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1245E: Failed to read the control file 'my.control'.")
+}
+
+func TestRunsControlWithInvalidControlFileReturnsError(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockFileSystem.WriteTextFile("my.control", "not a control file")
+
+	// When...
+	err := PauseSubmission("my.control", mockFileSystem, utils.NewMockConsole())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1246E: The control file 'my.control' is not valid.")
+}
+
+func TestRunsControlWhenSubmissionHasEndedReturnsError(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	server, _ := startControlServerForTests(t, mockFileSystem)
+	address := server.GetAddress()
+	server.Stop()
+	mockFileSystem.WriteTextFile("my.control", "address: "+address+"\ntoken: my-token\n")
+
+	// When...
+	err := PauseSubmission("my.control", mockFileSystem, utils.NewMockConsole())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1247E: Failed to contact the 'runs submit' command at address '"+address+"'.")
+}
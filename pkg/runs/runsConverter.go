@@ -82,15 +82,9 @@ func getAvailableResultLabelsinOrder(formattableTest []runsformatter.Formattable
 }
 
 func FormattableTestFromGalasaApi(runs []galasaapi.Run, apiServerUrl string) []runsformatter.FormattableTest {
-	var formattableTest []runsformatter.FormattableTest
-
 	log.Printf("FormattableTestFromGalasaApi: There are %v runs passed\n", len(runs))
 
-	for _, run := range runs {
-		//Get the data for each TestStructure in runs
-		newFormattableTest := getTestStructureData(run, apiServerUrl)
-		formattableTest = append(formattableTest, newFormattableTest)
-	}
+	formattableTest := formattableTestInOrderFromGalasaApi(runs, apiServerUrl)
 
 	log.Printf("FormattableTestFromGalasaApi: There are %v runs to format\n", len(formattableTest))
 
@@ -101,6 +95,18 @@ func FormattableTestFromGalasaApi(runs []galasaapi.Run, apiServerUrl string) []r
 	return orderedFormattableTest
 }
 
+// formattableTestInOrderFromGalasaApi - Converts the runs, keeping them in the order they are given,
+// rather than grouping them by result.
+func formattableTestInOrderFromGalasaApi(runs []galasaapi.Run, apiServerUrl string) []runsformatter.FormattableTest {
+	var formattableTest []runsformatter.FormattableTest
+	for _, run := range runs {
+		//Get the data for each TestStructure in runs
+		newFormattableTest := getTestStructureData(run, apiServerUrl)
+		formattableTest = append(formattableTest, newFormattableTest)
+	}
+	return formattableTest
+}
+
 func getTestStructureData(run galasaapi.Run, apiServerUrl string) runsformatter.FormattableTest {
 	newFormattableTest := runsformatter.NewFormattableTest()
 
@@ -109,6 +115,7 @@ func getTestStructureData(run galasaapi.Run, apiServerUrl string) runsformatter.
 
 	newFormattableTest.Name = run.TestStructure.GetRunName()
 	newFormattableTest.TestName = run.TestStructure.GetTestName()
+	newFormattableTest.TestShortName = run.TestStructure.GetTestShortName()
 	newFormattableTest.Status = run.TestStructure.GetStatus()
 	newFormattableTest.Result = run.TestStructure.GetResult()
 	newFormattableTest.StartTimeUTC = run.TestStructure.GetStartTime()
@@ -143,7 +150,7 @@ func FormattableTestFromTestRun(finishedMap map[string]*TestRun, lostMap map[str
 func getTestRunData(run TestRun, isLost bool) runsformatter.FormattableTest {
 	newFormattableTest := runsformatter.NewFormattableTest()
 
-	newFormattableTest.RunId = ""
+	newFormattableTest.RunId = run.RasRunId
 	newFormattableTest.ApiServerUrl = ""
 
 	newFormattableTest.Name = run.Name
@@ -152,16 +159,42 @@ func getTestRunData(run TestRun, isLost bool) runsformatter.FormattableTest {
 	} else {
 		newFormattableTest.TestName = run.Stream + "/" + run.Bundle + "/" + run.Class
 	}
+	newFormattableTest.TestName += getCombinationSuffix(&run)
 	newFormattableTest.Status = run.Status
 	newFormattableTest.Result = run.Result
-	newFormattableTest.StartTimeUTC = ""
-	newFormattableTest.EndTimeUTC = ""
+	newFormattableTest.StartTimeUTC = run.StartTimeUTC
+	newFormattableTest.EndTimeUTC = run.EndTimeUTC
 	newFormattableTest.QueuedTimeUTC = run.QueuedTimeUTC
 	newFormattableTest.Requestor = run.Requestor
 	newFormattableTest.Bundle = run.Bundle
-	newFormattableTest.Methods = nil
+	newFormattableTest.Methods = getTestMethodsData(run.Tests)
 	newFormattableTest.Lost = isLost
 	newFormattableTest.Group = run.Group
+	newFormattableTest.ExcusedBy = run.ExcusedBy
+
+	if len(run.PreviousAttempts) > 0 {
+		for _, attempt := range run.PreviousAttempts {
+			newFormattableTest.AttemptResults = append(newFormattableTest.AttemptResults, attempt.Result)
+		}
+		newFormattableTest.AttemptResults = append(newFormattableTest.AttemptResults, run.Result)
+	}
 
 	return newFormattableTest
 }
+
+// getTestMethodsData - The methods of a test run, in the form the formatters show them.
+func getTestMethodsData(tests []TestMethod) []galasaapi.TestMethod {
+	var methods []galasaapi.TestMethod
+	for index := range tests {
+		test := tests[index]
+		methods = append(methods, galasaapi.TestMethod{
+			MethodName: &test.Method,
+			Result:     &test.Result,
+			Type:       &test.Type,
+			Status:     &test.Status,
+			StartTime:  &test.StartTimeUTC,
+			EndTime:    &test.EndTimeUTC,
+		})
+	}
+	return methods
+}
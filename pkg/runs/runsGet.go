@@ -19,6 +19,8 @@ import (
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/runsformatter"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
+	"github.com/galasa-dev/cli/pkg/utils"
 )
 
 var (
@@ -46,7 +48,10 @@ func GetRuns(
 	resultParameter string,
 	shouldGetActive bool,
 	outputFormatString string,
+	tableOptions *utils.TableOptions,
 	group string,
+	filters *RunsGetFilters,
+	shouldStream bool,
 	timeService spi.TimeService,
 	console spi.Console,
 	apiServerUrl string,
@@ -58,7 +63,7 @@ func GetRuns(
 
 	log.Printf("GetRuns entered.")
 
-	if runName == "" && age == "" && group == "" {
+	if runName == "" && age == "" && group == "" && (filters == nil || filters.From == "") {
 		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_NO_TEST_RUN_IDENTIFIER_FLAG_SPECIFIED)
 	}
 
@@ -68,7 +73,11 @@ func GetRuns(
 	}
 
 	if err == nil && age != "" {
-		fromAge, toAge, err = getTimesFromAge(age)
+		if filters != nil && (filters.From != "" || filters.To != "") {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_AGE_AND_TIME_RANGE_ARE_MUTUALLY_EXCLUSIVE)
+		} else {
+			fromAge, toAge, err = getTimesFromAge(age)
+		}
 	}
 
 	if err == nil && group != "" {
@@ -84,12 +93,23 @@ func GetRuns(
 		}
 	}
 
+	var runsQuery *RunsQuery
+	if err == nil {
+		runsQuery = NewRunsQuery(runName, requestorParameter, resultParameter, group, fromAge, toAge, shouldGetActive, timeService.Now())
+		err = runsQuery.applyFilters(filters)
+	}
+
 	if err == nil {
 		var chosenFormatter runsformatter.RunsFormatter
 		chosenFormatter, err = validateOutputFormatFlagValue(outputFormatString, validFormatters)
 		if err == nil {
+			err = utils.ApplyTableOptions(chosenFormatter, tableOptions)
+		}
+		if err == nil && shouldStream {
+			err = streamRuns(runsQuery, chosenFormatter, tableOptions, console, apiServerUrl, apiClient)
+		} else if err == nil {
 			var runJson []galasaapi.Run
-			runJson, err = GetRunsFromRestApi(runName, requestorParameter, resultParameter, fromAge, toAge, shouldGetActive, timeService, apiClient, group)
+			runJson, err = getRunsFromRestApiWithQuery(runsQuery, apiClient)
 			if err == nil {
 				// Some formatters need extra fields filled-in so they can be displayed.
 				if chosenFormatter.IsNeedingMethodDetails() {
@@ -103,7 +123,13 @@ func GetRuns(
 					log.Printf("There are %v results to display in total.\n", len(runJson))
 
 					//convert galsaapi.Runs tests into formattable data
-					formattableTest := FormattableTestFromGalasaApi(runJson, apiServerUrl)
+					var formattableTest []runsformatter.FormattableTest
+					if runsQuery.isSortChosen {
+						// Keep the runs in the order they were sorted in, rather than grouping them by result.
+						formattableTest = formattableTestInOrderFromGalasaApi(runJson, apiServerUrl)
+					} else {
+						formattableTest = FormattableTestFromGalasaApi(runJson, apiServerUrl)
+					}
 					outputText, err = chosenFormatter.FormatRuns(formattableTest)
 
 					if err == nil {
@@ -128,6 +154,21 @@ func CreateFormatters() map[string]runsformatter.RunsFormatter {
 	rawFormatter := runsformatter.NewRawFormatter()
 	validFormatters[rawFormatter.GetName()] = rawFormatter
 
+	htmlFormatter := runsformatter.NewHtmlFormatter()
+	validFormatters[htmlFormatter.GetName()] = htmlFormatter
+
+	jsonFormatter := runsformatter.NewJsonFormatter()
+	validFormatters[jsonFormatter.GetName()] = jsonFormatter
+
+	yamlFormatter := runsformatter.NewYamlFormatter()
+	validFormatters[yamlFormatter.GetName()] = yamlFormatter
+
+	csvFormatter := runsformatter.NewCsvFormatter()
+	validFormatters[csvFormatter.GetName()] = csvFormatter
+
+	jsonLinesFormatter := runsformatter.NewJsonLinesFormatter()
+	validFormatters[jsonLinesFormatter.GetName()] = jsonLinesFormatter
+
 	return validFormatters
 }
 
@@ -163,10 +204,17 @@ func GetFormatterNamesString(validFormatters map[string]runsformatter.RunsFormat
 func validateOutputFormatFlagValue(outputFormatString string, validFormatters map[string]runsformatter.RunsFormatter) (runsformatter.RunsFormatter, error) {
 	var err error
 
-	chosenFormatter, isPresent := validFormatters[outputFormatString]
+	var chosenFormatter runsformatter.RunsFormatter
+
+	if templateformatter.IsTemplateFormat(outputFormatString) {
+		chosenFormatter, err = runsformatter.NewTemplateFormatter(outputFormatString)
+	} else {
+		var isPresent bool
+		chosenFormatter, isPresent = validFormatters[outputFormatString]
 
-	if !isPresent {
-		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_OUTPUT_FORMAT, outputFormatString, GetFormatterNamesString(validFormatters))
+		if !isPresent {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_OUTPUT_FORMAT, outputFormatString, GetFormatterNamesString(validFormatters))
+		}
 	}
 
 	return chosenFormatter, err
@@ -235,9 +283,48 @@ func GetRunsFromRestApi(
 	group string,
 ) ([]galasaapi.Run, error) {
 
-	var err error
+	runsQuery := NewRunsQuery(
+		runName,
+		requestorParameter,
+		resultParameter,
+		group,
+		fromAgeMins,
+		toAgeMins,
+		shouldGetActive,
+		timeService.Now(),
+	)
+
+	return getRunsFromRestApiWithQuery(runsQuery, apiClient)
+}
+
+// Retrieves the test runs which match a query from the ecosystem API.
+func getRunsFromRestApiWithQuery(runsQuery *RunsQuery, apiClient *galasaapi.APIClient) ([]galasaapi.Run, error) {
+
 	var results []galasaapi.Run = make([]galasaapi.Run, 0)
 
+	err := walkRunsPages(runsQuery, apiClient, func(runsOnThisPage []galasaapi.Run) error {
+		// Note: The ... syntax means 'all of the array', so they all get appended at once.
+		results = append(results, runsOnThisPage...)
+		return nil
+	})
+
+	if err == nil {
+		results = runsQuery.sortAndLimitRuns(results)
+	}
+
+	log.Printf("total runs returned: %v", len(results))
+
+	return results, err
+}
+
+// walkRunsPages - Retrieves the test runs which match a query from the ecosystem API a page at a time,
+// passing the runs on each page which pass the filters the RAS search can't apply to the page handler.
+// Stops once the last page is reached, or once enough runs are got to reach the limit.
+func walkRunsPages(runsQuery *RunsQuery, apiClient *galasaapi.APIClient, handlePage func(runsOnThisPage []galasaapi.Run) error) error {
+
+	var err error
+	var runCount int = 0
+
 	var pageNumberWanted int32 = 1
 	gotAllResults := false
 	var restApiVersion string
@@ -245,17 +332,6 @@ func GetRunsFromRestApi(
 	restApiVersion, err = embedded.GetGalasactlRestApiVersion()
 	if err == nil {
 
-		runsQuery := NewRunsQuery(
-			runName,
-			requestorParameter,
-			resultParameter,
-			group,
-			fromAgeMins,
-			toAgeMins,
-			shouldGetActive,
-			timeService.Now(),
-		)
-
 		for !gotAllResults && err == nil {
 
 			log.Printf("Requesting page '%d' ", pageNumberWanted)
@@ -264,15 +340,16 @@ func GetRunsFromRestApi(
 			runData, err = runsQuery.GetRunsPageFromRestApi(apiClient, restApiVersion)
 
 			if err == nil {
-				// Add all the runs into our set of results.
-				// Note: The ... syntax means 'all of the array', so they all get appended at once.
 				runsOnThisPage := runData.GetRuns()
-				results = append(results, runsOnThisPage...)
+				matchingRuns := runsQuery.limitPageOfRuns(runsQuery.filterRuns(runsOnThisPage), runCount)
+				runCount += len(matchingRuns)
+
+				log.Printf("total runs: %v", runCount)
 
-				log.Printf("total runs: %v", len(results))
+				err = handlePage(matchingRuns)
 
-				// Have we processed the last page ?
-				if !runData.HasNextCursor() || len(runsOnThisPage) < int(runData.GetPageSize()) {
+				// Have we processed the last page, or got as many runs as we need ?
+				if !runData.HasNextCursor() || len(runsOnThisPage) < int(runData.GetPageSize()) || runsQuery.hasEnoughRuns(runCount) {
 					gotAllResults = true
 				} else {
 					runsQuery.SetPageCursor(runData.GetNextCursor())
@@ -282,9 +359,7 @@ func GetRunsFromRestApi(
 		}
 	}
 
-	log.Printf("total runs returned: %v", len(results))
-
-	return results, err
+	return err
 }
 
 func getTimesFromAge(age string) (int, int, error) {
@@ -0,0 +1,323 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// RunsGetFilters - Holds the 'runs get' flags which narrow down, order and limit the runs
+// which are got, on top of the name, requestor, result, group, age and active flags.
+// Those which the RAS search supports are passed to it. The rest are applied to the runs
+// which the RAS search returns.
+type RunsGetFilters struct {
+	// The name of the bundle the test is in.
+	Bundle string
+
+	// The full name of the test class, or a pattern using '*' as a wildcard,
+	// which is matched against the full and the short name of the test class.
+	Test string
+
+	// A comma-separated list of statuses, such as "queued,running".
+	Status string
+
+	// Absolute times, used instead of --age.
+	From string
+	To   string
+
+	// The field to sort the runs on, with an optional ':asc' or ':desc' suffix.
+	Sort string
+
+	// The most runs to get. 0 gets every run which matches.
+	Limit int
+}
+
+const (
+	// The order the RAS search returns runs in, unless told otherwise. Newest first.
+	DEFAULT_RAS_RUNS_SORT = "from:desc"
+
+	TEST_NAME_WILDCARD = "*"
+)
+
+var (
+	// Every status a run can have.
+	allStatusNames = "queued," + activeStatusNames + ",finished"
+
+	// The times given with --from and --to can be in any of these layouts.
+	// Those without a time zone are in UTC.
+	runsTimeLayouts = []string{
+		time.RFC3339,
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02",
+	}
+
+	// The fields which runs can be sorted on, named after the columns of the summary format.
+	runsSortFields = []runsSortField{
+		{name: "name", getValue: func(run galasaapi.Run) string { return run.TestStructure.GetRunName() }},
+		{name: "submitted-time", rasSortName: "from"},
+		{name: "start-time", getValue: func(run galasaapi.Run) string { return run.TestStructure.GetStartTime() }},
+		{name: "end-time", getValue: func(run galasaapi.Run) string { return run.TestStructure.GetEndTime() }},
+		{name: "status", getValue: func(run galasaapi.Run) string { return run.TestStructure.GetStatus() }},
+		{name: "result", getValue: func(run galasaapi.Run) string { return run.TestStructure.GetResult() }},
+		{name: "test-name", getValue: func(run galasaapi.Run) string { return run.TestStructure.GetTestName() }},
+		{name: "bundle", getValue: func(run galasaapi.Run) string { return run.TestStructure.GetBundle() }},
+		{name: "requestor", getValue: func(run galasaapi.Run) string { return run.TestStructure.GetRequestor() }},
+		{name: "group", getValue: func(run galasaapi.Run) string { return run.TestStructure.GetGroup() }},
+	}
+)
+
+type runsSortField struct {
+	name string
+
+	// The name the RAS search sorts this field by. Empty if the RAS search can't sort on it,
+	// in which case the runs are sorted on the values got from each run.
+	rasSortName string
+	getValue    func(run galasaapi.Run) string
+}
+
+// applyFilters - Validates the filters and adds them to the query.
+// Nil filters leave the query as it is.
+func (query *RunsQuery) applyFilters(filters *RunsGetFilters) error {
+	var err error
+
+	if filters != nil {
+		query.bundle = strings.TrimSpace(filters.Bundle)
+		query.applyTestFilter(strings.TrimSpace(filters.Test))
+
+		err = query.applyStatusFilter(filters.Status)
+
+		if err == nil {
+			err = query.applyTimeRange(strings.TrimSpace(filters.From), strings.TrimSpace(filters.To))
+		}
+
+		if err == nil {
+			err = query.applySort(strings.TrimSpace(filters.Sort))
+		}
+
+		if err == nil {
+			if filters.Limit < 0 {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_RUNS_LIMIT, filters.Limit)
+			} else {
+				query.limit = filters.Limit
+			}
+		}
+	}
+
+	return err
+}
+
+// applyTestFilter - The RAS search only finds the exact name of a test class,
+// so patterns with wildcards are matched against the runs it returns.
+func (query *RunsQuery) applyTestFilter(test string) {
+	if strings.Contains(test, TEST_NAME_WILDCARD) {
+		quotedParts := strings.Split(test, TEST_NAME_WILDCARD)
+		for index, part := range quotedParts {
+			quotedParts[index] = regexp.QuoteMeta(part)
+		}
+		query.testNamePattern = regexp.MustCompile("^" + strings.Join(quotedParts, ".*") + "$")
+	} else {
+		query.testName = test
+	}
+}
+
+func (query *RunsQuery) applyStatusFilter(statusParameter string) error {
+	var err error
+	var statuses []string
+
+	for _, status := range strings.Split(statusParameter, ",") {
+		status = strings.ToLower(strings.TrimSpace(status))
+		if status != "" {
+			if !isKnownRunStatus(status) {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_RUN_STATUS, status, getQuotedNames(strings.Split(allStatusNames, ",")))
+				break
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	if err == nil && len(statuses) > 0 {
+		if query.shouldGetActive {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_ACTIVE_AND_STATUS_ARE_MUTUALLY_EXCLUSIVE)
+		} else {
+			query.statuses = strings.Join(statuses, ",")
+		}
+	}
+
+	return err
+}
+
+func isKnownRunStatus(status string) bool {
+	isKnown := false
+	for _, knownStatus := range strings.Split(allStatusNames, ",") {
+		if status == knownStatus {
+			isKnown = true
+			break
+		}
+	}
+	return isKnown
+}
+
+func (query *RunsQuery) applyTimeRange(from string, to string) error {
+	var err error
+	var fromTime time.Time
+	var toTime time.Time
+
+	if from != "" {
+		fromTime, err = parseRunsTime("from", from)
+	}
+
+	if err == nil && to != "" {
+		toTime, err = parseRunsTime("to", to)
+	}
+
+	if err == nil && !fromTime.IsZero() && !toTime.IsZero() && !fromTime.Before(toTime) {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_FROM_TIME_NOT_BEFORE_TO_TIME, from, to)
+	}
+
+	if err == nil {
+		if !fromTime.IsZero() {
+			query.fromTime = fromTime.UTC()
+		}
+		if !toTime.IsZero() {
+			query.toTime = toTime.UTC()
+		}
+	}
+
+	return err
+}
+
+func parseRunsTime(flagName string, value string) (time.Time, error) {
+	var err error
+	var parsedTime time.Time
+	isParsed := false
+
+	for _, layout := range runsTimeLayouts {
+		parsedTime, err = time.Parse(layout, value)
+		if err == nil {
+			isParsed = true
+			break
+		}
+	}
+
+	if !isParsed {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_RUNS_TIME, flagName, value)
+	}
+
+	return parsedTime, err
+}
+
+// applySort - Sorting on a field the RAS search supports is done by the RAS search,
+// so the runs arrive in order and paging can stop once the limit is reached.
+func (query *RunsQuery) applySort(sortParameter string) error {
+	var err error
+
+	if sortParameter != "" {
+		fieldName := sortParameter
+		isDescending := false
+		if strings.HasSuffix(fieldName, utils.SORT_DESCENDING_SUFFIX) {
+			fieldName = strings.TrimSuffix(fieldName, utils.SORT_DESCENDING_SUFFIX)
+			isDescending = true
+		} else {
+			fieldName = strings.TrimSuffix(fieldName, utils.SORT_ASCENDING_SUFFIX)
+		}
+
+		var sortField *runsSortField
+		var fieldNames []string
+		for index, field := range runsSortFields {
+			fieldNames = append(fieldNames, field.name)
+			if strings.EqualFold(field.name, fieldName) {
+				sortField = &runsSortFields[index]
+			}
+		}
+
+		if sortField == nil {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_RUNS_SORT, sortParameter, getQuotedNames(fieldNames))
+		} else {
+			query.isSortChosen = true
+			if sortField.rasSortName != "" {
+				if isDescending {
+					query.sort = sortField.rasSortName + utils.SORT_DESCENDING_SUFFIX
+				} else {
+					query.sort = sortField.rasSortName + utils.SORT_ASCENDING_SUFFIX
+				}
+			} else {
+				query.sortField = sortField
+				query.isSortDescending = isDescending
+			}
+		}
+	}
+
+	return err
+}
+
+// filterRuns - Keeps the runs which match the filters which the RAS search can't apply.
+func (query *RunsQuery) filterRuns(runs []galasaapi.Run) []galasaapi.Run {
+	filteredRuns := runs
+	if query.testNamePattern != nil {
+		filteredRuns = make([]galasaapi.Run, 0)
+		for _, run := range runs {
+			if query.testNamePattern.MatchString(run.TestStructure.GetTestName()) ||
+				query.testNamePattern.MatchString(run.TestStructure.GetTestShortName()) {
+				filteredRuns = append(filteredRuns, run)
+			}
+		}
+	}
+	return filteredRuns
+}
+
+// hasEnoughRuns - Can paging through the RAS search stop ? Only if the limit is reached and
+// the runs don't need sorting once they are all got.
+func (query *RunsQuery) hasEnoughRuns(runCount int) bool {
+	return query.limit > 0 && query.sortField == nil && runCount >= query.limit
+}
+
+// limitPageOfRuns - Keeps no more runs from a page than are needed to reach the limit,
+// unless the runs need sorting once they are all got.
+func (query *RunsQuery) limitPageOfRuns(runs []galasaapi.Run, runCountSoFar int) []galasaapi.Run {
+	if query.limit > 0 && query.sortField == nil && runCountSoFar+len(runs) > query.limit {
+		runs = runs[:query.limit-runCountSoFar]
+	}
+	return runs
+}
+
+// sortAndLimitRuns - Sorts the runs on a field the RAS search can't sort on, then keeps
+// no more than the limit.
+func (query *RunsQuery) sortAndLimitRuns(runs []galasaapi.Run) []galasaapi.Run {
+	if query.sortField != nil {
+		getValue := query.sortField.getValue
+		sort.SliceStable(runs, func(i, j int) bool {
+			var isBefore bool
+			if query.isSortDescending {
+				isBefore = getValue(runs[i]) > getValue(runs[j])
+			} else {
+				isBefore = getValue(runs[i]) < getValue(runs[j])
+			}
+			return isBefore
+		})
+	}
+
+	if query.limit > 0 && len(runs) > query.limit {
+		runs = runs[:query.limit]
+	}
+	return runs
+}
+
+func getQuotedNames(names []string) string {
+	quotedNames := make([]string, 0, len(names))
+	for _, name := range names {
+		quotedNames = append(quotedNames, "'"+name+"'")
+	}
+	return strings.Join(quotedNames, ", ")
+}
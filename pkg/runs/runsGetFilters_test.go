@@ -0,0 +1,265 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/api"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRunsSearchServletMock - Returns each page of runs in turn from the RAS search,
+// and records the query parameters of each request.
+func newRunsSearchServletMock(t *testing.T, pages [][]string, queries *[]url.Values) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ras/runs", r.URL.Path)
+
+		pageIndex := len(*queries)
+		*queries = append(*queries, r.URL.Query())
+
+		pageRuns := []string{}
+		if pageIndex < len(pages) {
+			pageRuns = pages[pageIndex]
+		} else {
+			t.Errorf("Unexpected request for page %d of runs", pageIndex+1)
+		}
+
+		// The last page has no cursor to the next page.
+		nextCursorField := ""
+		if pageIndex+1 < len(pages) {
+			nextCursorField = `"nextCursor": "page` + strconv.Itoa(pageIndex+2) + `",`
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{
+			%s
+			"pageSize": %d,
+			"amountOfRuns": %d,
+			"runs":[ %s ]
+		}`, nextCursorField, len(pageRuns), len(pageRuns), strings.Join(pageRuns, ","))))
+	}))
+	return server
+}
+
+func getRunsWithFilters(t *testing.T, pages [][]string, age string, filters *RunsGetFilters) ([]url.Values, string, error) {
+	queries := make([]url.Values, 0)
+	server := newRunsSearchServletMock(t, pages, &queries)
+	defer server.Close()
+
+	mockConsole := utils.NewMockConsole()
+	apiClient := api.InitialiseAPI(server.URL)
+	tableOptions := utils.NewTableOptions([]string{"name", "test-name"}, "", true)
+
+	err := GetRuns("", age, "", "", false, "csv", tableOptions, "", filters, false, utils.NewMockTimeService(), mockConsole, server.URL, apiClient)
+
+	return queries, mockConsole.ReadText(), err
+}
+
+func TestRunsGetPassesFiltersTheRasSearchSupportsToIt(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{
+		Bundle: "myBundleId",
+		Test:   "myTestPackage.MyTestName",
+		Status: "Queued, RUNNING",
+		From:   "2026-10-01",
+		To:     "2026-10-02T09:30Z",
+	}
+
+	// When...
+	queries, output, err := getRunsWithFilters(t, [][]string{{RUN_U456}}, "", filters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "U456,myTestPackage.MyTestName\n", output)
+	assert.Len(t, queries, 1)
+	assert.Equal(t, "myBundleId", queries[0].Get("bundle"))
+	assert.Equal(t, "myTestPackage.MyTestName", queries[0].Get("testname"))
+	assert.Equal(t, "queued,running", queries[0].Get("status"))
+	assert.Equal(t, "2026-10-01T00:00:00Z", queries[0].Get("from"))
+	assert.Equal(t, "2026-10-02T09:30:00Z", queries[0].Get("to"))
+	assert.Equal(t, "from:desc", queries[0].Get("sort"))
+}
+
+func TestRunsGetConvertsTimesWithATimeZoneToUtc(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{From: "2026-10-01T09:30:00+01:00"}
+
+	// When...
+	queries, _, err := getRunsWithFilters(t, [][]string{{RUN_U456}}, "", filters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "2026-10-01T08:30:00Z", queries[0].Get("from"))
+	assert.Equal(t, "", queries[0].Get("to"))
+}
+
+func TestRunsGetMatchesTestPatternAgainstRunsTheRasSearchReturns(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{Test: "*.MyTest2"}
+
+	// When...
+	queries, output, err := getRunsWithFilters(t, [][]string{{RUN_U456, RUN_U456_v2}}, "1d", filters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "U456,myTestPackage.MyTest2\n", output)
+	assert.Equal(t, "", queries[0].Get("testname"))
+}
+
+func TestRunsGetMatchesTestPatternAgainstShortTestName(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{Test: "MyTestName*"}
+
+	// When...
+	_, output, err := getRunsWithFilters(t, [][]string{{RUN_U456, RUN_U456_v2}}, "1d", filters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "U456,myTestPackage.MyTestName\nU456,myTestPackage.MyTest2\n", output)
+}
+
+func TestRunsGetSortOnSubmittedTimeIsDoneByTheRasSearch(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{Sort: "submitted-time"}
+
+	// When...
+	queries, _, err := getRunsWithFilters(t, [][]string{{RUN_U456}}, "1d", filters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "from:asc", queries[0].Get("sort"))
+}
+
+func TestRunsGetSortsOnOtherFieldsOnceAllPagesAreGot(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{Sort: "BUNDLE:desc", Limit: 1}
+
+	// When...
+	queries, output, err := getRunsWithFilters(t, [][]string{{RUN_U456_v2}, {RUN_U456}}, "1d", filters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Len(t, queries, 2)
+	assert.Equal(t, "page2", queries[1].Get("cursor"))
+	assert.Equal(t, "U456,myTestPackage.MyTestName\n", output)
+}
+
+func TestRunsGetKeepsSortedRunsInOrderRatherThanGroupingThemByResult(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{Sort: "result"}
+
+	// When...
+	_, output, err := getRunsWithFilters(t, [][]string{{RUN_U456, RUN_U456_v2}}, "1d", filters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "U456,myTestPackage.MyTest2\nU456,myTestPackage.MyTestName\n", output)
+}
+
+func TestRunsGetStopsPagingOnceLimitIsReached(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{Limit: 1}
+
+	// When...
+	queries, output, err := getRunsWithFilters(t, [][]string{{RUN_U456}, {RUN_U456_v2}}, "1d", filters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Len(t, queries, 1)
+	assert.Equal(t, "U456,myTestPackage.MyTestName\n", output)
+}
+
+func TestRunsGetLimitCountsOnlyRunsWhichMatchTheTestPattern(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{Test: "*.MyTest2", Limit: 1}
+
+	// When...
+	queries, output, err := getRunsWithFilters(t, [][]string{{RUN_U456}, {RUN_U456_v2}}, "1d", filters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Len(t, queries, 2)
+	assert.Equal(t, "U456,myTestPackage.MyTest2\n", output)
+}
+
+func TestRunsGetWithFromTimeNeedsNoOtherWayToIdentifyRuns(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{From: "2026-10-01T00:00Z"}
+
+	// When...
+	_, _, err := getRunsWithFilters(t, [][]string{{RUN_U456}}, "", filters)
+
+	// Then...
+	assert.Nil(t, err)
+}
+
+func TestRunsGetWithOnlyToTimeFails(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{To: "2026-10-01T00:00Z"}
+
+	// When...
+	_, _, err := getRunsWithFilters(t, nil, "", filters)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1079E")
+}
+
+func TestRunsGetWithBadFiltersFails(t *testing.T) {
+	var badFilters = []struct {
+		age           string
+		filters       RunsGetFilters
+		expectedError string
+	}{
+		{"", RunsGetFilters{From: "01/10/2026"}, "GAL1314E: Badly formed '--from' parameter value '01/10/2026'"},
+		{"", RunsGetFilters{From: "2026-10-01", To: "yesterday"}, "GAL1314E: Badly formed '--to' parameter value 'yesterday'"},
+		{"", RunsGetFilters{From: "2026-10-02", To: "2026-10-01T23:59Z"}, "GAL1315E"},
+		{"1d", RunsGetFilters{From: "2026-10-01"}, "GAL1316E"},
+		{"1d", RunsGetFilters{To: "2026-10-01"}, "GAL1316E"},
+		{"1d", RunsGetFilters{Status: "queued,sleeping"}, "GAL1317E: Unsupported value 'sleeping'"},
+		{"1d", RunsGetFilters{Sort: "colour:desc"}, "GAL1319E: Unsupported value 'colour:desc'"},
+		{"1d", RunsGetFilters{Limit: -1}, "GAL1320E: Unsupported value '-1'"},
+	}
+
+	for _, badFilter := range badFilters {
+		// Given...
+		filters := badFilter.filters
+
+		// When...
+		queries, _, err := getRunsWithFilters(t, nil, badFilter.age, &filters)
+
+		// Then...
+		assert.NotNil(t, err, badFilter.expectedError)
+		if err != nil {
+			assert.Contains(t, err.Error(), badFilter.expectedError)
+		}
+		assert.Empty(t, queries)
+	}
+}
+
+func TestRunsGetWithActiveAndStatusFails(t *testing.T) {
+	// Given...
+	mockConsole := utils.NewMockConsole()
+	apiServerUrl := "http://localhost:8080"
+	apiClient := api.InitialiseAPI(apiServerUrl)
+	filters := &RunsGetFilters{Status: "queued"}
+
+	// When...
+	err := GetRuns("", "1d", "", "", true, "summary", nil, "", filters, false, utils.NewMockTimeService(), mockConsole, apiServerUrl, apiClient)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1318E")
+}
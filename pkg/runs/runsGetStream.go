@@ -0,0 +1,106 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"log"
+	"sort"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/runsformatter"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// streamRuns - Writes the runs which match the query a page at a time, as each page
+// arrives from the ecosystem, rather than once every run has been got.
+// The runs are written in the order they arrive, so they are not grouped by result.
+func streamRuns(
+	runsQuery *RunsQuery,
+	chosenFormatter runsformatter.RunsFormatter,
+	tableOptions *utils.TableOptions,
+	console spi.Console,
+	apiServerUrl string,
+	apiClient *galasaapi.APIClient,
+) error {
+	var err error
+	var streamingFormatter runsformatter.StreamingRunsFormatter
+
+	streamingFormatter, err = validateStreaming(runsQuery, chosenFormatter, tableOptions)
+	if err == nil {
+		stream := streamingFormatter.NewRunsStream()
+		runCount := 0
+
+		err = walkRunsPages(runsQuery, apiClient, func(runsOnThisPage []galasaapi.Run) error {
+			var err error
+
+			// Some formatters need extra fields filled-in so they can be displayed.
+			if streamingFormatter.IsNeedingMethodDetails() {
+				runsOnThisPage, err = GetRunDetailsFromRasSearchRuns(runsOnThisPage, apiClient)
+			}
+
+			if err == nil {
+				var outputText string
+				outputText, err = stream.FormatRunsPage(formattableTestInOrderFromGalasaApi(runsOnThisPage, apiServerUrl))
+				if err == nil {
+					runCount += len(runsOnThisPage)
+					err = writeOutput(outputText, console)
+				}
+			}
+			return err
+		})
+
+		if err == nil {
+			var outputText string
+			outputText, err = stream.FormatRunsEnd()
+			if err == nil {
+				log.Printf("There were %v results streamed in total.\n", runCount)
+				err = writeOutput(outputText, console)
+			}
+		}
+	}
+
+	return err
+}
+
+// validateStreaming - Only some formatters can write runs a page at a time, and the runs can only
+// be streamed in an order which the RAS search returns them in.
+func validateStreaming(
+	runsQuery *RunsQuery,
+	chosenFormatter runsformatter.RunsFormatter,
+	tableOptions *utils.TableOptions,
+) (runsformatter.StreamingRunsFormatter, error) {
+	var err error
+
+	streamingFormatter, isStreamingFormatter := chosenFormatter.(runsformatter.StreamingRunsFormatter)
+	if !isStreamingFormatter {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_STREAM_FORMAT_NOT_SUPPORTED, chosenFormatter.GetName(), GetStreamingFormatterNamesString(validFormatters))
+	} else if runsQuery.sortField != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_STREAM_CANNOT_BE_SORTED, "--sort "+runsQuery.sortField.name)
+	} else if tableOptions.IsSorted() {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_STREAM_CANNOT_BE_SORTED, "--sort-by")
+	}
+
+	return streamingFormatter, err
+}
+
+// GetStreamingFormatterNamesString builds a string of comma separated, quoted names
+// of the formatters which can write runs a page at a time.
+func GetStreamingFormatterNamesString(validFormatters map[string]runsformatter.RunsFormatter) string {
+	names := make([]string, 0, len(validFormatters))
+	for name, formatter := range validFormatters {
+		_, isStreamingFormatter := formatter.(runsformatter.StreamingRunsFormatter)
+		if isStreamingFormatter {
+			names = append(names, name)
+		}
+	}
+	names = append(names, templateformatter.TEMPLATE_FORMAT_PREFIX)
+	sort.Strings(names)
+
+	return getQuotedNames(names)
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/api"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func streamRunsWithFilters(t *testing.T, pages [][]string, outputFormat string, tableOptions *utils.TableOptions, filters *RunsGetFilters) ([]url.Values, string, error) {
+	queries := make([]url.Values, 0)
+	server := newRunsSearchServletMock(t, pages, &queries)
+	defer server.Close()
+
+	mockConsole := utils.NewMockConsole()
+	apiClient := api.InitialiseAPI(server.URL)
+
+	err := GetRuns("", "1d", "", "", false, outputFormat, tableOptions, "", filters, true, utils.NewMockTimeService(), mockConsole, server.URL, apiClient)
+
+	return queries, mockConsole.ReadText(), err
+}
+
+func TestRunsGetStreamWritesEachPageWithHeadersOnlyOnce(t *testing.T) {
+	// Given...
+	tableOptions := utils.NewTableOptions([]string{"name", "test-name"}, "", false)
+
+	// When...
+	queries, output, err := streamRunsWithFilters(t, [][]string{{RUN_U456}, {RUN_U456_v2}}, "csv", tableOptions, nil)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Len(t, queries, 2)
+	assert.Equal(t, "name,test-name\n"+
+		"U456,myTestPackage.MyTestName\n"+
+		"U456,myTestPackage.MyTest2\n", output)
+}
+
+func TestRunsGetStreamWritesSummaryTotalsAfterTheLastPage(t *testing.T) {
+	// Given...
+	tableOptions := utils.NewTableOptions([]string{"name", "result"}, "", false)
+
+	// When...
+	_, output, err := streamRunsWithFilters(t, [][]string{{RUN_U456}, {RUN_U456_v2}}, "summary", tableOptions, nil)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "name result\n"+
+		"U456 Passed\n"+
+		"U456 LongResultString\n"+
+		"\n"+
+		"Total:2 Passed:1\n", output)
+}
+
+func TestRunsGetStreamStopsPagingOnceLimitIsReached(t *testing.T) {
+	// Given...
+	tableOptions := utils.NewTableOptions([]string{"name", "test-name"}, "", true)
+	filters := &RunsGetFilters{Limit: 1}
+
+	// When...
+	queries, output, err := streamRunsWithFilters(t, [][]string{{RUN_U456, RUN_U456_v2}, {RUN_U456}}, "csv", tableOptions, filters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Len(t, queries, 1)
+	assert.Equal(t, "U456,myTestPackage.MyTestName\n", output)
+}
+
+func TestRunsGetStreamWithFormatWhichCannotBeStreamedFails(t *testing.T) {
+	// When...
+	queries, _, err := streamRunsWithFilters(t, nil, "html", nil, nil)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1321E")
+	assert.Contains(t, err.Error(), "'html'")
+	assert.Contains(t, err.Error(), "'csv', 'jsonl', 'raw', 'summary', 'template='")
+	assert.Empty(t, queries)
+}
+
+func TestRunsGetStreamWithSortTheRasSearchCannotDoFails(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{Sort: "end-time"}
+
+	// When...
+	queries, _, err := streamRunsWithFilters(t, nil, "summary", nil, filters)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1322E: --stream cannot be used with '--sort end-time'")
+	assert.Empty(t, queries)
+}
+
+func TestRunsGetStreamWithSortByFails(t *testing.T) {
+	// Given...
+	tableOptions := utils.NewTableOptions(nil, "name", false)
+
+	// When...
+	queries, _, err := streamRunsWithFilters(t, nil, "summary", tableOptions, nil)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1322E: --stream cannot be used with '--sort-by'")
+	assert.Empty(t, queries)
+}
+
+func TestRunsGetStreamWithSortTheRasSearchDoesIsOk(t *testing.T) {
+	// Given...
+	filters := &RunsGetFilters{Sort: "submitted-time:desc"}
+
+	// When...
+	queries, output, err := streamRunsWithFilters(t, [][]string{{RUN_U456}}, "raw", nil, filters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "from:desc", queries[0].Get("sort"))
+	assert.Contains(t, output, "U456|")
+}
@@ -6,6 +6,7 @@
 package runs
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -234,6 +235,7 @@ func TestOutputFormatGarbageStringValidationGivesError(t *testing.T) {
 	assert.Contains(t, err.Error(), "'summary'")
 	assert.Contains(t, err.Error(), "'details'")
 	assert.Contains(t, err.Error(), "'raw'")
+	assert.Contains(t, err.Error(), "'html'")
 }
 
 func TestRunsGetOfRunNameWhichExistsProducesExpectedSummary(t *testing.T) {
@@ -262,7 +264,7 @@ func TestRunsGetOfRunNameWhichExistsProducesExpectedSummary(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then...
 	// We expect
@@ -304,7 +306,7 @@ func TestRunsGetOfRunNameWhichDoesNotExistProducesError(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then...
 	// We expect
@@ -339,7 +341,7 @@ func TestRunsGetWhereRunNameExistsTwiceProducesTwoRunResultLines(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then...
 	// We expect
@@ -380,7 +382,7 @@ func TestFailingGetRunsRequestReturnsError(t *testing.T) {
 	group := ""
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then...
 	assert.Contains(t, err.Error(), "GAL1075")
@@ -420,7 +422,7 @@ func TestRunsGetOfRunNameWhichExistsProducesExpectedDetails(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then...
 	// We expect
@@ -486,7 +488,7 @@ func TestAPIInternalErrorIsHandledOk(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then...
 	// We expect
@@ -520,7 +522,7 @@ func TestRunsGetOfRunNameWhichExistsProducesExpectedRaw(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then...
 	// We expect
@@ -531,6 +533,155 @@ func TestRunsGetOfRunNameWhichExistsProducesExpectedRaw(t *testing.T) {
 	assert.Equal(t, textGotBack, want)
 }
 
+func TestRunsGetOfRunNameWhichExistsProducesExpectedJson(t *testing.T) {
+
+	// Given ...
+	pages := make(map[string][]string, 0)
+	pages[""] = []string{RUN_U456}
+	nextPageCursors := []string{""}
+	runName := "U456"
+
+	server := NewRunsGetServletMock(t, http.StatusOK, nextPageCursors, pages, 100, runName, RUN_U456)
+	defer server.Close()
+
+	mockConsole := utils.NewMockConsole()
+	apiServerUrl := server.URL
+	apiClient := api.InitialiseAPI(apiServerUrl)
+
+	// When...
+	err := GetRuns(runName, "", "", "", false, "json", nil, "", nil, false, utils.NewMockTimeService(), mockConsole, apiServerUrl, apiClient)
+
+	// Then...
+	assert.Nil(t, err)
+
+	var runsGotBack []runsformatter.StructuredRun
+	err = json.Unmarshal([]byte(mockConsole.ReadText()), &runsGotBack)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(runsGotBack))
+
+	run := runsGotBack[0]
+	assert.Equal(t, "GalasaRun", run.Kind)
+	assert.Equal(t, "U456", run.Metadata.Name)
+	assert.Equal(t, "xxx876xxx", run.Metadata.RunId)
+	assert.Equal(t, "Passed", run.Data.Result)
+	assert.Equal(t, "myTestPackage.MyTestName", run.Data.TestName)
+	assert.Equal(t, "MyTestName", run.Data.TestShortName)
+	assert.Equal(t, "unitTesting", run.Data.Requestor)
+	assert.Equal(t, "dummyGroup", run.Data.Group)
+	assert.Equal(t, int64(137664), *run.Data.DurationMillisecs)
+	assert.Equal(t, apiServerUrl+"/ras/runs/xxx876xxx/artifacts", run.Data.ArtifactsUrl)
+	assert.Equal(t, 1, len(run.Data.Methods))
+	assert.Equal(t, "myTestMethodName", run.Data.Methods[0].MethodName)
+}
+
+func TestRunsGetOfRunNameWhichExistsProducesExpectedYaml(t *testing.T) {
+
+	// Given ...
+	pages := make(map[string][]string, 0)
+	pages[""] = []string{RUN_U456}
+	nextPageCursors := []string{""}
+	runName := "U456"
+
+	server := NewRunsGetServletMock(t, http.StatusOK, nextPageCursors, pages, 100, runName, RUN_U456)
+	defer server.Close()
+
+	mockConsole := utils.NewMockConsole()
+	apiServerUrl := server.URL
+	apiClient := api.InitialiseAPI(apiServerUrl)
+
+	// When...
+	err := GetRuns(runName, "", "", "", false, "yaml", nil, "", nil, false, utils.NewMockTimeService(), mockConsole, apiServerUrl, apiClient)
+
+	// Then...
+	assert.Nil(t, err)
+	textGotBack := mockConsole.ReadText()
+	assert.Contains(t, textGotBack, "kind: GalasaRun\nmetadata:\n    name: U456\n    runId: xxx876xxx\n")
+	assert.Contains(t, textGotBack, "    result: Passed\n")
+	assert.Contains(t, textGotBack, "        - className: myTestPackage.MyTestName\n          methodName: myTestMethodName\n")
+}
+
+func TestRunsGetOfRunNameWhichExistsProducesExpectedTemplateOutput(t *testing.T) {
+
+	// Given ...
+	pages := make(map[string][]string, 0)
+	pages[""] = []string{RUN_U456}
+	nextPageCursors := []string{""}
+	runName := "U456"
+
+	server := NewRunsGetServletMock(t, http.StatusOK, nextPageCursors, pages, 100, runName, RUN_U456)
+	defer server.Close()
+
+	mockConsole := utils.NewMockConsole()
+	apiServerUrl := server.URL
+	apiClient := api.InitialiseAPI(apiServerUrl)
+
+	template := `template={{padRight .metadata.name 6}}{{.data.testShortName}} {{.data.result}} ` +
+		`{{formatDate .data.startTime "2006-01-02"}} {{duration .data.startTime .data.endTime}}` +
+		`{{range .data.methods}} {{.methodName}}{{end}}`
+
+	// When...
+	err := GetRuns(runName, "", "", "", false, template, nil, "", nil, false, utils.NewMockTimeService(), mockConsole, apiServerUrl, apiClient)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "U456  MyTestName Passed 2023-05-10 2m17.664s myTestMethodName\n", mockConsole.ReadText())
+}
+
+func TestRunsGetWithBadTemplateFormatReturnsError(t *testing.T) {
+
+	// Given ...
+	mockConsole := utils.NewMockConsole()
+	apiServerUrl := "http://localhost:8080"
+	apiClient := api.InitialiseAPI(apiServerUrl)
+
+	// When...
+	err := GetRuns("U456", "", "", "", false, "template={{.metadata.name", nil, "", nil, false, utils.NewMockTimeService(), mockConsole, apiServerUrl, apiClient)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1309E")
+}
+
+func TestRunsGetOfRunNameInCsvFormatWithChosenColumnsAndNoHeaders(t *testing.T) {
+
+	// Given ...
+	pages := make(map[string][]string, 0)
+	pages[""] = []string{RUN_U456}
+	nextPageCursors := []string{""}
+	runName := "U456"
+
+	server := NewRunsGetServletMock(t, http.StatusOK, nextPageCursors, pages, 100, runName, RUN_U456)
+	defer server.Close()
+
+	mockConsole := utils.NewMockConsole()
+	apiServerUrl := server.URL
+	apiClient := api.InitialiseAPI(apiServerUrl)
+	tableOptions := utils.NewTableOptions([]string{"result", "name"}, "", true)
+
+	// When...
+	err := GetRuns(runName, "", "", "", false, "csv", tableOptions, "", nil, false, utils.NewMockTimeService(), mockConsole, apiServerUrl, apiClient)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "Passed,U456\n", mockConsole.ReadText())
+}
+
+func TestRunsGetInYamlFormatWithChosenColumnsReturnsError(t *testing.T) {
+
+	// Given ...
+	mockConsole := utils.NewMockConsole()
+	apiServerUrl := "http://localhost:8080"
+	apiClient := api.InitialiseAPI(apiServerUrl)
+	tableOptions := utils.NewTableOptions([]string{"name"}, "", false)
+
+	// When...
+	err := GetRuns("U456", "", "", "", false, "yaml", tableOptions, "", nil, false, utils.NewMockTimeService(), mockConsole, apiServerUrl, apiClient)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1313E")
+}
+
 func TestRunsGetWithFromAndToAge(t *testing.T) {
 
 	// Given ...
@@ -656,7 +807,7 @@ func TestRunsGetURLQueryWithFromAndToDate(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -690,7 +841,7 @@ func TestRunsGetURLQueryJustFromAge(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -724,7 +875,7 @@ func TestRunsGetURLQueryWithNoRunNameAndNoFromAgeReturnsError(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.NotNil(t, err)
@@ -759,7 +910,7 @@ func TestRunsGetURLQueryWithOlderToAgeThanFromAgeReturnsError(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.NotNil(t, err)
@@ -794,7 +945,7 @@ func TestRunsGetURLQueryWithBadlyFormedFromAndToParameterReturnsError(t *testing
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.NotNil(t, err)
@@ -984,7 +1135,7 @@ func TestRunsGetURLQueryWithRequestorNotSuppliedReturnsOK(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -1020,7 +1171,7 @@ func TestRunsGetURLQueryWithRequestorSuppliedReturnsOK(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -1056,7 +1207,7 @@ func TestRunsGetURLQueryWithNumericRequestorSuppliedReturnsOK(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -1092,7 +1243,7 @@ func TestRunsGetURLQueryWithDashInRequestorSuppliedReturnsOK(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -1128,7 +1279,7 @@ func TestRunsGetURLQueryWithAmpersandRequestorSuppliedReturnsOK(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -1165,7 +1316,7 @@ func TestRunsGetURLQueryWithSpecialCharactersRequestorSuppliedReturnsOK(t *testi
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -1195,7 +1346,7 @@ func TestRunsGetURLQueryWithResultSuppliedReturnsOK(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -1228,7 +1379,7 @@ func TestRunsGetURLQueryWithMultipleResultSuppliedReturnsOK(t *testing.T) {
 
 	// When...
 
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -1260,7 +1411,7 @@ func TestRunsGetURLQueryWithResultNotSuppliedReturnsOK(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -1290,7 +1441,7 @@ func TestRunsGetURLQueryWithInvalidResultSuppliedReturnsError(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Error(t, err)
@@ -1322,7 +1473,7 @@ func TestActiveAndResultAreMutuallyExclusiveShouldReturnError(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Error(t, err)
@@ -1353,7 +1504,7 @@ func TestActiveParameterReturnsOk(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -1392,7 +1543,7 @@ func TestRunsGetActiveRunsBuildsQueryCorrectly(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then ...
 	assert.Nil(t, err)
@@ -1426,7 +1577,7 @@ func TestRunsGetWithNextCursorGetsNextPageOfRuns(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then...
 	assert.Nil(t, err)
@@ -1463,7 +1614,7 @@ func TestRunsGetOfGroupWhichExistsProducesExpectedRaw(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then...
 	// We expect
@@ -1501,7 +1652,7 @@ func TestRunsGetWithBadGroupNameThrowsError(t *testing.T) {
 	mockTimeService := utils.NewMockTimeService()
 
 	// When...
-	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, group, mockTimeService, mockConsole, apiServerUrl, apiClient)
+	err := GetRuns(runName, age, requestor, result, shouldGetActive, outputFormat, nil, group, nil, false, mockTimeService, mockConsole, apiServerUrl, apiClient)
 
 	// Then...
 	assert.NotNil(t, err, "A non-Latin-1 group name should throw an error")
@@ -9,6 +9,7 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"regexp"
 	"strconv"
 	"time"
 
@@ -25,6 +26,19 @@ type RunsQuery struct {
     fromTime time.Time
     toTime time.Time
     shouldGetActive bool
+
+    // Filters which the RAS search supports.
+    bundle string
+    testName string
+    statuses string
+    sort string
+
+    // Filters which the RAS search does not support, so are applied to the runs it returns.
+    testNamePattern *regexp.Regexp
+    isSortChosen bool
+    sortField *runsSortField
+    isSortDescending bool
+    limit int
 }
 
 func NewRunsQuery(
@@ -43,6 +57,7 @@ func NewRunsQuery(
         result: result,
         group: group,
         shouldGetActive: shouldGetActive,
+        sort: DEFAULT_RAS_RUNS_SORT,
     }
 
     if fromAgeMins != 0 {
@@ -85,16 +100,24 @@ func (query *RunsQuery) GetRunsPageFromRestApi(
     if query.result != "" {
         apicall = apicall.Result(query.result)
     }
-    if query.shouldGetActive {
+    if query.statuses != "" {
+        apicall = apicall.Status(query.statuses)
+    } else if query.shouldGetActive {
         apicall = apicall.Status(activeStatusNames)
     }
+    if query.bundle != "" {
+        apicall = apicall.Bundle(query.bundle)
+    }
+    if query.testName != "" {
+        apicall = apicall.Testname(query.testName)
+    }
     if query.pageCursor != "" {
         apicall = apicall.Cursor(query.pageCursor)
     }
     if query.group != "" {
         apicall = apicall.Group(query.group)
     }
-    apicall = apicall.Sort("from:desc")
+    apicall = apicall.Sort(query.sort)
     runData, httpResponse, err = apicall.Execute()
 
     var statusCode int
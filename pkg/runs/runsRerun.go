@@ -0,0 +1,88 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"log"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+// GetRunToRerun - Looks the test run up in the result archive store. When the ecosystem ran the test
+// more than once under the same run name, they all ran the same test, so the latest is used.
+func GetRunToRerun(
+	runName string,
+	timeService spi.TimeService,
+	apiClient *galasaapi.APIClient,
+) (*galasaapi.Run, error) {
+	var run *galasaapi.Run
+
+	err := ValidateRunName(runName)
+	if err == nil {
+		requestorParameter := ""
+		resultParameter := ""
+		group := ""
+		fromAgeHours := 0
+		toAgeHours := 0
+		shouldGetActive := false
+
+		var runs []galasaapi.Run
+		runs, err = GetRunsFromRestApi(runName, requestorParameter, resultParameter, fromAgeHours, toAgeHours, shouldGetActive, timeService, apiClient, group)
+		if err == nil {
+			if len(runs) == 0 {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_RERUN_RUN_NOT_FOUND, runName)
+			} else {
+				// The runs are sorted with the most recent first.
+				run = &runs[0]
+				log.Printf("Found run %v with id %v to rerun\n", runName, run.GetRunId())
+			}
+		}
+	}
+	return run, err
+}
+
+// NewRerunPortfolio - A portfolio holding the test class which the run ran, so that it can be submitted again.
+// The result archive store doesn't record the stream or obr the test class came from, so they are given.
+func NewRerunPortfolio(run *galasaapi.Run, stream string, obr string) (*Portfolio, error) {
+	var err error
+	var portfolio *Portfolio
+
+	testStructure := run.GetTestStructure()
+	bundle := testStructure.GetBundle()
+	className := testStructure.GetTestName()
+
+	if bundle == "" || className == "" {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_RERUN_NO_TEST_CLASS, testStructure.GetRunName())
+	} else {
+		// The new test run is requested by the current user, not by whoever requested the earlier one.
+		log.Printf("Run %v ran test %v/%v for requestor %v\n",
+			testStructure.GetRunName(), bundle, className, testStructure.GetRequestor())
+
+		// The result archive store doesn't record the overrides the run was submitted with,
+		// so only those given for the rerun are used.
+		portfolio = NewPortfolio()
+		portfolio.Classes = append(portfolio.Classes, PortfolioClass{
+			Bundle:    bundle,
+			Class:     className,
+			Stream:    stream,
+			Obr:       obr,
+			Overrides: make(map[string]string),
+		})
+	}
+	return portfolio, err
+}
+
+// GetRerunGroupName - The new test run joins the group of the earlier one, so they can be listed together,
+// unless a group was given for it.
+func GetRerunGroupName(run *galasaapi.Run, groupName string) string {
+	if groupName == "" {
+		testStructure := run.GetTestStructure()
+		groupName = testStructure.GetGroup()
+	}
+	return groupName
+}
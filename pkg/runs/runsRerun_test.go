@@ -0,0 +1,185 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/api"
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func NewRunsRerunServletMock(t *testing.T, runName string, runResultStrings []string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/ras/runs" {
+			WriteMockRasRunsResponse(t, writer, req, runName, runResultStrings)
+		}
+	}))
+	return server
+}
+
+func newRunToRerun(bundle string, testName string) *galasaapi.Run {
+	run := galasaapi.NewRun()
+	run.TestStructure = galasaapi.NewTestStructure()
+	run.TestStructure.SetRunName("U123")
+	run.TestStructure.SetBundle(bundle)
+	run.TestStructure.SetTestName(testName)
+	return run
+}
+
+func TestGetRunToRerunFindsTheRun(t *testing.T) {
+	// Given...
+	server := NewRunsRerunServletMock(t, "U123", []string{RUN_U123_RE_RUN})
+	defer server.Close()
+	apiClient := api.InitialiseAPI(server.URL)
+
+	// When...
+	run, err := GetRunToRerun("U123", utils.NewMockTimeService(), apiClient)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "xxx123xxx", run.GetRunId())
+	assert.Equal(t, "myBundleId", run.TestStructure.GetBundle())
+}
+
+func TestGetRunToRerunWhichDoesNotExistFails(t *testing.T) {
+	// Given...
+	server := NewRunsRerunServletMock(t, "U123", []string{})
+	defer server.Close()
+	apiClient := api.InitialiseAPI(server.URL)
+
+	// When...
+	_, err := GetRunToRerun("U123", utils.NewMockTimeService(), apiClient)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1295E")
+}
+
+func TestGetRunToRerunWithBadRunNameFails(t *testing.T) {
+	// Given...
+	apiClient := api.InitialiseAPI("http://my.server")
+
+	// When...
+	_, err := GetRunToRerun("U1-23", utils.NewMockTimeService(), apiClient)
+
+	// Then...
+	assert.NotNil(t, err)
+}
+
+func TestNewRerunPortfolioHoldsTheTestClassOfTheRun(t *testing.T) {
+	// Given...
+	run := newRunToRerun("myBundle", "my.package.MyClass")
+
+	// When...
+	portfolio, err := NewRerunPortfolio(run, "myStream", "myObr")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Len(t, portfolio.Classes, 1)
+	assert.Equal(t, "myBundle", portfolio.Classes[0].Bundle)
+	assert.Equal(t, "my.package.MyClass", portfolio.Classes[0].Class)
+	assert.Equal(t, "myStream", portfolio.Classes[0].Stream)
+	assert.Equal(t, "myObr", portfolio.Classes[0].Obr)
+}
+
+func TestNewRerunPortfolioForRunWithNoTestClassFails(t *testing.T) {
+	// Given...
+	run := newRunToRerun("", "")
+
+	// When...
+	_, err := NewRerunPortfolio(run, "myStream", "")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1296E")
+}
+
+func TestRerunJoinsTheGroupOfTheEarlierRun(t *testing.T) {
+	// Given...
+	run := newRunToRerun("myBundle", "my.package.MyClass")
+	run.TestStructure.SetGroup("originalGroup")
+
+	// When...
+	groupName := GetRerunGroupName(run, "")
+
+	// Then...
+	assert.Equal(t, "originalGroup", groupName)
+}
+
+func TestRerunIsPutInTheGroupGiven(t *testing.T) {
+	// Given...
+	run := newRunToRerun("myBundle", "my.package.MyClass")
+	run.TestStructure.SetGroup("originalGroup")
+
+	// When...
+	groupName := GetRerunGroupName(run, "myGroup")
+
+	// Then...
+	assert.Equal(t, "myGroup", groupName)
+}
+
+func TestSubmitRunsWithoutWaitingSubmitsThePortfolioSet(t *testing.T) {
+	// Given...
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, files.NewMockFileSystem(), mockLauncher)
+	portfolio, err := NewRerunPortfolio(newRunToRerun("myBundle", "myClass1"), "myStream", "")
+	assert.Nil(t, err)
+	submitter.SetPortfolio(portfolio)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		GroupName:        "myGroup",
+		RequestType:      "CLI",
+		OverrideFilePath: "-",
+		Overrides:        []string{"myOverride=myValue"},
+	}
+
+	// When...
+	err = submitter.SubmitRunsWithoutWaiting(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	launches := mockLauncher.GetRecordedLaunchRecords()
+	assert.Equal(t, 1, len(launches))
+	assert.Equal(t, "myBundle/myClass1", launches[0].ClassName)
+	assert.Equal(t, "myStream", launches[0].Stream)
+	assert.Equal(t, "myValue", launches[0].Overrides["myOverride"])
+
+	consoleText := submitter.console.(*utils.MockConsole).ReadText()
+	assert.Contains(t, consoleText, "GAL2505I")
+	assert.Contains(t, consoleText, "M100")
+}
+
+func TestSubmitWaitsForThePortfolioSet(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass1", "Failed")
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	portfolio, err := NewRerunPortfolio(newRunToRerun("myBundle", "myClass1"), "myStream", "")
+	assert.Nil(t, err)
+	submitter.SetPortfolio(portfolio)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		OverrideFilePath:   "-",
+		ReportYamlFilename: "report.yaml",
+	}
+
+	// When...
+	err = submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	report := readTestReport(t, mockFileSystem)
+	assert.Len(t, report.Tests, 1)
+	assert.Equal(t, "Failed", report.Tests[0].Result)
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"log"
+	"time"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// WaitForGroup - Attaches to a group of test runs which were submitted earlier, possibly by
+// another galasactl process, and waits for them all to finish. The reports and the exit code
+// are produced in the same way as 'runs submit' produces them.
+func (submitter *Submitter) WaitForGroup(params *utils.RunsWaitCmdValues) error {
+
+	var err error
+
+	// Guard against the poll time being less than 1 second
+	if params.PollIntervalSeconds < 1 {
+		log.Printf("poll value is invalid. Less than 1. Defaulting value to %v seconds.\n", DEFAULT_POLL_INTERVAL_SECONDS)
+		params.PollIntervalSeconds = DEFAULT_POLL_INTERVAL_SECONDS
+	}
+
+	reportParams := utils.RunsSubmitCmdValues{
+		GroupName:                params.GroupName,
+		ReportYamlFilename:       params.ReportYamlFilename,
+		ReportJsonFilename:       params.ReportJsonFilename,
+		ReportJunitFilename:      params.ReportJunitFilename,
+		ReportHtmlFilename:       params.ReportHtmlFilename,
+		ReportTapFilename:        params.ReportTapFilename,
+		ReportCtrfFilename:       params.ReportCtrfFilename,
+		ReportMarkdownFilename:   params.ReportMarkdownFilename,
+		ReportJunitRunLogLines:   params.ReportJunitRunLogLines,
+		NoExitCodeOnTestFailures: params.NoExitCodeOnTestFailures,
+		ResultPolicyFileName:     params.ResultPolicyFileName,
+		ExcusedResults:           params.ExcusedResults,
+		MinPassRate:              params.MinPassRate,
+		Quarantine:               params.Quarantine,
+	}
+	err = submitter.tildaExpandAllPaths(&reportParams)
+
+	if err == nil {
+		submitter.resultPolicy, err = NewResultPolicy(submitter.fileSystem, reportParams)
+	}
+
+	var submittedRuns map[string]*TestRun
+	if err == nil {
+		submittedRuns, err = submitter.getRunsInGroup(params.GroupName)
+	}
+
+	if err == nil {
+		finishedRuns := make(map[string]*TestRun)
+		lostRuns := make(map[string]*TestRun)
+
+		var isTimedOut bool
+		isTimedOut = submitter.waitForRunsToFinish(params, reportParams, submittedRuns, finishedRuns, lostRuns)
+
+		unfinishedCount := len(submittedRuns)
+		if isTimedOut {
+			// Anything which didn't finish in time can't be reported as passing.
+			for runName, run := range submittedRuns {
+				log.Printf("Run %v did not finish before the timeout - %v/%v/%v\n", runName, run.Stream, run.Bundle, run.Class)
+				lostRuns[runName] = run
+				delete(submittedRuns, runName)
+			}
+
+			// Timing out is more important to report than the test failures it causes, but not
+			// more important than failing to write the reports.
+			reportParams.NoExitCodeOnTestFailures = true
+		}
+
+		err = submitter.reportResults(reportParams, finishedRuns, lostRuns)
+
+		if err == nil && isTimedOut {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_WAIT_TIMED_OUT, params.Timeout, params.GroupName, unfinishedCount)
+		}
+	}
+
+	return err
+}
+
+// getRunsInGroup - Builds the list of runs to monitor from the runs the ecosystem knows are in the group.
+func (submitter *Submitter) getRunsInGroup(groupName string) (map[string]*TestRun, error) {
+	var err error
+	runsInGroup := make(map[string]*TestRun)
+
+	var currentGroup *galasaapi.TestRuns
+	currentGroup, err = submitter.launcher.GetRunsByGroup(groupName)
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_WAIT_GROUP_FETCH_FAILED, groupName, err.Error())
+	} else {
+		for _, currentRun := range currentGroup.GetRuns() {
+			runName := currentRun.GetName()
+			runsInGroup[runName] = &TestRun{
+				Name:          runName,
+				Bundle:        currentRun.GetBundleName(),
+				Class:         currentRun.GetTestName(),
+				Stream:        currentRun.GetStream(),
+				Obr:           currentRun.GetObr(),
+				Status:        currentRun.GetStatus(),
+				QueuedTimeUTC: currentRun.GetQueued(),
+				Requestor:     currentRun.GetRequestor(),
+				Overrides:     make(map[string]string),
+				Group:         groupName,
+			}
+			log.Printf("Waiting for run %v - %v/%v/%v\n", runName, currentRun.GetStream(), currentRun.GetBundleName(), currentRun.GetTestName())
+		}
+
+		if len(runsInGroup) == 0 {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_WAIT_GROUP_EMPTY, groupName)
+		}
+	}
+	return runsInGroup, err
+}
+
+// waitForRunsToFinish - Polls the status of the runs in the group until they have all finished,
+// or the timeout passes. Returns true if it gave up waiting because of the timeout.
+func (submitter *Submitter) waitForRunsToFinish(
+	params *utils.RunsWaitCmdValues,
+	reportParams utils.RunsSubmitCmdValues,
+	submittedRuns map[string]*TestRun,
+	finishedRuns map[string]*TestRun,
+	lostRuns map[string]*TestRun,
+) bool {
+
+	isTimedOut := false
+
+	pollInterval := time.Second * time.Duration(params.PollIntervalSeconds)
+	progressReportInterval := time.Minute * time.Duration(params.ProgressReportIntervalMinutes)
+	fetchRas := submitter.isRasDetailNeededForReports(reportParams)
+
+	startTime := submitter.timeService.Now()
+	nextProgressReport := startTime.Add(progressReportInterval)
+
+	for len(submittedRuns) > 0 && !isTimedOut {
+
+		submitter.runsFetchCurrentStatus(params.GroupName, submittedRuns, finishedRuns, lostRuns, fetchRas)
+
+		now := submitter.timeService.Now()
+
+		// Only do progress reporting if the user didn't disable it.
+		if params.ProgressReportIntervalMinutes > 0 && now.After(nextProgressReport) {
+			submitter.displayInterrimProgressReport(nil, submittedRuns, finishedRuns, lostRuns, len(submittedRuns))
+			nextProgressReport = now.Add(progressReportInterval)
+		}
+
+		if len(submittedRuns) > 0 {
+			if params.Timeout > 0 && !now.Before(startTime.Add(params.Timeout)) {
+				log.Printf("Timed out after %v waiting for runs in group '%v' to finish\n", params.Timeout, params.GroupName)
+				isTimedOut = true
+			} else {
+				submitter.timedSleeper.Sleep(pollInterval)
+			}
+		}
+	}
+
+	return isTimedOut
+}
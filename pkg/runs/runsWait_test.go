@@ -0,0 +1,198 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/images"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSubmitterForWaitTests(t *testing.T, mockFileSystem spi.FileSystem, mockLauncher *launcher.MockLauncher, mockTimeService *utils.MockTimeService) *Submitter {
+	env := utils.NewMockEnv()
+	galasaHome, err := utils.NewGalasaHome(mockFileSystem, env, "")
+	assert.Nil(t, err)
+
+	return NewSubmitter(
+		galasaHome,
+		mockFileSystem,
+		mockLauncher,
+		mockTimeService,
+		utils.NewMockTimedSleeper(mockTimeService),
+		env,
+		utils.NewMockConsole(),
+		images.NewImageExpanderNullImpl(),
+	)
+}
+
+func TestWaitForGroupOfPassedRunsWritesReports(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SubmitTestRun("myGroup", "myBundle/myClass1", "CLI", "myuserid", "myStream", "myobr", false, "", "", nil)
+	mockLauncher.SubmitTestRun("myGroup", "myBundle/myClass2", "CLI", "myuserid", "myStream", "myobr", false, "", "", nil)
+
+	submitter := newSubmitterForWaitTests(t, mockFileSystem, mockLauncher, utils.NewMockTimeService())
+	params := &utils.RunsWaitCmdValues{
+		GroupName:          "myGroup",
+		ReportYamlFilename: "report.yaml",
+	}
+
+	// When...
+	err := submitter.WaitForGroup(params)
+
+	// Then...
+	assert.Nil(t, err)
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 2, len(report.Tests))
+
+	// The runs in the group aren't reported in any particular order.
+	classNames := make([]string, 0)
+	for _, test := range report.Tests {
+		classNames = append(classNames, test.Class)
+		assert.Equal(t, RESULT_PASSED, test.Result)
+		assert.Equal(t, "myGroup", test.Group)
+	}
+	assert.ElementsMatch(t, []string{"myClass1", "myClass2"}, classNames)
+}
+
+func TestWaitForGroupWritesTapCtrfAndMarkdownReports(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SubmitTestRun("myGroup", "myBundle/myClass1", "CLI", "myuserid", "myStream", "myobr", false, "", "", nil)
+
+	submitter := newSubmitterForWaitTests(t, mockFileSystem, mockLauncher, utils.NewMockTimeService())
+	params := &utils.RunsWaitCmdValues{
+		GroupName:              "myGroup",
+		ReportTapFilename:      "report.tap",
+		ReportCtrfFilename:     "report.ctrf.json",
+		ReportMarkdownFilename: "report.md",
+	}
+
+	// When...
+	err := submitter.WaitForGroup(params)
+
+	// Then...
+	assert.Nil(t, err)
+	for _, filename := range []string{"report.tap", "report.ctrf.json", "report.md"} {
+		isExists, _ := mockFileSystem.Exists(filename)
+		assert.True(t, isExists, "report %v was not written", filename)
+	}
+}
+
+func TestWaitForGroupWithAFailedRunReturnsTestsFailedError(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass1", RESULT_FAILED)
+	mockLauncher.SubmitTestRun("myGroup", "myBundle/myClass1", "CLI", "myuserid", "myStream", "myobr", false, "", "", nil)
+
+	submitter := newSubmitterForWaitTests(t, mockFileSystem, mockLauncher, utils.NewMockTimeService())
+	params := &utils.RunsWaitCmdValues{GroupName: "myGroup"}
+
+	// When...
+	err := submitter.WaitForGroup(params)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1017E")
+}
+
+func TestWaitForGroupWithAFailedRunAndNoExitCodeOnTestFailuresIsOk(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass1", RESULT_FAILED)
+	mockLauncher.SubmitTestRun("myGroup", "myBundle/myClass1", "CLI", "myuserid", "myStream", "myobr", false, "", "", nil)
+
+	submitter := newSubmitterForWaitTests(t, mockFileSystem, mockLauncher, utils.NewMockTimeService())
+	params := &utils.RunsWaitCmdValues{GroupName: "myGroup", NoExitCodeOnTestFailures: true}
+
+	// When...
+	err := submitter.WaitForGroup(params)
+
+	// Then...
+	assert.Nil(t, err)
+}
+
+func TestWaitForEmptyGroupFails(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	submitter := newSubmitterForWaitTests(t, mockFileSystem, launcher.NewMockLauncher(), utils.NewMockTimeService())
+	params := &utils.RunsWaitCmdValues{GroupName: "myGroup"}
+
+	// When...
+	err := submitter.WaitForGroup(params)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1239E")
+}
+
+func TestWaitForGroupTimesOutAndReportsUnfinishedRunsAsLost(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SubmitTestRun("myGroup", "myBundle/myClass1", "CLI", "myuserid", "myStream", "myobr", false, "", "", nil)
+	mockLauncher.SubmitTestRun("myGroup", "myBundle/myClass2", "CLI", "myuserid", "myStream", "myobr", false, "", "", nil)
+	mockLauncher.SetRunStatus("M101", "running")
+
+	mockTimeService := utils.NewMockTimeService()
+	startTime := mockTimeService.Now()
+	submitter := newSubmitterForWaitTests(t, mockFileSystem, mockLauncher, mockTimeService)
+	params := &utils.RunsWaitCmdValues{
+		GroupName:           "myGroup",
+		PollIntervalSeconds: 60,
+		Timeout:             10 * time.Minute,
+		ReportYamlFilename:  "report.yaml",
+	}
+
+	// When...
+	err := submitter.WaitForGroup(params)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1240E")
+	assert.Equal(t, startTime.Add(10*time.Minute), mockTimeService.Now())
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 2, len(report.Tests))
+}
+
+func TestWaitForGroupTimesOutButReportsFailingToWriteTheReport(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewOverridableMockFileSystem()
+	mockFileSystem.VirtualFunction_WriteBinaryFile = func(targetFilePath string, desiredContents []byte) error {
+		return errors.New("simulated disk full")
+	}
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SubmitTestRun("myGroup", "myBundle/myClass1", "CLI", "myuserid", "myStream", "myobr", false, "", "", nil)
+	mockLauncher.SetRunStatus("M100", "running")
+
+	submitter := newSubmitterForWaitTests(t, mockFileSystem, mockLauncher, utils.NewMockTimeService())
+	params := &utils.RunsWaitCmdValues{
+		GroupName:           "myGroup",
+		PollIntervalSeconds: 60,
+		Timeout:             10 * time.Minute,
+		ReportYamlFilename:  "report.yaml",
+	}
+
+	// When...
+	err := submitter.WaitForGroup(params)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1007E")
+	assert.NotContains(t, err.Error(), "GAL1240E")
+}
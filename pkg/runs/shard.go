@@ -0,0 +1,259 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+)
+
+// Ways of deciding which shard each test class of a portfolio goes in.
+const (
+	// Each shard gets as close to the same number of test classes as possible.
+	SHARD_STRATEGY_COUNT = "count"
+
+	// Each shard gets as close to the same total run time as possible, judged by how long
+	// each test class took to run in the past.
+	SHARD_STRATEGY_DURATION = "duration"
+
+	DEFAULT_SHARD_STRATEGY = SHARD_STRATEGY_COUNT
+)
+
+// A test class which has never run before is assumed to take as long as the average of those
+// which have. If none of them have run before, they are all assumed to take this long.
+const DEFAULT_UNKNOWN_RUN_DURATION = time.Minute * 10
+
+// Shard - One of a number of slices of a portfolio, numbered from 1.
+type Shard struct {
+	Index int
+	Count int
+}
+
+// ParseShard - Reads a shard given as "index/count", such as "2/5".
+func ParseShard(shardValue string) (*Shard, error) {
+	var err error
+	var shard *Shard
+
+	parts := strings.Split(shardValue, "/")
+	if len(parts) != 2 {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_INVALID_SHARD, shardValue)
+	} else {
+		var index int
+		var count int
+		index, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err == nil {
+			count, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+		}
+
+		if err != nil || count < 1 || index < 1 || index > count {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_INVALID_SHARD, shardValue)
+		} else {
+			shard = &Shard{Index: index, Count: count}
+		}
+	}
+	return shard, err
+}
+
+func (shard *Shard) String() string {
+	return strconv.Itoa(shard.Index) + "/" + strconv.Itoa(shard.Count)
+}
+
+func IsValidShardStrategy(strategy string) bool {
+	return strategy == SHARD_STRATEGY_COUNT || strategy == SHARD_STRATEGY_DURATION
+}
+
+// shardUnit - Test classes which must go in the same shard, because some of them depend on others.
+type shardUnit struct {
+	classIndexes []int
+
+	// The lowest class name in the unit, to order units which weigh the same.
+	name   string
+	weight time.Duration
+}
+
+// ShardPortfolio - Keeps only the test classes of the portfolio which belong to the shard.
+//
+// Every invocation sharding the same portfolio the same way puts each class in the same shard,
+// whatever order the portfolio lists its classes in, so every class lands in exactly one shard.
+// Classes which depend on each other are kept together in the same shard.
+//
+// With the duration strategy, the run durations must be the same for every invocation too.
+// See RunDurationHistory.
+func ShardPortfolio(portfolio *Portfolio, shard *Shard, strategy string, durationHistory RunDurationHistory) error {
+	var err error
+
+	units := getShardUnits(portfolio.Classes)
+
+	if strategy == SHARD_STRATEGY_DURATION {
+		err = weighShardUnitsByDuration(units, portfolio.Classes, durationHistory)
+	} else {
+		for index := range units {
+			units[index].weight = time.Duration(len(units[index].classIndexes))
+		}
+	}
+
+	if err == nil {
+		isClassInShard := make([]bool, len(portfolio.Classes))
+		for _, unit := range assignUnitsToShard(units, shard) {
+			for _, classIndex := range unit.classIndexes {
+				isClassInShard[classIndex] = true
+			}
+		}
+
+		shardClasses := make([]PortfolioClass, 0)
+		for classIndex, portfolioClass := range portfolio.Classes {
+			if isClassInShard[classIndex] {
+				shardClasses = append(shardClasses, portfolioClass)
+			}
+		}
+
+		log.Printf("Shard %v has %v of the %v test classes in the portfolio\n", shard, len(shardClasses), len(portfolio.Classes))
+		portfolio.Classes = shardClasses
+	}
+
+	return err
+}
+
+// getShardUnits - Groups together the classes which depend on each other, directly or indirectly.
+func getShardUnits(classes []PortfolioClass) []shardUnit {
+
+	// Each class starts in a group of its own. Groups are merged as dependencies are found.
+	groupOfClass := make([]int, len(classes))
+	for index := range classes {
+		groupOfClass[index] = index
+	}
+	var findGroup func(index int) int
+	findGroup = func(index int) int {
+		if groupOfClass[index] != index {
+			groupOfClass[index] = findGroup(groupOfClass[index])
+		}
+		return groupOfClass[index]
+	}
+
+	classIndexesByName := make(map[string][]int)
+	for index := range classes {
+		className := classes[index].getClassName()
+		classIndexesByName[className] = append(classIndexesByName[className], index)
+	}
+
+	for index := range classes {
+		for _, dependency := range classes[index].DependsOn {
+			for _, dependencyIndex := range classIndexesByName[dependency] {
+				groupOfClass[findGroup(dependencyIndex)] = findGroup(index)
+			}
+		}
+	}
+
+	unitsByGroup := make(map[int]*shardUnit)
+	groups := make([]int, 0)
+	for index := range classes {
+		group := findGroup(index)
+		unit, isFound := unitsByGroup[group]
+		if !isFound {
+			unit = new(shardUnit)
+			unitsByGroup[group] = unit
+			groups = append(groups, group)
+		}
+		unit.classIndexes = append(unit.classIndexes, index)
+	}
+
+	units := make([]shardUnit, 0, len(groups))
+	for _, group := range groups {
+		unit := unitsByGroup[group]
+		unit.name = getShardSortName(classes, unit.classIndexes)
+		units = append(units, *unit)
+	}
+	return units
+}
+
+// getShardSortName - A name for a group of classes which doesn't depend on the order the portfolio lists them in.
+func getShardSortName(classes []PortfolioClass, classIndexes []int) string {
+	name := ""
+	for _, classIndex := range classIndexes {
+		className := classes[classIndex].getClassName() + classes[classIndex].GherkinUrl
+		if name == "" || className < name {
+			name = className
+		}
+	}
+	return name
+}
+
+func weighShardUnitsByDuration(units []shardUnit, classes []PortfolioClass, durationHistory RunDurationHistory) error {
+	var err error
+
+	if durationHistory == nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_SHARD_DURATION_UNAVAILABLE)
+	} else {
+		durations := make([]time.Duration, len(classes))
+		isKnown := make([]bool, len(classes))
+		var totalKnownDuration time.Duration
+		knownCount := 0
+
+		for index := range classes {
+			durations[index], isKnown[index], err = durationHistory.GetAverageRunDuration(classes[index].Bundle, classes[index].Class)
+			if err != nil {
+				break
+			}
+			if isKnown[index] {
+				totalKnownDuration += durations[index]
+				knownCount++
+			}
+		}
+
+		if err == nil {
+			unknownDuration := DEFAULT_UNKNOWN_RUN_DURATION
+			if knownCount > 0 {
+				unknownDuration = totalKnownDuration / time.Duration(knownCount)
+			}
+
+			for unitIndex := range units {
+				for _, classIndex := range units[unitIndex].classIndexes {
+					if isKnown[classIndex] {
+						units[unitIndex].weight += durations[classIndex]
+					} else {
+						units[unitIndex].weight += unknownDuration
+					}
+				}
+			}
+		}
+	}
+	return err
+}
+
+// assignUnitsToShard - Gives each unit in turn, heaviest first, to whichever shard is lightest so far.
+// Returns the units which the shard asked for was given.
+func assignUnitsToShard(units []shardUnit, shard *Shard) []shardUnit {
+	sortedUnits := make([]shardUnit, len(units))
+	copy(sortedUnits, units)
+	sort.SliceStable(sortedUnits, func(i int, j int) bool {
+		if sortedUnits[i].weight != sortedUnits[j].weight {
+			return sortedUnits[i].weight > sortedUnits[j].weight
+		}
+		return sortedUnits[i].name < sortedUnits[j].name
+	})
+
+	shardWeights := make([]time.Duration, shard.Count)
+	unitsInShard := make([]shardUnit, 0)
+	for _, unit := range sortedUnits {
+		lightestShard := 0
+		for shardIndex := range shardWeights {
+			if shardWeights[shardIndex] < shardWeights[lightestShard] {
+				lightestShard = shardIndex
+			}
+		}
+		shardWeights[lightestShard] += unit.weight
+
+		if lightestShard == shard.Index-1 {
+			unitsInShard = append(unitsInShard, unit)
+		}
+	}
+	return unitsInShard
+}
@@ -0,0 +1,286 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// A run duration history which knows how long some test classes take, by bundle/class name.
+type mockRunDurationHistory map[string]time.Duration
+
+func (history mockRunDurationHistory) GetAverageRunDuration(bundle string, className string) (time.Duration, bool, error) {
+	duration, isKnown := history[bundle+"/"+className]
+	return duration, isKnown, nil
+}
+
+func newPortfolioOfClasses(classNames ...string) *Portfolio {
+	portfolio := NewPortfolio()
+	for _, className := range classNames {
+		portfolio.Classes = append(portfolio.Classes, PortfolioClass{Bundle: "myBundle", Class: className, Obr: "myobr"})
+	}
+	return portfolio
+}
+
+func getShardClassNames(t *testing.T, portfolio *Portfolio, shard *Shard, strategy string, history RunDurationHistory) []string {
+	shardPortfolio := *portfolio
+	err := ShardPortfolio(&shardPortfolio, shard, strategy, history)
+	assert.Nil(t, err)
+
+	classNames := make([]string, 0)
+	for _, portfolioClass := range shardPortfolio.Classes {
+		classNames = append(classNames, portfolioClass.Class)
+	}
+	return classNames
+}
+
+func TestParseShardReadsIndexAndCount(t *testing.T) {
+	shard, err := ParseShard("2/5")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Shard{Index: 2, Count: 5}, shard)
+	assert.Equal(t, "2/5", shard.String())
+}
+
+func TestParseShardRejectsBadValues(t *testing.T) {
+	for _, shardValue := range []string{"", "2", "0/5", "6/5", "1/0", "-1/5", "a/5", "1/b", "1/2/3"} {
+		_, err := ParseShard(shardValue)
+
+		assert.NotNil(t, err, shardValue)
+		assert.Contains(t, err.Error(), "GAL1263E", shardValue)
+	}
+}
+
+func TestShardByCountPutsEachClassInExactlyOneShard(t *testing.T) {
+	// Given...
+	portfolio := newPortfolioOfClasses("myClass1", "myClass2", "myClass3", "myClass4", "myClass5", "myClass6", "myClass7")
+
+	// When...
+	timesShardedByClass := make(map[string]int)
+	for index := 1; index <= 3; index++ {
+		classNames := getShardClassNames(t, portfolio, &Shard{Index: index, Count: 3}, SHARD_STRATEGY_COUNT, nil)
+
+		// Then...
+		assert.GreaterOrEqual(t, len(classNames), 2, "shard "+strconv.Itoa(index))
+		assert.LessOrEqual(t, len(classNames), 3, "shard "+strconv.Itoa(index))
+		for _, className := range classNames {
+			timesShardedByClass[className]++
+		}
+	}
+
+	assert.Equal(t, 7, len(timesShardedByClass))
+	for className, times := range timesShardedByClass {
+		assert.Equal(t, 1, times, className)
+	}
+}
+
+func TestShardDoesNotDependOnTheOrderOfThePortfolio(t *testing.T) {
+	// Given...
+	portfolio := newPortfolioOfClasses("myClass1", "myClass2", "myClass3", "myClass4", "myClass5")
+	reversedPortfolio := newPortfolioOfClasses("myClass5", "myClass4", "myClass3", "myClass2", "myClass1")
+
+	for index := 1; index <= 2; index++ {
+		shard := &Shard{Index: index, Count: 2}
+
+		// When...
+		classNames := getShardClassNames(t, portfolio, shard, SHARD_STRATEGY_COUNT, nil)
+		reversedClassNames := getShardClassNames(t, reversedPortfolio, shard, SHARD_STRATEGY_COUNT, nil)
+
+		// Then...
+		assert.ElementsMatch(t, classNames, reversedClassNames)
+	}
+}
+
+func TestShardKeepsThePortfolioOrderWithinTheShard(t *testing.T) {
+	portfolio := newPortfolioOfClasses("myClass3", "myClass1", "myClass4", "myClass2")
+
+	classNames := getShardClassNames(t, portfolio, &Shard{Index: 1, Count: 1}, SHARD_STRATEGY_COUNT, nil)
+
+	assert.Equal(t, []string{"myClass3", "myClass1", "myClass4", "myClass2"}, classNames)
+}
+
+func TestShardWithMoreShardsThanClassesLeavesSomeShardsEmpty(t *testing.T) {
+	portfolio := newPortfolioOfClasses("myClass1", "myClass2")
+
+	classNames := getShardClassNames(t, portfolio, &Shard{Index: 3, Count: 3}, SHARD_STRATEGY_COUNT, nil)
+
+	assert.Empty(t, classNames)
+}
+
+func TestShardKeepsClassesWhichDependOnEachOtherTogether(t *testing.T) {
+	// Given...
+	portfolio := newV1betaPortfolio(
+		newScheduledClass("mySetup", PortfolioClassScheduling{}),
+		newScheduledClass("myClass1", PortfolioClassScheduling{}),
+		newScheduledClass("myTest", PortfolioClassScheduling{DependsOn: []string{"myBundle/mySetup"}}),
+		newScheduledClass("myClass2", PortfolioClassScheduling{}),
+		newScheduledClass("myTeardown", PortfolioClassScheduling{DependsOn: []string{"myBundle/myTest"}}),
+	)
+
+	// When...
+	shard1ClassNames := getShardClassNames(t, portfolio, &Shard{Index: 1, Count: 2}, SHARD_STRATEGY_COUNT, nil)
+	shard2ClassNames := getShardClassNames(t, portfolio, &Shard{Index: 2, Count: 2}, SHARD_STRATEGY_COUNT, nil)
+
+	// Then...
+	assert.Equal(t, []string{"mySetup", "myTest", "myTeardown"}, shard1ClassNames)
+	assert.Equal(t, []string{"myClass1", "myClass2"}, shard2ClassNames)
+}
+
+func TestShardByDurationBalancesTheRunTimeOfEachShard(t *testing.T) {
+	// Given...
+	portfolio := newPortfolioOfClasses("myClass1", "myClass2", "myClass3", "myClass4")
+	history := mockRunDurationHistory{
+		"myBundle/myClass1": time.Minute * 10,
+		"myBundle/myClass2": time.Minute * 60,
+		"myBundle/myClass3": time.Minute * 20,
+		"myBundle/myClass4": time.Minute * 30,
+	}
+
+	// When...
+	shard1ClassNames := getShardClassNames(t, portfolio, &Shard{Index: 1, Count: 2}, SHARD_STRATEGY_DURATION, history)
+	shard2ClassNames := getShardClassNames(t, portfolio, &Shard{Index: 2, Count: 2}, SHARD_STRATEGY_DURATION, history)
+
+	// Then...
+	assert.Equal(t, []string{"myClass2"}, shard1ClassNames)
+	assert.Equal(t, []string{"myClass1", "myClass3", "myClass4"}, shard2ClassNames)
+}
+
+func TestShardByDurationAssumesClassesWithNoHistoryTakeTheAverageTime(t *testing.T) {
+	// Given...
+	portfolio := newPortfolioOfClasses("myClass1", "myClass2", "myNewClass")
+	history := mockRunDurationHistory{
+		"myBundle/myClass1": time.Minute * 10,
+		"myBundle/myClass2": time.Minute * 50,
+	}
+
+	// When...
+	// myNewClass is taken to need 30 minutes, so goes with myClass1 rather than with myClass2.
+	shard1ClassNames := getShardClassNames(t, portfolio, &Shard{Index: 1, Count: 2}, SHARD_STRATEGY_DURATION, history)
+	shard2ClassNames := getShardClassNames(t, portfolio, &Shard{Index: 2, Count: 2}, SHARD_STRATEGY_DURATION, history)
+
+	// Then...
+	assert.Equal(t, []string{"myClass2"}, shard1ClassNames)
+	assert.Equal(t, []string{"myClass1", "myNewClass"}, shard2ClassNames)
+}
+
+func TestShardByDurationWithoutHistoryReturnsError(t *testing.T) {
+	portfolio := newPortfolioOfClasses("myClass1", "myClass2")
+
+	err := ShardPortfolio(portfolio, &Shard{Index: 1, Count: 2}, SHARD_STRATEGY_DURATION, nil)
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1265E")
+}
+
+func TestSubmitWithShardOnlySubmitsTheClassesOfThatShard(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createThreeClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+		Shard:              "2/2",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"myBundle/myClass2"}, getLaunchedClassNames(mockLauncher))
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 1, len(report.Tests))
+	assert.Contains(t, submitter.console.(*utils.MockConsole).ReadText(), "Submitting shard 2/2, with 1 test classes.")
+}
+
+func TestSubmitWithEmptyShardSubmitsNothingAndSucceeds(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+		Shard:              "3/3",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Empty(t, mockLauncher.GetRecordedLaunchRecords())
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Empty(t, report.Tests)
+}
+
+func TestSubmitWithShardByDurationUsesTheRunDurationHistory(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createThreeClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	submitter.SetRunDurationHistory(mockRunDurationHistory{
+		"myBundle/myClass1": time.Minute * 5,
+		"myBundle/myClass2": time.Minute * 5,
+		"myBundle/myClass3": time.Minute * 60,
+	})
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		Shard:             "2/2",
+		ShardStrategy:     SHARD_STRATEGY_DURATION,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"myBundle/myClass1", "myBundle/myClass2"}, getLaunchedClassNames(mockLauncher))
+}
+
+func TestSubmitWithBadShardParametersReturnsErrors(t *testing.T) {
+	testCases := []struct {
+		params        utils.RunsSubmitCmdValues
+		expectedError string
+	}{
+		{utils.RunsSubmitCmdValues{PortfolioFileName: "my.portfolio", Shard: "4/3"}, "GAL1263E"},
+		{utils.RunsSubmitCmdValues{PortfolioFileName: "my.portfolio", Shard: "1/3", ShardStrategy: "random"}, "GAL1264E"},
+		{utils.RunsSubmitCmdValues{ResumeJournalFileName: "my.journal", Shard: "1/3"}, "GAL1267E"},
+	}
+
+	for _, testCase := range testCases {
+		// Given...
+		mockFileSystem := files.NewMockFileSystem()
+		createTwoClassPortfolio(t, mockFileSystem)
+		mockLauncher := launcher.NewMockLauncher()
+		submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+		params := testCase.params
+
+		// When...
+		err := submitter.ExecuteSubmitRuns(&params, newEmptyTestSelectionFlags())
+
+		// Then...
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), testCase.expectedError)
+		assert.Empty(t, mockLauncher.GetRecordedLaunchRecords())
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"sync"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+)
+
+// SubmissionState - What a running submission is doing, as seen by a 'runs control' command.
+type SubmissionState struct {
+	Paused    bool      `json:"paused"`
+	Throttle  int       `json:"throttle"`
+	Ready     []TestRun `json:"ready"`
+	Submitted []TestRun `json:"submitted"`
+	Finished  []TestRun `json:"finished"`
+	Lost      []TestRun `json:"lost"`
+}
+
+// SubmissionControls - Requests made to a running submission from outside it, and the latest state
+// of the submission for those requests to be checked against. Requests arrive on other goroutines,
+// so everything is guarded by a mutex. The submitter acts on the requests each time it polls.
+type SubmissionControls struct {
+	mutex sync.Mutex
+
+	isPaused bool
+
+	// The throttle the submission should change to. Zero if no change was asked for.
+	requestedThrottle int
+
+	// Names of runs in progress, and bundle/class names of tests waiting to be submitted, to cancel.
+	cancelRequests []string
+
+	state SubmissionState
+}
+
+func NewSubmissionControls() *SubmissionControls {
+	controls := new(SubmissionControls)
+	controls.state = newEmptySubmissionState()
+	return controls
+}
+
+func newEmptySubmissionState() SubmissionState {
+	return SubmissionState{
+		Ready:     make([]TestRun, 0),
+		Submitted: make([]TestRun, 0),
+		Finished:  make([]TestRun, 0),
+		Lost:      make([]TestRun, 0),
+	}
+}
+
+// Pause - Stops any more tests being submitted. Tests already submitted carry on running.
+func (controls *SubmissionControls) Pause() {
+	controls.mutex.Lock()
+	defer controls.mutex.Unlock()
+	controls.isPaused = true
+}
+
+// Resume - Allows tests to be submitted again after a pause.
+func (controls *SubmissionControls) Resume() {
+	controls.mutex.Lock()
+	defer controls.mutex.Unlock()
+	controls.isPaused = false
+}
+
+// SetThrottle - Changes how many tests may be running at once.
+func (controls *SubmissionControls) SetThrottle(throttle int) error {
+	var err error
+	if throttle < 1 {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_CONTROL_INVALID_THROTTLE, throttle)
+	} else {
+		controls.mutex.Lock()
+		defer controls.mutex.Unlock()
+		controls.requestedThrottle = throttle
+	}
+	return err
+}
+
+// RequestCancel - Asks for test runs in progress to be cancelled, given their names, and for tests
+// waiting to be submitted not to be, given their bundle/class names. Nothing is cancelled if any of
+// the names isn't known.
+func (controls *SubmissionControls) RequestCancel(names []string) error {
+	var err error
+
+	controls.mutex.Lock()
+	defer controls.mutex.Unlock()
+
+	for _, name := range names {
+		if !controls.isCancellable(name) {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_CONTROL_CANCEL_UNKNOWN_RUN, name)
+			break
+		}
+	}
+
+	if err == nil {
+		controls.cancelRequests = append(controls.cancelRequests, names...)
+	}
+	return err
+}
+
+func (controls *SubmissionControls) isCancellable(name string) bool {
+	isCancellable := false
+	for _, run := range controls.state.Submitted {
+		if run.Name == name {
+			isCancellable = true
+		}
+	}
+	for _, run := range controls.state.Ready {
+		if run.getTestKey() == name {
+			isCancellable = true
+		}
+	}
+	return isCancellable
+}
+
+// GetState - What the submission was doing the last time it polled.
+func (controls *SubmissionControls) GetState() SubmissionState {
+	controls.mutex.Lock()
+	defer controls.mutex.Unlock()
+	state := controls.state
+	state.Paused = controls.isPaused
+	return state
+}
+
+func (controls *SubmissionControls) isSubmissionPaused() bool {
+	controls.mutex.Lock()
+	defer controls.mutex.Unlock()
+	return controls.isPaused
+}
+
+// takeThrottleRequest - The throttle which was asked for since the last time, if any.
+func (controls *SubmissionControls) takeThrottleRequest() (int, bool) {
+	controls.mutex.Lock()
+	defer controls.mutex.Unlock()
+	throttle := controls.requestedThrottle
+	controls.requestedThrottle = 0
+	return throttle, throttle > 0
+}
+
+// takeCancelRequests - The names of the runs and tests asked to be cancelled since the last time.
+func (controls *SubmissionControls) takeCancelRequests() []string {
+	controls.mutex.Lock()
+	defer controls.mutex.Unlock()
+	names := controls.cancelRequests
+	controls.cancelRequests = nil
+	return names
+}
+
+// ReportProgress - Records the state of the submission each time it polls. Copies of the runs are
+// kept, as the submitter carries on changing its own. The state is sent to anyone holding the control
+// token, so override values which look like secrets are masked in the copies.
+func (controls *SubmissionControls) ReportProgress(progress SubmissionProgress) {
+	state := newEmptySubmissionState()
+	state.Throttle = progress.Throttle
+	for _, run := range progress.ReadyRuns {
+		state.Ready = append(state.Ready, copyRunForState(&run))
+	}
+	for _, runName := range getSortedRunNames(progress.SubmittedRuns) {
+		state.Submitted = append(state.Submitted, copyRunForState(progress.SubmittedRuns[runName]))
+	}
+	for _, runName := range getSortedRunNames(progress.FinishedRuns) {
+		state.Finished = append(state.Finished, copyRunForState(progress.FinishedRuns[runName]))
+	}
+	for _, runName := range getSortedRunNames(progress.LostRuns) {
+		state.Lost = append(state.Lost, copyRunForState(progress.LostRuns[runName]))
+	}
+
+	controls.mutex.Lock()
+	defer controls.mutex.Unlock()
+	controls.state = state
+}
+
+func copyRunForState(run *TestRun) TestRun {
+	runCopy := *run
+	runCopy.Overrides = maskSecretOverrides(run.Overrides)
+	return runCopy
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newControlsWithOneRunOfEachKind() *SubmissionControls {
+	controls := NewSubmissionControls()
+	controls.ReportProgress(SubmissionProgress{
+		ReadyRuns: []TestRun{{Bundle: "myBundle", Class: "myClass2"}},
+		SubmittedRuns: map[string]*TestRun{
+			"U100": {Name: "U100", Bundle: "myBundle", Class: "myClass1", Status: "running"},
+		},
+		FinishedRuns: map[string]*TestRun{
+			"U99": {Name: "U99", Bundle: "myBundle", Class: "myClass0", Status: "finished", Result: RESULT_PASSED},
+		},
+		LostRuns: make(map[string]*TestRun),
+		Throttle: 1,
+	})
+	return controls
+}
+
+func TestControlsStateIsACopyOfTheLastProgressReported(t *testing.T) {
+	// Given...
+	controls := newControlsWithOneRunOfEachKind()
+	controls.Pause()
+
+	// When...
+	state := controls.GetState()
+
+	// Then...
+	assert.True(t, state.Paused)
+	assert.Equal(t, 1, state.Throttle)
+	assert.Equal(t, "myClass2", state.Ready[0].Class)
+	assert.Equal(t, "U100", state.Submitted[0].Name)
+	assert.Equal(t, "U99", state.Finished[0].Name)
+	assert.Empty(t, state.Lost)
+}
+
+func TestControlsStateMasksOverridesWhichLookLikeSecrets(t *testing.T) {
+	// Given...
+	overrides := map[string]string{"my.password": "hunter2", "my.host": "myhost"}
+	controls := NewSubmissionControls()
+	controls.ReportProgress(SubmissionProgress{
+		ReadyRuns: []TestRun{{Bundle: "myBundle", Class: "myClass2", Overrides: overrides}},
+		SubmittedRuns: map[string]*TestRun{
+			"U100": {Name: "U100", Bundle: "myBundle", Class: "myClass1", Overrides: overrides},
+		},
+		FinishedRuns: make(map[string]*TestRun),
+		LostRuns:     make(map[string]*TestRun),
+	})
+
+	// When...
+	state := controls.GetState()
+
+	// Then...
+	expectedOverrides := map[string]string{"my.password": MASKED_OVERRIDE_VALUE, "my.host": "myhost"}
+	assert.Equal(t, expectedOverrides, state.Ready[0].Overrides)
+	assert.Equal(t, expectedOverrides, state.Submitted[0].Overrides)
+	assert.Equal(t, "hunter2", overrides["my.password"], "The submitter's own overrides must not be masked")
+}
+
+func TestControlsResumeUnpauses(t *testing.T) {
+	// Given...
+	controls := NewSubmissionControls()
+	controls.Pause()
+
+	// When...
+	controls.Resume()
+
+	// Then...
+	assert.False(t, controls.isSubmissionPaused())
+}
+
+func TestControlsThrottleRequestIsOnlyTakenOnce(t *testing.T) {
+	// Given...
+	controls := NewSubmissionControls()
+	err := controls.SetThrottle(5)
+	assert.Nil(t, err)
+
+	// When...
+	firstThrottle, isFirstChanged := controls.takeThrottleRequest()
+	_, isSecondChanged := controls.takeThrottleRequest()
+
+	// Then...
+	assert.Equal(t, 5, firstThrottle)
+	assert.True(t, isFirstChanged)
+	assert.False(t, isSecondChanged)
+}
+
+func TestControlsThrottleLessThanOneIsRejected(t *testing.T) {
+	// Given...
+	controls := NewSubmissionControls()
+
+	// When...
+	err := controls.SetThrottle(0)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1249E: Invalid throttle value 0. The throttle must be 1 or more.")
+	_, isChanged := controls.takeThrottleRequest()
+	assert.False(t, isChanged)
+}
+
+func TestControlsCancelOfRunInProgressAndReadyTestIsAccepted(t *testing.T) {
+	// Given...
+	controls := newControlsWithOneRunOfEachKind()
+
+	// When...
+	err := controls.RequestCancel([]string{"U100", "myBundle/myClass2"})
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"U100", "myBundle/myClass2"}, controls.takeCancelRequests())
+	assert.Empty(t, controls.takeCancelRequests())
+}
+
+func TestControlsCancelOfFinishedRunIsRejected(t *testing.T) {
+	// Given...
+	controls := newControlsWithOneRunOfEachKind()
+
+	// When...
+	err := controls.RequestCancel([]string{"U100", "U99"})
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1250E: Can't cancel 'U99'.")
+	assert.Empty(t, controls.takeCancelRequests())
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+// The things which happen to a submission, as recorded in the events file and sent to webhooks.
+const (
+	EVENT_SUBMITTED        = "submitted"
+	EVENT_STATUS_CHANGED   = "status-changed"
+	EVENT_FINISHED         = "finished"
+	EVENT_LOST             = "lost"
+	EVENT_THROTTLE_CHANGED = "throttle-changed"
+
+	// The submission has finished, and the final results are known.
+	EVENT_COMPLETED = "completed"
+)
+
+// SubmissionEvent - Something which happened to the submission, or to one of its test runs.
+// Only the fields which mean something for the type of event are set.
+type SubmissionEvent struct {
+	// When it happened, in RFC3339 format, UTC.
+	Time  string `json:"time"`
+	Event string `json:"event"`
+	Group string `json:"group,omitempty"`
+
+	// The test run it happened to.
+	RunName string `json:"runName,omitempty"`
+	Stream  string `json:"stream,omitempty"`
+	Bundle  string `json:"bundle,omitempty"`
+	Class   string `json:"class,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Result  string `json:"result,omitempty"`
+
+	// Why the test run did not pass, but is not counted as a failure. Only set once the submission has completed.
+	ExcusedBy string `json:"excusedBy,omitempty"`
+
+	// The throttle before and after it was changed.
+	PreviousThrottle int `json:"previousThrottle,omitempty"`
+	Throttle         int `json:"throttle,omitempty"`
+
+	// The final results, when the submission has completed.
+	Summary *SubmissionSummary `json:"summary,omitempty"`
+}
+
+// SubmissionSummary - The final results of a submission.
+type SubmissionSummary struct {
+	Total int `json:"total"`
+
+	// How many test runs had each result. Lost test runs are counted as 'Lost'.
+	Results map[string]int `json:"results"`
+
+	// How many test runs failed, and are counted as failures by the result policy.
+	Failed      int `json:"failed"`
+	Quarantined int `json:"quarantined"`
+	Excused     int `json:"excused"`
+
+	// Whether the result policy passed the submission.
+	Passed bool `json:"passed"`
+
+	// The test runs, in name order.
+	Runs []SubmissionEvent `json:"runs"`
+}
+
+// SubmissionEventListener - Told about each thing which happens to a submission, as it happens.
+type SubmissionEventListener interface {
+	// OnSubmissionEvent - An error does not stop the submission. It is shown to the user, and the submission carries on.
+	OnSubmissionEvent(event SubmissionEvent) error
+}
+
+// EventsFileWriter - Writes each event as a line of json to a file, so other tools can follow
+// what is happening to the submission as it happens.
+type EventsFileWriter struct {
+	eventsFileName string
+	writer         io.WriteCloser
+}
+
+// NewEventsFileWriter - Opens the events file, creating it if it isn't there. Events are added to the
+// end of a file which is already there, so a resumed submission carries on where it left off.
+func NewEventsFileWriter(fileSystem spi.FileSystem, eventsFileName string) (*EventsFileWriter, error) {
+	var instance *EventsFileWriter
+
+	writer, err := fileSystem.Append(eventsFileName)
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_EVENTS_FILE_CREATE_FAILED, eventsFileName, err.Error())
+	} else {
+		log.Printf("Writing submission events to %v\n", eventsFileName)
+		instance = new(EventsFileWriter)
+		instance.eventsFileName = eventsFileName
+		instance.writer = writer
+	}
+	return instance, err
+}
+
+func (eventsFile *EventsFileWriter) OnSubmissionEvent(event SubmissionEvent) error {
+	data, err := json.Marshal(&event)
+	if err == nil {
+		_, err = eventsFile.writer.Write(append(data, '\n'))
+	}
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_EVENTS_FILE_WRITE_FAILED, eventsFile.eventsFileName, err.Error())
+	}
+	return err
+}
+
+// Close - Closes the events file once the submission has finished.
+func (eventsFile *EventsFileWriter) Close() error {
+	return eventsFile.writer.Close()
+}
+
+// newRunEvent - An event which happened to a test run.
+func newRunEvent(eventType string, run *TestRun) SubmissionEvent {
+	return SubmissionEvent{
+		Event:     eventType,
+		Group:     run.Group,
+		RunName:   run.Name,
+		Stream:    run.Stream,
+		Bundle:    run.Bundle,
+		Class:     run.Class,
+		Status:    run.Status,
+		Result:    run.Result,
+		ExcusedBy: run.ExcusedBy,
+	}
+}
+
+// newCompletedEvent - The event giving the final results of the submission. The result policy
+// must already have excused the runs it doesn't count as failures.
+func newCompletedEvent(
+	groupName string,
+	finishedRuns map[string]*TestRun,
+	lostRuns map[string]*TestRun,
+	policy *ResultPolicy,
+) SubmissionEvent {
+	summary := &SubmissionSummary{
+		Total:       len(finishedRuns) + len(lostRuns),
+		Results:     make(map[string]int),
+		Failed:      CountTotalFailedRuns(finishedRuns, lostRuns),
+		Quarantined: CountTotalQuarantinedRuns(finishedRuns, lostRuns),
+		Excused:     CountTotalExcusedRuns(finishedRuns, lostRuns),
+		Passed:      policy.isPassing(finishedRuns, lostRuns),
+		Runs:        make([]SubmissionEvent, 0, len(finishedRuns)+len(lostRuns)),
+	}
+
+	for _, run := range getRunsInNameOrder(finishedRuns) {
+		summary.Results[run.Result] += 1
+		summary.Runs = append(summary.Runs, newRunEvent(EVENT_FINISHED, run))
+	}
+	for _, run := range getRunsInNameOrder(lostRuns) {
+		summary.Results[RESULT_LOST] += 1
+		runEvent := newRunEvent(EVENT_LOST, run)
+		if runEvent.Result == "" {
+			runEvent.Result = RESULT_LOST
+		}
+		summary.Runs = append(summary.Runs, runEvent)
+	}
+
+	return SubmissionEvent{
+		Event:   EVENT_COMPLETED,
+		Group:   groupName,
+		Summary: summary,
+	}
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// A webhook sender which records what it was asked to send, rather than sending it.
+type mockWebhookSender struct {
+	sentEventTypes []string
+	sentPayloads   []string
+
+	// Returned from each send, if set.
+	err error
+}
+
+func (sender *mockWebhookSender) SendWebhook(webhookUrl string, eventType string, payload []byte) error {
+	sender.sentEventTypes = append(sender.sentEventTypes, eventType)
+	sender.sentPayloads = append(sender.sentPayloads, string(payload))
+	return sender.err
+}
+
+func readEventsFile(t *testing.T, mockFileSystem spi.FileSystem, eventsFileName string) []SubmissionEvent {
+	events := make([]SubmissionEvent, 0)
+	text, err := mockFileSystem.ReadTextFile(eventsFileName)
+	assert.Nil(t, err)
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		var event SubmissionEvent
+		err = json.Unmarshal([]byte(line), &event)
+		assert.Nil(t, err)
+		events = append(events, event)
+	}
+	return events
+}
+
+func getEventTypes(events []SubmissionEvent) []string {
+	eventTypes := make([]string, 0, len(events))
+	for _, event := range events {
+		eventTypes = append(eventTypes, event.Event)
+	}
+	return eventTypes
+}
+
+func TestSubmitWritesEachEventToTheEventsFile(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass2", RESULT_FAILED)
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		GroupName:         "myGroup",
+		Throttle:          1,
+		EventsFileName:    "events.ndjson",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1017E")
+
+	events := readEventsFile(t, mockFileSystem, "events.ndjson")
+	assert.Equal(t, []string{EVENT_SUBMITTED, EVENT_FINISHED, EVENT_SUBMITTED, EVENT_FINISHED, EVENT_COMPLETED}, getEventTypes(events))
+
+	assert.Equal(t, "myClass1", events[0].Class)
+	assert.Equal(t, "myGroup", events[0].Group)
+	assert.NotEmpty(t, events[0].RunName)
+	assert.NotEmpty(t, events[0].Time)
+	assert.Equal(t, RESULT_PASSED, events[1].Result)
+	assert.Equal(t, RESULT_FAILED, events[3].Result)
+
+	summary := events[4].Summary
+	assert.NotNil(t, summary)
+	assert.Equal(t, 2, summary.Total)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, 1, summary.Results[RESULT_PASSED])
+	assert.Equal(t, 1, summary.Results[RESULT_FAILED])
+	assert.False(t, summary.Passed)
+	assert.Equal(t, 2, len(summary.Runs))
+}
+
+func TestEventsFileWriterAddsToTheEndOfEventsFileAlreadyThere(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockFileSystem.WriteTextFile("events.ndjson", `{"time":"earlier","event":"submitted"}`+"\n")
+
+	// When...
+	eventsFile, err := NewEventsFileWriter(mockFileSystem, "events.ndjson")
+	assert.Nil(t, err)
+	err = eventsFile.OnSubmissionEvent(SubmissionEvent{Time: "later", Event: EVENT_FINISHED})
+	assert.Nil(t, err)
+	eventsFile.Close()
+
+	// Then...
+	events := readEventsFile(t, mockFileSystem, "events.ndjson")
+	assert.Equal(t, []string{EVENT_SUBMITTED, EVENT_FINISHED}, getEventTypes(events))
+	assert.Equal(t, "earlier", events[0].Time)
+}
+
+func TestSubmitPostsTheCompletedEventToTheWebhook(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	webhookSender := new(mockWebhookSender)
+	submitter.SetWebhookSender(webhookSender)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		GroupName:         "myGroup",
+		WebhookUrl:        "https://example.com/hooks/galasa",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, []string{EVENT_COMPLETED}, webhookSender.sentEventTypes)
+
+	var event SubmissionEvent
+	err = json.Unmarshal([]byte(webhookSender.sentPayloads[0]), &event)
+	assert.Nil(t, err)
+	assert.Equal(t, "myGroup", event.Group)
+	assert.True(t, event.Summary.Passed)
+	assert.Equal(t, 2, event.Summary.Results[RESULT_PASSED])
+}
+
+func TestSubmitPostsEachFinishedRunToTheWebhookUsingTheTemplate(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockFileSystem.WriteTextFile("webhook.tmpl",
+		`{"text": {{if .Summary}}{{json (printf "Group %v passed: %v" .Group .Summary.Passed)}}{{else}}{{json .Class}}{{end}}}`)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	webhookSender := new(mockWebhookSender)
+	submitter.SetWebhookSender(webhookSender)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:   "my.portfolio",
+		GroupName:           "myGroup",
+		Throttle:            1,
+		WebhookUrl:          "https://example.com/hooks/galasa",
+		WebhookTemplateFile: "webhook.tmpl",
+		IsWebhookForEachRun: true,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, []string{EVENT_FINISHED, EVENT_FINISHED, EVENT_COMPLETED}, webhookSender.sentEventTypes)
+	assert.Equal(t, `{"text": "myClass1"}`, webhookSender.sentPayloads[0])
+	assert.Equal(t, `{"text": "myClass2"}`, webhookSender.sentPayloads[1])
+	assert.Equal(t, `{"text": "Group myGroup passed: true"}`, webhookSender.sentPayloads[2])
+}
+
+func TestSubmitWithInvalidWebhookUrlFails(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	submitter.SetWebhookSender(new(mockWebhookSender))
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		WebhookUrl:        "example.com/hooks/galasa",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1284E")
+	assert.Empty(t, mockLauncher.GetRecordedLaunchRecords())
+}
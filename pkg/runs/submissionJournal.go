@@ -0,0 +1,165 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Inside the journal file, it must carry a format field with this value inside.
+	SUBMISSION_JOURNAL_DECLARED_FORMAT_VERSION = "v1alpha"
+
+	// Inside the journal file, it should claim to be a resource of this kind.
+	SUBMISSION_JOURNAL_DECLARED_RESOURCE_KIND = "galasa.dev/submissionJournal"
+
+	// Added to the name of the journal file, for the file each new state of the journal is written to first.
+	SUBMISSION_JOURNAL_TEMP_FILE_SUFFIX = ".tmp"
+)
+
+// SubmissionJournal - The state of a 'runs submit' command. It is written to disk each time
+// the state changes, so that the command can be resumed from where it got to if the galasactl
+// process is stopped before all the tests have finished.
+type SubmissionJournal struct {
+	APIVersion  string `yaml:"apiVersion"`
+	Kind        string `yaml:"kind"`
+	GroupName   string `yaml:"group"`
+	RequestType string `yaml:"requestType"`
+	Trace       bool   `yaml:"trace"`
+
+	// Tests which have not been submitted yet.
+	ReadyRuns []TestRun `yaml:"ready"`
+
+	// Tests which have been submitted and are not finished yet. Keyed by run name.
+	SubmittedRuns map[string]*TestRun `yaml:"submitted"`
+
+	// Tests which will be attempted again. Keyed by the run name of the attempt which finished.
+	RerunRuns map[string]*TestRun `yaml:"rerun"`
+
+	// Tests which have finished. Keyed by run name.
+	FinishedRuns map[string]*TestRun `yaml:"finished"`
+
+	// Tests which were lost. Keyed by run name, or by bundle/class if they failed to submit.
+	LostRuns map[string]*TestRun `yaml:"lost"`
+
+	// The test which was being submitted when the journal was written, if any. The ecosystem doesn't
+	// say which matrix combination a run was submitted with, so this is how a run the journal doesn't
+	// know about is matched to its combination on resume.
+	SubmittingRun *TestRun `yaml:"submitting,omitempty"`
+}
+
+func NewSubmissionJournal(groupName string, requestType string, trace bool, readyRuns []TestRun) *SubmissionJournal {
+	journal := new(SubmissionJournal)
+	journal.APIVersion = SUBMISSION_JOURNAL_DECLARED_FORMAT_VERSION
+	journal.Kind = SUBMISSION_JOURNAL_DECLARED_RESOURCE_KIND
+	journal.GroupName = groupName
+	journal.RequestType = requestType
+	journal.Trace = trace
+	journal.ReadyRuns = readyRuns
+	journal.SubmittedRuns = make(map[string]*TestRun)
+	journal.RerunRuns = make(map[string]*TestRun)
+	journal.FinishedRuns = make(map[string]*TestRun)
+	journal.LostRuns = make(map[string]*TestRun)
+	return journal
+}
+
+// isKnownRunName - Has the journal already recorded a run with this name, in any state,
+// or as an earlier attempt of a test ?
+func (journal *SubmissionJournal) isKnownRunName(runName string) bool {
+	isKnown := false
+	for _, runs := range []map[string]*TestRun{journal.SubmittedRuns, journal.RerunRuns, journal.FinishedRuns, journal.LostRuns} {
+		for knownName, run := range runs {
+			if knownName == runName || run.Name == runName {
+				isKnown = true
+			}
+			for _, attempt := range run.PreviousAttempts {
+				if attempt.Name == runName {
+					isKnown = true
+				}
+			}
+		}
+	}
+	return isKnown
+}
+
+// getTestKeyOfUnknownRun - The key of the test a run in the group was submitted for, when the journal
+// has no record of the run. Only one test is submitted between writes of the journal, so such a run is
+// the test which was being submitted, if it has the same bundle and class. Otherwise it can only be a
+// test without a matrix combination.
+func (journal *SubmissionJournal) getTestKeyOfUnknownRun(bundle string, class string) string {
+	testKey := bundle + "/" + class
+	submittingRun := journal.SubmittingRun
+	if submittingRun != nil && submittingRun.Bundle == bundle && submittingRun.Class == class {
+		testKey = submittingRun.getTestKey()
+	}
+	return testKey
+}
+
+// WriteSubmissionJournal - Writes the journal to a temporary file first, then swaps it for the old
+// journal, so the journal is never left part-written if galasactl stops while writing it.
+// The journal holds the values of overrides, which may be secrets, so only its owner can read it.
+func WriteSubmissionJournal(fileSystem spi.FileSystem, filename string, journal *SubmissionJournal) error {
+	bytes, err := yaml.Marshal(journal)
+	if err == nil {
+		tempFilename := filename + SUBMISSION_JOURNAL_TEMP_FILE_SUFFIX
+		err = fileSystem.WritePrivateBinaryFile(tempFilename, bytes)
+		if err == nil {
+			err = fileSystem.Rename(tempFilename, filename)
+			if err != nil {
+				fileSystem.DeleteFile(tempFilename)
+			}
+		}
+	}
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_JOURNAL_WRITE_FAILED, filename, err.Error())
+	}
+	return err
+}
+
+func ReadSubmissionJournal(fileSystem spi.FileSystem, filename string) (*SubmissionJournal, error) {
+
+	var journal SubmissionJournal
+
+	text, err := fileSystem.ReadTextFile(filename)
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_JOURNAL_OPEN_FAILED, filename, err.Error())
+		return nil, err
+	}
+
+	err = yaml.Unmarshal([]byte(text), &journal)
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_JOURNAL_BAD_FORMAT, filename, err.Error())
+		return nil, err
+	}
+
+	if journal.APIVersion != SUBMISSION_JOURNAL_DECLARED_FORMAT_VERSION || journal.Kind != SUBMISSION_JOURNAL_DECLARED_RESOURCE_KIND {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_JOURNAL_BAD_RESOURCE_KIND, filename, SUBMISSION_JOURNAL_DECLARED_RESOURCE_KIND, SUBMISSION_JOURNAL_DECLARED_FORMAT_VERSION)
+		return nil, err
+	}
+
+	if journal.GroupName == "" {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_JOURNAL_BAD_FORMAT, filename, "the journal does not contain a group name")
+		return nil, err
+	}
+
+	// Empty sections of the journal are not written out, so put them back.
+	if journal.SubmittedRuns == nil {
+		journal.SubmittedRuns = make(map[string]*TestRun)
+	}
+	if journal.RerunRuns == nil {
+		journal.RerunRuns = make(map[string]*TestRun)
+	}
+	if journal.FinishedRuns == nil {
+		journal.FinishedRuns = make(map[string]*TestRun)
+	}
+	if journal.LostRuns == nil {
+		journal.LostRuns = make(map[string]*TestRun)
+	}
+
+	return &journal, nil
+}
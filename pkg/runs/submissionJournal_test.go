@@ -0,0 +1,154 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanWriteAndReadBackASubmissionJournal(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	readyRuns := []TestRun{{Bundle: "myBundle", Class: "myClass2", Status: "queued"}}
+	journal := NewSubmissionJournal("myGroup", "CLI", true, readyRuns)
+	journal.SubmittedRuns["U100"] = &TestRun{Name: "U100", Bundle: "myBundle", Class: "myClass1", Status: "running"}
+	journal.FinishedRuns["U99"] = &TestRun{Name: "U99", Bundle: "myBundle", Class: "myClass0", Status: "finished", Result: "Passed"}
+
+	// When...
+	err := WriteSubmissionJournal(fs, "my.journal", journal)
+	assert.Nil(t, err)
+	journalGotBack, err := ReadSubmissionJournal(fs, "my.journal")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "myGroup", journalGotBack.GroupName)
+	assert.Equal(t, "CLI", journalGotBack.RequestType)
+	assert.True(t, journalGotBack.Trace)
+	assert.Equal(t, 1, len(journalGotBack.ReadyRuns))
+	assert.Equal(t, "myClass2", journalGotBack.ReadyRuns[0].Class)
+	assert.Equal(t, "myClass1", journalGotBack.SubmittedRuns["U100"].Class)
+	assert.Equal(t, "Passed", journalGotBack.FinishedRuns["U99"].Result)
+	assert.NotNil(t, journalGotBack.RerunRuns)
+	assert.NotNil(t, journalGotBack.LostRuns)
+}
+
+func TestSubmissionJournalKeepsTheRetryBackOffAndWhenRunsWereSubmitted(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	submittedTime := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	notBefore := submittedTime.Add(5 * time.Minute)
+	journal := NewSubmissionJournal("myGroup", "CLI", false, []TestRun{})
+	journal.SubmittedRuns["U100"] = &TestRun{Name: "U100", Bundle: "myBundle", Class: "myClass1", Status: "running", Timeout: "10m", SubmittedTime: submittedTime}
+	journal.RerunRuns["myBundle/myClass2"] = &TestRun{Bundle: "myBundle", Class: "myClass2", NextAttemptNotBefore: notBefore}
+
+	// When...
+	err := WriteSubmissionJournal(fs, "my.journal", journal)
+	assert.Nil(t, err)
+	journalGotBack, err := ReadSubmissionJournal(fs, "my.journal")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.True(t, submittedTime.Equal(journalGotBack.SubmittedRuns["U100"].SubmittedTime))
+	assert.True(t, journalGotBack.SubmittedRuns["U100"].NextAttemptNotBefore.IsZero())
+	assert.True(t, notBefore.Equal(journalGotBack.RerunRuns["myBundle/myClass2"].NextAttemptNotBefore))
+}
+
+func TestSubmissionJournalIsSwappedForTheOldOneOnlyOnceWrittenInFull(t *testing.T) {
+	// Given...
+	fs := files.NewOverridableMockFileSystem()
+	oldJournal := NewSubmissionJournal("myGroup", "CLI", false, []TestRun{{Bundle: "myBundle", Class: "myClass1"}})
+	err := WriteSubmissionJournal(fs, "my.journal", oldJournal)
+	assert.Nil(t, err)
+
+	// Writing the new state of the journal fails part of the way through.
+	fs.VirtualFunction_WritePrivateBinaryFile = func(targetFilePath string, desiredContents []byte) error {
+		assert.Equal(t, "my.journal.tmp", targetFilePath)
+		return errors.New("disk full")
+	}
+	newJournal := NewSubmissionJournal("myGroup", "CLI", false, []TestRun{})
+
+	// When...
+	err = WriteSubmissionJournal(fs, "my.journal", newJournal)
+
+	// Then...
+	assert.NotNil(t, err)
+	journalGotBack, err := ReadSubmissionJournal(fs, "my.journal")
+	assert.Nil(t, err)
+	assert.Equal(t, "myClass1", journalGotBack.ReadyRuns[0].Class)
+}
+
+func TestSubmissionJournalCanOnlyBeReadByItsOwner(t *testing.T) {
+	// Given...
+	fs := files.NewOverridableMockFileSystem()
+	journal := NewSubmissionJournal("myGroup", "CLI", false, []TestRun{})
+
+	// When...
+	err := WriteSubmissionJournal(fs, "my.journal", journal)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.True(t, fs.IsPrivateFile("my.journal"))
+	isTempFileExists, _ := fs.Exists("my.journal.tmp")
+	assert.False(t, isTempFileExists)
+}
+
+func TestReadSubmissionJournalWhichDoesNotExistFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+
+	// When...
+	_, err := ReadSubmissionJournal(fs, "missing.journal")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1232E")
+}
+
+func TestReadSubmissionJournalWhichIsAPortfolioFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	createTestPortfolioFile(t, fs, "my.portfolio", "myBundle", "myClass", "myStream", "myObr")
+
+	// When...
+	_, err := ReadSubmissionJournal(fs, "my.portfolio")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1234E")
+}
+
+func TestReadSubmissionJournalWhichIsNotYamlFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	fs.WriteTextFile("my.journal", "{{{ this is not yaml")
+
+	// When...
+	_, err := ReadSubmissionJournal(fs, "my.journal")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1233E")
+}
+
+func TestJournalKnowsRunNamesOfEarlierAttempts(t *testing.T) {
+	// Given...
+	journal := NewSubmissionJournal("myGroup", "CLI", false, nil)
+	journal.SubmittedRuns["U101"] = &TestRun{
+		Name:             "U101",
+		PreviousAttempts: []TestRunAttempt{{Name: "U100", Result: "EnvFail"}},
+	}
+
+	// When...
+	// Then...
+	assert.True(t, journal.isKnownRunName("U101"))
+	assert.True(t, journal.isKnownRunName("U100"))
+	assert.False(t, journal.isKnownRunName("U102"))
+}
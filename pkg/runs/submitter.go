@@ -11,6 +11,7 @@ import (
 	"os/user"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	randomGenerator "github.com/google/uuid"
@@ -35,6 +36,45 @@ type Submitter struct {
 	env          spi.Environment
 	console      spi.Console
 	expander     images.ImageExpander
+
+	// Used to cancel runs which are in progress. nil if runs can't be cancelled.
+	runCanceller RunCanceller
+
+	// Shows the user how the submission is progressing. nil if nothing is shown.
+	progressReporter ProgressReporter
+
+	// Requests from 'runs control' commands. nil if the submission can't be controlled.
+	controls *SubmissionControls
+
+	// How long test classes took to run before, used to share them out between shards,
+	// and to submit the longest-running ones first. nil if there is no history to go by.
+	durationHistory RunDurationHistory
+
+	// The ecosystem the tests run in, so reports can link to the runs there. Empty if the tests run locally.
+	apiServerUrl string
+
+	// Gets the run logs to include in the junit report. nil if the tests run locally.
+	runLogFetcher RunLogFetcher
+
+	// Decides whether the results of the test runs fail the command. nil until the parameters are validated.
+	resultPolicy *ResultPolicy
+
+	// Posts events to webhooks. nil if webhooks can't be used.
+	webhookSender WebhookSender
+
+	// Told about each thing which happens to the submission. Empty unless an events file or webhook is wanted.
+	eventListeners []SubmissionEventListener
+
+	// The tests to submit, when they don't come from a portfolio file or the test selection flags. nil if they do.
+	portfolio *Portfolio
+
+	// Set to non-zero once the submission has been interrupted. Accessed atomically,
+	// as interrupts arrive on a different goroutine.
+	interrupted int32
+
+	// Why the submission stopped before all the tests had finished, if it did.
+	// One of the STOP_REASON_ values, or empty.
+	stopReason string
 }
 
 func NewSubmitter(
@@ -59,6 +99,52 @@ func NewSubmitter(
 	return instance
 }
 
+// SetRunCanceller - Allows the submitter to cancel the runs it submitted, if it is interrupted.
+func (submitter *Submitter) SetRunCanceller(runCanceller RunCanceller) {
+	submitter.runCanceller = runCanceller
+}
+
+// SetProgressReporter - Shows the user how the submission is progressing each time the status of the runs is checked.
+func (submitter *Submitter) SetProgressReporter(progressReporter ProgressReporter) {
+	submitter.progressReporter = progressReporter
+}
+
+// SetRunDurationHistory - Allows the submitter to share tests out between shards by how long they take to run.
+func (submitter *Submitter) SetRunDurationHistory(durationHistory RunDurationHistory) {
+	submitter.durationHistory = durationHistory
+}
+
+// SetApiServerUrl - Allows the reports to link to the runs in the ecosystem.
+func (submitter *Submitter) SetApiServerUrl(apiServerUrl string) {
+	submitter.apiServerUrl = apiServerUrl
+}
+
+// SetRunLogFetcher - Allows the junit report to include the end of the run log of each run.
+func (submitter *Submitter) SetRunLogFetcher(runLogFetcher RunLogFetcher) {
+	submitter.runLogFetcher = runLogFetcher
+}
+
+// SetWebhookSender - Allows the submitter to post events to a webhook.
+func (submitter *Submitter) SetWebhookSender(webhookSender WebhookSender) {
+	submitter.webhookSender = webhookSender
+}
+
+// SetPortfolio - Submits the tests in this portfolio, rather than those in a portfolio file or picked by the test selection flags.
+func (submitter *Submitter) SetPortfolio(portfolio *Portfolio) {
+	submitter.portfolio = portfolio
+}
+
+// Interrupt - Asks the submitter to stop submitting tests and to finish as soon as it can.
+// Safe to call from a different goroutine, such as one which handles operating system signals.
+func (submitter *Submitter) Interrupt(reason string) {
+	atomic.StoreInt32(&submitter.interrupted, 1)
+	submitter.timedSleeper.Interrupt(reason)
+}
+
+func (submitter *Submitter) isInterrupted() bool {
+	return atomic.LoadInt32(&submitter.interrupted) != 0
+}
+
 func (submitter *Submitter) ExecuteSubmitRuns(
 	params *utils.RunsSubmitCmdValues,
 	TestSelectionFlagValues *utils.TestSelectionFlagValues,
@@ -67,17 +153,90 @@ func (submitter *Submitter) ExecuteSubmitRuns(
 
 	var err error
 
+	err = submitter.validateAndCorrectParams(params, TestSelectionFlagValues)
+	if err == nil {
+		var runOverrides map[string]string
+		runOverrides, err = submitter.buildOverrideMap(*params)
+
+		if err == nil {
+			var stopEventListeners func()
+			stopEventListeners, err = submitter.startEventListeners(*params)
+			if err == nil {
+				defer stopEventListeners()
+			}
+		}
+
+		if err == nil && params.ControlFileName != "" {
+			var controlServer *ControlServer
+			controlServer, err = submitter.startControlServer(params.ControlFileName)
+			if err == nil {
+				defer controlServer.Stop()
+			}
+		}
+
+		if err == nil {
+			if params.ResumeJournalFileName != "" {
+				err = submitter.resumeJournal(runOverrides, params)
+			} else {
+				var portfolio *Portfolio
+				portfolio, err = submitter.getPortfolio(params.PortfolioFileName, NewEnvVarExpander(submitter.env, params.StrictEnvVars), TestSelectionFlagValues)
+				if err == nil {
+					err = submitter.validatePortfolio(portfolio, params.PortfolioFileName)
+					if err == nil && params.Shard != "" {
+						err = submitter.shardPortfolio(portfolio, *params)
+					}
+					if err == nil && params.Schedule == SCHEDULE_LONGEST_FIRST {
+						portfolio.Classes, err = getClassesLongestFirst(portfolio.Classes, submitter.durationHistory)
+					}
+					if err == nil {
+						err = submitter.executePortfolio(portfolio, runOverrides, *params)
+					}
+				}
+			}
+		}
+	}
+
+	return err
+}
+
+// SubmitRunsWithoutWaiting - Submits a run for each test, and tells the user the name of each run,
+// without waiting for them to finish. No reports are written, as there are no results to report.
+func (submitter *Submitter) SubmitRunsWithoutWaiting(
+	params *utils.RunsSubmitCmdValues,
+	TestSelectionFlagValues *utils.TestSelectionFlagValues,
+) error {
+
+	var err error
+
 	err = submitter.validateAndCorrectParams(params, TestSelectionFlagValues)
 	if err == nil {
 		var runOverrides map[string]string
 		runOverrides, err = submitter.buildOverrideMap(*params)
 		if err == nil {
 			var portfolio *Portfolio
-			portfolio, err = submitter.getPortfolio(params.PortfolioFileName, TestSelectionFlagValues)
+			portfolio, err = submitter.getPortfolio(params.PortfolioFileName, NewEnvVarExpander(submitter.env, params.StrictEnvVars), TestSelectionFlagValues)
 			if err == nil {
 				err = submitter.validatePortfolio(portfolio, params.PortfolioFileName)
-				if err == nil {
-					err = submitter.executePortfolio(portfolio, runOverrides, *params)
+			}
+
+			if err == nil {
+				readyRuns := submitter.buildListOfRunsToSubmit(portfolio, runOverrides)
+				if params.PrintEffectiveOverrides {
+					submitter.console.WriteString(formatEffectiveOverrides(readyRuns))
+				}
+
+				submittedRuns := make(map[string]*TestRun)
+				lostRuns := make(map[string]*TestRun)
+				currentUser := submitter.GetCurrentUserName()
+
+				for len(readyRuns) > 0 && err == nil {
+					readyRuns, err = submitter.submitRun(params.GroupName, readyRuns, submittedRuns,
+						lostRuns, &runOverrides, params.Trace, currentUser, params.RequestType)
+				}
+
+				for _, run := range getRunsInNameOrder(submittedRuns) {
+					submitter.console.WriteString(fmt.Sprintf(galasaErrors.GALASA_INFO_RUN_SUBMITTED.Template,
+						run.Name, run.Bundle+"/"+run.Class, run.Group))
 				}
 			}
 		}
@@ -86,41 +245,264 @@ func (submitter *Submitter) ExecuteSubmitRuns(
 	return err
 }
 
+// startEventListeners - Starts writing the events file and posting to the webhook, if they are wanted.
+// Returns a function which stops them, once the submission has finished.
+func (submitter *Submitter) startEventListeners(params utils.RunsSubmitCmdValues) (func(), error) {
+	var err error
+	var eventsFile *EventsFileWriter
+	var notifier *WebhookNotifier
+	submitter.eventListeners = nil
+
+	if params.EventsFileName != "" {
+		eventsFile, err = NewEventsFileWriter(submitter.fileSystem, params.EventsFileName)
+		if err == nil {
+			submitter.eventListeners = append(submitter.eventListeners, eventsFile)
+		}
+	}
+
+	if err == nil && params.WebhookUrl != "" {
+		if submitter.webhookSender == nil {
+			log.Printf("Webhook %v is not used, as this launcher can't post to webhooks\n", params.WebhookUrl)
+		} else {
+			notifier, err = NewWebhookNotifier(submitter.fileSystem, params.WebhookUrl, params.WebhookTemplateFile,
+				params.IsWebhookForEachRun, submitter.webhookSender)
+			if err == nil {
+				submitter.eventListeners = append(submitter.eventListeners, notifier)
+			}
+		}
+	}
+
+	stopEventListeners := func() {
+		submitter.eventListeners = nil
+		if eventsFile != nil {
+			eventsFile.Close()
+		}
+		if notifier != nil {
+			// The submission has finished, but the last events may not have been posted yet.
+			for _, deliveryError := range notifier.Close() {
+				submitter.reportMessage(fmt.Sprintf("%s\n", deliveryError.Error()))
+			}
+		}
+	}
+	if err != nil {
+		stopEventListeners()
+	}
+	return stopEventListeners, err
+}
+
+// reportMessage - Shows the user a message while the submission is in progress. It goes through
+// the progress reporter, if there is one, so that redrawing the progress doesn't hide it.
+func (submitter *Submitter) reportMessage(message string) {
+	if submitter.progressReporter != nil {
+		submitter.progressReporter.ReportMessage(message)
+	} else {
+		submitter.console.WriteString(message)
+	}
+}
+
+// recordEvent - Tells the event listeners about something which happened to the submission.
+// A listener which fails doesn't stop the submission.
+func (submitter *Submitter) recordEvent(event SubmissionEvent) {
+	if len(submitter.eventListeners) > 0 {
+		event.Time = submitter.timeService.Now().UTC().Format(time.RFC3339)
+		for _, listener := range submitter.eventListeners {
+			err := listener.OnSubmissionEvent(event)
+			if err != nil {
+				submitter.reportMessage(fmt.Sprintf("%s\n", err.Error()))
+			}
+		}
+	}
+}
+
+// startControlServer - Lets 'runs control' commands control the submission while it runs.
+func (submitter *Submitter) startControlServer(controlFileName string) (*ControlServer, error) {
+	submitter.controls = NewSubmissionControls()
+
+	controlServer, err := StartControlServer(submitter.fileSystem, controlFileName, submitter.controls)
+	if err == nil {
+		submitter.console.WriteString(fmt.Sprintf("Listening for requests to control the submission on %v. "+
+			"Use 'galasactl runs control --controlfile %v' to control it.\n", controlServer.GetAddress(), controlFileName))
+	}
+	return controlServer, err
+}
+
+// shardPortfolio - Drops the tests which belong to other shards from the portfolio.
+// A shard may end up with no tests, if there are more shards than tests.
+func (submitter *Submitter) shardPortfolio(portfolio *Portfolio, params utils.RunsSubmitCmdValues) error {
+	shard, err := ParseShard(params.Shard)
+	if err == nil {
+		err = ShardPortfolio(portfolio, shard, params.ShardStrategy, submitter.durationHistory)
+	}
+	if err == nil {
+		submitter.console.WriteString(fmt.Sprintf("Submitting shard %v, with %v test classes.\n", shard, len(portfolio.Classes)))
+	}
+	return err
+}
+
 func (submitter *Submitter) executePortfolio(portfolio *Portfolio,
 	runOverrides map[string]string,
 	params utils.RunsSubmitCmdValues,
 ) error {
 
-	var err error
-
 	// Build list of runs to submit
 	readyRuns := submitter.buildListOfRunsToSubmit(portfolio, runOverrides)
+	if params.PrintEffectiveOverrides {
+		submitter.console.WriteString(formatEffectiveOverrides(readyRuns))
+	}
+
+	journal := NewSubmissionJournal(params.GroupName, params.RequestType, params.Trace, readyRuns)
+
+	return submitter.executeJournal(journal, runOverrides, params)
+}
+
+// resumeJournal - Picks up a submission from where it got to when its journal was last written.
+// The group, request type and trace settings recorded in the journal are used in preference
+// to those on the command line, as the tests which are already running were submitted using them.
+func (submitter *Submitter) resumeJournal(runOverrides map[string]string, params *utils.RunsSubmitCmdValues) error {
+
+	journal, err := ReadSubmissionJournal(submitter.fileSystem, params.ResumeJournalFileName)
+	if err == nil {
+		if params.GroupName != "" && params.GroupName != journal.GroupName {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_RESUME_GROUP_MISMATCH,
+				params.GroupName, journal.GroupName, params.ResumeJournalFileName)
+		}
+	}
+
+	if err == nil {
+		log.Printf("Resuming the submission of tests in group '%v' from journal file '%v'\n", journal.GroupName, params.ResumeJournalFileName)
+		params.GroupName = journal.GroupName
+		params.RequestType = journal.RequestType
+		params.Trace = journal.Trace
+
+		// Carry on recording progress in the same journal, unless told otherwise.
+		if params.JournalFileName == "" {
+			params.JournalFileName = params.ResumeJournalFileName
+		}
+
+		err = submitter.reattachToGroup(journal)
+		if err == nil {
+			err = submitter.executeJournal(journal, runOverrides, *params)
+		}
+	}
+
+	return err
+}
+
+// reattachToGroup - Tests may have been submitted after the journal was last written.
+// Any run in the group which the journal doesn't know about is matched up with a test
+// in the ready list, so that the same test is not submitted twice.
+func (submitter *Submitter) reattachToGroup(journal *SubmissionJournal) error {
+	var err error
+
+	// The tests of each matrix combination are submitted to a group of their own.
+	runGroupNames := []string{journal.GroupName}
+	for index := range journal.ReadyRuns {
+		runGroupNames = append(runGroupNames, getRunGroupName(journal.GroupName, &journal.ReadyRuns[index]))
+	}
+
+	for _, runGroupName := range getDistinctGroupNames(runGroupNames) {
+		if err == nil {
+			var currentGroup *galasaapi.TestRuns
+			currentGroup, err = submitter.launcher.GetRunsByGroup(runGroupName)
+			if err != nil {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_RESUME_GROUP_CHECK_FAILED, runGroupName, err.Error())
+			} else {
+				journal.reattachRunsInGroup(runGroupName, currentGroup.GetRuns())
+			}
+		}
+	}
+
+	if err == nil {
+		journal.SubmittingRun = nil
+	}
+	return err
+}
+
+// reattachRunsInGroup - Moves each test in the ready list which has a run in the group, that the journal
+// doesn't know about, to the submitted runs.
+func (journal *SubmissionJournal) reattachRunsInGroup(runGroupName string, currentRuns []galasaapi.TestRun) {
+	for _, currentRun := range currentRuns {
+		runName := currentRun.GetName()
+		if !journal.isKnownRunName(runName) {
+			testKey := journal.getTestKeyOfUnknownRun(currentRun.GetBundleName(), currentRun.GetTestName())
+			for index, readyRun := range journal.ReadyRuns {
+				if readyRun.getTestKey() == testKey && getRunGroupName(journal.GroupName, &readyRun) == runGroupName {
+
+					readyRun.Name = runName
+					readyRun.Group = runGroupName
+					readyRun.Status = currentRun.GetStatus()
+					journal.SubmittedRuns[runName] = &readyRun
+					journal.ReadyRuns = append(journal.ReadyRuns[:index], journal.ReadyRuns[index+1:]...)
+
+					log.Printf("Run %v re-attached - %v/%v/%v%v\n", runName, readyRun.Stream, readyRun.Bundle, readyRun.Class, getCombinationSuffix(&readyRun))
+					break
+				}
+			}
+		}
+	}
+}
+
+func (submitter *Submitter) executeJournal(journal *SubmissionJournal,
+	runOverrides map[string]string,
+	params utils.RunsSubmitCmdValues,
+) error {
+
+	var err error
 
 	// Run all the tests
 	var finishedRuns map[string]*TestRun
 	var lostRuns map[string]*TestRun
 	finishedRuns, lostRuns, err = submitter.executeSubmitRuns(
-		params, readyRuns, runOverrides)
+		params, journal, runOverrides)
 
 	// Report on the results.
 	if err == nil {
-		// Generate all the reports summarising the end-results.
-		err = submitter.createReports(params, finishedRuns, lostRuns)
-		if err == nil {
+		err = submitter.reportResults(params, finishedRuns, lostRuns)
+
+		// Being stopped early is more important to report than any test failures.
+		// A fail-fast stop is reported as the test failures which caused it.
+		switch submitter.stopReason {
+		case STOP_REASON_INTERRUPT:
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_INTERRUPTED, CountTotalInterruptedRuns(lostRuns))
+		case STOP_REASON_TIMEOUT:
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_TIMED_OUT, params.Timeout, CountTotalCancelledRuns(finishedRuns, lostRuns))
+		}
+	}
 
-			err = reportRendedImages(finishedRuns, submitter)
+	return err
+}
 
-			if err == nil {
+// getResultPolicy - The policy deciding which test runs count as failures. Without one, every
+// test run which didn't pass counts.
+func (submitter *Submitter) getResultPolicy() *ResultPolicy {
+	policy := submitter.resultPolicy
+	if policy == nil {
+		policy = new(ResultPolicy)
+	}
+	return policy
+}
 
-				// Fail the command if tests failed, and the user wanted us to fail if tests fail.
-				failureCount := CountTotalFailedRuns(finishedRuns, lostRuns)
-				if failureCount > 0 && !params.NoExitCodeOnTestFailures {
-					// Not all runs passed
-					err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_TESTS_FAILED, failureCount)
-				}
-			}
-		}
+// reportResults - Writes the reports summarising the end-results, and fails if any tests failed
+// (unless the user asked us not to).
+func (submitter *Submitter) reportResults(params utils.RunsSubmitCmdValues,
+	finishedRuns map[string]*TestRun,
+	lostRuns map[string]*TestRun,
+) error {
+
+	policy := submitter.getResultPolicy()
+	policy.ExcuseRuns(finishedRuns, lostRuns)
+	submitter.recordEvent(newCompletedEvent(params.GroupName, finishedRuns, lostRuns, policy))
+
+	// Generate all the reports summarising the end-results.
+	err := submitter.createReports(params, finishedRuns, lostRuns)
+	if err == nil {
 
+		err = reportRendedImages(finishedRuns, submitter)
+
+		if err == nil && !params.NoExitCodeOnTestFailures {
+			// Fail the command if too many tests failed, and the user wanted us to fail if tests fail.
+			err = policy.CheckResults(finishedRuns, lostRuns)
+		}
 	}
 
 	return err
@@ -143,23 +525,36 @@ func reportRendedImages(finishedRuns map[string]*TestRun, submitter *Submitter)
 
 func (submitter *Submitter) executeSubmitRuns(
 	params utils.RunsSubmitCmdValues,
-	readyRuns []TestRun,
+	journal *SubmissionJournal,
 	runOverrides map[string]string,
 ) (map[string]*TestRun, map[string]*TestRun, error) {
 
 	var err error
 
-	submittedRuns := make(map[string]*TestRun)
-	rerunRuns := make(map[string]*TestRun)
-	finishedRuns := make(map[string]*TestRun)
-	lostRuns := make(map[string]*TestRun)
+	readyRuns := journal.ReadyRuns
+	submittedRuns := journal.SubmittedRuns
+	rerunRuns := journal.RerunRuns
+	finishedRuns := journal.FinishedRuns
+	lostRuns := journal.LostRuns
 
 	progressReportInterval := time.Minute * time.Duration(params.ProgressReportIntervalMinutes)
 	throttle := params.Throttle
 	fetchRas := submitter.isRasDetailNeededForReports(params)
 	pollInterval := time.Second * time.Duration(params.PollIntervalSeconds)
+	retryPolicy := NewRetryPolicy(params.MaxAttempts, params.RetryResults, params.RetryBackoffSeconds)
+
+	// The whole submission must finish by the deadline, if there is one.
+	var deadline time.Time
+	if params.Timeout > 0 {
+		deadline = submitter.timeService.Now().Add(params.Timeout)
+	}
+	submitter.stopReason = ""
 
 	err = submitter.writeThrottleFile(params.ThrottleFileName, throttle)
+	if err == nil {
+		// Record where we are starting from, before anything changes.
+		err = submitter.writeJournal(params.JournalFileName, journal)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -171,16 +566,52 @@ func (submitter *Submitter) executeSubmitRuns(
 	nextProgressReport := submitter.timeService.Now().Add(progressReportInterval)
 	isThrottleFileLost := false
 
-	for len(readyRuns) > 0 || len(submittedRuns) > 0 || len(rerunRuns) > 0 { // Loop whilst there are runs to submit or are running
+	// Loop whilst there are runs to submit or are running, and nothing has told us to stop.
+	for len(readyRuns) > 0 || len(submittedRuns) > 0 || len(rerunRuns) > 0 {
 
-		for len(submittedRuns) < throttle && len(readyRuns) > 0 {
+		submitter.stopReason = submitter.getReasonToStop(params.FailFast, deadline, finishedRuns)
+		if submitter.stopReason != "" {
+			break
+		}
+
+		if submitter.controls != nil {
+			throttle, readyRuns = submitter.applyControlRequests(params.ThrottleFileName, throttle, readyRuns, submittedRuns, lostRuns)
+		}
+
+		readyRuns = submitter.cancelRunsWithFailedDependencies(readyRuns, submittedRuns, rerunRuns, finishedRuns, lostRuns)
+		submitter.cancelRunsPastTheirTimeout(submittedRuns)
+
+		for len(submittedRuns) < throttle && len(readyRuns) > 0 && !submitter.isInterrupted() && !submitter.isPaused() {
+
+			// Tests waiting for the tests they depend on are passed over, until those have passed.
+			var isRunToSubmit bool
+			readyRuns, isRunToSubmit = moveNextRunToSubmitToFront(readyRuns, submittedRuns, rerunRuns, finishedRuns, lostRuns)
+			if !isRunToSubmit {
+				break
+			}
+
+			// Record which test is being submitted first, so that if galasactl stops before the journal
+			// records the run, a resume can tell which matrix combination the run is for.
+			submittingRun := readyRuns[0]
+			journal.SubmittingRun = &submittingRun
+			err = submitter.writeJournal(params.JournalFileName, journal)
+			if err != nil {
+				return nil, nil, err
+			}
 
 			readyRuns, err = submitter.submitRun(params.GroupName, readyRuns, submittedRuns,
 				lostRuns, &runOverrides, params.Trace, currentUser, params.RequestType)
 
 			if err != nil {
 				// Ignore the error and continue to process the list of available runs.
-				submitter.console.WriteString(fmt.Sprintf("%s\n", err.Error()))
+				submitter.reportMessage(fmt.Sprintf("%s\n", err.Error()))
+			}
+
+			journal.ReadyRuns = readyRuns
+			journal.SubmittingRun = nil
+			err = submitter.writeJournal(params.JournalFileName, journal)
+			if err != nil {
+				return nil, nil, err
 			}
 		}
 
@@ -198,17 +629,394 @@ func (submitter *Submitter) executeSubmitRuns(
 
 		submitter.runsFetchCurrentStatus(params.GroupName, submittedRuns, finishedRuns, lostRuns, fetchRas)
 
-		// Only sleep if there are runs in progress but not yet finished.
-		if len(submittedRuns) > 0 || len(rerunRuns) > 0 {
+		// Give the tests which failed in a retryable way another attempt.
+		submitter.moveRetryableRunsToRerun(retryPolicy, finishedRuns, rerunRuns)
+		readyRuns = submitter.requeueRunsDueForRetry(readyRuns, rerunRuns)
+
+		journal.ReadyRuns = readyRuns
+		err = submitter.writeJournal(params.JournalFileName, journal)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		progress := SubmissionProgress{
+			Now:           submitter.timeService.Now(),
+			ReadyRuns:     readyRuns,
+			SubmittedRuns: submittedRuns,
+			FinishedRuns:  finishedRuns,
+			LostRuns:      lostRuns,
+			Throttle:      throttle,
+		}
+		if submitter.progressReporter != nil {
+			submitter.progressReporter.ReportProgress(progress)
+		}
+		if submitter.controls != nil {
+			submitter.controls.ReportProgress(progress)
+		}
+
+		// Only sleep if there are runs in progress but not yet finished, or we are paused waiting to be resumed.
+		if len(submittedRuns) > 0 || len(rerunRuns) > 0 || submitter.isPaused() {
 			// log.Printf("Sleeping for the poll interval of %v seconds\n", params.PollIntervalSeconds)
-			submitter.timedSleeper.Sleep(pollInterval)
+			submitter.timedSleeper.Sleep(submitter.getSleepTimeBeforeDeadline(pollInterval, deadline))
 			// log.Printf("Awake from poll interval sleep of %v Gathering test results under theseconds\n", params.PollIntervalSeconds)
 		}
 	}
 
+	if submitter.stopReason != "" {
+		finishedRuns, lostRuns, err = submitter.finishStoppedSubmission(params, journal, fetchRas)
+	}
+
+	return finishedRuns, lostRuns, err
+}
+
+// cancelRunsWithFailedDependencies - Tests which depend on a test which didn't pass are never submitted.
+// They are reported as cancelled instead. Returns the tests which are still ready to be submitted.
+func (submitter *Submitter) cancelRunsWithFailedDependencies(
+	readyRuns []TestRun,
+	submittedRuns map[string]*TestRun,
+	rerunRuns map[string]*TestRun,
+	finishedRuns map[string]*TestRun,
+	lostRuns map[string]*TestRun,
+) []TestRun {
+	// Cancelling one test can fail the tests which depend on it, so keep going until nothing changes.
+	isCancelled := true
+	for isCancelled {
+		isCancelled = false
+		for index := range readyRuns {
+			readyRun := &readyRuns[index]
+			for _, dependencyKey := range getDependencyKeys(readyRun) {
+				state := getDependencyState(dependencyKey, readyRuns, submittedRuns, rerunRuns, finishedRuns, lostRuns)
+				if state == DEPENDENCY_FAILED {
+					log.Printf("Test %v will not be submitted, as the test %v it depends on did not pass\n", readyRun.getTestKey(), dependencyKey)
+					lostRuns[readyRun.getTestKey()] = newStoppedRun(readyRun, CANCELLED_BY_DEPENDENCY)
+					readyRuns = append(readyRuns[:index], readyRuns[index+1:]...)
+					isCancelled = true
+					break
+				}
+			}
+			if isCancelled {
+				break
+			}
+		}
+	}
+	return readyRuns
+}
+
+// moveNextRunToSubmitToFront - Finds the first ready test whose dependencies have all passed, and moves
+// it to the front of the ready tests, keeping the others in order. Returns false if no test can be
+// submitted yet.
+func moveNextRunToSubmitToFront(
+	readyRuns []TestRun,
+	submittedRuns map[string]*TestRun,
+	rerunRuns map[string]*TestRun,
+	finishedRuns map[string]*TestRun,
+	lostRuns map[string]*TestRun,
+) ([]TestRun, bool) {
+	isRunToSubmit := false
+	for index := range readyRuns {
+		isRunToSubmit = true
+		for _, dependencyKey := range getDependencyKeys(&readyRuns[index]) {
+			if getDependencyState(dependencyKey, readyRuns, submittedRuns, rerunRuns, finishedRuns, lostRuns) != DEPENDENCY_PASSED {
+				isRunToSubmit = false
+				break
+			}
+		}
+
+		if isRunToSubmit {
+			if index > 0 {
+				nextRun := readyRuns[index]
+				copy(readyRuns[1:index+1], readyRuns[:index])
+				readyRuns[0] = nextRun
+			}
+			break
+		}
+	}
+	return readyRuns, isRunToSubmit
+}
+
+// cancelRunsPastTheirTimeout - Cancels the test runs which have run for longer than their portfolio
+// class allows.
+func (submitter *Submitter) cancelRunsPastTheirTimeout(submittedRuns map[string]*TestRun) {
+	now := submitter.timeService.Now()
+	for runName, run := range submittedRuns {
+		if run.Timeout != "" && run.CancelledBy == "" {
+			if run.SubmittedTime.IsZero() {
+				// A run re-attached when resuming, which the journal has no record of, is timed from when it was first seen.
+				run.SubmittedTime = now
+			}
+
+			timeout, _ := time.ParseDuration(run.Timeout)
+			if timeout > 0 && !now.Before(run.SubmittedTime.Add(timeout)) {
+				if submitter.runCanceller == nil {
+					log.Printf("Run %v has run for longer than its timeout of %v, but can't be cancelled by this launcher, so it is left to finish.\n", runName, run.Timeout)
+				} else {
+					log.Printf("Cancelling run %v, as it has run for longer than its timeout of %v\n", runName, run.Timeout)
+					run.CancelledBy = CANCELLED_BY_CLASS_TIMEOUT
+					err := submitter.runCanceller.CancelRun(runName)
+					if err != nil {
+						submitter.reportMessage(fmt.Sprintf("%s\n", err.Error()))
+					}
+				}
+			}
+		}
+	}
+}
+
+func (submitter *Submitter) isPaused() bool {
+	return submitter.controls != nil && submitter.controls.isSubmissionPaused()
+}
+
+// applyControlRequests - Acts on any requests to change the throttle or cancel runs which
+// 'runs control' commands have made since the last time.
+// Returns the new throttle and the tests which are still ready to be submitted.
+func (submitter *Submitter) applyControlRequests(
+	throttleFileName string,
+	throttle int,
+	readyRuns []TestRun,
+	submittedRuns map[string]*TestRun,
+	lostRuns map[string]*TestRun,
+) (int, []TestRun) {
+
+	newThrottle, isThrottleChanged := submitter.controls.takeThrottleRequest()
+	if isThrottleChanged && newThrottle != throttle {
+		log.Printf("Throttle changed from %v to %v by 'runs control'\n", throttle, newThrottle)
+		submitter.recordEvent(SubmissionEvent{Event: EVENT_THROTTLE_CHANGED, PreviousThrottle: throttle, Throttle: newThrottle})
+		throttle = newThrottle
+
+		// Keep the throttle file in step, otherwise the old throttle would be read back from it.
+		err := submitter.writeThrottleFile(throttleFileName, throttle)
+		if err != nil {
+			submitter.reportMessage(fmt.Sprintf("%s\n", err.Error()))
+		}
+	}
+
+	for _, name := range submitter.controls.takeCancelRequests() {
+		submittedRun, isSubmitted := submittedRuns[name]
+		if isSubmitted {
+			if submitter.runCanceller == nil {
+				log.Printf("Run %v can't be cancelled by this launcher, so it is left to finish.\n", name)
+			} else {
+				log.Printf("Cancelling run %v, as asked by 'runs control'\n", name)
+				submittedRun.CancelledBy = CANCELLED_BY_RUNS_CONTROL
+				err := submitter.runCanceller.CancelRun(name)
+				if err != nil {
+					submitter.reportMessage(fmt.Sprintf("%s\n", err.Error()))
+				}
+			}
+		} else {
+			for index := range readyRuns {
+				readyRun := &readyRuns[index]
+				if readyRun.getTestKey() == name {
+					log.Printf("Test %v will not be submitted, as asked by 'runs control'\n", name)
+					lostRuns[name] = newStoppedRun(readyRun, CANCELLED_BY_RUNS_CONTROL)
+					readyRuns = append(readyRuns[:index], readyRuns[index+1:]...)
+					break
+				}
+			}
+		}
+	}
+
+	return throttle, readyRuns
+}
+
+// getReasonToStop - Decides whether the submission should stop before all the tests have finished.
+// Returns one of the STOP_REASON_ values, or an empty string if the submission should carry on.
+func (submitter *Submitter) getReasonToStop(isFailFast bool, deadline time.Time, finishedRuns map[string]*TestRun) string {
+	reason := ""
+
+	if submitter.isInterrupted() {
+		reason = STOP_REASON_INTERRUPT
+	} else if isFailFast && getFirstFailedRun(finishedRuns, submitter.getResultPolicy()) != nil {
+		// Only runs which won't be re-submitted are finished, so the retry policy has already had its chance.
+		reason = STOP_REASON_FAIL_FAST
+	} else if !deadline.IsZero() && !submitter.timeService.Now().Before(deadline) {
+		reason = STOP_REASON_TIMEOUT
+	}
+
+	return reason
+}
+
+// getFirstFailedRun - Finds a finished run which counts as a failure, choosing the first by name so the
+// same run is always picked. Runs the result policy excuses or quarantines don't count.
+// Returns nil if none of the finished runs count as failures.
+func getFirstFailedRun(finishedRuns map[string]*TestRun, policy *ResultPolicy) *TestRun {
+	var failedRun *TestRun
+	for runName, run := range finishedRuns {
+		if policy.isCountedAsFailure(run, run.Result) {
+			if failedRun == nil || runName < failedRun.Name {
+				failedRun = run
+			}
+		}
+	}
+	return failedRun
+}
+
+// getSleepTimeBeforeDeadline - How long to sleep before polling again. Never sleeps past the deadline,
+// so that a timeout is noticed as soon as it passes.
+func (submitter *Submitter) getSleepTimeBeforeDeadline(pollInterval time.Duration, deadline time.Time) time.Duration {
+	sleepTime := pollInterval
+	if !deadline.IsZero() {
+		timeLeft := deadline.Sub(submitter.timeService.Now())
+		if timeLeft < sleepTime {
+			sleepTime = timeLeft
+		}
+		if sleepTime < 0 {
+			sleepTime = 0
+		}
+	}
+	return sleepTime
+}
+
+// finishStoppedSubmission - Stops the submission early. When stopped by --fail-fast or --timeout,
+// or when interrupted and the user asked for it, the runs which are still in progress are cancelled,
+// and given a short time to finish.
+// Returns the runs to report on, in which every test which didn't finish is a lost run with an
+// Interrupted or Cancelled result, and each test cut short records why. The journal still records
+// those tests as ready or submitted, so the submission can be resumed.
+func (submitter *Submitter) finishStoppedSubmission(
+	params utils.RunsSubmitCmdValues,
+	journal *SubmissionJournal,
+	fetchRas bool,
+) (map[string]*TestRun, map[string]*TestRun, error) {
+
+	var err error
+	stopReason := submitter.stopReason
+
+	log.Printf("Submission of tests in group '%v' stopped early. Reason: %v. %v runs are in progress.\n",
+		params.GroupName, stopReason, len(journal.SubmittedRuns))
+
+	isCancelWanted := true
+	switch stopReason {
+	case STOP_REASON_INTERRUPT:
+		submitter.reportMessage("Interrupted. No more tests will be submitted. Interrupt again to exit immediately.\n")
+		isCancelWanted = params.CancelOnInterrupt
+	case STOP_REASON_FAIL_FAST:
+		failedRun := getFirstFailedRun(journal.FinishedRuns, submitter.getResultPolicy())
+		submitter.reportMessage(fmt.Sprintf("Run %v finished with result '%v'. No more tests will be submitted, and tests in progress will be cancelled.\n",
+			failedRun.Name, failedRun.Result))
+	case STOP_REASON_TIMEOUT:
+		submitter.reportMessage(fmt.Sprintf("Timed out after %v. No more tests will be submitted, and tests in progress will be cancelled.\n", params.Timeout))
+	}
+
+	if isCancelWanted && len(journal.SubmittedRuns) > 0 {
+		if submitter.runCanceller == nil {
+			log.Printf("Runs in progress can't be cancelled by this launcher, so they are left to finish.\n")
+		} else {
+			for _, run := range journal.SubmittedRuns {
+				run.CancelledBy = stopReason
+			}
+			submitter.cancelSubmittedRuns(journal.SubmittedRuns)
+
+			if params.InterruptWaitSeconds > 0 {
+				waitParams := &utils.RunsWaitCmdValues{
+					GroupName:           params.GroupName,
+					PollIntervalSeconds: params.PollIntervalSeconds,
+					Timeout:             time.Second * time.Duration(params.InterruptWaitSeconds),
+				}
+				submitter.waitForRunsToFinish(waitParams, params, journal.SubmittedRuns, journal.FinishedRuns, journal.LostRuns)
+			}
+
+			err = submitter.writeJournal(params.JournalFileName, journal)
+		}
+	}
+
+	finishedRuns := make(map[string]*TestRun)
+	for runName, run := range journal.FinishedRuns {
+		finishedRuns[runName] = run
+	}
+
+	lostRuns := make(map[string]*TestRun)
+	for runName, run := range journal.LostRuns {
+		lostRuns[runName] = run
+	}
+
+	for runName, run := range journal.SubmittedRuns {
+		lostRuns[runName] = newStoppedRun(run, stopReason)
+	}
+
+	for _, run := range journal.RerunRuns {
+		lostRuns[run.getTestKey()] = newStoppedRun(run, stopReason)
+	}
+
+	for index := range journal.ReadyRuns {
+		run := &journal.ReadyRuns[index]
+		lostRuns[run.getTestKey()] = newStoppedRun(run, stopReason)
+	}
+
 	return finishedRuns, lostRuns, err
 }
 
+// cancelSubmittedRuns - Asks the ecosystem to cancel each of the runs which are in progress.
+func (submitter *Submitter) cancelSubmittedRuns(submittedRuns map[string]*TestRun) {
+	for runName := range submittedRuns {
+		log.Printf("Cancelling run %v\n", runName)
+		err := submitter.runCanceller.CancelRun(runName)
+		if err != nil {
+			// Carry on, so that as many runs as possible are cancelled.
+			submitter.reportMessage(fmt.Sprintf("%s\n", err.Error()))
+		}
+	}
+}
+
+// newStoppedRun - A copy of a test run which didn't finish, marked so that reports show it was
+// interrupted or cancelled, and why.
+func newStoppedRun(run *TestRun, stopReason string) *TestRun {
+	stoppedRun := *run
+	if stopReason == STOP_REASON_INTERRUPT {
+		stoppedRun.Result = RESULT_INTERRUPTED
+	} else {
+		stoppedRun.Result = RESULT_CANCELLED
+	}
+	stoppedRun.CancelledBy = stopReason
+	return &stoppedRun
+}
+
+// writeJournal - Records the current state of the submission, if a journal file is being used.
+func (submitter *Submitter) writeJournal(journalFileName string, journal *SubmissionJournal) error {
+	var err error
+	if journalFileName != "" {
+		err = WriteSubmissionJournal(submitter.fileSystem, journalFileName, journal)
+	}
+	return err
+}
+
+// moveRetryableRunsToRerun - Takes the finished runs which the retry policy says should be
+// attempted again out of the finished runs, and prepares the next attempt of each.
+func (submitter *Submitter) moveRetryableRunsToRerun(
+	retryPolicy *RetryPolicy,
+	finishedRuns map[string]*TestRun,
+	rerunRuns map[string]*TestRun,
+) {
+	now := submitter.timeService.Now()
+	for runName, finishedRun := range finishedRuns {
+		if retryPolicy.IsRetryNeeded(finishedRun) {
+			nextAttempt := newRetryAttempt(finishedRun, now, now.Add(retryPolicy.GetBackoff()))
+			attemptNumber := len(nextAttempt.PreviousAttempts) + 1
+
+			log.Printf("Run %v finished with result %v. Attempt %v of %v will be submitted for %v/%v/%v\n",
+				runName, finishedRun.Result, attemptNumber, retryPolicy.GetMaxAttemptsOf(finishedRun),
+				finishedRun.Stream, finishedRun.Bundle, finishedRun.Class)
+
+			rerunRuns[runName] = &nextAttempt
+			delete(finishedRuns, runName)
+		}
+	}
+}
+
+// requeueRunsDueForRetry - Adds the runs waiting to be re-submitted to the list of ready runs,
+// once any back-off time required by the retry policy has passed.
+func (submitter *Submitter) requeueRunsDueForRetry(readyRuns []TestRun, rerunRuns map[string]*TestRun) []TestRun {
+	now := submitter.timeService.Now()
+	for previousRunName, rerun := range rerunRuns {
+		if !now.Before(rerun.NextAttemptNotBefore) {
+			log.Printf("Re-submission of run %v added to the ready queue\n", previousRunName)
+			readyRuns = append(readyRuns, *rerun)
+			delete(rerunRuns, previousRunName)
+		}
+	}
+	return readyRuns
+}
+
 func (submitter *Submitter) displayInterrimProgressReport(readyRuns []TestRun,
 	submittedRuns map[string]*TestRun,
 	finishedRuns map[string]*TestRun,
@@ -272,6 +1080,7 @@ func (submitter *Submitter) updateThrottleFromFileIfDifferent(
 			// Only log something if we are changing the throttle value.
 			if savedThrottle != currentThrottle {
 				log.Printf("Changing throttle from %v to %v\n", currentThrottle, newThrottle)
+				submitter.recordEvent(SubmissionEvent{Event: EVENT_THROTTLE_CHANGED, PreviousThrottle: currentThrottle, Throttle: savedThrottle})
 			}
 			newThrottle = savedThrottle
 		}
@@ -332,16 +1141,18 @@ func (submitter *Submitter) submitRun(
 
 		var resultGroup *galasaapi.TestRuns
 		log.Printf("submitRun - %s, %s", className, requestType)
-		resultGroup, err = submitter.launcher.SubmitTestRun(groupName, className, requestType, requestor,
+		resultGroup, err = submitter.launcher.SubmitTestRun(getRunGroupName(groupName, &nextRun), className, requestType, requestor,
 			nextRun.Stream, nextRun.Obr, trace, nextRun.GherkinUrl, nextRun.GherkinFeature, submitOverrides)
 		if err != nil {
 			log.Printf("Failed to submit test %v/%v - %v\n", nextRun.Bundle, nextRun.Class, err)
-			lostRuns[className] = &nextRun
+			lostRuns[nextRun.getTestKey()] = &nextRun
+			submitter.recordEvent(newRunEvent(EVENT_LOST, &nextRun))
 			err = galasaErrors.NewGalasaErrorWithCause(err, galasaErrors.GALASA_ERROR_FAILED_TO_SUBMIT_TEST, nextRun.Bundle, nextRun.Class, err.Error())
 		} else {
 			if len(resultGroup.GetRuns()) < 1 {
 				log.Printf("Lost the run attempting to submit test %v/%v\n", nextRun.Bundle, nextRun.Class)
-				lostRuns[className] = &nextRun
+				lostRuns[nextRun.getTestKey()] = &nextRun
+				submitter.recordEvent(newRunEvent(EVENT_LOST, &nextRun))
 				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_TEST_NOT_IN_RUN_GROUP_LOST, nextRun.Bundle, nextRun.Class)
 			}
 
@@ -349,8 +1160,10 @@ func (submitter *Submitter) submitRun(
 				submittedRun := resultGroup.GetRuns()[0]
                 nextRun.Group = *submittedRun.Group
 				nextRun.Name = *submittedRun.Name
+				nextRun.SubmittedTime = submitter.timeService.Now()
 
 				submittedRuns[nextRun.Name] = &nextRun
+				submitter.recordEvent(newRunEvent(EVENT_SUBMITTED, &nextRun))
 
 				if nextRun.GherkinUrl != "" {
 					log.Printf("Run %v submitted - %v\n", nextRun.Name, nextRun.GherkinFeature)
@@ -371,16 +1184,33 @@ func (submitter *Submitter) runsFetchCurrentStatus(
 	lostRuns map[string]*TestRun,
 	fetchRas bool) {
 
-	currentGroup, err := submitter.launcher.GetRunsByGroup(groupName)
-	if err != nil {
-		log.Printf("Received error from group request - %v\n", err)
-		return
+	var err error
+
+	// The runs of each matrix combination are in a group of their own.
+	runGroupNames := make([]string, 0, 1)
+	for _, run := range submittedRuns {
+		if run.Group == "" {
+			runGroupNames = append(runGroupNames, groupName)
+		} else {
+			runGroupNames = append(runGroupNames, run.Group)
+		}
+	}
+
+	currentRuns := make([]galasaapi.TestRun, 0)
+	for _, runGroupName := range getDistinctGroupNames(runGroupNames) {
+		var currentGroup *galasaapi.TestRuns
+		currentGroup, err = submitter.launcher.GetRunsByGroup(runGroupName)
+		if err != nil {
+			log.Printf("Received error from group request - %v\n", err)
+			return
+		}
+		currentRuns = append(currentRuns, currentGroup.GetRuns()...)
 	}
 
 	// a copy to find lost runs
 	checkRuns := DeepClone(submittedRuns)
 
-	for _, currentRun := range currentGroup.GetRuns() {
+	for _, currentRun := range currentRuns {
 		runName := currentRun.GetName()
 
 		checkRun, ok := submittedRuns[runName]
@@ -417,10 +1247,18 @@ func (submitter *Submitter) runsFetchCurrentStatus(
 						testStructure := rasRun.GetTestStructure()
 						log.Printf("runsFetchCurrentStatus - testStructure- %v", testStructure)
 
+						checkRun.RasRunId = *rasRunID
+						checkRun.StartTimeUTC = testStructure.GetStartTime()
+						checkRun.EndTimeUTC = testStructure.GetEndTime()
+
 						for _, testMethod := range testStructure.GetMethods() {
 							test := TestMethod{
-								Method: testMethod.GetMethodName(),
-								Result: testMethod.GetResult(),
+								Method:       testMethod.GetMethodName(),
+								Result:       testMethod.GetResult(),
+								Type:         testMethod.GetType(),
+								Status:       testMethod.GetStatus(),
+								StartTimeUTC: testMethod.GetStartTime(),
+								EndTimeUTC:   testMethod.GetEndTime(),
 							}
 
 							checkRun.Tests = append(checkRun.Tests, test)
@@ -433,6 +1271,7 @@ func (submitter *Submitter) runsFetchCurrentStatus(
 				} else {
 					log.Printf("Run %v has finished(%v) - %v/%v/%v - %s\n", runName, result, checkRun.Stream, checkRun.Bundle, checkRun.Class, currentRun.GetStatus())
 				}
+				submitter.recordEvent(newRunEvent(EVENT_FINISHED, checkRun))
 			} else {
 				// Check to see if there was a status change
 				if checkRun.Status != currentRun.GetStatus() {
@@ -442,6 +1281,7 @@ func (submitter *Submitter) runsFetchCurrentStatus(
 					} else {
 						log.Printf("    Run %v status is now '%v' - %v/%v/%v\n", runName, checkRun.Status, checkRun.Stream, checkRun.Bundle, checkRun.Class)
 					}
+					submitter.recordEvent(newRunEvent(EVENT_STATUS_CHANGED, checkRun))
 				}
 			}
 		}
@@ -452,6 +1292,7 @@ func (submitter *Submitter) runsFetchCurrentStatus(
 		lostRuns[runName] = lostRun
 		delete(submittedRuns, runName)
 		log.Printf("Run %v was lost - %v/%v/%v\n", runName, lostRun.Stream, lostRun.Bundle, lostRun.Class)
+		submitter.recordEvent(newRunEvent(EVENT_LOST, lostRun))
 	}
 
 }
@@ -463,19 +1304,22 @@ func (submitter *Submitter) createReports(params utils.RunsSubmitCmdValues,
 	submitter.displayTestRunResults(finishedRuns, lostRuns)
 
 	var err error
-	if params.ReportYamlFilename != "" {
-		err = ReportYaml(submitter.fileSystem, params.ReportYamlFilename, finishedRuns, lostRuns)
-	}
-
-	if err == nil {
-		if params.ReportJsonFilename != "" {
-			err = ReportJSON(submitter.fileSystem, params.ReportJsonFilename, finishedRuns, lostRuns)
-		}
+	results := TestReportResults{
+		GroupName:            params.GroupName,
+		FinishedRuns:         finishedRuns,
+		LostRuns:             lostRuns,
+		ApiServerUrl:         submitter.apiServerUrl,
+		RunLogFetcher:        submitter.runLogFetcher,
+		JunitRunLogLineCount: params.ReportJunitRunLogLines,
 	}
 
-	if err == nil {
-		if params.ReportJunitFilename != "" {
-			err = ReportJunit(submitter.fileSystem, params.ReportJunitFilename, params.GroupName, finishedRuns, lostRuns)
+	for _, reporter := range CreateTestReporters() {
+		if err == nil {
+			reportFilename := *reporter.GetReportFilename(&params)
+			if reportFilename != "" {
+				log.Printf("Writing the %v test report to %v\n", reporter.GetName(), reportFilename)
+				err = reporter.WriteReport(submitter.fileSystem, reportFilename, results)
+			}
 		}
 	}
 
@@ -498,14 +1342,10 @@ func (submitter *Submitter) isRasDetailNeededForReports(params utils.RunsSubmitC
 
 	// Do we need to ask the RAS for the test structure
 	isRasDetailNeeded := false
-	if params.ReportYamlFilename != "" {
-		isRasDetailNeeded = true
-	}
-	if params.ReportJsonFilename != "" {
-		isRasDetailNeeded = true
-	}
-	if params.ReportJunitFilename != "" {
-		isRasDetailNeeded = true
+	for _, reporter := range CreateTestReporters() {
+		if *reporter.GetReportFilename(&params) != "" {
+			isRasDetailNeeded = true
+		}
 	}
 
 	return isRasDetailNeeded
@@ -513,37 +1353,50 @@ func (submitter *Submitter) isRasDetailNeededForReports(params utils.RunsSubmitC
 
 func (submitter *Submitter) buildListOfRunsToSubmit(portfolio *Portfolio, runOverrides map[string]string) []TestRun {
 	log.Printf("buildListOfRunsToSubmit - portfolio %v, runOverrides %v", portfolio, runOverrides)
-	readyRuns := make([]TestRun, 0, len(portfolio.Classes))
+	combinations := portfolio.GetMatrixCombinations()
+	readyRuns := make([]TestRun, 0, len(portfolio.Classes)*len(combinations))
 	currentUser := submitter.GetCurrentUserName()
-	for _, portfolioTest := range portfolio.Classes {
-		newTestrun := TestRun{
-			Bundle:         portfolioTest.Bundle,
-			Class:          portfolioTest.Class,
-			Stream:         portfolioTest.Stream,
-			Obr:            portfolioTest.Obr,
-			QueuedTimeUTC:  submitter.timeService.Now().String(),
-			Requestor:      currentUser,
-			Status:         "queued",
-			Overrides:      make(map[string]string, 0),
-			GherkinUrl:     portfolioTest.GherkinUrl,
-			GherkinFeature: submitter.getFeatureFromGherkinUrl(portfolioTest.GherkinUrl),
-		}
+	for _, portfolioTest := range getClassesInPriorityOrder(portfolio.Classes) {
+		for _, combination := range combinations {
+			newTestrun := TestRun{
+				Bundle:         portfolioTest.Bundle,
+				Class:          portfolioTest.Class,
+				Stream:         portfolioTest.Stream,
+				Obr:            portfolioTest.Obr,
+				QueuedTimeUTC:  submitter.timeService.Now().String(),
+				Requestor:      currentUser,
+				Status:         "queued",
+				Overrides:      make(map[string]string, 0),
+				GherkinUrl:     portfolioTest.GherkinUrl,
+				GherkinFeature: submitter.getFeatureFromGherkinUrl(portfolioTest.GherkinUrl),
+				Combination:    combination.Name,
+				Labels:         portfolioTest.Labels,
+				MaxAttempts:    portfolioTest.MaxAttempts,
+				Timeout:        portfolioTest.Timeout,
+				DependsOn:      portfolioTest.DependsOn,
+			}
 
-		// load the run overrides
-		for key, value := range runOverrides {
-			newTestrun.Overrides[key] = value
-		}
+			// load the run overrides
+			for key, value := range runOverrides {
+				newTestrun.Overrides[key] = value
+			}
 
-		// load the assemble overrides, they take precedence on the run overrides
-		for key, value := range portfolioTest.Overrides {
-			newTestrun.Overrides[key] = value
-		}
+			// load the assemble overrides, they take precedence on the run overrides
+			for key, value := range portfolioTest.Overrides {
+				newTestrun.Overrides[key] = value
+			}
 
-		readyRuns = append(readyRuns, newTestrun)
-		if newTestrun.GherkinUrl == "" {
-			log.Printf("Added test %v/%v/%v to the ready queue\n", newTestrun.Stream, newTestrun.Bundle, newTestrun.Class)
-		} else {
-			log.Printf("Added gherkin test %v to the ready queue\n", newTestrun.GherkinFeature)
+			// load the matrix overrides, they take precedence on both, as they are what varies between the runs of a class
+			for key, value := range combination.Overrides {
+				newTestrun.Overrides[key] = value
+			}
+
+			readyRuns = append(readyRuns, newTestrun)
+			if newTestrun.GherkinUrl == "" {
+				log.Printf("Added test %v/%v/%v%v to the ready queue\n", newTestrun.Stream, newTestrun.Bundle, newTestrun.Class, getCombinationSuffix(&newTestrun))
+			} else {
+				log.Printf("Added gherkin test %v%v to the ready queue\n", newTestrun.GherkinFeature, getCombinationSuffix(&newTestrun))
+			}
 		}
 	}
 
@@ -573,18 +1426,41 @@ func (submitter *Submitter) validateAndCorrectParams(
 		params.Throttle = MAX_INT // set to maximum size of the int
 	}
 
-	//  Dont mix portfolio and test selection on the same command
-	if params.PortfolioFileName != "" {
+	// Every test class is attempted at least once.
+	if params.MaxAttempts < 1 {
+		params.MaxAttempts = DEFAULT_MAX_ATTEMPTS
+	}
+
+	// Guard against a negative back-off time between attempts
+	if params.RetryBackoffSeconds < 0 {
+		params.RetryBackoffSeconds = DEFAULT_RETRY_BACKOFF_SECONDS
+	}
+
+	//  Dont mix portfolio and test selection on the same command.
+	//  When resuming, the tests come from the journal instead.
+	if params.ResumeJournalFileName != "" {
+		if params.PortfolioFileName != "" || AreSelectionFlagsProvided(submitSelectionFlags) {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_RESUME_MIXED_WITH_TESTS)
+		}
+	} else if params.PortfolioFileName != "" {
 		if AreSelectionFlagsProvided(submitSelectionFlags) {
 			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_MIX_FLAGS_AND_PORTFOLIO)
 		}
-	} else {
+	} else if submitter.portfolio == nil {
 		if !AreSelectionFlagsProvided(submitSelectionFlags) {
 			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_MISSING_ACTION_FLAGS)
 		}
 	}
 
 	if err == nil {
+		err = submitter.validateShardParams(params)
+	}
+
+	if err == nil {
+		err = submitter.validateScheduleParams(params)
+	}
+
+	if err == nil && params.ResumeJournalFileName == "" {
 		// generate a group name if required
 		if params.GroupName == "" {
 			params.GroupName = randomGenerator.NewString()
@@ -600,6 +1476,44 @@ func (submitter *Submitter) validateAndCorrectParams(
 
 	submitter.tildaExpandAllPaths(params)
 
+	if err == nil {
+		submitter.resultPolicy, err = NewResultPolicy(submitter.fileSystem, *params)
+	}
+
+	return err
+}
+
+func (submitter *Submitter) validateShardParams(params *utils.RunsSubmitCmdValues) error {
+	var err error
+
+	if params.ShardStrategy == "" {
+		params.ShardStrategy = DEFAULT_SHARD_STRATEGY
+	}
+
+	if params.Shard != "" {
+		if params.ResumeJournalFileName != "" {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_SHARD_MIXED_WITH_RESUME)
+		} else {
+			_, err = ParseShard(params.Shard)
+		}
+	}
+
+	if err == nil && !IsValidShardStrategy(params.ShardStrategy) {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_INVALID_SHARD_STRATEGY, params.ShardStrategy)
+	}
+	return err
+}
+
+func (submitter *Submitter) validateScheduleParams(params *utils.RunsSubmitCmdValues) error {
+	var err error
+
+	if params.Schedule == "" {
+		params.Schedule = DEFAULT_SCHEDULE
+	}
+
+	if !IsValidSchedule(params.Schedule) {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_INVALID_SCHEDULE, params.Schedule)
+	}
 	return err
 }
 
@@ -635,20 +1549,39 @@ func (submitter *Submitter) tildaExpandAllPaths(params *utils.RunsSubmitCmdValue
 		params.PortfolioFileName, err = files.TildaExpansion(submitter.fileSystem, params.PortfolioFileName)
 	}
 
+	for _, reporter := range CreateTestReporters() {
+		if err == nil {
+			reportFilename := reporter.GetReportFilename(params)
+			*reportFilename, err = files.TildaExpansion(submitter.fileSystem, *reportFilename)
+		}
+	}
+
 	if err == nil {
-		params.ReportJsonFilename, err = files.TildaExpansion(submitter.fileSystem, params.ReportJsonFilename)
+		params.ThrottleFileName, err = files.TildaExpansion(submitter.fileSystem, params.ThrottleFileName)
 	}
 
 	if err == nil {
-		params.ReportJunitFilename, err = files.TildaExpansion(submitter.fileSystem, params.ReportJunitFilename)
+		params.ResultPolicyFileName, err = files.TildaExpansion(submitter.fileSystem, params.ResultPolicyFileName)
 	}
 
 	if err == nil {
-		params.ReportYamlFilename, err = files.TildaExpansion(submitter.fileSystem, params.ReportYamlFilename)
+		params.JournalFileName, err = files.TildaExpansion(submitter.fileSystem, params.JournalFileName)
 	}
 
 	if err == nil {
-		params.ThrottleFileName, err = files.TildaExpansion(submitter.fileSystem, params.ThrottleFileName)
+		params.ResumeJournalFileName, err = files.TildaExpansion(submitter.fileSystem, params.ResumeJournalFileName)
+	}
+
+	if err == nil {
+		params.ControlFileName, err = files.TildaExpansion(submitter.fileSystem, params.ControlFileName)
+	}
+
+	if err == nil {
+		params.EventsFileName, err = files.TildaExpansion(submitter.fileSystem, params.EventsFileName)
+	}
+
+	if err == nil {
+		params.WebhookTemplateFile, err = files.TildaExpansion(submitter.fileSystem, params.WebhookTemplateFile)
 	}
 	return err
 }
@@ -663,6 +1596,14 @@ func (submitter *Submitter) buildOverrideMap(commandParameters utils.RunsSubmitC
 		runOverrides, err = submitter.addOverridesFromCmdLine(runOverrides, commandParameters.Overrides)
 	}
 
+	if err == nil {
+		expander := NewEnvVarExpander(submitter.env, commandParameters.StrictEnvVars)
+		err = expander.ExpandOverrides(runOverrides, "given to the command")
+		if err != nil {
+			runOverrides = nil
+		}
+	}
+
 	return runOverrides, err
 }
 
@@ -709,13 +1650,15 @@ func (submitter *Submitter) addOverridesFromCmdLine(overrides map[string]string,
 	return overrides, nil
 }
 
-func (submitter *Submitter) getPortfolio(portfolioFileName string, submitSelectionFlags *utils.TestSelectionFlagValues) (*Portfolio, error) {
+func (submitter *Submitter) getPortfolio(portfolioFileName string, expander *EnvVarExpander, submitSelectionFlags *utils.TestSelectionFlagValues) (*Portfolio, error) {
 	// Load the portfolio of tests
 	var portfolio *Portfolio = nil
 	var err error
 
-	if portfolioFileName != "" {
-		portfolio, err = ReadPortfolio(submitter.fileSystem, portfolioFileName)
+	if submitter.portfolio != nil {
+		portfolio = submitter.portfolio
+	} else if portfolioFileName != "" {
+		portfolio, err = ReadPortfolioExpandingEnvVars(submitter.fileSystem, portfolioFileName, expander)
 	} else {
 		// There is no portfolio file, so create an in-memory portfolio
 		// from the tests we can find from the test selection.
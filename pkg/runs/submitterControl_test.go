@@ -0,0 +1,184 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// A sleeper which does something, such as sending a control request, instead of its nth sleep.
+type actingSleeper struct {
+	*utils.MockTimedSleeper
+	actOnSleep int
+	action     func()
+}
+
+func (sleeper *actingSleeper) Sleep(duration time.Duration) {
+	sleeper.MockTimedSleeper.Sleep(duration)
+	if sleeper.SleepCount == sleeper.actOnSleep {
+		sleeper.action()
+	}
+}
+
+func TestSubmitPausedSubmitsNothingUntilResumed(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	submitter.controls = NewSubmissionControls()
+	submitter.controls.Pause()
+
+	launchesWhilePaused := -1
+	submitter.timedSleeper = &actingSleeper{
+		MockTimedSleeper: utils.NewMockTimedSleeper(submitter.timeService.(*utils.MockTimeService)),
+		actOnSleep:       3,
+		action: func() {
+			launchesWhilePaused = len(mockLauncher.GetRecordedLaunchRecords())
+			submitter.controls.Resume()
+		},
+	}
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 0, launchesWhilePaused)
+	assert.Equal(t, 2, len(mockLauncher.GetRecordedLaunchRecords()))
+}
+
+func TestSubmitThrottleChangedByControlsIsUsedAndWrittenToTheThrottleFile(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createThreeClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	submitter.controls = NewSubmissionControls()
+	err := submitter.controls.SetThrottle(3)
+	assert.Nil(t, err)
+
+	progressReporter := new(recordingProgressReporter)
+	submitter.SetProgressReporter(progressReporter)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		Throttle:          1,
+		ThrottleFileName:  "my.throttle",
+	}
+
+	// When...
+	err = submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(progressReporter.reportedProgress))
+	assert.Equal(t, 3, progressReporter.reportedProgress[0].Throttle)
+
+	throttleFileContents, err := mockFileSystem.ReadTextFile("my.throttle")
+	assert.Nil(t, err)
+	assert.Equal(t, "3", throttleFileContents)
+}
+
+func TestSubmitCancelsRunsAndTestsAskedForByControls(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createThreeClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter, canceller := newSubmitterForStopPolicyTests(t, mockFileSystem, mockLauncher, "myBundle/myClass1")
+
+	submitter.controls = NewSubmissionControls()
+	var cancelErr error
+	submitter.timedSleeper = &actingSleeper{
+		MockTimedSleeper: utils.NewMockTimedSleeper(submitter.timeService.(*utils.MockTimeService)),
+		actOnSleep:       1,
+		action: func() {
+			cancelErr = submitter.controls.RequestCancel([]string{"M100", "myBundle/myClass2"})
+		},
+	}
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+		Throttle:           1,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, cancelErr)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1017E: Not all runs passed. 2 failed.")
+	assert.Equal(t, []string{"M100"}, canceller.cancelledRuns)
+	assert.Equal(t, 2, len(mockLauncher.GetRecordedLaunchRecords()))
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 3, len(report.Tests))
+
+	cancelledTest := getReportedTestByClass(report, "myClass1")
+	assert.Equal(t, CANCEL_RESULT, cancelledTest.Result)
+	assert.Equal(t, CANCELLED_BY_RUNS_CONTROL, cancelledTest.CancelledBy)
+
+	notSubmittedTest := getReportedTestByClass(report, "myClass2")
+	assert.Equal(t, RESULT_CANCELLED, notSubmittedTest.Result)
+	assert.Equal(t, CANCELLED_BY_RUNS_CONTROL, notSubmittedTest.CancelledBy)
+
+	passedTest := getReportedTestByClass(report, "myClass3")
+	assert.Equal(t, RESULT_PASSED, passedTest.Result)
+}
+
+// A progress reporter which calls a function each time progress is reported.
+type progressReporterFunc func(progress SubmissionProgress)
+
+func (reporter progressReporterFunc) ReportProgress(progress SubmissionProgress) {
+	reporter(progress)
+}
+
+func (reporter progressReporterFunc) ReportMessage(message string) {
+}
+
+func TestSubmitWithControlFileAnnouncesControlServerAndRemovesFileAtEnd(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	isControlFileWritten := false
+	submitter.SetProgressReporter(progressReporterFunc(func(progress SubmissionProgress) {
+		isControlFileWritten, _ = mockFileSystem.Exists("my.control")
+	}))
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		ControlFileName:   "my.control",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.True(t, isControlFileWritten)
+	assert.Contains(t, submitter.console.(*utils.MockConsole).ReadText(),
+		"Use 'galasactl runs control --controlfile my.control' to control it.")
+
+	isExists, _ := mockFileSystem.Exists("my.control")
+	assert.False(t, isExists)
+}
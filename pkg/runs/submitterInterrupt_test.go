@@ -0,0 +1,178 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// A launcher whose runs keep running, and which interrupts the submitter
+// as soon as the first run has been submitted.
+type interruptingLauncher struct {
+	*launcher.MockLauncher
+	submitter *Submitter
+}
+
+func (launcher *interruptingLauncher) SubmitTestRun(
+	groupName string,
+	className string,
+	requestType string,
+	requestor string,
+	stream string,
+	obrFromPortfolio string,
+	isTraceEnabled bool,
+	gherkinURL string,
+	gherkinFeature string,
+	overrides map[string]interface{},
+) (*galasaapi.TestRuns, error) {
+	testRuns, err := launcher.MockLauncher.SubmitTestRun(groupName, className, requestType, requestor, stream,
+		obrFromPortfolio, isTraceEnabled, gherkinURL, gherkinFeature, overrides)
+	if err == nil {
+		launcher.MockLauncher.SetRunStatus(testRuns.GetRuns()[0].GetName(), "running")
+		launcher.submitter.Interrupt("interrupted by the unit test")
+	}
+	return testRuns, err
+}
+
+// A run canceller which finishes the runs of the mock launcher with a cancelled result.
+type mockRunCanceller struct {
+	mockLauncher  *launcher.MockLauncher
+	cancelledRuns []string
+}
+
+func (canceller *mockRunCanceller) CancelRun(runName string) error {
+	canceller.cancelledRuns = append(canceller.cancelledRuns, runName)
+	canceller.mockLauncher.SetRunStatus(runName, "finished")
+	canceller.mockLauncher.SetRunResult(runName, CANCEL_RESULT)
+	return nil
+}
+
+func createTwoClassPortfolio(t *testing.T, mockFileSystem spi.FileSystem) {
+	portfolio := NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes,
+		PortfolioClass{Bundle: "myBundle", Class: "myClass1", Obr: "myobr"},
+		PortfolioClass{Bundle: "myBundle", Class: "myClass2", Obr: "myobr"},
+	)
+	err := WritePortfolio(mockFileSystem, "my.portfolio", portfolio)
+	assert.Nil(t, err)
+}
+
+func TestSubmitInterruptedBeforeStartingSubmitsNothing(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	submitter.Interrupt("interrupted by the unit test")
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+		JournalFileName:    "my.journal",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1241E: The submission of tests was interrupted. 2 tests did not finish.")
+	assert.Empty(t, mockLauncher.GetRecordedLaunchRecords())
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 2, len(report.Tests))
+	assert.Equal(t, RESULT_INTERRUPTED, report.Tests[0].Result)
+	assert.Equal(t, RESULT_INTERRUPTED, report.Tests[1].Result)
+
+	// The journal still has the tests waiting to be submitted, so the submission can be resumed.
+	journal, err := ReadSubmissionJournal(mockFileSystem, "my.journal")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(journal.ReadyRuns))
+}
+
+func TestSubmitInterruptedCancelsRunsInProgressIfAskedTo(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	wrappedLauncher := &interruptingLauncher{MockLauncher: mockLauncher}
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	submitter.launcher = wrappedLauncher
+	wrappedLauncher.submitter = submitter
+
+	canceller := &mockRunCanceller{mockLauncher: mockLauncher}
+	submitter.SetRunCanceller(canceller)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:    "my.portfolio",
+		ReportYamlFilename:   "report.yaml",
+		CancelOnInterrupt:    true,
+		InterruptWaitSeconds: 60,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1241E: The submission of tests was interrupted. 1 tests did not finish.")
+	assert.Equal(t, 1, len(mockLauncher.GetRecordedLaunchRecords()))
+	assert.Equal(t, []string{"M100"}, canceller.cancelledRuns)
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 2, len(report.Tests))
+	for _, test := range report.Tests {
+		if test.Class == "myClass1" {
+			assert.Equal(t, CANCEL_RESULT, test.Result)
+		} else {
+			assert.Equal(t, RESULT_INTERRUPTED, test.Result)
+		}
+	}
+}
+
+func TestSubmitInterruptedLeavesRunsInProgressAloneByDefault(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	wrappedLauncher := &interruptingLauncher{MockLauncher: mockLauncher}
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	submitter.launcher = wrappedLauncher
+	wrappedLauncher.submitter = submitter
+
+	canceller := &mockRunCanceller{mockLauncher: mockLauncher}
+	submitter.SetRunCanceller(canceller)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1241E: The submission of tests was interrupted. 2 tests did not finish.")
+	assert.Empty(t, canceller.cancelledRuns)
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 2, len(report.Tests))
+	for _, test := range report.Tests {
+		assert.Equal(t, RESULT_INTERRUPTED, test.Result)
+		if test.Class == "myClass1" {
+			assert.Equal(t, "M100", test.Name)
+			assert.Equal(t, "running", test.Status)
+		}
+	}
+}
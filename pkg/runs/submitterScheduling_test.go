@@ -0,0 +1,226 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeV1betaPortfolio(t *testing.T, mockFileSystem spi.FileSystem, classes ...PortfolioClass) {
+	err := WritePortfolio(mockFileSystem, "my.portfolio", newV1betaPortfolio(classes...))
+	assert.Nil(t, err)
+}
+
+func getLaunchedClassNames(mockLauncher *launcher.MockLauncher) []string {
+	classNames := make([]string, 0)
+	for _, launch := range mockLauncher.GetRecordedLaunchRecords() {
+		classNames = append(classNames, launch.ClassName)
+	}
+	return classNames
+}
+
+func TestSubmitSubmitsHigherPriorityClassesFirst(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	writeV1betaPortfolio(t, mockFileSystem,
+		newScheduledClass("myClass1", PortfolioClassScheduling{}),
+		newScheduledClass("myClass2", PortfolioClassScheduling{Priority: 10}),
+		newScheduledClass("myClass3", PortfolioClassScheduling{Priority: 5}),
+	)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		Throttle:          1,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"myBundle/myClass2", "myBundle/myClass3", "myBundle/myClass1"}, getLaunchedClassNames(mockLauncher))
+}
+
+func TestSubmitWaitsForDependencyToPassBeforeSubmittingADependentClass(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	writeV1betaPortfolio(t, mockFileSystem,
+		newScheduledClass("myTest", PortfolioClassScheduling{DependsOn: []string{"myBundle/mySetup"}}),
+		newScheduledClass("mySetup", PortfolioClassScheduling{}),
+		newScheduledClass("myOther", PortfolioClassScheduling{}),
+	)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		Throttle:          3,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"myBundle/mySetup", "myBundle/myOther", "myBundle/myTest"}, getLaunchedClassNames(mockLauncher))
+}
+
+func TestSubmitCancelsClassesWhoseDependencyFailed(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	writeV1betaPortfolio(t, mockFileSystem,
+		newScheduledClass("mySetup", PortfolioClassScheduling{}),
+		newScheduledClass("myTest", PortfolioClassScheduling{DependsOn: []string{"myBundle/mySetup"}}),
+		newScheduledClass("myLastTest", PortfolioClassScheduling{DependsOn: []string{"myBundle/myTest"}}),
+	)
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/mySetup", RESULT_FAILED)
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"myBundle/mySetup"}, getLaunchedClassNames(mockLauncher))
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 3, len(report.Tests))
+	for _, className := range []string{"myTest", "myLastTest"} {
+		cancelledTest := getReportedTestByClass(report, className)
+		assert.Equal(t, RESULT_CANCELLED, cancelledTest.Result)
+		assert.Equal(t, CANCELLED_BY_DEPENDENCY, cancelledTest.CancelledBy)
+	}
+}
+
+func TestSubmitUsesMaxAttemptsOfTheClassInPlaceOfTheFlag(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	writeV1betaPortfolio(t, mockFileSystem,
+		newScheduledClass("myClass1", PortfolioClassScheduling{MaxAttempts: 3}),
+		newScheduledClass("myClass2", PortfolioClassScheduling{}),
+	)
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass1", RESULT_ENVFAIL, RESULT_ENVFAIL, RESULT_PASSED)
+	mockLauncher.SetPlannedResults("myBundle/myClass2", RESULT_ENVFAIL, RESULT_PASSED)
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+		MaxAttempts:        1,
+		RetryResults:       []string{RESULT_ENVFAIL},
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Equal(t, 4, len(mockLauncher.GetRecordedLaunchRecords()))
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, RESULT_PASSED, getReportedTestByClass(report, "myClass1").Result)
+	assert.Equal(t, RESULT_ENVFAIL, getReportedTestByClass(report, "myClass2").Result)
+}
+
+func TestSubmitCancelsRunsWhichRunForLongerThanTheirClassTimeout(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	writeV1betaPortfolio(t, mockFileSystem,
+		newScheduledClass("myClass1", PortfolioClassScheduling{Timeout: "1m", Labels: []string{"slow"}}),
+		newScheduledClass("myClass2", PortfolioClassScheduling{}),
+	)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter, canceller := newSubmitterForStopPolicyTests(t, mockFileSystem, mockLauncher, "myBundle/myClass1")
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:   "my.portfolio",
+		ReportYamlFilename:  "report.yaml",
+		PollIntervalSeconds: 30,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"M100"}, canceller.cancelledRuns)
+
+	report := readTestReport(t, mockFileSystem)
+	timedOutTest := getReportedTestByClass(report, "myClass1")
+	assert.Equal(t, CANCEL_RESULT, timedOutTest.Result)
+	assert.Equal(t, CANCELLED_BY_CLASS_TIMEOUT, timedOutTest.CancelledBy)
+	assert.Equal(t, []string{"slow"}, timedOutTest.Labels)
+	assert.Equal(t, RESULT_PASSED, getReportedTestByClass(report, "myClass2").Result)
+}
+
+func TestSubmitLongestFirstSubmitsSlowestClassesFirstWithinEachPriority(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	writeV1betaPortfolio(t, mockFileSystem,
+		newScheduledClass("myQuickClass", PortfolioClassScheduling{}),
+		newScheduledClass("myNewClass", PortfolioClassScheduling{}),
+		newScheduledClass("mySlowClass", PortfolioClassScheduling{}),
+		newScheduledClass("mySetup", PortfolioClassScheduling{Priority: 10}),
+	)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	submitter.SetRunDurationHistory(mockRunDurationHistory{
+		"myBundle/myQuickClass": time.Minute * 2,
+		"myBundle/mySlowClass":  time.Minute * 90,
+		"myBundle/mySetup":      time.Minute * 1,
+	})
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		Throttle:          1,
+		Schedule:          SCHEDULE_LONGEST_FIRST,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"myBundle/mySetup", "myBundle/mySlowClass", "myBundle/myQuickClass", "myBundle/myNewClass"},
+		getLaunchedClassNames(mockLauncher))
+}
+
+func TestSubmitWithUnknownScheduleReturnsError(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		Schedule:          "shortest-first",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1268E")
+	assert.Empty(t, mockLauncher.GetRecordedLaunchRecords())
+}
@@ -0,0 +1,292 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/launcher"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// A launcher whose runs of some test classes keep running until they are cancelled.
+type longRunningLauncher struct {
+	*launcher.MockLauncher
+	longRunningClasses map[string]bool
+}
+
+func (launcher *longRunningLauncher) SubmitTestRun(
+	groupName string,
+	className string,
+	requestType string,
+	requestor string,
+	stream string,
+	obrFromPortfolio string,
+	isTraceEnabled bool,
+	gherkinURL string,
+	gherkinFeature string,
+	overrides map[string]interface{},
+) (*galasaapi.TestRuns, error) {
+	testRuns, err := launcher.MockLauncher.SubmitTestRun(groupName, className, requestType, requestor, stream,
+		obrFromPortfolio, isTraceEnabled, gherkinURL, gherkinFeature, overrides)
+	if err == nil && launcher.longRunningClasses[className] {
+		launcher.MockLauncher.SetRunStatus(testRuns.GetRuns()[0].GetName(), "running")
+	}
+	return testRuns, err
+}
+
+func newSubmitterForStopPolicyTests(
+	t *testing.T,
+	mockFileSystem spi.FileSystem,
+	mockLauncher *launcher.MockLauncher,
+	longRunningClasses ...string,
+) (*Submitter, *mockRunCanceller) {
+	wrappedLauncher := &longRunningLauncher{MockLauncher: mockLauncher, longRunningClasses: make(map[string]bool)}
+	for _, className := range longRunningClasses {
+		wrappedLauncher.longRunningClasses[className] = true
+	}
+
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	submitter.launcher = wrappedLauncher
+
+	canceller := &mockRunCanceller{mockLauncher: mockLauncher}
+	submitter.SetRunCanceller(canceller)
+	return submitter, canceller
+}
+
+func createThreeClassPortfolio(t *testing.T, mockFileSystem spi.FileSystem) {
+	portfolio := NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes,
+		PortfolioClass{Bundle: "myBundle", Class: "myClass1", Obr: "myobr"},
+		PortfolioClass{Bundle: "myBundle", Class: "myClass2", Obr: "myobr"},
+		PortfolioClass{Bundle: "myBundle", Class: "myClass3", Obr: "myobr"},
+	)
+	err := WritePortfolio(mockFileSystem, "my.portfolio", portfolio)
+	assert.Nil(t, err)
+}
+
+func getReportedTestByClass(report TestReport, className string) TestRun {
+	var reportedTest TestRun
+	for _, test := range report.Tests {
+		if test.Class == className {
+			reportedTest = test
+		}
+	}
+	return reportedTest
+}
+
+func TestSubmitFailFastStopsSubmittingAfterAFailure(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createThreeClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass1", RESULT_FAILED)
+	submitter, canceller := newSubmitterForStopPolicyTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+		Throttle:           1,
+		FailFast:           true,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1017E: Not all runs passed. 3 failed.")
+	assert.Equal(t, 1, len(mockLauncher.GetRecordedLaunchRecords()))
+	assert.Empty(t, canceller.cancelledRuns)
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 3, len(report.Tests))
+
+	failedTest := getReportedTestByClass(report, "myClass1")
+	assert.Equal(t, RESULT_FAILED, failedTest.Result)
+	assert.Empty(t, failedTest.CancelledBy)
+
+	for _, className := range []string{"myClass2", "myClass3"} {
+		notSubmittedTest := getReportedTestByClass(report, className)
+		assert.Equal(t, RESULT_CANCELLED, notSubmittedTest.Result)
+		assert.Equal(t, STOP_REASON_FAIL_FAST, notSubmittedTest.CancelledBy)
+	}
+}
+
+func TestSubmitFailFastCarriesOnAfterFailuresTheResultPolicyExcuses(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createThreeClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass1", RESULT_FAILED)
+	mockLauncher.SetPlannedResults("myBundle/myClass2", RESULT_ENVFAIL)
+	submitter, _ := newSubmitterForStopPolicyTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+		Throttle:           1,
+		FailFast:           true,
+		Quarantine:         []string{"myBundle/myClass1"},
+		ExcusedResults:     []string{RESULT_ENVFAIL},
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(mockLauncher.GetRecordedLaunchRecords()))
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, EXCUSED_BY_QUARANTINE, getReportedTestByClass(report, "myClass1").ExcusedBy)
+	assert.Equal(t, EXCUSED_BY_POLICY, getReportedTestByClass(report, "myClass2").ExcusedBy)
+	assert.Equal(t, RESULT_PASSED, getReportedTestByClass(report, "myClass3").Result)
+	assert.Empty(t, getReportedTestByClass(report, "myClass3").CancelledBy)
+}
+
+func TestSubmitFailFastCancelsRunsInProgress(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createThreeClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass1", RESULT_FAILED)
+	submitter, canceller := newSubmitterForStopPolicyTests(t, mockFileSystem, mockLauncher, "myBundle/myClass2")
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:    "my.portfolio",
+		ReportYamlFilename:   "report.yaml",
+		Throttle:             2,
+		FailFast:             true,
+		InterruptWaitSeconds: 60,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1017E: Not all runs passed. 3 failed.")
+	assert.Equal(t, 2, len(mockLauncher.GetRecordedLaunchRecords()))
+	assert.Equal(t, []string{"M101"}, canceller.cancelledRuns)
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 3, len(report.Tests))
+
+	cancelledTest := getReportedTestByClass(report, "myClass2")
+	assert.Equal(t, "M101", cancelledTest.Name)
+	assert.Equal(t, CANCEL_RESULT, cancelledTest.Result)
+	assert.Equal(t, STOP_REASON_FAIL_FAST, cancelledTest.CancelledBy)
+
+	notSubmittedTest := getReportedTestByClass(report, "myClass3")
+	assert.Equal(t, RESULT_CANCELLED, notSubmittedTest.Result)
+	assert.Equal(t, STOP_REASON_FAIL_FAST, notSubmittedTest.CancelledBy)
+}
+
+func TestSubmitFailFastWaitsForTheRetryPolicyBeforeStopping(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createThreeClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass1", RESULT_ENVFAIL, RESULT_PASSED)
+	submitter, _ := newSubmitterForStopPolicyTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+		Throttle:           1,
+		FailFast:           true,
+		MaxAttempts:        2,
+		RetryResults:       []string{RESULT_ENVFAIL},
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(mockLauncher.GetRecordedLaunchRecords()))
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 3, len(report.Tests))
+	for _, test := range report.Tests {
+		assert.Equal(t, RESULT_PASSED, test.Result)
+		assert.Empty(t, test.CancelledBy)
+	}
+}
+
+func TestSubmitTimeoutCancelsRunsStillInProgressAtTheDeadline(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createThreeClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter, canceller := newSubmitterForStopPolicyTests(t, mockFileSystem, mockLauncher, "myBundle/myClass2")
+	startTime := submitter.timeService.Now()
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:    "my.portfolio",
+		ReportYamlFilename:   "report.yaml",
+		Throttle:             1,
+		PollIntervalSeconds:  120,
+		Timeout:              time.Minute * 5,
+		InterruptWaitSeconds: 60,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1242E: The submission of tests timed out after 5m0s. 2 tests were cancelled or not submitted.")
+	assert.Equal(t, 2, len(mockLauncher.GetRecordedLaunchRecords()))
+	assert.Equal(t, []string{"M101"}, canceller.cancelledRuns)
+
+	// The last poll is cut short so the deadline isn't overshot.
+	assert.Equal(t, time.Minute*5, submitter.timeService.Now().Sub(startTime))
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 3, len(report.Tests))
+
+	passedTest := getReportedTestByClass(report, "myClass1")
+	assert.Equal(t, RESULT_PASSED, passedTest.Result)
+	assert.Empty(t, passedTest.CancelledBy)
+
+	cancelledTest := getReportedTestByClass(report, "myClass2")
+	assert.Equal(t, CANCEL_RESULT, cancelledTest.Result)
+	assert.Equal(t, STOP_REASON_TIMEOUT, cancelledTest.CancelledBy)
+
+	notSubmittedTest := getReportedTestByClass(report, "myClass3")
+	assert.Equal(t, RESULT_CANCELLED, notSubmittedTest.Result)
+	assert.Equal(t, STOP_REASON_TIMEOUT, notSubmittedTest.CancelledBy)
+}
+
+func TestSubmitFinishingBeforeTheTimeoutSucceeds(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createThreeClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter, canceller := newSubmitterForStopPolicyTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+		Throttle:           1,
+		Timeout:            time.Minute * 5,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(mockLauncher.GetRecordedLaunchRecords()))
+	assert.Empty(t, canceller.cancelledRuns)
+}
@@ -7,13 +7,16 @@ package runs
 
 import (
 	"testing"
+	"time"
 
 	"github.com/galasa-dev/cli/pkg/files"
 	"github.com/galasa-dev/cli/pkg/images"
 	"github.com/galasa-dev/cli/pkg/launcher"
 	"github.com/galasa-dev/cli/pkg/props"
+	"github.com/galasa-dev/cli/pkg/spi"
 	"github.com/galasa-dev/cli/pkg/utils"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestCanWriteAndReadBackThrottleFile(t *testing.T) {
@@ -559,7 +562,7 @@ func TestGetPortfolioReturnsGherkinPortfolio(t *testing.T) {
 	(*flags.GherkinUrl)[1] = "file:///demo/test.feature"
 	(*flags.GherkinUrl)[2] = "file:///demo/excellent.feature"
 
-	portfolio, err := submitter.getPortfolio("", flags)
+	portfolio, err := submitter.getPortfolio("", NewEnvVarExpander(submitter.env, false), flags)
 
 	assert.Nil(t, err)
 	assert.NotEmpty(t, portfolio)
@@ -600,7 +603,7 @@ func TestGetReadyRunsFromPortfolioReturnsGherkinReadyRuns(t *testing.T) {
 	(*flags.GherkinUrl)[1] = "file:///demo/test.feature"
 	(*flags.GherkinUrl)[2] = "file:///demo/excellent.feature"
 
-	portfolio, err := submitter.getPortfolio("", flags)
+	portfolio, err := submitter.getPortfolio("", NewEnvVarExpander(submitter.env, false), flags)
 	if err != nil {
 		assert.Fail(t, "Should not have failed! message = %s", err.Error())
 	}
@@ -650,7 +653,7 @@ func TestSubmitRunsFromGherkinPortfolioOutputsFeatureNames(t *testing.T) {
 	(*flags.GherkinUrl)[1] = "file:///demo/test.feature"
 	(*flags.GherkinUrl)[2] = "file:///demo/excellent.feature"
 
-	portfolio, err := submitter.getPortfolio("", flags)
+	portfolio, err := submitter.getPortfolio("", NewEnvVarExpander(submitter.env, false), flags)
 	if err != nil {
 		assert.Fail(t, "Should not have failed! message = %s", err.Error())
 	}
@@ -667,3 +670,623 @@ func TestSubmitRunsFromGherkinPortfolioOutputsFeatureNames(t *testing.T) {
 	assert.Contains(t, readyRuns[2].GherkinUrl, "file:///demo/excellent.feature")
 	assert.Contains(t, readyRuns[2].GherkinFeature, "excellent")
 }
+
+func submitPortfolioWithRetries(t *testing.T, mockLauncher *launcher.MockLauncher, mockTimeService *utils.MockTimeService, commandParameters *utils.RunsSubmitCmdValues) (spi.FileSystem, error) {
+	mockFileSystem := files.NewMockFileSystem()
+
+	portfolioFilePath := "myportfolio.yaml"
+	_ = createTestPortfolioFile(t, mockFileSystem, portfolioFilePath, "myBundle", "myClass", "", "myobr")
+
+	env := utils.NewMockEnv()
+	env.SetUserName("myuserid")
+
+	galasaHome, err := utils.NewGalasaHome(mockFileSystem, env, "")
+	if err != nil {
+		assert.Fail(t, "Should not have failed! message = %s", err.Error())
+	}
+
+	commandParameters.PortfolioFileName = portfolioFilePath
+	commandParameters.ReportYamlFilename = "report.yaml"
+
+	regexSelectValue := false
+	submitSelectionFlags := &utils.TestSelectionFlagValues{
+		Bundles:     new([]string),
+		Packages:    new([]string),
+		Tests:       new([]string),
+		Tags:        new([]string),
+		Classes:     new([]string),
+		Stream:      "",
+		RegexSelect: &regexSelectValue,
+		GherkinUrl:  new([]string),
+	}
+
+	submitter := NewSubmitter(
+		galasaHome,
+		mockFileSystem,
+		mockLauncher,
+		mockTimeService,
+		utils.NewMockTimedSleeper(mockTimeService),
+		env,
+		utils.NewMockConsole(),
+		images.NewImageExpanderNullImpl(),
+	)
+
+	err = submitter.ExecuteSubmitRuns(commandParameters, submitSelectionFlags)
+	return mockFileSystem, err
+}
+
+func readTestReport(t *testing.T, fs spi.FileSystem) TestReport {
+	var report TestReport
+	reportContents, err := fs.ReadTextFile("report.yaml")
+	assert.Nil(t, err)
+	err = yaml.Unmarshal([]byte(reportContents), &report)
+	assert.Nil(t, err)
+	return report
+}
+
+func TestSubmitWithoutRetriesDoesNotResubmitEnvFail(t *testing.T) {
+	// Given...
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass", RESULT_ENVFAIL)
+
+	commandParameters := &utils.RunsSubmitCmdValues{}
+
+	// When...
+	fs, err := submitPortfolioWithRetries(t, mockLauncher, utils.NewMockTimeService(), commandParameters)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, len(mockLauncher.GetRecordedLaunchRecords()))
+
+	report := readTestReport(t, fs)
+	assert.Equal(t, 1, len(report.Tests))
+	assert.Equal(t, RESULT_ENVFAIL, report.Tests[0].Result)
+	assert.Empty(t, report.Tests[0].PreviousAttempts)
+}
+
+func TestSubmitWithRetriesResubmitsEnvFailUntilItPasses(t *testing.T) {
+	// Given...
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass", RESULT_ENVFAIL, RESULT_ENVFAIL, RESULT_PASSED)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		MaxAttempts:  3,
+		RetryResults: []string{"envfail"},
+	}
+
+	// When...
+	fs, err := submitPortfolioWithRetries(t, mockLauncher, utils.NewMockTimeService(), commandParameters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(mockLauncher.GetRecordedLaunchRecords()))
+
+	report := readTestReport(t, fs)
+	assert.Equal(t, 1, len(report.Tests))
+	assert.Equal(t, "M102", report.Tests[0].Name)
+	assert.Equal(t, RESULT_PASSED, report.Tests[0].Result)
+
+	previousAttempts := report.Tests[0].PreviousAttempts
+	assert.Equal(t, 2, len(previousAttempts))
+	assert.Equal(t, "M100", previousAttempts[0].Name)
+	assert.Equal(t, RESULT_ENVFAIL, previousAttempts[0].Result)
+	assert.Equal(t, "M101", previousAttempts[1].Name)
+	assert.Equal(t, RESULT_ENVFAIL, previousAttempts[1].Result)
+}
+
+func TestSubmitWithRetriesStopsAtMaxAttemptsAndReportsLastResult(t *testing.T) {
+	// Given...
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass", RESULT_FAILED, RESULT_FAILED, RESULT_FAILED)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		MaxAttempts:  2,
+		RetryResults: DEFAULT_RETRY_RESULTS,
+	}
+
+	// When...
+	fs, err := submitPortfolioWithRetries(t, mockLauncher, utils.NewMockTimeService(), commandParameters)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, len(mockLauncher.GetRecordedLaunchRecords()))
+
+	report := readTestReport(t, fs)
+	assert.Equal(t, 1, len(report.Tests))
+	assert.Equal(t, RESULT_FAILED, report.Tests[0].Result)
+	assert.Equal(t, 1, len(report.Tests[0].PreviousAttempts))
+}
+
+func TestSubmitWithRetriesDoesNotResubmitResultsNotAskedFor(t *testing.T) {
+	// Given...
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass", RESULT_FAILED)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		MaxAttempts:  3,
+		RetryResults: []string{RESULT_ENVFAIL},
+	}
+
+	// When...
+	_, err := submitPortfolioWithRetries(t, mockLauncher, utils.NewMockTimeService(), commandParameters)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, len(mockLauncher.GetRecordedLaunchRecords()))
+}
+
+func TestSubmitWithRetryBackoffWaitsBeforeResubmitting(t *testing.T) {
+	// Given...
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass", RESULT_ENVFAIL, RESULT_PASSED)
+	mockTimeService := utils.NewMockTimeService()
+	startTime := mockTimeService.Now()
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		MaxAttempts:         2,
+		RetryResults:        DEFAULT_RETRY_RESULTS,
+		RetryBackoffSeconds: 120,
+	}
+
+	// When...
+	_, err := submitPortfolioWithRetries(t, mockLauncher, mockTimeService, commandParameters)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(mockLauncher.GetRecordedLaunchRecords()))
+	assert.False(t, mockTimeService.Now().Before(startTime.Add(120*time.Second)))
+}
+
+func newSubmitterForResumeTests(t *testing.T, mockFileSystem spi.FileSystem, mockLauncher *launcher.MockLauncher) *Submitter {
+	env := utils.NewMockEnv()
+	env.SetUserName("myuserid")
+
+	galasaHome, err := utils.NewGalasaHome(mockFileSystem, env, "")
+	if err != nil {
+		assert.Fail(t, "Should not have failed! message = %s", err.Error())
+	}
+
+	mockTimeService := utils.NewMockTimeService()
+	return NewSubmitter(
+		galasaHome,
+		mockFileSystem,
+		mockLauncher,
+		mockTimeService,
+		utils.NewMockTimedSleeper(mockTimeService),
+		env,
+		utils.NewMockConsole(),
+		images.NewImageExpanderNullImpl(),
+	)
+}
+
+func newEmptyTestSelectionFlags() *utils.TestSelectionFlagValues {
+	regexSelectValue := false
+	return &utils.TestSelectionFlagValues{
+		Bundles:     new([]string),
+		Packages:    new([]string),
+		Tests:       new([]string),
+		Tags:        new([]string),
+		Classes:     new([]string),
+		Stream:      "",
+		RegexSelect: &regexSelectValue,
+		GherkinUrl:  new([]string),
+	}
+}
+
+func TestSubmitWithJournalRecordsFinishedRuns(t *testing.T) {
+	// Given...
+	mockLauncher := launcher.NewMockLauncher()
+	commandParameters := &utils.RunsSubmitCmdValues{
+		JournalFileName: "my.journal",
+	}
+
+	// When...
+	fs, err := submitPortfolioWithRetries(t, mockLauncher, utils.NewMockTimeService(), commandParameters)
+
+	// Then...
+	assert.Nil(t, err)
+	journal, err := ReadSubmissionJournal(fs, "my.journal")
+	assert.Nil(t, err)
+	assert.Equal(t, commandParameters.GroupName, journal.GroupName)
+	assert.Empty(t, journal.ReadyRuns)
+	assert.Empty(t, journal.SubmittedRuns)
+	assert.Equal(t, 1, len(journal.FinishedRuns))
+	assert.Equal(t, "myBundle", journal.FinishedRuns["M100"].Bundle)
+}
+
+func TestResumeSubmitsOnlyTestsNotYetSubmitted(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockLauncher := launcher.NewMockLauncher()
+
+	// The first test was submitted, but galasactl was stopped before the journal recorded it.
+	_, err := mockLauncher.SubmitTestRun("myGroup", "myBundle/myClass1", "CLI", "myuserid", "", "myobr", false, "", "", nil)
+	assert.Nil(t, err)
+
+	readyRuns := []TestRun{
+		{Bundle: "myBundle", Class: "myClass1", Obr: "myobr", Status: "queued"},
+		{Bundle: "myBundle", Class: "myClass2", Obr: "myobr", Status: "queued"},
+	}
+	journal := NewSubmissionJournal("myGroup", "CLI", false, readyRuns)
+	err = WriteSubmissionJournal(mockFileSystem, "my.journal", journal)
+	assert.Nil(t, err)
+
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	commandParameters := &utils.RunsSubmitCmdValues{
+		ResumeJournalFileName: "my.journal",
+		ReportYamlFilename:    "report.yaml",
+	}
+
+	// When...
+	err = submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "myGroup", commandParameters.GroupName)
+
+	launches := mockLauncher.GetRecordedLaunchRecords()
+	assert.Equal(t, 2, len(launches))
+	assert.Equal(t, "myBundle/myClass2", launches[1].ClassName)
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 2, len(report.Tests))
+
+	journal, err = ReadSubmissionJournal(mockFileSystem, "my.journal")
+	assert.Nil(t, err)
+	assert.Empty(t, journal.ReadyRuns)
+	assert.Equal(t, 2, len(journal.FinishedRuns))
+}
+
+func TestResumeReattachesRunToTheMatrixCombinationItWasSubmittedFor(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockLauncher := launcher.NewMockLauncher()
+
+	linuxRun := TestRun{Bundle: "myBundle", Class: "myClass1", Obr: "myobr", Status: "queued",
+		Combination: "os=linux", Overrides: map[string]string{"my.os": "linux"}}
+	windowsRun := TestRun{Bundle: "myBundle", Class: "myClass1", Obr: "myobr", Status: "queued",
+		Combination: "os=windows", Overrides: map[string]string{"my.os": "windows"}}
+
+	// The windows combination was being submitted when galasactl was stopped, before the journal recorded the run.
+	_, err := mockLauncher.SubmitTestRun("myGroup-os_windows", "myBundle/myClass1", "CLI", "myuserid", "", "myobr", false, "", "",
+		map[string]interface{}{"my.os": "windows"})
+	assert.Nil(t, err)
+
+	journal := NewSubmissionJournal("myGroup", "CLI", false, []TestRun{linuxRun, windowsRun})
+	journal.SubmittingRun = &windowsRun
+	err = WriteSubmissionJournal(mockFileSystem, "my.journal", journal)
+	assert.Nil(t, err)
+
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	commandParameters := &utils.RunsSubmitCmdValues{
+		ResumeJournalFileName: "my.journal",
+		ReportYamlFilename:    "report.yaml",
+	}
+
+	// When...
+	err = submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+
+	// Only the linux combination is submitted again.
+	launches := mockLauncher.GetRecordedLaunchRecords()
+	assert.Equal(t, 2, len(launches))
+	assert.Equal(t, "linux", launches[1].Overrides["my.os"])
+
+	journal, err = ReadSubmissionJournal(mockFileSystem, "my.journal")
+	assert.Nil(t, err)
+	assert.Nil(t, journal.SubmittingRun)
+	assert.Equal(t, 2, len(journal.FinishedRuns))
+	windowsRunGotBack := journal.FinishedRuns["M100"]
+	assert.Equal(t, "os=windows", windowsRunGotBack.Combination)
+	assert.Equal(t, "windows", windowsRunGotBack.Overrides["my.os"])
+	assert.Equal(t, "myGroup-os_windows", windowsRunGotBack.Group)
+}
+
+func TestResumeDoesNotGuessTheMatrixCombinationOfAnUnknownRun(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockLauncher := launcher.NewMockLauncher()
+
+	// A run of the class is in the group, but the journal doesn't say which combination was being submitted.
+	_, err := mockLauncher.SubmitTestRun("myGroup", "myBundle/myClass1", "CLI", "myuserid", "", "myobr", false, "", "", nil)
+	assert.Nil(t, err)
+
+	readyRuns := []TestRun{
+		{Bundle: "myBundle", Class: "myClass1", Obr: "myobr", Status: "queued", Combination: "os=linux"},
+		{Bundle: "myBundle", Class: "myClass1", Obr: "myobr", Status: "queued", Combination: "os=windows"},
+	}
+	journal := NewSubmissionJournal("myGroup", "CLI", false, readyRuns)
+
+	// When...
+	err = newSubmitterForResumeTests(t, mockFileSystem, mockLauncher).reattachToGroup(journal)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(journal.ReadyRuns))
+	assert.Empty(t, journal.SubmittedRuns)
+}
+
+func TestResumeOfACompletedJournalJustWritesTheReports(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockLauncher := launcher.NewMockLauncher()
+
+	journal := NewSubmissionJournal("myGroup", "CLI", false, nil)
+	journal.FinishedRuns["U100"] = &TestRun{Name: "U100", Bundle: "myBundle", Class: "myClass1", Status: "finished", Result: "Passed"}
+	err := WriteSubmissionJournal(mockFileSystem, "my.journal", journal)
+	assert.Nil(t, err)
+
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	commandParameters := &utils.RunsSubmitCmdValues{
+		ResumeJournalFileName: "my.journal",
+		ReportYamlFilename:    "report.yaml",
+	}
+
+	// When...
+	err = submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Empty(t, mockLauncher.GetRecordedLaunchRecords())
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 1, len(report.Tests))
+	assert.Equal(t, "U100", report.Tests[0].Name)
+}
+
+func TestResumeWithAPortfolioFails(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, launcher.NewMockLauncher())
+	commandParameters := &utils.RunsSubmitCmdValues{
+		ResumeJournalFileName: "my.journal",
+		PortfolioFileName:     "my.portfolio",
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1235E")
+}
+
+func TestResumeWithADifferentGroupFails(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	journal := NewSubmissionJournal("myGroup", "CLI", false, nil)
+	err := WriteSubmissionJournal(mockFileSystem, "my.journal", journal)
+	assert.Nil(t, err)
+
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, launcher.NewMockLauncher())
+	commandParameters := &utils.RunsSubmitCmdValues{
+		ResumeJournalFileName: "my.journal",
+		GroupName:             "anotherGroup",
+	}
+
+	// When...
+	err = submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1236E")
+}
+
+// A progress reporter which remembers the progress it was shown.
+type recordingProgressReporter struct {
+	reportedProgress []SubmissionProgress
+	reportedMessages []string
+}
+
+func (reporter *recordingProgressReporter) ReportProgress(progress SubmissionProgress) {
+	reporter.reportedProgress = append(reporter.reportedProgress, progress)
+}
+
+func (reporter *recordingProgressReporter) ReportMessage(message string) {
+	reporter.reportedMessages = append(reporter.reportedMessages, message)
+}
+
+func TestSubmitReportsProgressAfterCheckingTheStatusOfRuns(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	progressReporter := new(recordingProgressReporter)
+	submitter.SetProgressReporter(progressReporter)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		Throttle:          1,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(progressReporter.reportedProgress))
+
+	firstProgress := progressReporter.reportedProgress[0]
+	assert.Equal(t, 1, firstProgress.Throttle)
+	assert.Equal(t, 1, len(firstProgress.ReadyRuns))
+	assert.Contains(t, firstProgress.FinishedRuns, "M100")
+}
+
+func TestSubmitShowsMessagesThroughTheProgressReporter(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	createTwoClassPortfolio(t, mockFileSystem)
+	mockLauncher := launcher.NewMockLauncher()
+	mockLauncher.SetPlannedResults("myBundle/myClass1", RESULT_FAILED)
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	progressReporter := new(recordingProgressReporter)
+	submitter.SetProgressReporter(progressReporter)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		Throttle:          1,
+		FailFast:          true,
+	}
+
+	// When...
+	err := submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Len(t, progressReporter.reportedMessages, 1)
+	assert.Contains(t, progressReporter.reportedMessages[0], "No more tests will be submitted")
+}
+
+func TestSubmitPortfolioWithMatrixSubmitsEachClassOncePerCombination(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	portfolio := NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes, PortfolioClass{
+		Bundle:    "myBundle",
+		Class:     "myClass",
+		Obr:       "myobr",
+		Overrides: map[string]string{"zos.image": "DEFAULT", "other": "kept"},
+	})
+	portfolio.Matrix = []PortfolioMatrixAxis{{
+		Name: "image",
+		OverrideSets: []PortfolioOverrideSet{
+			{Name: "zos1", Overrides: map[string]string{"zos.image": "MV1A"}},
+			{Name: "zos2", Overrides: map[string]string{"zos.image": "MV2A"}},
+		},
+	}}
+	err := WritePortfolio(mockFileSystem, "my.portfolio", portfolio)
+	assert.Nil(t, err)
+
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:  "my.portfolio",
+		ReportYamlFilename: "report.yaml",
+		GroupName:          "myGroup",
+	}
+
+	// When...
+	err = submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+
+	launches := mockLauncher.GetRecordedLaunchRecords()
+	assert.Equal(t, 2, len(launches))
+	assert.Equal(t, "MV1A", launches[0].Overrides["zos.image"])
+	assert.Equal(t, "kept", launches[0].Overrides["other"])
+	assert.Equal(t, "MV2A", launches[1].Overrides["zos.image"])
+
+	// Each combination is submitted to a group of its own, so they can be told apart with 'runs get --group'.
+	assert.Equal(t, "myGroup-image_zos1", launches[0].GroupName)
+	assert.Equal(t, "myGroup-image_zos2", launches[1].GroupName)
+
+	report := readTestReport(t, mockFileSystem)
+	assert.Equal(t, 2, len(report.Tests))
+	combinations := []string{report.Tests[0].Combination, report.Tests[1].Combination}
+	assert.ElementsMatch(t, []string{"image=zos1", "image=zos2"}, combinations)
+	groups := []string{report.Tests[0].Group, report.Tests[1].Group}
+	assert.ElementsMatch(t, []string{"myGroup-image_zos1", "myGroup-image_zos2"}, groups)
+	assert.Contains(t, submitter.console.(*utils.MockConsole).ReadText(), "myBundle/myClass[image=zos1]")
+}
+
+func TestGroupOfARunInAMatrixCombinationIsNamedAfterTheCombination(t *testing.T) {
+	// Given...
+	run := &TestRun{Bundle: "myBundle", Class: "myClass", Combination: "image=zos1,region=cicsA"}
+
+	// When...
+	groupName := getRunGroupName("myGroup", run)
+
+	// Then...
+	assert.Equal(t, "myGroup-image_zos1_region_cicsA", groupName)
+	assert.True(t, utils.IsNameValid(groupName))
+}
+
+func TestGroupOfARunWithoutAMatrixCombinationIsTheGroupOfTheSubmission(t *testing.T) {
+	// Given...
+	run := &TestRun{Bundle: "myBundle", Class: "myClass"}
+
+	// When...
+	groupName := getRunGroupName("myGroup", run)
+
+	// Then...
+	assert.Equal(t, "myGroup", groupName)
+}
+
+func TestSubmitExpandsEnvVarsInOverridesAndPrintsEffectiveOverrides(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	portfolio := NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes, PortfolioClass{
+		Bundle:    "myBundle",
+		Class:     "myClass",
+		Obr:       "myobr",
+		Overrides: map[string]string{"zos.image": "${IMAGE:-MV1A}"},
+	})
+	err := WritePortfolio(mockFileSystem, "my.portfolio", portfolio)
+	assert.Nil(t, err)
+
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+	submitter.env.(*utils.MockEnv).SetEnv("HOST", "myhost")
+	submitter.env.(*utils.MockEnv).SetEnv("MY_TOKEN", "abc123")
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName:       "my.portfolio",
+		OverrideFilePath:        "-",
+		Overrides:               []string{"zos.host=${HOST}", "api.token=${MY_TOKEN}"},
+		StrictEnvVars:           true,
+		PrintEffectiveOverrides: true,
+	}
+
+	// When...
+	err = submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.Nil(t, err)
+
+	launches := mockLauncher.GetRecordedLaunchRecords()
+	assert.Equal(t, 1, len(launches))
+	assert.Equal(t, "myhost", launches[0].Overrides["zos.host"])
+	assert.Equal(t, "MV1A", launches[0].Overrides["zos.image"])
+	assert.Equal(t, "abc123", launches[0].Overrides["api.token"])
+
+	assert.Contains(t, submitter.console.(*utils.MockConsole).ReadText(),
+		"Overrides for myBundle/myClass:\n  api.token=********\n  zos.host=myhost\n  zos.image=MV1A\n")
+}
+
+func TestSubmitWithStrictEnvFailsWhenPortfolioOverrideRefersToVariableNotSet(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	portfolio := NewPortfolio()
+	portfolio.Classes = append(portfolio.Classes, PortfolioClass{
+		Bundle:    "myBundle",
+		Class:     "myClass",
+		Obr:       "myobr",
+		Overrides: map[string]string{"zos.image": "${IMAGE}"},
+	})
+	err := WritePortfolio(mockFileSystem, "my.portfolio", portfolio)
+	assert.Nil(t, err)
+
+	mockLauncher := launcher.NewMockLauncher()
+	submitter := newSubmitterForResumeTests(t, mockFileSystem, mockLauncher)
+
+	commandParameters := &utils.RunsSubmitCmdValues{
+		PortfolioFileName: "my.portfolio",
+		OverrideFilePath:  "-",
+		StrictEnvVars:     true,
+	}
+
+	// When...
+	err = submitter.ExecuteSubmitRuns(commandParameters, newEmptyTestSelectionFlags())
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1307E: The value of override 'zos.image' of class 'myBundle/myClass' in portfolio 'my.portfolio'")
+	assert.Empty(t, mockLauncher.GetRecordedLaunchRecords())
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"gopkg.in/yaml.v3"
+)
+
+// tapDiagnostics - The yaml block which follows a TAP test point which did not pass.
+type tapDiagnostics struct {
+	Result      string       `yaml:"result"`
+	CancelledBy string       `yaml:"cancelledBy,omitempty"`
+	Methods     []TestMethod `yaml:"methods,omitempty"`
+}
+
+// ReportTap - Writes each test run as a TAP version 13 test point. A run which did not pass is
+// followed by a yaml block giving its result, and the test methods which did not pass.
+// Ignored and Disabled runs are marked with a SKIP directive.
+func ReportTap(
+	fileSystem spi.FileSystem,
+	reportTapFilename string,
+	finishedRuns map[string]*TestRun,
+	lostRuns map[string]*TestRun) error {
+
+	var err error
+	buff := strings.Builder{}
+
+	buff.WriteString("TAP version 13\n")
+	buff.WriteString("1.." + strconv.Itoa(len(finishedRuns)+len(lostRuns)) + "\n")
+
+	testNumber := 0
+	for _, run := range getRunsInNameOrder(finishedRuns) {
+		testNumber++
+		if err == nil {
+			err = writeTapTestPoint(&buff, testNumber, run, run.Result)
+		}
+	}
+
+	for _, run := range getRunsInNameOrder(lostRuns) {
+		testNumber++
+		if err == nil {
+			err = writeTapTestPoint(&buff, testNumber, run, getLostRunResult(run))
+		}
+	}
+
+	if err == nil {
+		err = fileSystem.WriteTextFile(reportTapFilename, buff.String())
+	}
+
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_SUBMIT_REPORT_TAP_WRITE_FAIL, reportTapFilename, err.Error())
+	} else {
+		log.Printf("TAP test report written to %v\n", reportTapFilename)
+	}
+	return err
+}
+
+func writeTapTestPoint(buff *strings.Builder, testNumber int, run *TestRun, result string) error {
+	var err error
+
+	// A '#' would start a directive, so it is escaped in the description.
+	description := strings.ReplaceAll(run.Name+" - "+run.Stream+"/"+run.getTestKey(), "#", "\\#")
+
+	switch getReportOutcome(result) {
+	case REPORT_OUTCOME_PASSED:
+		buff.WriteString("ok " + strconv.Itoa(testNumber) + " - " + description + "\n")
+	case REPORT_OUTCOME_SKIPPED:
+		buff.WriteString("ok " + strconv.Itoa(testNumber) + " - " + description + " # SKIP " + result + "\n")
+	default:
+		buff.WriteString("not ok " + strconv.Itoa(testNumber) + " - " + description + "\n")
+		err = writeTapDiagnostics(buff, run, result)
+	}
+	return err
+}
+
+func writeTapDiagnostics(buff *strings.Builder, run *TestRun, result string) error {
+	diagnostics := tapDiagnostics{
+		Result:      result,
+		CancelledBy: run.CancelledBy,
+	}
+	for _, method := range run.Tests {
+		if getReportOutcome(method.Result) != REPORT_OUTCOME_PASSED {
+			diagnostics.Methods = append(diagnostics.Methods, method)
+		}
+	}
+
+	data, err := yaml.Marshal(&diagnostics)
+	if err == nil {
+		buff.WriteString("  ---\n")
+		for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+			buff.WriteString("  " + line + "\n")
+		}
+		buff.WriteString("  ...\n")
+	}
+	return err
+}
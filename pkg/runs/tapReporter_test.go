@@ -0,0 +1,94 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportTapWritesATestPointForEachRun(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	finishedRuns := map[string]*TestRun{
+		"U100": {Name: "U100", Stream: "myStream", Bundle: "myBundle", Class: "myClass1", Result: "Passed",
+			Tests: []TestMethod{{Method: "testOne", Result: "Passed"}}},
+		"U101": {Name: "U101", Stream: "myStream", Bundle: "myBundle", Class: "myClass2", Result: "Failed",
+			Tests: []TestMethod{{Method: "testOne", Result: "Passed"}, {Method: "testTwo", Result: "Failed"}}},
+		"U102": {Name: "U102", Stream: "myStream", Bundle: "myBundle", Class: "myClass3", Result: "Ignored"},
+	}
+	lostRuns := map[string]*TestRun{
+		"U103": {Name: "U103", Stream: "myStream", Bundle: "myBundle", Class: "myClass4", Combination: "os=#1"},
+	}
+
+	// When...
+	err := ReportTap(mockFileSystem, "report.tap", finishedRuns, lostRuns)
+
+	// Then...
+	assert.Nil(t, err)
+	tap, err := mockFileSystem.ReadTextFile("report.tap")
+	assert.Nil(t, err)
+	assert.Equal(t, `TAP version 13
+1..4
+ok 1 - U100 - myStream/myBundle/myClass1
+not ok 2 - U101 - myStream/myBundle/myClass2
+  ---
+  result: Failed
+  methods:
+      - name: testTwo
+        result: Failed
+  ...
+ok 3 - U102 - myStream/myBundle/myClass3 # SKIP Ignored
+not ok 4 - U103 - myStream/myBundle/myClass4[os=\#1]
+  ---
+  result: Lost
+  ...
+`, tap)
+}
+
+func TestReportTapGivesARunWhichDidNotFinishItsOwnResult(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	lostRuns := map[string]*TestRun{
+		"U100": {Name: "U100", Stream: "myStream", Bundle: "myBundle", Class: "myClass1", Result: RESULT_CANCELLED, CancelledBy: STOP_REASON_TIMEOUT},
+		"U101": {Name: "U101", Stream: "myStream", Bundle: "myBundle", Class: "myClass2", Result: RESULT_INTERRUPTED},
+	}
+
+	// When...
+	err := ReportTap(mockFileSystem, "report.tap", map[string]*TestRun{}, lostRuns)
+
+	// Then...
+	assert.Nil(t, err)
+	tap, err := mockFileSystem.ReadTextFile("report.tap")
+	assert.Nil(t, err)
+	assert.Equal(t, `TAP version 13
+1..2
+not ok 1 - U100 - myStream/myBundle/myClass1
+  ---
+  result: Cancelled
+  cancelledBy: timeout
+  ...
+not ok 2 - U101 - myStream/myBundle/myClass2
+  ---
+  result: Interrupted
+  ...
+`, tap)
+}
+
+func TestReportTapOfNoRunsHasAnEmptyPlan(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+
+	// When...
+	err := ReportTap(mockFileSystem, "report.tap", map[string]*TestRun{}, map[string]*TestRun{})
+
+	// Then...
+	assert.Nil(t, err)
+	tap, _ := mockFileSystem.ReadTextFile("report.tap")
+	assert.Equal(t, "TAP version 13\n1..0\n", tap)
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/runsformatter"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+const (
+	HEADER_ELAPSED = "elapsed"
+
+	// ANSI escape sequences. Moves the cursor up a number of lines, and clears from the cursor to the end of the screen.
+	ANSI_CURSOR_UP_FORMAT = "\033[%dA"
+	ANSI_CLEAR_TO_END     = "\033[J"
+)
+
+// TerminalProgressReporter - A dashboard which is redrawn in place each time there is progress to show.
+// It shows each run which is in progress, and how many runs are in each state.
+type TerminalProgressReporter struct {
+	console    spi.Console
+	startTimes runStartTimes
+
+	// How many lines the dashboard took up the last time it was drawn, so they can be overwritten.
+	linesDrawn int
+
+	// The dashboard as it was last drawn, so it can be drawn again below a message.
+	lastDashboard string
+}
+
+func NewTerminalProgressReporter(console spi.Console) *TerminalProgressReporter {
+	reporter := new(TerminalProgressReporter)
+	reporter.console = console
+	reporter.startTimes = make(runStartTimes)
+	return reporter
+}
+
+func (reporter *TerminalProgressReporter) ReportProgress(progress SubmissionProgress) {
+	buff := strings.Builder{}
+	reporter.eraseDashboard(&buff)
+
+	dashboard := reporter.formatDashboard(progress)
+	buff.WriteString(dashboard)
+	reporter.linesDrawn = strings.Count(dashboard, "\n")
+	reporter.lastDashboard = dashboard
+
+	reporter.console.WriteString(buff.String())
+}
+
+// ReportMessage - Writes the message where the dashboard was, then draws the dashboard again below it,
+// so the message is not overwritten the next time the dashboard is redrawn.
+func (reporter *TerminalProgressReporter) ReportMessage(message string) {
+	buff := strings.Builder{}
+	reporter.eraseDashboard(&buff)
+
+	buff.WriteString(message)
+	if !strings.HasSuffix(message, "\n") {
+		buff.WriteString("\n")
+	}
+	buff.WriteString(reporter.lastDashboard)
+
+	reporter.console.WriteString(buff.String())
+}
+
+func (reporter *TerminalProgressReporter) eraseDashboard(buff *strings.Builder) {
+	if reporter.linesDrawn > 0 {
+		buff.WriteString(fmt.Sprintf(ANSI_CURSOR_UP_FORMAT, reporter.linesDrawn))
+		buff.WriteString(ANSI_CLEAR_TO_END)
+	}
+}
+
+func (reporter *TerminalProgressReporter) formatDashboard(progress SubmissionProgress) string {
+	buff := strings.Builder{}
+
+	if len(progress.SubmittedRuns) > 0 {
+		table := [][]string{{runsformatter.HEADER_RUNNAME, runsformatter.HEADER_TEST_NAME, runsformatter.HEADER_STATUS, HEADER_ELAPSED}}
+
+		for _, runName := range getSortedRunNames(progress.SubmittedRuns) {
+			run := progress.SubmittedRuns[runName]
+			elapsed := reporter.startTimes.getElapsedTime(runName, run, progress.Now)
+			table = append(table, []string{runName, getRunClassName(run), run.Status, elapsed.String()})
+		}
+
+		columnLengths := utils.CalculateMaxLengthOfEachColumn(table)
+		utils.WriteFormattedTableToStringBuilder(table, &buff, columnLengths)
+	}
+
+	buff.WriteString(formatProgressCounters(progress))
+
+	return buff.String()
+}
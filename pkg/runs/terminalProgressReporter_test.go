@@ -0,0 +1,131 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newProgressForTests(now time.Time, submittedRuns map[string]*TestRun) SubmissionProgress {
+	return SubmissionProgress{
+		Now:           now,
+		ReadyRuns:     []TestRun{{Bundle: "myBundle", Class: "myClass3"}},
+		SubmittedRuns: submittedRuns,
+		FinishedRuns:  make(map[string]*TestRun),
+		LostRuns:      make(map[string]*TestRun),
+		Throttle:      2,
+	}
+}
+
+func TestTerminalProgressShowsRunsInProgressAndCounters(t *testing.T) {
+	// Given...
+	console := utils.NewMockConsole()
+	reporter := NewTerminalProgressReporter(console)
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	submittedRuns := map[string]*TestRun{
+		"U101": {Name: "U101", Bundle: "myBundle", Class: "myClass2", Status: "queued"},
+		"U100": {Name: "U100", Bundle: "myBundle", Class: "myClass1", Status: "running"},
+	}
+
+	// When...
+	reporter.ReportProgress(newProgressForTests(now, submittedRuns))
+
+	// Then...
+	assert.Equal(t,
+		"name test-name         status  elapsed\n"+
+			"U100 myBundle/myClass1 running 0s\n"+
+			"U101 myBundle/myClass2 queued  0s\n"+
+			"Ready=1, Submitted=2, Finished=0, Lost=0, Throttle=2\n",
+		console.ReadText())
+}
+
+func TestTerminalProgressRedrawsOverThePreviousDashboard(t *testing.T) {
+	// Given...
+	console := utils.NewMockConsole()
+	reporter := NewTerminalProgressReporter(console)
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	submittedRuns := map[string]*TestRun{
+		"U100": {Name: "U100", Bundle: "myBundle", Class: "myClass1", Status: "building"},
+	}
+	reporter.ReportProgress(newProgressForTests(now, submittedRuns))
+	firstDashboard := console.ReadText()
+
+	// When...
+	submittedRuns["U100"].Status = "running"
+	reporter.ReportProgress(newProgressForTests(now.Add(time.Second*95), submittedRuns))
+
+	// Then...
+	secondDashboard := console.ReadText()[len(firstDashboard):]
+	assert.Equal(t,
+		"\033[3A\033[J"+
+			"name test-name         status  elapsed\n"+
+			"U100 myBundle/myClass1 running 1m35s\n"+
+			"Ready=1, Submitted=1, Finished=0, Lost=0, Throttle=2\n",
+		secondDashboard)
+}
+
+func TestTerminalProgressMessageIsWrittenAboveTheDashboard(t *testing.T) {
+	// Given...
+	console := utils.NewMockConsole()
+	reporter := NewTerminalProgressReporter(console)
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	submittedRuns := map[string]*TestRun{
+		"U100": {Name: "U100", Bundle: "myBundle", Class: "myClass1", Status: "running"},
+	}
+	reporter.ReportProgress(newProgressForTests(now, submittedRuns))
+	firstDashboard := console.ReadText()
+
+	// When...
+	reporter.ReportMessage("GAL1234E: Something went wrong")
+	reporter.ReportProgress(newProgressForTests(now, submittedRuns))
+
+	// Then...
+	// The message takes the place of the dashboard, which is drawn again below it, and only
+	// the dashboard is drawn over the next time.
+	assert.Equal(t,
+		"\033[3A\033[J"+
+			"GAL1234E: Something went wrong\n"+
+			firstDashboard+
+			"\033[3A\033[J"+
+			firstDashboard,
+		console.ReadText()[len(firstDashboard):])
+}
+
+func TestTerminalProgressShowsTimeSinceRunWasSubmitted(t *testing.T) {
+	// Given...
+	console := utils.NewMockConsole()
+	reporter := NewTerminalProgressReporter(console)
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	submittedRuns := map[string]*TestRun{
+		"U100": {Name: "U100", Bundle: "myBundle", Class: "myClass1", Status: "running", SubmittedTime: now.Add(-2 * time.Minute)},
+	}
+
+	// When...
+	reporter.ReportProgress(newProgressForTests(now, submittedRuns))
+
+	// Then...
+	assert.Contains(t, console.ReadText(), "U100 myBundle/myClass1 running 2m0s\n")
+}
+
+func TestTerminalProgressWithNothingInProgressShowsOnlyCounters(t *testing.T) {
+	// Given...
+	console := utils.NewMockConsole()
+	reporter := NewTerminalProgressReporter(console)
+
+	// When...
+	reporter.ReportProgress(newProgressForTests(time.Now(), make(map[string]*TestRun)))
+
+	// Then...
+	assert.Equal(t, "Ready=1, Submitted=0, Finished=0, Lost=0, Throttle=2\n", console.ReadText())
+}
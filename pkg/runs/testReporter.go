@@ -0,0 +1,206 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// TestReporter - Writes the final results of the test runs to a file, in one report format.
+// A new report format is added by writing a TestReporter for it and adding it to CreateTestReporters.
+type TestReporter interface {
+	// GetName - The name of the report format, as used in the log.
+	GetName() string
+
+	// GetReportFilename - The command parameter holding the file the report is wanted in.
+	// The file name is empty if the report is not wanted. A pointer to the parameter is returned,
+	// so that a leading '~' can be expanded in place.
+	GetReportFilename(params *utils.RunsSubmitCmdValues) *string
+
+	// WriteReport - Writes the report to the file.
+	WriteReport(fileSystem spi.FileSystem, reportFilename string, results TestReportResults) error
+}
+
+// TestReportResults - Everything the reports are made from.
+type TestReportResults struct {
+	GroupName    string
+	FinishedRuns map[string]*TestRun
+	LostRuns     map[string]*TestRun
+
+	// The ecosystem the tests ran in, so reports can link to the runs there. Empty if the tests ran locally.
+	ApiServerUrl string
+
+	// Gets the run logs to include in the reports. nil if the tests ran locally.
+	RunLogFetcher RunLogFetcher
+
+	// How many lines from the end of each run log to include in the junit report.
+	JunitRunLogLineCount int
+}
+
+// CreateTestReporters - All the report formats, in the order their reports are written.
+func CreateTestReporters() []TestReporter {
+	return []TestReporter{
+		new(yamlTestReporter),
+		new(jsonTestReporter),
+		new(junitTestReporter),
+		new(htmlTestReporter),
+		new(tapTestReporter),
+		new(ctrfTestReporter),
+		new(markdownTestReporter),
+	}
+}
+
+type yamlTestReporter struct {
+}
+
+func (*yamlTestReporter) GetName() string {
+	return "yaml"
+}
+
+func (*yamlTestReporter) GetReportFilename(params *utils.RunsSubmitCmdValues) *string {
+	return &params.ReportYamlFilename
+}
+
+func (*yamlTestReporter) WriteReport(fileSystem spi.FileSystem, reportFilename string, results TestReportResults) error {
+	return ReportYaml(fileSystem, reportFilename, results.FinishedRuns, results.LostRuns)
+}
+
+type jsonTestReporter struct {
+}
+
+func (*jsonTestReporter) GetName() string {
+	return "json"
+}
+
+func (*jsonTestReporter) GetReportFilename(params *utils.RunsSubmitCmdValues) *string {
+	return &params.ReportJsonFilename
+}
+
+func (*jsonTestReporter) WriteReport(fileSystem spi.FileSystem, reportFilename string, results TestReportResults) error {
+	return ReportJSON(fileSystem, reportFilename, results.FinishedRuns, results.LostRuns)
+}
+
+type junitTestReporter struct {
+}
+
+func (*junitTestReporter) GetName() string {
+	return "junit"
+}
+
+func (*junitTestReporter) GetReportFilename(params *utils.RunsSubmitCmdValues) *string {
+	return &params.ReportJunitFilename
+}
+
+func (*junitTestReporter) WriteReport(fileSystem spi.FileSystem, reportFilename string, results TestReportResults) error {
+	return ReportJunit(fileSystem, reportFilename, results.GroupName, results.FinishedRuns, results.LostRuns,
+		results.RunLogFetcher, results.JunitRunLogLineCount)
+}
+
+type htmlTestReporter struct {
+}
+
+func (*htmlTestReporter) GetName() string {
+	return "html"
+}
+
+func (*htmlTestReporter) GetReportFilename(params *utils.RunsSubmitCmdValues) *string {
+	return &params.ReportHtmlFilename
+}
+
+func (*htmlTestReporter) WriteReport(fileSystem spi.FileSystem, reportFilename string, results TestReportResults) error {
+	return ReportHtml(fileSystem, reportFilename, results.ApiServerUrl, results.FinishedRuns, results.LostRuns)
+}
+
+type tapTestReporter struct {
+}
+
+func (*tapTestReporter) GetName() string {
+	return "tap"
+}
+
+func (*tapTestReporter) GetReportFilename(params *utils.RunsSubmitCmdValues) *string {
+	return &params.ReportTapFilename
+}
+
+func (*tapTestReporter) WriteReport(fileSystem spi.FileSystem, reportFilename string, results TestReportResults) error {
+	return ReportTap(fileSystem, reportFilename, results.FinishedRuns, results.LostRuns)
+}
+
+type ctrfTestReporter struct {
+}
+
+func (*ctrfTestReporter) GetName() string {
+	return "ctrf"
+}
+
+func (*ctrfTestReporter) GetReportFilename(params *utils.RunsSubmitCmdValues) *string {
+	return &params.ReportCtrfFilename
+}
+
+func (*ctrfTestReporter) WriteReport(fileSystem spi.FileSystem, reportFilename string, results TestReportResults) error {
+	return ReportCtrf(fileSystem, reportFilename, results.FinishedRuns, results.LostRuns)
+}
+
+type markdownTestReporter struct {
+}
+
+func (*markdownTestReporter) GetName() string {
+	return "markdown"
+}
+
+func (*markdownTestReporter) GetReportFilename(params *utils.RunsSubmitCmdValues) *string {
+	return &params.ReportMarkdownFilename
+}
+
+func (*markdownTestReporter) WriteReport(fileSystem spi.FileSystem, reportFilename string, results TestReportResults) error {
+	return ReportMarkdown(fileSystem, reportFilename, results.ApiServerUrl, results.FinishedRuns, results.LostRuns)
+}
+
+// How the reports treat the result of a test run or test method.
+const (
+	REPORT_OUTCOME_PASSED  = "passed"
+	REPORT_OUTCOME_FAILED  = "failed"
+	REPORT_OUTCOME_ERROR   = "error"
+	REPORT_OUTCOME_SKIPPED = "skipped"
+)
+
+// getReportOutcome - EnvFail means the environment the test needed was not working, rather than
+// the test failing, so it is an error. Ignored and Disabled tests did not run, so are skipped.
+// Anything else which did not pass failed.
+func getReportOutcome(result string) string {
+	outcome := REPORT_OUTCOME_FAILED
+	lowerCaseResult := strings.ToLower(result)
+	if strings.HasPrefix(lowerCaseResult, strings.ToLower(RESULT_PASSED)) {
+		outcome = REPORT_OUTCOME_PASSED
+	} else if strings.HasPrefix(lowerCaseResult, strings.ToLower(RESULT_ENVFAIL)) {
+		outcome = REPORT_OUTCOME_ERROR
+	} else if strings.HasPrefix(lowerCaseResult, "ignored") || strings.HasPrefix(lowerCaseResult, "disabled") {
+		outcome = REPORT_OUTCOME_SKIPPED
+	}
+	return outcome
+}
+
+// getLostRunResult - A run which never finished has the result it was given when it was cancelled
+// or interrupted, if it was. Otherwise it was lost.
+func getLostRunResult(run *TestRun) string {
+	result := run.Result
+	if result == "" {
+		result = RESULT_LOST
+	}
+	return result
+}
+
+// getRunsInNameOrder - So that the same results always give the same report.
+func getRunsInNameOrder(runs map[string]*TestRun) []*TestRun {
+	sortedRuns := make([]*TestRun, 0, len(runs))
+	for _, key := range sortFinishedRunsKeys(runs) {
+		sortedRuns = append(sortedRuns, runs[key])
+	}
+	return sortedRuns
+}
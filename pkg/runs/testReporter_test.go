@@ -0,0 +1,41 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEachTestReporterHasItsOwnNameAndReportFilename(t *testing.T) {
+	// Given...
+	params := utils.RunsSubmitCmdValues{}
+	names := make(map[string]bool)
+	filenames := make(map[*string]bool)
+
+	// When...
+	for _, reporter := range CreateTestReporters() {
+		names[reporter.GetName()] = true
+		filenames[reporter.GetReportFilename(&params)] = true
+	}
+
+	// Then...
+	assert.Equal(t, len(CreateTestReporters()), len(names))
+	assert.Equal(t, len(CreateTestReporters()), len(filenames))
+}
+
+func TestGetReportOutcomeOfGalasaResults(t *testing.T) {
+	assert.Equal(t, REPORT_OUTCOME_PASSED, getReportOutcome("Passed"))
+	assert.Equal(t, REPORT_OUTCOME_PASSED, getReportOutcome("Passed With Defects"))
+	assert.Equal(t, REPORT_OUTCOME_FAILED, getReportOutcome("Failed"))
+	assert.Equal(t, REPORT_OUTCOME_FAILED, getReportOutcome("failed"))
+	assert.Equal(t, REPORT_OUTCOME_FAILED, getReportOutcome(RESULT_LOST))
+	assert.Equal(t, REPORT_OUTCOME_ERROR, getReportOutcome("EnvFail"))
+	assert.Equal(t, REPORT_OUTCOME_SKIPPED, getReportOutcome("Ignored"))
+	assert.Equal(t, REPORT_OUTCOME_SKIPPED, getReportOutcome("Disabled"))
+}
@@ -0,0 +1,221 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/api"
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/spi"
+)
+
+const (
+	WEBHOOK_REQUEST_TIMEOUT = 30 * time.Second
+
+	// How many events can wait to be posted before the submission has to wait for the webhook.
+	WEBHOOK_QUEUE_SIZE = 1000
+)
+
+// WebhookSender - Posts a json payload to a webhook URL.
+type WebhookSender interface {
+	SendWebhook(webhookUrl string, eventType string, payload []byte) error
+}
+
+type httpWebhookSender struct {
+	commsRetrier api.CommsRetrier
+}
+
+// NewHttpWebhookSender - Posts to webhooks over http. A webhook which can't be reached, is rate-limiting
+// its callers or fails with a server error is tried again, as the comms retrier allows.
+func NewHttpWebhookSender(commsRetrier api.CommsRetrier) WebhookSender {
+	instance := new(httpWebhookSender)
+	instance.commsRetrier = commsRetrier
+	return instance
+}
+
+func (sender *httpWebhookSender) SendWebhook(webhookUrl string, eventType string, payload []byte) error {
+	return sender.commsRetrier.ExecuteCommandWithRateLimitRetries(func() error {
+		var err error
+		var request *http.Request
+		var response *http.Response
+
+		request, err = http.NewRequest(http.MethodPost, webhookUrl, bytes.NewReader(payload))
+		if err == nil {
+			request.Header.Set("Content-Type", "application/json")
+
+			log.Printf("Sending the '%v' event to webhook %v\n", eventType, webhookUrl)
+			client := &http.Client{Timeout: WEBHOOK_REQUEST_TIMEOUT}
+			response, err = client.Do(request)
+		}
+
+		if err != nil {
+			err = &retryableWebhookError{galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_WEBHOOK_SEND_FAILED, eventType, webhookUrl, err.Error())}
+		} else {
+			defer response.Body.Close()
+			if response.StatusCode < 200 || response.StatusCode > 299 {
+				responseBody, _ := ioutil.ReadAll(response.Body)
+				err = galasaErrors.NewGalasaErrorWithHttpStatusCode(response.StatusCode, galasaErrors.GALASA_ERROR_WEBHOOK_REJECTED,
+					webhookUrl, eventType, response.StatusCode, strings.TrimSpace(string(responseBody)))
+
+				if response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500 {
+					err = &retryableWebhookError{err.(*galasaErrors.GalasaError)}
+				}
+			}
+		}
+		return err
+	})
+}
+
+// retryableWebhookError - A failure to post to a webhook which may work if it is tried again.
+// Unlike the ecosystem, a webhook is not only tried again when it is rate-limiting its callers.
+type retryableWebhookError struct {
+	*galasaErrors.GalasaError
+}
+
+func (err *retryableWebhookError) IsRetryRequired() bool {
+	return true
+}
+
+// WebhookNotifier - Sends the event when the submission completes to a webhook, and optionally the
+// event when each test run finishes. The payload is the event as json, unless a template is given.
+// Events are posted in the background, in the order they happened, so a slow webhook doesn't hold up
+// the submission.
+type WebhookNotifier struct {
+	webhookUrl         string
+	payloadTemplate    *template.Template
+	isNotifyingEachRun bool
+	sender             WebhookSender
+
+	// The events waiting to be posted.
+	deliveries chan webhookDelivery
+
+	// Closed once every event has been posted, or has failed to be.
+	deliveriesDone chan struct{}
+
+	// The events which couldn't be posted. Only read once the deliveries are done.
+	deliveryErrors []error
+}
+
+type webhookDelivery struct {
+	eventType string
+	payload   []byte
+}
+
+// NewWebhookNotifier - templateFileName is the file holding a go template which builds the json payload
+// from the event. Empty if the event itself is sent.
+func NewWebhookNotifier(
+	fileSystem spi.FileSystem,
+	webhookUrl string,
+	templateFileName string,
+	isNotifyingEachRun bool,
+	sender WebhookSender,
+) (*WebhookNotifier, error) {
+	var err error
+	var instance *WebhookNotifier
+	var payloadTemplate *template.Template
+
+	if !strings.HasPrefix(webhookUrl, "http://") && !strings.HasPrefix(webhookUrl, "https://") {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_WEBHOOK_URL, webhookUrl)
+	}
+
+	if err == nil && templateFileName != "" {
+		payloadTemplate, err = readWebhookTemplate(fileSystem, templateFileName)
+	}
+
+	if err == nil {
+		instance = new(WebhookNotifier)
+		instance.webhookUrl = webhookUrl
+		instance.payloadTemplate = payloadTemplate
+		instance.isNotifyingEachRun = isNotifyingEachRun
+		instance.sender = sender
+		instance.deliveries = make(chan webhookDelivery, WEBHOOK_QUEUE_SIZE)
+		instance.deliveriesDone = make(chan struct{})
+
+		go instance.deliver()
+	}
+	return instance, err
+}
+
+// deliver - Posts each event as it is queued, until the notifier is closed.
+func (notifier *WebhookNotifier) deliver() {
+	for delivery := range notifier.deliveries {
+		err := notifier.sender.SendWebhook(notifier.webhookUrl, delivery.eventType, delivery.payload)
+		if err != nil {
+			notifier.deliveryErrors = append(notifier.deliveryErrors, err)
+		}
+	}
+	close(notifier.deliveriesDone)
+}
+
+// Close - Waits for the events already queued to be posted. Returns the errors of any which couldn't be.
+func (notifier *WebhookNotifier) Close() []error {
+	close(notifier.deliveries)
+	<-notifier.deliveriesDone
+	return notifier.deliveryErrors
+}
+
+// readWebhookTemplate - As well as the usual template functions, the template can use 'json',
+// which writes a value as json. For example {"text": {{json .Group}}} quotes and escapes the group name.
+func readWebhookTemplate(fileSystem spi.FileSystem, templateFileName string) (*template.Template, error) {
+	var payloadTemplate *template.Template
+
+	text, err := fileSystem.ReadTextFile(templateFileName)
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_OPEN_WEBHOOK_TEMPLATE_FAILED, templateFileName, err.Error())
+	} else {
+		payloadTemplate, err = template.New(templateFileName).Funcs(template.FuncMap{"json": toWebhookJson}).Parse(text)
+		if err != nil {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_WEBHOOK_TEMPLATE_BAD_FORMAT, templateFileName, err.Error())
+		}
+	}
+	return payloadTemplate, err
+}
+
+func toWebhookJson(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+// OnSubmissionEvent - Queues the event to be posted. An event whose payload can't be built fails
+// straight away, but a failure to post it is only returned by Close.
+func (notifier *WebhookNotifier) OnSubmissionEvent(event SubmissionEvent) error {
+	var err error
+	if event.Event == EVENT_COMPLETED || (notifier.isNotifyingEachRun && event.Event == EVENT_FINISHED) {
+		var payload []byte
+		payload, err = notifier.getPayload(event)
+		if err == nil {
+			notifier.deliveries <- webhookDelivery{eventType: event.Event, payload: payload}
+		}
+	}
+	return err
+}
+
+func (notifier *WebhookNotifier) getPayload(event SubmissionEvent) ([]byte, error) {
+	var payload []byte
+	var err error
+
+	if notifier.payloadTemplate == nil {
+		payload, err = json.Marshal(&event)
+	} else {
+		buff := bytes.Buffer{}
+		err = notifier.payloadTemplate.Execute(&buff, event)
+		payload = buff.Bytes()
+		if err != nil {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_WEBHOOK_PAYLOAD_NOT_JSON, event.Event, err.Error())
+		} else if !json.Valid(payload) {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_WEBHOOK_PAYLOAD_NOT_JSON, event.Event, "the payload is: "+buff.String())
+		}
+	}
+	return payload, err
+}
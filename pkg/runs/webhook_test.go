@@ -0,0 +1,229 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runs
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/galasa-dev/cli/pkg/api"
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttpWebhookSenderPostsThePayload(t *testing.T) {
+	// Given...
+	var receivedBody string
+	var receivedContentType string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := ioutil.ReadAll(request.Body)
+		receivedBody = string(body)
+		receivedContentType = request.Header.Get("Content-Type")
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewHttpWebhookSender(api.NewCommsRetrier(3, 0, utils.NewMockTimeService()))
+
+	// When...
+	err := sender.SendWebhook(server.URL, EVENT_COMPLETED, []byte(`{"text": "hello"}`))
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, `{"text": "hello"}`, receivedBody)
+	assert.Equal(t, "application/json", receivedContentType)
+}
+
+func TestHttpWebhookSenderRetriesWhenRateLimited(t *testing.T) {
+	// Given...
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestCount++
+		if requestCount == 1 {
+			writer.WriteHeader(http.StatusTooManyRequests)
+		} else {
+			writer.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	sender := NewHttpWebhookSender(api.NewCommsRetrier(3, 0, utils.NewMockTimeService()))
+
+	// When...
+	err := sender.SendWebhook(server.URL, EVENT_COMPLETED, []byte(`{}`))
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 2, requestCount)
+}
+
+func TestHttpWebhookSenderRetriesAfterServerError(t *testing.T) {
+	// Given...
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestCount++
+		if requestCount == 1 {
+			writer.WriteHeader(http.StatusBadGateway)
+		} else {
+			writer.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	sender := NewHttpWebhookSender(api.NewCommsRetrier(3, 0, utils.NewMockTimeService()))
+
+	// When...
+	err := sender.SendWebhook(server.URL, EVENT_COMPLETED, []byte(`{}`))
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, 2, requestCount)
+}
+
+func TestHttpWebhookSenderRetriesWhenWebhookCantBeReached(t *testing.T) {
+	// Given...
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	unreachableUrl := server.URL
+	server.Close()
+
+	mockTimeService := utils.NewMockTimeService()
+	startTime := mockTimeService.Now()
+	sender := NewHttpWebhookSender(api.NewCommsRetrier(3, 1, mockTimeService))
+
+	// When...
+	err := sender.SendWebhook(unreachableUrl, EVENT_COMPLETED, []byte(`{}`))
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1288E")
+	// Waits between each of the 3 attempts.
+	assert.Equal(t, startTime.Add(2*time.Second), mockTimeService.Now())
+}
+
+func TestHttpWebhookSenderReportsRejection(t *testing.T) {
+	// Given...
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requestCount++
+		writer.WriteHeader(http.StatusBadRequest)
+		writer.Write([]byte("invalid_payload"))
+	}))
+	defer server.Close()
+
+	sender := NewHttpWebhookSender(api.NewCommsRetrier(3, 0, utils.NewMockTimeService()))
+
+	// When...
+	err := sender.SendWebhook(server.URL, EVENT_FINISHED, []byte(`{}`))
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1289E")
+	assert.Contains(t, err.Error(), "rejected the 'finished' event. Status code 400. Response is: invalid_payload")
+	// A webhook which rejects the payload won't accept it if it is tried again.
+	assert.Equal(t, 1, requestCount)
+}
+
+func TestWebhookNotifierWithTemplateWhichIsNotJsonFails(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockFileSystem.WriteTextFile("webhook.tmpl", `{"text": {{.Group}}}`)
+	sender := new(mockWebhookSender)
+	notifier, err := NewWebhookNotifier(mockFileSystem, "http://localhost/hook", "webhook.tmpl", false, sender)
+	assert.Nil(t, err)
+
+	// When...
+	err = notifier.OnSubmissionEvent(SubmissionEvent{Event: EVENT_COMPLETED, Group: "myGroup"})
+	deliveryErrors := notifier.Close()
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1287E")
+	assert.Empty(t, deliveryErrors)
+	assert.Empty(t, sender.sentPayloads)
+}
+
+func TestWebhookNotifierWithBadTemplateFails(t *testing.T) {
+	// Given...
+	mockFileSystem := files.NewMockFileSystem()
+	mockFileSystem.WriteTextFile("webhook.tmpl", `{"text": {{.Group}`)
+
+	// When...
+	_, err := NewWebhookNotifier(mockFileSystem, "http://localhost/hook", "webhook.tmpl", false, new(mockWebhookSender))
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1286E")
+}
+
+func TestWebhookNotifierIgnoresOtherEvents(t *testing.T) {
+	// Given...
+	sender := new(mockWebhookSender)
+	notifier, err := NewWebhookNotifier(files.NewMockFileSystem(), "http://localhost/hook", "", false, sender)
+	assert.Nil(t, err)
+
+	// When...
+	for _, eventType := range []string{EVENT_SUBMITTED, EVENT_STATUS_CHANGED, EVENT_FINISHED, EVENT_LOST, EVENT_THROTTLE_CHANGED} {
+		err = notifier.OnSubmissionEvent(SubmissionEvent{Event: eventType})
+		assert.Nil(t, err)
+	}
+	notifier.Close()
+
+	// Then...
+	assert.Empty(t, sender.sentEventTypes)
+}
+
+// A webhook sender which doesn't finish sending until it is told it can.
+type blockedWebhookSender struct {
+	mockWebhookSender
+	unblock chan struct{}
+}
+
+func (sender *blockedWebhookSender) SendWebhook(webhookUrl string, eventType string, payload []byte) error {
+	<-sender.unblock
+	return sender.mockWebhookSender.SendWebhook(webhookUrl, eventType, payload)
+}
+
+func TestWebhookNotifierDoesNotWaitForTheWebhook(t *testing.T) {
+	// Given...
+	sender := &blockedWebhookSender{unblock: make(chan struct{})}
+	notifier, err := NewWebhookNotifier(files.NewMockFileSystem(), "http://localhost/hook", "", true, sender)
+	assert.Nil(t, err)
+
+	// When...
+	// The webhook hasn't been posted to yet, but the events are queued without waiting for it.
+	err = notifier.OnSubmissionEvent(SubmissionEvent{Event: EVENT_FINISHED, RunName: "U1"})
+	assert.Nil(t, err)
+	err = notifier.OnSubmissionEvent(SubmissionEvent{Event: EVENT_COMPLETED})
+	assert.Nil(t, err)
+
+	close(sender.unblock)
+	deliveryErrors := notifier.Close()
+
+	// Then...
+	assert.Empty(t, deliveryErrors)
+	assert.Equal(t, []string{EVENT_FINISHED, EVENT_COMPLETED}, sender.sentEventTypes)
+}
+
+func TestWebhookNotifierReturnsFailuresToPostWhenClosed(t *testing.T) {
+	// Given...
+	sender := &mockWebhookSender{err: errors.New("simulated failure to post")}
+	notifier, err := NewWebhookNotifier(files.NewMockFileSystem(), "http://localhost/hook", "", false, sender)
+	assert.Nil(t, err)
+
+	// When...
+	err = notifier.OnSubmissionEvent(SubmissionEvent{Event: EVENT_COMPLETED})
+	deliveryErrors := notifier.Close()
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Len(t, deliveryErrors, 1)
+	assert.Contains(t, deliveryErrors[0].Error(), "simulated failure to post")
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// -----------------------------------------------------
+// CSV format. The table the summary format writes, as comma-separated values,
+// without the totals. Lost runs are left out.
+type CsvFormatter struct {
+	utils.TableLayout
+}
+
+func NewCsvFormatter() RunsFormatter {
+	return new(CsvFormatter)
+}
+
+func (*CsvFormatter) GetName() string {
+	return utils.CSV_FORMATTER_NAME
+}
+
+func (*CsvFormatter) IsNeedingMethodDetails() bool {
+	return false
+}
+
+func (formatter *CsvFormatter) FormatRuns(runs []FormattableTest) (string, error) {
+	// All of the runs are a stream with a single page.
+	return formatter.NewRunsStream().FormatRunsPage(runs)
+}
+
+// csvRunsStream - Writes the rows of runs a page at a time, with the headers before the first page.
+type csvRunsStream struct {
+	tableStream *utils.TableStream
+}
+
+func (formatter *CsvFormatter) NewRunsStream() RunsStream {
+	stream := new(csvRunsStream)
+	stream.tableStream = formatter.NewTableStream()
+	return stream
+}
+
+func (stream *csvRunsStream) FormatRunsPage(runs []FormattableTest) (string, error) {
+	buff := strings.Builder{}
+
+	// Every column is written, so scripts can rely on them being there.
+	isShowingAttempts := true
+	isShowingExcusedBy := true
+	err := stream.tableStream.WriteCsvRows(getRunsTable(runs, isShowingAttempts, isShowingExcusedBy), &buff)
+
+	return buff.String(), err
+}
+
+func (*csvRunsStream) FormatRunsEnd() (string, error) {
+	return "", nil
+}
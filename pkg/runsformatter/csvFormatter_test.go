@@ -0,0 +1,65 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCsvFormatterNoDataReturnsHeadersOnly(t *testing.T) {
+	// Given...
+	formatter := NewCsvFormatter()
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(make([]FormattableTest, 0))
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "submitted-time(UTC),name,requestor,status,result,test-name,group,attempts,excused-by\n", actualFormattedOutput)
+}
+
+func TestCsvFormatterWritesEveryColumnAndLeavesOutLostRuns(t *testing.T) {
+	// Given...
+	formatter := NewCsvFormatter()
+	runs := []FormattableTest{
+		createFormattableTestForSummary("2023-05-04T10:55:29.545323Z", "U456", "MyTestName", "Finished", "Passed", "myUserId1", false, "none"),
+		createFormattableTestForSummary("2023-05-04T10:55:30.545323Z", "U457", "MyTestName", "Finished", "Failed", "myUserId1", true, "none"),
+	}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(runs)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "submitted-time(UTC),name,requestor,status,result,test-name,group,attempts,excused-by\n"+
+		"2023-05-04 10:55:29,U456,myUserId1,Finished,Passed,MyTestName,none,Passed,\n", actualFormattedOutput)
+}
+
+func TestSummaryFormatterWithTableOptionsChoosesAndSortsColumns(t *testing.T) {
+	// Given...
+	formatter := NewSummaryFormatter()
+	err := utils.ApplyTableOptions(formatter, utils.NewTableOptions([]string{"name", "result", "attempts"}, "name:desc", false))
+	assert.Nil(t, err)
+
+	runs := []FormattableTest{
+		createFormattableTestForSummary("2023-05-04T10:55:29.545323Z", "U456", "MyTestName", "Finished", "Passed", "myUserId1", false, "none"),
+		createFormattableTestForSummary("2023-05-04T10:55:30.545323Z", "U457", "MyTestName", "Finished", "Failed", "myUserId1", false, "none"),
+	}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(runs)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "name result attempts\n"+
+		"U457 Failed Failed\n"+
+		"U456 Passed Passed\n"+
+		"\n"+
+		"Total:2 Passed:1 Failed:1\n", actualFormattedOutput)
+}
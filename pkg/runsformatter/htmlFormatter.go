@@ -0,0 +1,276 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+)
+
+// -----------------------------------------------------
+// HTML format.
+// A single self-contained page, with no scripts or styles fetched from elsewhere,
+// so that it can be shared as a file.
+const (
+	HTML_FORMATTER_NAME = "html"
+)
+
+type HtmlFormatter struct {
+}
+
+// -----------------------------------------------------
+// Constructors
+func NewHtmlFormatter() RunsFormatter {
+	return new(HtmlFormatter)
+}
+
+// -----------------------------------------------------
+// Functions in the RunsFormatter interface
+func (*HtmlFormatter) GetName() string {
+	return HTML_FORMATTER_NAME
+}
+
+func (*HtmlFormatter) IsNeedingMethodDetails() bool {
+	return true
+}
+
+func (*HtmlFormatter) FormatRuns(runs []FormattableTest) (string, error) {
+	var err error
+	buff := strings.Builder{}
+
+	page := newHtmlPage(runs)
+	err = htmlPageTemplate.Execute(&buff, page)
+
+	return buff.String(), err
+}
+
+// -----------------------------------------------------
+// Internal functions
+
+// htmlPage - Everything shown on the page, worked out before the template is filled in.
+type htmlPage struct {
+	Totals         []htmlResultTotal
+	Runs           []htmlRun
+	HasAttempts    bool
+	HasMethods     bool
+	TotalRunsLabel string
+}
+
+type htmlResultTotal struct {
+	Label     string
+	Count     int
+	CssSuffix string
+}
+
+type htmlRun struct {
+	FormattableTest
+	Link           string
+	RunLogLink     string
+	SubmittedTime  string
+	StartTime      string
+	EndTime        string
+	DurationMillis string
+	Attempts       string
+	ResultLabel    string
+	CssSuffix      string
+	Methods        []htmlMethod
+}
+
+type htmlMethod struct {
+	Name           string
+	Type           string
+	Status         string
+	Result         string
+	StartTime      string
+	EndTime        string
+	DurationMillis string
+	CssSuffix      string
+}
+
+func newHtmlPage(runs []FormattableTest) htmlPage {
+	page := htmlPage{}
+	resultCountsMap := initialiseResultMap()
+
+	page.HasAttempts = isAnyTestReattempted(runs)
+	for _, run := range runs {
+		if run.Lost {
+			resultCountsMap[RUN_RESULT_LOST] += 1
+		} else {
+			accumulateResults(resultCountsMap, run)
+		}
+
+		htmlRun := newHtmlRun(run)
+		if len(htmlRun.Methods) > 0 {
+			page.HasMethods = true
+		}
+		page.Runs = append(page.Runs, htmlRun)
+	}
+
+	page.TotalRunsLabel = RUN_RESULT_TOTAL + ": " + strconv.Itoa(len(runs))
+	for _, label := range RESULT_LABELS {
+		if resultCountsMap[label] > 0 {
+			page.Totals = append(page.Totals, htmlResultTotal{
+				Label:     label,
+				Count:     resultCountsMap[label],
+				CssSuffix: getResultCssSuffix(label),
+			})
+		}
+	}
+	return page
+}
+
+func newHtmlRun(run FormattableTest) htmlRun {
+	htmlRun := htmlRun{FormattableTest: run}
+
+	if run.ApiServerUrl != "" && run.RunId != "" {
+		htmlRun.Link = run.ApiServerUrl + RAS_RUNS_URL + run.RunId
+		htmlRun.RunLogLink = htmlRun.Link + "/runlog"
+	}
+
+	htmlRun.SubmittedTime = formatTimeReadable(run.QueuedTimeUTC)
+	htmlRun.StartTime = getReadableTime(run.StartTimeUTC)
+	htmlRun.EndTime = getReadableTime(run.EndTimeUTC)
+	htmlRun.DurationMillis = getDuration(run.StartTimeUTC, run.EndTimeUTC)
+	htmlRun.Attempts = getAttemptsHistory(run)
+
+	htmlRun.ResultLabel = run.Result
+	if run.Lost {
+		htmlRun.ResultLabel = RUN_RESULT_LOST
+	} else if run.Result == "" {
+		htmlRun.ResultLabel = RUN_RESULT_ACTIVE
+	}
+	htmlRun.CssSuffix = getResultCssSuffix(htmlRun.ResultLabel)
+
+	for _, method := range run.Methods {
+		htmlRun.Methods = append(htmlRun.Methods, newHtmlMethod(method))
+	}
+	return htmlRun
+}
+
+func newHtmlMethod(method galasaapi.TestMethod) htmlMethod {
+	return htmlMethod{
+		Name:           method.GetMethodName(),
+		Type:           method.GetType(),
+		Status:         method.GetStatus(),
+		Result:         method.GetResult(),
+		StartTime:      getReadableTime(method.GetStartTime()),
+		EndTime:        getReadableTime(method.GetEndTime()),
+		DurationMillis: getDuration(method.GetStartTime(), method.GetEndTime()),
+		CssSuffix:      getResultCssSuffix(method.GetResult()),
+	}
+}
+
+// getResultCssSuffix - Results are coloured by the style of the first word of the result, such as 'passed' or 'failed'.
+func getResultCssSuffix(result string) string {
+	suffix := "other"
+	lowerCaseResult := strings.ToLower(result)
+	for _, knownResult := range []string{"passed", "success", "failed", "envfail", "lost", "ignored", "active"} {
+		if strings.HasPrefix(lowerCaseResult, knownResult) {
+			suffix = knownResult
+			break
+		}
+	}
+	return suffix
+}
+
+var htmlPageTemplate = template.Must(template.New("runs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Galasa test runs</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #161616; }
+h1 { font-size: 1.5em; }
+.totals span { display: inline-block; margin: 0 1em 1em 0; padding: 0.4em 0.8em; border-radius: 4px; background: #e0e0e0; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #c6c6c6; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+table.sortable th { cursor: pointer; }
+table.sortable th:after { content: " \2195"; color: #8d8d8d; }
+.result-passed, .result-success { background: #defbe6; }
+.result-failed, .result-lost { background: #fff1f1; }
+.result-envfail { background: #fcf4d6; }
+.result-ignored, .result-active { background: #edf5ff; }
+</style>
+</head>
+<body>
+<h1>Galasa test runs</h1>
+<div class="totals">
+<span>{{.TotalRunsLabel}}</span>
+{{- range .Totals}}
+<span class="result-{{.CssSuffix}}">{{.Label}}: {{.Count}}</span>
+{{- end}}
+</div>
+{{- if .Runs}}
+<table id="runs" class="sortable">
+<thead>
+<tr><th>name</th><th>test-name</th><th>status</th><th>result</th>{{if .HasAttempts}}<th>attempts</th>{{end}}<th>submitted-time(UTC)</th><th>start-time(UTC)</th><th>end-time(UTC)</th><th data-numeric="true">duration(ms)</th><th>requestor</th><th>group</th><th>run-log</th></tr>
+</thead>
+<tbody>
+{{- range .Runs}}
+<tr>
+<td>{{if .Link}}<a href="{{.Link}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</td>
+<td>{{.TestName}}</td>
+<td>{{.Status}}</td>
+<td class="result-{{.CssSuffix}}">{{.ResultLabel}}</td>
+{{- if $.HasAttempts}}
+<td>{{.Attempts}}</td>
+{{- end}}
+<td>{{.SubmittedTime}}</td>
+<td>{{.StartTime}}</td>
+<td>{{.EndTime}}</td>
+<td>{{.DurationMillis}}</td>
+<td>{{.Requestor}}</td>
+<td>{{.Group}}</td>
+<td>{{if .RunLogLink}}<a href="{{.RunLogLink}}">run-log</a>{{end}}</td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .HasMethods}}
+<h2>Methods</h2>
+{{- range .Runs}}
+{{- if .Methods}}
+<h3>{{.Name}} - {{.TestName}}</h3>
+<table>
+<thead>
+<tr><th>method</th><th>type</th><th>status</th><th>result</th><th>start-time(UTC)</th><th>end-time(UTC)</th><th>duration(ms)</th></tr>
+</thead>
+<tbody>
+{{- range .Methods}}
+<tr><td>{{.Name}}</td><td>{{.Type}}</td><td>{{.Status}}</td><td class="result-{{.CssSuffix}}">{{.Result}}</td><td>{{.StartTime}}</td><td>{{.EndTime}}</td><td>{{.DurationMillis}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- end}}
+{{- end}}
+<script>
+document.querySelectorAll("table.sortable th").forEach(function (header, column) {
+  header.addEventListener("click", function () {
+    var table = header.closest("table");
+    var body = table.tBodies[0];
+    var isNumeric = header.dataset.numeric === "true";
+    var isAscending = header.dataset.order !== "asc";
+    header.dataset.order = isAscending ? "asc" : "desc";
+    var rows = Array.prototype.slice.call(body.rows);
+    rows.sort(function (a, b) {
+      var x = a.cells[column].textContent;
+      var y = b.cells[column].textContent;
+      var order = isNumeric ? (Number(x) - Number(y)) : x.localeCompare(y);
+      return isAscending ? order : -order;
+    });
+    rows.forEach(function (row) { body.appendChild(row); });
+  });
+});
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,103 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHtmlFormatterNoDataReturnsPageWithZeroTotal(t *testing.T) {
+	// Given...
+	formatter := NewHtmlFormatter()
+	formattableTest := make([]FormattableTest, 0)
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(formattableTest)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(actualFormattedOutput, "<!DOCTYPE html>"))
+	assert.Contains(t, actualFormattedOutput, "<span>Total: 0</span>")
+	assert.NotContains(t, actualFormattedOutput, `<table id="runs"`)
+}
+
+func TestHtmlFormatterShowsTotalsRunsMethodsAndLinks(t *testing.T) {
+	// Given...
+	formatter := NewHtmlFormatter()
+	methods := []galasaapi.TestMethod{
+		CreateMethod("testMethod1", "test", "finished", "Passed", "2023-05-04T10:55:29.545323Z", "2023-05-05T06:03:38.872709Z"),
+		CreateMethod("testMethod2", "test", "finished", "Failed", "2023-05-05T06:03:38.872709Z", "2023-05-05T06:03:39.872709Z"),
+	}
+	formattableTest := []FormattableTest{
+		createFormattableTestForDetails("cdb-123", "U456", "Finished", "Failed", "myBundle", "myTestName", "galasa",
+			"2023-05-04T10:45:29.545323Z", "2023-05-04T10:55:29.545323Z", "2023-05-05T06:03:38.872709Z",
+			"https://my-ecosystem/api", methods, false, "myGroup"),
+		createFormattableTestForDetails("cdb-456", "U457", "Finished", "Passed", "myBundle", "myOtherTest", "galasa",
+			"2023-05-04T10:45:29.545323Z", "2023-05-04T10:55:29.545323Z", "2023-05-04T10:56:29.545323Z",
+			"https://my-ecosystem/api", nil, false, "myGroup"),
+	}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(formattableTest)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Contains(t, actualFormattedOutput, "<span>Total: 2</span>")
+	assert.Contains(t, actualFormattedOutput, `<span class="result-passed">Passed: 1</span>`)
+	assert.Contains(t, actualFormattedOutput, `<span class="result-failed">Failed: 1</span>`)
+	assert.Contains(t, actualFormattedOutput, `<a href="https://my-ecosystem/api/ras/runs/cdb-123">U456</a>`)
+	assert.Contains(t, actualFormattedOutput, `<a href="https://my-ecosystem/api/ras/runs/cdb-123/runlog">run-log</a>`)
+	assert.Contains(t, actualFormattedOutput, "<td>2023-05-04 10:55:29</td>")
+	assert.Contains(t, actualFormattedOutput, "<td>60000</td>")
+	assert.Contains(t, actualFormattedOutput, "<h3>U456 - myTestName</h3>")
+	assert.Contains(t, actualFormattedOutput, `<td class="result-failed">Failed</td><td>2023-05-05 06:03:38</td><td>2023-05-05 06:03:39</td><td>1000</td>`)
+	assert.NotContains(t, actualFormattedOutput, "<h3>U457")
+	assert.NotContains(t, actualFormattedOutput, "<th>attempts</th>")
+}
+
+func TestHtmlFormatterEscapesTextFromTheRuns(t *testing.T) {
+	// Given...
+	formatter := NewHtmlFormatter()
+	formattableTest := []FormattableTest{
+		createFormattableTestForDetails("", "U456", "Finished", "Passed", "myBundle", "<script>alert(1)</script>", "galasa",
+			"", "", "", "", nil, false, "myGroup"),
+	}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(formattableTest)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Contains(t, actualFormattedOutput, "&lt;script&gt;alert(1)&lt;/script&gt;")
+	assert.NotContains(t, actualFormattedOutput, "<script>alert(1)")
+
+	// Without a run id there is nothing to link to.
+	assert.Contains(t, actualFormattedOutput, "<td>U456</td>")
+}
+
+func TestHtmlFormatterShowsLostRunsAndAttempts(t *testing.T) {
+	// Given...
+	formatter := NewHtmlFormatter()
+	retriedTest := createFormattableTestForDetails("", "U456", "finished", "Passed", "myBundle", "myTestName", "galasa",
+		"", "", "", "", nil, false, "myGroup")
+	retriedTest.AttemptResults = []string{"EnvFail", "Passed"}
+	lostTest := createFormattableTestForDetails("", "U457", "running", "", "myBundle", "myLostTest", "galasa",
+		"", "", "", "", nil, true, "myGroup")
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns([]FormattableTest{retriedTest, lostTest})
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Contains(t, actualFormattedOutput, "<th>attempts</th>")
+	assert.Contains(t, actualFormattedOutput, "<td>EnvFail,Passed</td>")
+	assert.Contains(t, actualFormattedOutput, `<td class="result-lost">Lost</td>`)
+	assert.Contains(t, actualFormattedOutput, `<span class="result-lost">Lost: 1</span>`)
+}
@@ -0,0 +1,41 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"encoding/json"
+)
+
+// -----------------------------------------------------
+// Json format. A json array holding each run.
+const (
+	JSON_FORMATTER_NAME = "json"
+)
+
+type JsonFormatter struct {
+}
+
+func NewJsonFormatter() RunsFormatter {
+	return new(JsonFormatter)
+}
+
+func (*JsonFormatter) GetName() string {
+	return JSON_FORMATTER_NAME
+}
+
+func (*JsonFormatter) IsNeedingMethodDetails() bool {
+	return true
+}
+
+func (*JsonFormatter) FormatRuns(runs []FormattableTest) (string, error) {
+	var result string
+
+	jsonBytes, err := json.MarshalIndent(getStructuredRuns(runs), "", "  ")
+	if err == nil {
+		result = string(jsonBytes) + "\n"
+	}
+	return result, err
+}
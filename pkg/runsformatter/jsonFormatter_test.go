@@ -0,0 +1,128 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func createFormattableTestForStructuredFormats() FormattableTest {
+	className := "myTestPackage.MyTestName"
+	methodName := "myTestMethodName"
+	methodType := "test"
+	status := "finished"
+	result := "Passed"
+	startTime := "2023-05-10T06:00:13.000000Z"
+	endTime := "2023-05-10T06:00:14.500000Z"
+
+	formattableTest := createFormattableTestForRaw("xxx876xxx", "U456", "Finished", "Passed", "myBundleId", "myTestPackage.MyTestName",
+		"unitTesting", "2023-05-10T06:00:10.000000Z", "2023-05-10T06:00:12.000000Z", "2023-05-10T06:00:15.000000Z",
+		"https://my.galasa.server/api", false, "myGroup")
+	formattableTest.TestShortName = "MyTestName"
+	formattableTest.Methods = []galasaapi.TestMethod{{
+		ClassName:  &className,
+		MethodName: &methodName,
+		Type:       &methodType,
+		Status:     &status,
+		Result:     &result,
+		StartTime:  &startTime,
+		EndTime:    &endTime,
+	}}
+	return formattableTest
+}
+
+func TestJsonFormatterNoDataReturnsEmptyArray(t *testing.T) {
+	// Given...
+	formatter := NewJsonFormatter()
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(make([]FormattableTest, 0))
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "[]\n", actualFormattedOutput)
+}
+
+func TestJsonFormatterReturnsExpectedFormat(t *testing.T) {
+	// Given...
+	formatter := NewJsonFormatter()
+	activeRun := createFormattableTestForRaw("xxx543xxx", "U457", "running", "", "myBundleId", "myTestPackage.MyTestName",
+		"unitTesting", "2023-05-10T06:00:10.000000Z", "2023-05-10T06:00:12.000000Z", "",
+		"https://my.galasa.server/api", false, "myGroup")
+	lostRun := FormattableTest{Name: "U458", Lost: true}
+	runs := []FormattableTest{createFormattableTestForStructuredFormats(), activeRun, lostRun}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(runs)
+
+	// Then...
+	assert.Nil(t, err)
+	expectedFormattedOutput := `[
+  {
+    "apiVersion": "galasa-dev/v1alpha1",
+    "kind": "GalasaRun",
+    "metadata": {
+      "name": "U456",
+      "runId": "xxx876xxx"
+    },
+    "data": {
+      "status": "Finished",
+      "result": "Passed",
+      "bundle": "myBundleId",
+      "testName": "myTestPackage.MyTestName",
+      "testShortName": "MyTestName",
+      "requestor": "unitTesting",
+      "group": "myGroup",
+      "queuedTime": "2023-05-10T06:00:10.000000Z",
+      "startTime": "2023-05-10T06:00:12.000000Z",
+      "endTime": "2023-05-10T06:00:15.000000Z",
+      "durationMs": 3000,
+      "runLogUrl": "https://my.galasa.server/api/ras/runs/xxx876xxx/runlog",
+      "artifactsUrl": "https://my.galasa.server/api/ras/runs/xxx876xxx/artifacts",
+      "methods": [
+        {
+          "className": "myTestPackage.MyTestName",
+          "methodName": "myTestMethodName",
+          "type": "test",
+          "status": "finished",
+          "result": "Passed",
+          "startTime": "2023-05-10T06:00:13.000000Z",
+          "endTime": "2023-05-10T06:00:14.500000Z",
+          "durationMs": 1500
+        }
+      ]
+    }
+  },
+  {
+    "apiVersion": "galasa-dev/v1alpha1",
+    "kind": "GalasaRun",
+    "metadata": {
+      "name": "U457",
+      "runId": "xxx543xxx"
+    },
+    "data": {
+      "status": "running",
+      "result": "",
+      "bundle": "myBundleId",
+      "testName": "myTestPackage.MyTestName",
+      "testShortName": "",
+      "requestor": "unitTesting",
+      "group": "myGroup",
+      "queuedTime": "2023-05-10T06:00:10.000000Z",
+      "startTime": "2023-05-10T06:00:12.000000Z",
+      "endTime": "",
+      "runLogUrl": "https://my.galasa.server/api/ras/runs/xxx543xxx/runlog",
+      "artifactsUrl": "https://my.galasa.server/api/ras/runs/xxx543xxx/artifacts",
+      "methods": []
+    }
+  }
+]
+`
+	assert.Equal(t, expectedFormattedOutput, actualFormattedOutput)
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// -----------------------------------------------------
+// Json lines format. Each run on a line of its own, in the same
+// structure as the json format, so runs can be read one at a time.
+const (
+	JSON_LINES_FORMATTER_NAME = "jsonl"
+)
+
+type JsonLinesFormatter struct {
+}
+
+func NewJsonLinesFormatter() RunsFormatter {
+	return new(JsonLinesFormatter)
+}
+
+func (*JsonLinesFormatter) GetName() string {
+	return JSON_LINES_FORMATTER_NAME
+}
+
+func (*JsonLinesFormatter) IsNeedingMethodDetails() bool {
+	return true
+}
+
+func (formatter *JsonLinesFormatter) NewRunsStream() RunsStream {
+	return newPagedRunsStream(formatter)
+}
+
+func (*JsonLinesFormatter) FormatRuns(runs []FormattableTest) (string, error) {
+	var result string
+	var err error
+	buff := strings.Builder{}
+
+	for _, run := range getStructuredRuns(runs) {
+		var jsonBytes []byte
+		jsonBytes, err = json.Marshal(run)
+		if err != nil {
+			break
+		}
+		buff.Write(jsonBytes)
+		buff.WriteString("\n")
+	}
+
+	if err == nil {
+		result = buff.String()
+	}
+	return result, err
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonLinesFormatterNoDataReturnsNothing(t *testing.T) {
+	// Given...
+	formatter := NewJsonLinesFormatter()
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(make([]FormattableTest, 0))
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "", actualFormattedOutput)
+}
+
+func TestJsonLinesFormatterWritesEachRunOnItsOwnLine(t *testing.T) {
+	// Given...
+	formatter := NewJsonLinesFormatter()
+	activeRun := createFormattableTestForRaw("xxx543xxx", "U457", "running", "", "myBundleId", "myTestPackage.MyTestName",
+		"unitTesting", "2023-05-10T06:00:10.000000Z", "2023-05-10T06:00:12.000000Z", "",
+		"https://my.galasa.server/api", false, "myGroup")
+	lostRun := FormattableTest{Name: "U458", Lost: true}
+	runs := []FormattableTest{createFormattableTestForStructuredFormats(), activeRun, lostRun}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(runs)
+
+	// Then...
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSuffix(actualFormattedOutput, "\n"), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], `{"apiVersion":"galasa-dev/v1alpha1","kind":"GalasaRun","metadata":{"name":"U456","runId":"xxx876xxx"},"data":{"status":"Finished","result":"Passed",`))
+	assert.Contains(t, lines[0], `"methods":[{"className":"myTestPackage.MyTestName","methodName":"myTestMethodName"`)
+	assert.True(t, strings.HasPrefix(lines[1], `{"apiVersion":"galasa-dev/v1alpha1","kind":"GalasaRun","metadata":{"name":"U457","runId":"xxx543xxx"},"data":{"status":"running",`))
+}
+
+func TestJsonLinesFormatterStreamsEachPageAsItArrives(t *testing.T) {
+	// Given...
+	formatter := NewJsonLinesFormatter().(StreamingRunsFormatter)
+	stream := formatter.NewRunsStream()
+
+	// When...
+	firstPage, err := stream.FormatRunsPage([]FormattableTest{createFormattableTestForStructuredFormats()})
+	assert.Nil(t, err)
+	end, err := stream.FormatRunsEnd()
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Contains(t, firstPage, `"name":"U456"`)
+	assert.Equal(t, 1, strings.Count(firstPage, "\n"))
+	assert.Equal(t, "", end)
+}
@@ -28,6 +28,10 @@ func (*RawFormatter) IsNeedingMethodDetails() bool {
 	return false
 }
 
+func (formatter *RawFormatter) NewRunsStream() RunsStream {
+	return newPagedRunsStream(formatter)
+}
+
 func (*RawFormatter) FormatRuns(runs []FormattableTest) (string, error) {
 	var result string = ""
 	var err error
@@ -45,6 +45,15 @@ const (
 	HEADER_METHOD_NAME    = "method"
 	HEADER_METHOD_TYPE    = "type"
 	HEADER_GROUP          = "group"
+	HEADER_ATTEMPTS       = "attempts"
+	HEADER_EXCUSED_BY     = "excused-by"
+
+	// Totals of the runs which did not pass, but which the result policy of 'runs submit' doesn't count as failures.
+	RUN_TOTAL_QUARANTINED = "Quarantined"
+	RUN_TOTAL_EXCUSED     = "Excused"
+
+	// The ExcusedBy of runs which are quarantined.
+	RUN_EXCUSED_BY_QUARANTINE = "quarantine"
 
 	RAS_RUNS_URL = "/ras/runs/"
 )
@@ -55,6 +64,7 @@ type FormattableTest struct {
 	RunId         string
 	Name          string
 	TestName      string
+	TestShortName string
 	Status        string
 	Result        string
 	StartTimeUTC  string
@@ -66,6 +76,13 @@ type FormattableTest struct {
 	Group         string
 	Methods       []galasaapi.TestMethod
 	Lost          bool
+
+	// The results of every attempt at running this test, oldest first, when it was re-submitted.
+	// Empty if the test was only attempted once.
+	AttemptResults []string
+
+	// Why the run is not counted as a failure although it did not pass, such as "quarantine". Empty if it is counted.
+	ExcusedBy string
 }
 
 func NewFormattableTest() FormattableTest {
@@ -86,6 +103,41 @@ type RunsFormatter interface {
 	IsNeedingMethodDetails() bool
 }
 
+// StreamingRunsFormatter - Implemented by formatters which can write runs a page at a time,
+// as each page of runs arrives, rather than once every run has been got.
+type StreamingRunsFormatter interface {
+	RunsFormatter
+	NewRunsStream() RunsStream
+}
+
+// RunsStream - Formats the pages of runs of a single query, in the order they arrive.
+type RunsStream interface {
+	FormatRunsPage(runs []FormattableTest) (string, error)
+
+	// FormatRunsEnd - Anything which is written once every page has been formatted, such as totals.
+	FormatRunsEnd() (string, error)
+}
+
+// pagedRunsStream - Streams the runs of formatters which format each run on its own,
+// by formatting each page as if it were all of the runs.
+type pagedRunsStream struct {
+	formatter RunsFormatter
+}
+
+func newPagedRunsStream(formatter RunsFormatter) RunsStream {
+	stream := new(pagedRunsStream)
+	stream.formatter = formatter
+	return stream
+}
+
+func (stream *pagedRunsStream) FormatRunsPage(runs []FormattableTest) (string, error) {
+	return stream.formatter.FormatRuns(runs)
+}
+
+func (*pagedRunsStream) FormatRunsEnd() (string, error) {
+	return "", nil
+}
+
 // -----------------------------------------------------
 // Functions for time formats and duration
 func formatTimeReadable(rawTime string) string {
@@ -168,6 +220,59 @@ func accumulateResults(resultCounts map[string]int, run FormattableTest) {
 
 }
 
+func isAnyTestExcused(runs []FormattableTest) bool {
+	isExcused := false
+	for _, run := range runs {
+		if run.ExcusedBy != "" {
+			isExcused = true
+			break
+		}
+	}
+	return isExcused
+}
+
+// generateExcusedTotalsReport - How many runs were quarantined, and how many were excused by the result policy
+// for some other reason. Empty if there were none.
+func generateExcusedTotalsReport(runs []FormattableTest) string {
+	quarantinedCount := 0
+	excusedCount := 0
+	for _, run := range runs {
+		if run.ExcusedBy == RUN_EXCUSED_BY_QUARANTINE {
+			quarantinedCount++
+		} else if run.ExcusedBy != "" {
+			excusedCount++
+		}
+	}
+
+	var totalsString string
+	if quarantinedCount > 0 {
+		totalsString += " " + RUN_TOTAL_QUARANTINED + ":" + strconv.Itoa(quarantinedCount)
+	}
+	if excusedCount > 0 {
+		totalsString += " " + RUN_TOTAL_EXCUSED + ":" + strconv.Itoa(excusedCount)
+	}
+	return totalsString
+}
+
+func isAnyTestReattempted(runs []FormattableTest) bool {
+	isReattempted := false
+	for _, run := range runs {
+		if len(run.AttemptResults) > 1 {
+			isReattempted = true
+			break
+		}
+	}
+	return isReattempted
+}
+
+func getAttemptsHistory(run FormattableTest) string {
+	history := run.Result
+	if len(run.AttemptResults) > 0 {
+		history = strings.Join(run.AttemptResults, ",")
+	}
+	return history
+}
+
 func initialiseResultMap() map[string]int {
 	resultCounts := make(map[string]int, 0)
 
@@ -0,0 +1,150 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"strconv"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+)
+
+// -----------------------------------------------------
+// The structure the json and yaml formats write each run as. Scripts rely on it,
+// so fields may be added, but existing fields must not be renamed or removed
+// without changing the api version.
+const (
+	STRUCTURED_RUN_API_VERSION = "galasa-dev/v1alpha1"
+	STRUCTURED_RUN_KIND        = "GalasaRun"
+)
+
+type StructuredRun struct {
+	ApiVersion string                `json:"apiVersion" yaml:"apiVersion"`
+	Kind       string                `json:"kind" yaml:"kind"`
+	Metadata   StructuredRunMetadata `json:"metadata" yaml:"metadata"`
+	Data       StructuredRunData     `json:"data" yaml:"data"`
+}
+
+type StructuredRunMetadata struct {
+	Name  string `json:"name" yaml:"name"`
+	RunId string `json:"runId" yaml:"runId"`
+}
+
+type StructuredRunData struct {
+	Status        string `json:"status" yaml:"status"`
+	Result        string `json:"result" yaml:"result"`
+	Bundle        string `json:"bundle" yaml:"bundle"`
+	TestName      string `json:"testName" yaml:"testName"`
+	TestShortName string `json:"testShortName" yaml:"testShortName"`
+	Requestor     string `json:"requestor" yaml:"requestor"`
+	Group         string `json:"group" yaml:"group"`
+
+	// Times are in RFC3339 format, in UTC. Those the run hasn't reached yet are empty,
+	// and the duration is left out until the run has finished.
+	QueuedTime        string `json:"queuedTime" yaml:"queuedTime"`
+	StartTime         string `json:"startTime" yaml:"startTime"`
+	EndTime           string `json:"endTime" yaml:"endTime"`
+	DurationMillisecs *int64 `json:"durationMs,omitempty" yaml:"durationMs,omitempty"`
+
+	RunLogUrl    string `json:"runLogUrl" yaml:"runLogUrl"`
+	ArtifactsUrl string `json:"artifactsUrl" yaml:"artifactsUrl"`
+
+	Methods []StructuredRunMethod `json:"methods" yaml:"methods"`
+
+	// Only set for runs submitted by 'runs submit', when the run was attempted more than once,
+	// or the result policy didn't count the run as a failure although it did not pass.
+	AttemptResults []string `json:"attemptResults,omitempty" yaml:"attemptResults,omitempty"`
+	ExcusedBy      string   `json:"excusedBy,omitempty" yaml:"excusedBy,omitempty"`
+}
+
+type StructuredRunMethod struct {
+	ClassName         string                `json:"className" yaml:"className"`
+	MethodName        string                `json:"methodName" yaml:"methodName"`
+	Type              string                `json:"type" yaml:"type"`
+	Status            string                `json:"status" yaml:"status"`
+	Result            string                `json:"result" yaml:"result"`
+	StartTime         string                `json:"startTime" yaml:"startTime"`
+	EndTime           string                `json:"endTime" yaml:"endTime"`
+	DurationMillisecs *int64                `json:"durationMs,omitempty" yaml:"durationMs,omitempty"`
+	Befores           []StructuredRunMethod `json:"befores,omitempty" yaml:"befores,omitempty"`
+	Afters            []StructuredRunMethod `json:"afters,omitempty" yaml:"afters,omitempty"`
+}
+
+// getStructuredRuns - The runs in the structure written by the json and yaml formats.
+// Lost runs are left out, as nothing is known about them but their names.
+func getStructuredRuns(runs []FormattableTest) []StructuredRun {
+	structuredRuns := make([]StructuredRun, 0, len(runs))
+	for _, run := range runs {
+		if !run.Lost {
+			structuredRuns = append(structuredRuns, newStructuredRun(run))
+		}
+	}
+	return structuredRuns
+}
+
+func newStructuredRun(run FormattableTest) StructuredRun {
+	runUrl := run.ApiServerUrl + RAS_RUNS_URL + run.RunId
+
+	return StructuredRun{
+		ApiVersion: STRUCTURED_RUN_API_VERSION,
+		Kind:       STRUCTURED_RUN_KIND,
+		Metadata: StructuredRunMetadata{
+			Name:  run.Name,
+			RunId: run.RunId,
+		},
+		Data: StructuredRunData{
+			Status:            run.Status,
+			Result:            run.Result,
+			Bundle:            run.Bundle,
+			TestName:          run.TestName,
+			TestShortName:     run.TestShortName,
+			Requestor:         run.Requestor,
+			Group:             run.Group,
+			QueuedTime:        run.QueuedTimeUTC,
+			StartTime:         run.StartTimeUTC,
+			EndTime:           run.EndTimeUTC,
+			DurationMillisecs: getDurationMillisecs(run.StartTimeUTC, run.EndTimeUTC),
+			RunLogUrl:         runUrl + "/runlog",
+			ArtifactsUrl:      runUrl + "/artifacts",
+			Methods:           newStructuredRunMethods(run.Methods),
+			AttemptResults:    run.AttemptResults,
+			ExcusedBy:         run.ExcusedBy,
+		},
+	}
+}
+
+func newStructuredRunMethods(methods []galasaapi.TestMethod) []StructuredRunMethod {
+	structuredMethods := make([]StructuredRunMethod, 0, len(methods))
+	for _, method := range methods {
+		structuredMethod := StructuredRunMethod{
+			ClassName:         method.GetClassName(),
+			MethodName:        method.GetMethodName(),
+			Type:              method.GetType(),
+			Status:            method.GetStatus(),
+			Result:            method.GetResult(),
+			StartTime:         method.GetStartTime(),
+			EndTime:           method.GetEndTime(),
+			DurationMillisecs: getDurationMillisecs(method.GetStartTime(), method.GetEndTime()),
+		}
+		if len(method.Befores) > 0 {
+			structuredMethod.Befores = newStructuredRunMethods(method.Befores)
+		}
+		if len(method.Afters) > 0 {
+			structuredMethod.Afters = newStructuredRunMethods(method.Afters)
+		}
+		structuredMethods = append(structuredMethods, structuredMethod)
+	}
+	return structuredMethods
+}
+
+// getDurationMillisecs - nil unless both times are known.
+func getDurationMillisecs(startTimeUTC string, endTimeUTC string) *int64 {
+	var durationMillisecs *int64
+	duration, err := strconv.ParseInt(getDuration(startTimeUTC, endTimeUTC), 10, 64)
+	if err == nil {
+		durationMillisecs = &duration
+	}
+	return durationMillisecs
+}
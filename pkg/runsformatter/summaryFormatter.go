@@ -19,6 +19,7 @@ const (
 )
 
 type SummaryFormatter struct {
+	utils.TableLayout
 }
 
 func NewSummaryFormatter() RunsFormatter {
@@ -33,45 +34,131 @@ func (*SummaryFormatter) IsNeedingMethodDetails() bool {
 	return false
 }
 
-func (*SummaryFormatter) FormatRuns(testResultsData []FormattableTest) (string, error) {
+func (formatter *SummaryFormatter) FormatRuns(testResultsData []FormattableTest) (string, error) {
+	var result string
+
+	log.Printf("Formatter passed %v runs to show.\n", len(testResultsData))
+
+	// All of the runs are a stream with a single page.
+	stream := formatter.NewRunsStream()
+	tableText, err := stream.FormatRunsPage(testResultsData)
+	if err == nil {
+		var totalsText string
+		totalsText, err = stream.FormatRunsEnd()
+		if err == nil {
+			result = tableText + totalsText
+		}
+	}
+	return result, err
+}
+
+// summaryRunsStream - Writes the table of runs a page at a time, then the totals once every page is written.
+type summaryRunsStream struct {
+	formatter   *SummaryFormatter
+	tableStream *utils.TableStream
+
+	// Which optional columns are shown is decided by the first page of runs.
+	isTableStarted     bool
+	isShowingAttempts  bool
+	isShowingExcusedBy bool
+
+	totalResults    int
+	resultCountsMap map[string]int
+	excusedRuns     []FormattableTest
+}
+
+func (formatter *SummaryFormatter) NewRunsStream() RunsStream {
+	stream := new(summaryRunsStream)
+	stream.formatter = formatter
+	stream.tableStream = formatter.NewTableStream()
+	stream.resultCountsMap = initialiseResultMap()
+	return stream
+}
+
+func (stream *summaryRunsStream) FormatRunsPage(testResultsData []FormattableTest) (string, error) {
 	var result string
 	var err error
 	buff := strings.Builder{}
-	totalResults := len(testResultsData)
-	resultCountsMap := initialiseResultMap()
 
-	log.Printf("Formatter passed %v runs to show.\n", len(testResultsData))
+	if len(testResultsData) > 0 {
+		if !stream.isTableStarted {
+			tableOptions := stream.formatter.GetTableOptions()
 
-	if totalResults > 0 {
-		var table [][]string
+			// Only show the history of attempts if some tests were re-submitted,
+			// or the column was asked for.
+			stream.isShowingAttempts = isAnyTestReattempted(testResultsData) || tableOptions.IsColumnChosen(HEADER_ATTEMPTS)
 
-		var headers = []string{HEADER_SUBMITTED_TIME, HEADER_RUNNAME, HEADER_REQUESTOR, HEADER_STATUS, HEADER_RESULT, HEADER_TEST_NAME, HEADER_GROUP}
+			// Only show why runs are excused if the result policy excused some,
+			// or the column was asked for.
+			stream.isShowingExcusedBy = isAnyTestExcused(testResultsData) || tableOptions.IsColumnChosen(HEADER_EXCUSED_BY)
 
-		table = append(table, headers)
+			stream.isTableStarted = true
+		}
+
+		stream.totalResults += len(testResultsData)
 		for _, run := range testResultsData {
 			if run.Lost {
-				resultCountsMap[RUN_RESULT_LOST] += 1
+				stream.resultCountsMap[RUN_RESULT_LOST] += 1
 			} else {
-				var line []string
-				submittedTime := run.QueuedTimeUTC
-				submittedTimeReadable := formatTimeReadable(submittedTime)
-
-				accumulateResults(resultCountsMap, run)
-
-				line = append(line, submittedTimeReadable, run.Name, run.Requestor, run.Status, run.Result, run.TestName, run.Group)
-				table = append(table, line)
+				accumulateResults(stream.resultCountsMap, run)
+			}
+			if run.ExcusedBy != "" {
+				stream.excusedRuns = append(stream.excusedRuns, run)
 			}
 		}
 
-		columnLengths := utils.CalculateMaxLengthOfEachColumn(table)
-		utils.WriteFormattedTableToStringBuilder(table, &buff, columnLengths)
+		err = stream.tableStream.WriteRows(getRunsTable(testResultsData, stream.isShowingAttempts, stream.isShowingExcusedBy), &buff)
+	}
+
+	if err == nil {
+		result = buff.String()
+	}
+	return result, err
+}
 
+func (stream *summaryRunsStream) FormatRunsEnd() (string, error) {
+	buff := strings.Builder{}
+
+	if stream.totalResults > 0 {
 		buff.WriteString("\n")
 	}
 
-	totalReportString := generateResultTotalsReport(totalResults, resultCountsMap)
+	totalReportString := generateResultTotalsReport(stream.totalResults, stream.resultCountsMap) + generateExcusedTotalsReport(stream.excusedRuns)
 	buff.WriteString(totalReportString + "\n")
 
-	result = buff.String()
-	return result, err
+	return buff.String(), nil
+}
+
+// getRunsTable - The table of runs which the summary and csv formats write.
+// Lost runs are left out.
+func getRunsTable(runs []FormattableTest, isShowingAttempts bool, isShowingExcusedBy bool) [][]string {
+	var table [][]string
+
+	var headers = []string{HEADER_SUBMITTED_TIME, HEADER_RUNNAME, HEADER_REQUESTOR, HEADER_STATUS, HEADER_RESULT, HEADER_TEST_NAME, HEADER_GROUP}
+	if isShowingAttempts {
+		headers = append(headers, HEADER_ATTEMPTS)
+	}
+	if isShowingExcusedBy {
+		headers = append(headers, HEADER_EXCUSED_BY)
+	}
+
+	table = append(table, headers)
+	for _, run := range runs {
+		if !run.Lost {
+			var line []string
+			submittedTime := run.QueuedTimeUTC
+			submittedTimeReadable := formatTimeReadable(submittedTime)
+
+			line = append(line, submittedTimeReadable, run.Name, run.Requestor, run.Status, run.Result, run.TestName, run.Group)
+			if isShowingAttempts {
+				line = append(line, getAttemptsHistory(run))
+			}
+			if isShowingExcusedBy {
+				line = append(line, run.ExcusedBy)
+			}
+			table = append(table, line)
+		}
+	}
+
+	return table
 }
@@ -229,3 +229,94 @@ func TestSummaryFormatterHasTestWithoutTimeStamps(t *testing.T) {
 
 	assert.Equal(t, expectedFormattedOutput, actualFormattedOutput)
 }
+
+func TestSummaryFormatterShowsAttemptsWhenATestWasReattempted(t *testing.T) {
+	formatter := NewSummaryFormatter()
+
+	formattableTest := make([]FormattableTest, 0)
+	formattableTest1 := createFormattableTestForSummary("2023-05-04T10:55:29.545323Z", "U456", "MyTestName", "Finished", "Passed", "myUserId1", false, "none")
+	formattableTest1.AttemptResults = []string{"EnvFail", "Passed"}
+	formattableTest2 := createFormattableTestForSummary("2023-05-04T10:55:29.545323Z", "U457", "MyTestName2", "Finished", "Failed", "myUserId1", false, "none")
+	formattableTest = append(formattableTest, formattableTest1, formattableTest2)
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(formattableTest)
+
+	assert.Nil(t, err)
+	expectedFormattedOutput :=
+		"submitted-time(UTC) name requestor status   result test-name   group attempts\n" +
+			"2023-05-04 10:55:29 U456 myUserId1 Finished Passed MyTestName  none  EnvFail,Passed\n" +
+			"2023-05-04 10:55:29 U457 myUserId1 Finished Failed MyTestName2 none  Failed\n" +
+			"\n" +
+			"Total:2 Passed:1 Failed:1\n"
+	assert.Equal(t, expectedFormattedOutput, actualFormattedOutput)
+}
+
+func TestSummaryFormatterShowsExcusedByWhenATestWasExcused(t *testing.T) {
+	formatter := NewSummaryFormatter()
+
+	formattableTest := make([]FormattableTest, 0)
+	formattableTest1 := createFormattableTestForSummary("2023-05-04T10:55:29.545323Z", "U456", "MyTestName", "Finished", "Failed", "myUserId1", false, "none")
+	formattableTest1.ExcusedBy = RUN_EXCUSED_BY_QUARANTINE
+	formattableTest2 := createFormattableTestForSummary("2023-05-04T10:55:29.545323Z", "U457", "MyTestName2", "Finished", "EnvFail", "myUserId1", false, "none")
+	formattableTest2.ExcusedBy = "policy"
+	formattableTest3 := createFormattableTestForSummary("2023-05-04T10:55:29.545323Z", "U458", "MyTestName3", "Finished", "Passed", "myUserId1", false, "none")
+	formattableTest = append(formattableTest, formattableTest1, formattableTest2, formattableTest3)
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(formattableTest)
+
+	assert.Nil(t, err)
+	expectedFormattedOutput :=
+		"submitted-time(UTC) name requestor status   result  test-name   group excused-by\n" +
+			"2023-05-04 10:55:29 U456 myUserId1 Finished Failed  MyTestName  none  quarantine\n" +
+			"2023-05-04 10:55:29 U457 myUserId1 Finished EnvFail MyTestName2 none  policy\n" +
+			"2023-05-04 10:55:29 U458 myUserId1 Finished Passed  MyTestName3 none  \n" +
+			"\n" +
+			"Total:3 Passed:1 Failed:1 EnvFail:1 Quarantined:1 Excused:1\n"
+	assert.Equal(t, expectedFormattedOutput, actualFormattedOutput)
+}
+
+func TestSummaryFormatterStreamsPagesThenWritesTotals(t *testing.T) {
+	// Given...
+	formatter := NewSummaryFormatter().(StreamingRunsFormatter)
+	stream := formatter.NewRunsStream()
+	firstPage := []FormattableTest{
+		createFormattableTestForSummary("2023-05-04T10:45:29.545323Z", "U123", "TestName", "Finished", "Passed", "myUserId1", false, "none"),
+	}
+	secondPage := []FormattableTest{
+		createFormattableTestForSummary("2023-05-04T10:55:29.545323Z", "U456", "MyLongerTestName", "Finished", "Failed", "myUserId2", false, "none"),
+	}
+
+	// When...
+	firstPageOutput, err := stream.FormatRunsPage(firstPage)
+	assert.Nil(t, err)
+	secondPageOutput, err := stream.FormatRunsPage(secondPage)
+	assert.Nil(t, err)
+	endOutput, err := stream.FormatRunsEnd()
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t,
+		"submitted-time(UTC) name requestor status   result test-name group\n"+
+			"2023-05-04 10:45:29 U123 myUserId1 Finished Passed TestName  none\n", firstPageOutput)
+	assert.Equal(t,
+		"2023-05-04 10:55:29 U456 myUserId2 Finished Failed MyLongerTestName none\n", secondPageOutput)
+	assert.Equal(t, "\nTotal:2 Passed:1 Failed:1\n", endOutput)
+}
+
+func TestSummaryFormatterStreamWithNoRunsWritesZeroTotal(t *testing.T) {
+	// Given...
+	formatter := NewSummaryFormatter().(StreamingRunsFormatter)
+	stream := formatter.NewRunsStream()
+
+	// When...
+	pageOutput, err := stream.FormatRunsPage(make([]FormattableTest, 0))
+	assert.Nil(t, err)
+	endOutput, err := stream.FormatRunsEnd()
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "", pageOutput)
+	assert.Equal(t, "Total:0\n", endOutput)
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"github.com/galasa-dev/cli/pkg/templateformatter"
+)
+
+// -----------------------------------------------------
+// Go template format. The template is run against the same
+// data model as the yaml format, once for each run.
+type TemplateFormatter struct {
+	formatter *templateformatter.TemplateFormatter
+}
+
+func NewTemplateFormatter(format string) (RunsFormatter, error) {
+	var runsFormatter RunsFormatter
+
+	formatter, err := templateformatter.NewTemplateFormatter(format)
+	if err == nil {
+		templateFormatter := new(TemplateFormatter)
+		templateFormatter.formatter = formatter
+		runsFormatter = templateFormatter
+	}
+
+	return runsFormatter, err
+}
+
+func (templateFormatter *TemplateFormatter) GetName() string {
+	return templateFormatter.formatter.GetName()
+}
+
+func (*TemplateFormatter) IsNeedingMethodDetails() bool {
+	return true
+}
+
+func (templateFormatter *TemplateFormatter) NewRunsStream() RunsStream {
+	return newPagedRunsStream(templateFormatter)
+}
+
+func (templateFormatter *TemplateFormatter) FormatRuns(runs []FormattableTest) (string, error) {
+	items := make([]interface{}, 0)
+	for _, run := range getStructuredRuns(runs) {
+		items = append(items, run)
+	}
+	return templateFormatter.formatter.FormatItems(items)
+}
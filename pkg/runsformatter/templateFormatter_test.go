@@ -0,0 +1,58 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateFormatterNoDataReturnsNothing(t *testing.T) {
+	// Given...
+	formatter, err := NewTemplateFormatter("template={{.metadata.name}}")
+	assert.Nil(t, err)
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(make([]FormattableTest, 0))
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "", actualFormattedOutput)
+}
+
+func TestTemplateFormatterSeesSameFieldsAsYamlFormat(t *testing.T) {
+	// Given...
+	formatter, err := NewTemplateFormatter(`template={{.kind}} {{.metadata.name}} {{.data.result}} {{join .data.attemptResults "/"}}`)
+	assert.Nil(t, err)
+	run := createFormattableTestForStructuredFormats()
+	run.AttemptResults = []string{"EnvFail", "Passed"}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns([]FormattableTest{run})
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "GalasaRun U456 Passed EnvFail/Passed\n", actualFormattedOutput)
+	assert.True(t, formatter.IsNeedingMethodDetails())
+	assert.Equal(t, "template", formatter.GetName())
+}
+
+func TestTemplateFormatterSkipsLostRuns(t *testing.T) {
+	// Given...
+	formatter, err := NewTemplateFormatter("template={{.metadata.name}}")
+	assert.Nil(t, err)
+	run := createFormattableTestForStructuredFormats()
+	lostRun := createFormattableTestForStructuredFormats()
+	lostRun.Lost = true
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns([]FormattableTest{run, lostRun})
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "U456\n", actualFormattedOutput)
+}
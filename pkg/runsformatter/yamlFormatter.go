@@ -0,0 +1,58 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// -----------------------------------------------------
+// Yaml format. A yaml document for each run.
+const (
+	YAML_FORMATTER_NAME = "yaml"
+)
+
+type YamlFormatter struct {
+}
+
+func NewYamlFormatter() RunsFormatter {
+	return new(YamlFormatter)
+}
+
+func (*YamlFormatter) GetName() string {
+	return YAML_FORMATTER_NAME
+}
+
+func (*YamlFormatter) IsNeedingMethodDetails() bool {
+	return true
+}
+
+func (*YamlFormatter) FormatRuns(runs []FormattableTest) (string, error) {
+	var err error
+	buff := strings.Builder{}
+
+	for index, run := range getStructuredRuns(runs) {
+		runString := ""
+
+		if index > 0 {
+			runString += "---\n"
+		}
+
+		var yamlRepresentationBytes []byte
+		yamlRepresentationBytes, err = yaml.Marshal(run)
+		if err == nil {
+			yamlStr := string(yamlRepresentationBytes)
+			runString += yamlStr
+		}
+
+		buff.WriteString(runString)
+	}
+
+	result := buff.String()
+	return result, err
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package runsformatter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYamlFormatterNoDataReturnsNothing(t *testing.T) {
+	// Given...
+	formatter := NewYamlFormatter()
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns(make([]FormattableTest, 0))
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "", actualFormattedOutput)
+}
+
+func TestYamlFormatterReturnsExpectedFormat(t *testing.T) {
+	// Given...
+	formatter := NewYamlFormatter()
+	run := createFormattableTestForStructuredFormats()
+	run.AttemptResults = []string{"EnvFail", "Passed"}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns([]FormattableTest{run})
+
+	// Then...
+	assert.Nil(t, err)
+	expectedFormattedOutput := `apiVersion: galasa-dev/v1alpha1
+kind: GalasaRun
+metadata:
+    name: U456
+    runId: xxx876xxx
+data:
+    status: Finished
+    result: Passed
+    bundle: myBundleId
+    testName: myTestPackage.MyTestName
+    testShortName: MyTestName
+    requestor: unitTesting
+    group: myGroup
+    queuedTime: "2023-05-10T06:00:10.000000Z"
+    startTime: "2023-05-10T06:00:12.000000Z"
+    endTime: "2023-05-10T06:00:15.000000Z"
+    durationMs: 3000
+    runLogUrl: https://my.galasa.server/api/ras/runs/xxx876xxx/runlog
+    artifactsUrl: https://my.galasa.server/api/ras/runs/xxx876xxx/artifacts
+    methods:
+        - className: myTestPackage.MyTestName
+          methodName: myTestMethodName
+          type: test
+          status: finished
+          result: Passed
+          startTime: "2023-05-10T06:00:13.000000Z"
+          endTime: "2023-05-10T06:00:14.500000Z"
+          durationMs: 1500
+    attemptResults:
+        - EnvFail
+        - Passed
+`
+	assert.Equal(t, expectedFormattedOutput, actualFormattedOutput)
+}
+
+func TestYamlFormatterSeparatesRunsIntoDocuments(t *testing.T) {
+	// Given...
+	formatter := NewYamlFormatter()
+	run1 := createFormattableTestForStructuredFormats()
+	run2 := createFormattableTestForStructuredFormats()
+	run2.Name = "U457"
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatRuns([]FormattableTest{run1, run2})
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Contains(t, actualFormattedOutput, "    name: U456\n")
+	assert.Contains(t, actualFormattedOutput, "---\napiVersion: galasa-dev/v1alpha1\nkind: GalasaRun\nmetadata:\n    name: U457\n")
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/secretsformatter"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
+	"github.com/galasa-dev/cli/pkg/utils"
 )
 
 var (
@@ -27,6 +29,7 @@ var (
 func GetSecrets(
 	secretName string,
 	format string,
+	tableOptions *utils.TableOptions,
 	console spi.Console,
 	apiClient *galasaapi.APIClient,
 	byteReader spi.ByteReader,
@@ -36,6 +39,9 @@ func GetSecrets(
 	secrets := make([]galasaapi.GalasaSecret, 0)
 
 	chosenFormatter, err = validateFormatFlag(format)
+	if err == nil {
+		err = utils.ApplyTableOptions(chosenFormatter, tableOptions)
+	}
 	if err == nil {
 		if secretName != "" {
 			// The user has provided a secret name, so try to get that secret
@@ -164,9 +170,11 @@ func createFormatters() map[string]secretsformatter.SecretsFormatter {
 	formatters := make(map[string]secretsformatter.SecretsFormatter, 0)
 	summaryFormatter := secretsformatter.NewSecretSummaryFormatter()
 	yamlFormatter := secretsformatter.NewSecretYamlFormatter()
+	csvFormatter := secretsformatter.NewSecretCsvFormatter()
 
 	formatters[summaryFormatter.GetName()] = summaryFormatter
 	formatters[yamlFormatter.GetName()] = yamlFormatter
+	formatters[csvFormatter.GetName()] = csvFormatter
 
 	return formatters
 }
@@ -193,10 +201,17 @@ func GetFormatterNamesAsString() string {
 func validateFormatFlag(outputFormatString string) (secretsformatter.SecretsFormatter, error) {
 	var err error
 
-	chosenFormatter, isPresent := formatters[outputFormatString]
+	var chosenFormatter secretsformatter.SecretsFormatter
 
-	if !isPresent {
-		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_OUTPUT_FORMAT, outputFormatString, GetFormatterNamesAsString())
+	if templateformatter.IsTemplateFormat(outputFormatString) {
+		chosenFormatter, err = secretsformatter.NewSecretTemplateFormatter(outputFormatString)
+	} else {
+		var isPresent bool
+		chosenFormatter, isPresent = formatters[outputFormatString]
+
+		if !isPresent {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_OUTPUT_FORMAT, outputFormatString, GetFormatterNamesAsString())
+		}
 	}
 
 	return chosenFormatter, err
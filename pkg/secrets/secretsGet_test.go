@@ -102,6 +102,7 @@ func TestCanGetASecretByName(t *testing.T) {
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -152,6 +153,7 @@ func TestCanGetASecretByNameInYamlFormat(t *testing.T) {
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -205,6 +207,7 @@ func TestCanGetAllSecretsOk(t *testing.T) {
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -241,6 +244,7 @@ func TestGetASecretWithUnknownFormatDisplaysError(t *testing.T) {
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -273,6 +277,7 @@ func TestGetASecretWithBlankNameDisplaysError(t *testing.T) {
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -312,6 +317,7 @@ func TestGetNonExistantSecretDisplaysError(t *testing.T) {
     err := GetSecrets(
         nonExistantSecret,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -351,6 +357,7 @@ func TestSecretsGetFailsWithNoExplanationErrorPayloadGivesCorrectMessage(t *test
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -391,6 +398,7 @@ func TestSecretsGetFailsWithNonJsonContentTypeExplanationErrorPayloadGivesCorrec
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -433,6 +441,7 @@ func TestSecretsGetFailsWithBadlyFormedJsonContentExplanationErrorPayloadGivesCo
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -476,6 +485,7 @@ func TestSecretsGetFailsWithFailureToReadResponseBodyGivesCorrectMessage(t *test
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -516,6 +526,7 @@ func TestGetAllSecretsFailsWithNoExplanationErrorPayloadGivesCorrectMessage(t *t
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -555,6 +566,7 @@ func TestGetAllSecretsFailsWithNonJsonContentTypeExplanationErrorPayloadGivesCor
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -596,6 +608,7 @@ func TestGetAllSecretsFailsWithBadlyFormedJsonContentExplanationErrorPayloadGive
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -638,6 +651,7 @@ func TestGetAllSecretsFailsWithFailureToReadResponseBodyGivesCorrectMessage(t *t
     err := GetSecrets(
         secretName,
         outputFormat,
+        nil,
         console,
         apiClient,
         mockByteReader)
@@ -649,3 +663,134 @@ func TestGetAllSecretsFailsWithFailureToReadResponseBodyGivesCorrectMessage(t *t
     assert.Contains(t, errorMsg, "GAL1181E")
     assert.Contains(t, errorMsg, "Error details from the server could not be read")
 }
+
+func TestCanGetASecretByNameInTemplateFormat(t *testing.T) {
+    // Given...
+    secretName := "SYSTEM1"
+    description := "my SYSTEM1 secret"
+    outputFormat := "template={{.metadata.name}} {{.metadata.type}} {{formatDate .metadata.lastUpdatedTime \"2006-01-02\"}}"
+
+    // Create the mock secret to return
+    secret := createMockGalasaSecret(secretName, description)
+    secretBytes, _ := json.Marshal(secret)
+    secretJson := string(secretBytes)
+
+    // Create the expected HTTP interactions with the API server
+    getSecretInteraction := utils.NewHttpInteraction("/secrets/" + secretName, http.MethodGet)
+    getSecretInteraction.WriteHttpResponseFunc = func(writer http.ResponseWriter, req *http.Request) {
+        writer.Header().Set("Content-Type", "application/json")
+        writer.WriteHeader(http.StatusOK)
+        writer.Write([]byte(secretJson))
+    }
+
+    interactions := []utils.HttpInteraction{
+        getSecretInteraction,
+    }
+
+    server := utils.NewMockHttpServer(t, interactions)
+    defer server.Server.Close()
+
+    console := utils.NewMockConsole()
+    apiServerUrl := server.Server.URL
+    apiClient := api.InitialiseAPI(apiServerUrl)
+    mockByteReader := utils.NewMockByteReader()
+
+    // When...
+    err := GetSecrets(
+        secretName,
+        outputFormat,
+        nil,
+        console,
+        apiClient,
+        mockByteReader)
+
+    // Then...
+    assert.Nil(t, err, "GetSecrets returned an unexpected error")
+    assert.Equal(t, "SYSTEM1 UsernamePassword 2024-01-01\n", console.ReadText())
+}
+
+func TestCanGetAllSecretsInCsvFormatWithChosenColumnsSorted(t *testing.T) {
+    // Given...
+    secretName := ""
+    outputFormat := "csv"
+    tableOptions := utils.NewTableOptions([]string{"name", "description"}, "name", false)
+
+    // Create the mock secrets to return
+    secrets := make([]galasaapi.GalasaSecret, 0)
+    secret1 := createMockGalasaSecret("BOB", "my BOB secret, for tests")
+    secret2 := createMockGalasaSecret("BLAH", "my BLAH secret")
+
+    secrets = append(secrets, secret1, secret2)
+    secretsBytes, _ := json.Marshal(secrets)
+    secretsJson := string(secretsBytes)
+
+    // Create the expected HTTP interactions with the API server
+    getSecretInteraction := utils.NewHttpInteraction("/secrets", http.MethodGet)
+    getSecretInteraction.WriteHttpResponseFunc = func(writer http.ResponseWriter, req *http.Request) {
+        writer.Header().Set("Content-Type", "application/json")
+        writer.WriteHeader(http.StatusOK)
+        writer.Write([]byte(secretsJson))
+    }
+
+    interactions := []utils.HttpInteraction{
+        getSecretInteraction,
+    }
+
+    server := utils.NewMockHttpServer(t, interactions)
+    defer server.Server.Close()
+
+    console := utils.NewMockConsole()
+    apiServerUrl := server.Server.URL
+    apiClient := api.InitialiseAPI(apiServerUrl)
+    mockByteReader := utils.NewMockByteReader()
+
+    // When...
+    err := GetSecrets(
+        secretName,
+        outputFormat,
+        tableOptions,
+        console,
+        apiClient,
+        mockByteReader)
+
+    // Then...
+    expectedOutput :=
+`name,description
+BLAH,my BLAH secret
+BOB,"my BOB secret, for tests"
+`
+    assert.Nil(t, err, "GetSecrets returned an unexpected error")
+    assert.Equal(t, expectedOutput, console.ReadText())
+}
+
+func TestGetSecretsInYamlFormatWithNoHeadersDisplaysError(t *testing.T) {
+    // Given...
+    secretName := ""
+    outputFormat := "yaml"
+    tableOptions := utils.NewTableOptions(nil, "", true)
+
+    // The client-side validation should fail, so no HTTP interactions will be performed
+    interactions := []utils.HttpInteraction{}
+
+    server := utils.NewMockHttpServer(t, interactions)
+    defer server.Server.Close()
+
+    console := utils.NewMockConsole()
+    apiServerUrl := server.Server.URL
+    apiClient := api.InitialiseAPI(apiServerUrl)
+    mockByteReader := utils.NewMockByteReader()
+
+    // When...
+    err := GetSecrets(
+        secretName,
+        outputFormat,
+        tableOptions,
+        console,
+        apiClient,
+        mockByteReader)
+
+    // Then...
+    assert.NotNil(t, err, "GetSecrets did not return an error as expected")
+    assert.Contains(t, err.Error(), "GAL1313E")
+    assert.Contains(t, err.Error(), "'yaml'")
+}
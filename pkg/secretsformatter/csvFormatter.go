@@ -0,0 +1,36 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package secretsformatter
+
+import (
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// -----------------------------------------------------
+// CSV format. The table the summary format writes, as comma-separated values,
+// without the totals.
+type SecretCsvFormatter struct {
+	utils.TableLayout
+}
+
+func NewSecretCsvFormatter() SecretsFormatter {
+	return new(SecretCsvFormatter)
+}
+
+func (*SecretCsvFormatter) GetName() string {
+	return utils.CSV_FORMATTER_NAME
+}
+
+func (formatter *SecretCsvFormatter) FormatSecrets(secrets []galasaapi.GalasaSecret) (string, error) {
+	buff := strings.Builder{}
+
+	err := formatter.WriteCsvTable(getSecretsTable(secrets), &buff)
+
+	return buff.String(), err
+}
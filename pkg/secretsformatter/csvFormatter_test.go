@@ -0,0 +1,54 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package secretsformatter
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretCsvFormatterWritesSecretsWithoutTotals(t *testing.T) {
+	// Given...
+	formatter := NewSecretCsvFormatter()
+	secrets := []galasaapi.GalasaSecret{
+		createMockGalasaSecretWithDescription("SYSTEM1", "my system1 secret"),
+		createMockGalasaSecretWithDescription("SYSTEM2", ""),
+	}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatSecrets(secrets)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "name,type,last-updated(UTC),last-updated-by,description\n"+
+		"SYSTEM1,UsernamePassword,2024-01-01 10:00:00,dummy-username,my system1 secret\n"+
+		"SYSTEM2,UsernamePassword,2024-01-01 10:00:00,dummy-username,\n", actualFormattedOutput)
+}
+
+func TestSecretSummaryFormatterWithTableOptionsLeavesOutHeaders(t *testing.T) {
+	// Given...
+	formatter := NewSecretSummaryFormatter()
+	err := utils.ApplyTableOptions(formatter, utils.NewTableOptions([]string{"name", "description"}, "name:desc", true))
+	assert.Nil(t, err)
+
+	secrets := []galasaapi.GalasaSecret{
+		createMockGalasaSecretWithDescription("SYSTEM1", "my system1 secret"),
+		createMockGalasaSecretWithDescription("SYSTEM2", "my system2 secret"),
+	}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatSecrets(secrets)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "SYSTEM2 my system2 secret\n"+
+		"SYSTEM1 my system1 secret\n"+
+		"\n"+
+		"Total:2\n", actualFormattedOutput)
+}
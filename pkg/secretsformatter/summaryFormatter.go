@@ -20,6 +20,7 @@ const (
 )
 
 type SecretSummaryFormatter struct {
+	utils.TableLayout
 }
 
 func NewSecretSummaryFormatter() SecretsFormatter {
@@ -30,49 +31,55 @@ func (*SecretSummaryFormatter) GetName() string {
 	return SUMMARY_FORMATTER_NAME
 }
 
-func (*SecretSummaryFormatter) FormatSecrets(secrets []galasaapi.GalasaSecret) (string, error) {
+func (formatter *SecretSummaryFormatter) FormatSecrets(secrets []galasaapi.GalasaSecret) (string, error) {
 	var result string = ""
 	var err error = nil
 	buff := strings.Builder{}
 	totalSecrets := len(secrets)
 
 	if totalSecrets > 0 {
-		var table [][]string
-
-		var headers = []string{
-			HEADER_SECRET_NAME,
-			HEADER_SECRET_TYPE,
-			HEADER_LAST_UPDATED_TIME,
-			HEADER_LAST_UPDATED_BY,
-			HEADER_SECRET_DESCRIPTION,
-		}
-
-		table = append(table, headers)
-		for _, secret := range secrets {
-			var line []string
-			name := secret.Metadata.GetName()
-			secretType := secret.Metadata.GetType()
-			secretDescription := secret.Metadata.GetDescription()
-			lastUpdatedTime := secret.Metadata.GetLastUpdatedTime()
-
-			lastUpdatedTimeReadable := ""
-			if !lastUpdatedTime.IsZero() {
-				lastUpdatedTimeReadable = lastUpdatedTime.Format("2006-01-02 15:04:05")
-			}
-			lastUpdatedBy := secret.Metadata.GetLastUpdatedBy()
-
-			line = append(line, name, string(secretType), lastUpdatedTimeReadable, lastUpdatedBy, secretDescription)
-			table = append(table, line)
-		}
-
-		columnLengths := utils.CalculateMaxLengthOfEachColumn(table)
-		utils.WriteFormattedTableToStringBuilder(table, &buff, columnLengths)
+		err = formatter.WriteTable(getSecretsTable(secrets), &buff)
 
 		buff.WriteString("\n")
 
 	}
 	buff.WriteString("Total:" + strconv.Itoa(totalSecrets) + "\n")
 
-	result = buff.String()
+	if err == nil {
+		result = buff.String()
+	}
 	return result, err
 }
+
+// getSecretsTable - The table of secrets which the summary and csv formats write.
+func getSecretsTable(secrets []galasaapi.GalasaSecret) [][]string {
+	var table [][]string
+
+	var headers = []string{
+		HEADER_SECRET_NAME,
+		HEADER_SECRET_TYPE,
+		HEADER_LAST_UPDATED_TIME,
+		HEADER_LAST_UPDATED_BY,
+		HEADER_SECRET_DESCRIPTION,
+	}
+
+	table = append(table, headers)
+	for _, secret := range secrets {
+		var line []string
+		name := secret.Metadata.GetName()
+		secretType := secret.Metadata.GetType()
+		secretDescription := secret.Metadata.GetDescription()
+		lastUpdatedTime := secret.Metadata.GetLastUpdatedTime()
+
+		lastUpdatedTimeReadable := ""
+		if !lastUpdatedTime.IsZero() {
+			lastUpdatedTimeReadable = lastUpdatedTime.Format("2006-01-02 15:04:05")
+		}
+		lastUpdatedBy := secret.Metadata.GetLastUpdatedBy()
+
+		line = append(line, name, string(secretType), lastUpdatedTimeReadable, lastUpdatedBy, secretDescription)
+		table = append(table, line)
+	}
+
+	return table
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package secretsformatter
+
+import (
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
+)
+
+// -----------------------------------------------------
+// Go template format. The template is run against each secret in turn.
+type SecretTemplateFormatter struct {
+	formatter *templateformatter.TemplateFormatter
+}
+
+func NewSecretTemplateFormatter(format string) (SecretsFormatter, error) {
+	var chosenFormatter SecretsFormatter
+
+	formatter, err := templateformatter.NewTemplateFormatter(format)
+	if err == nil {
+		templateFormatter := new(SecretTemplateFormatter)
+		templateFormatter.formatter = formatter
+		chosenFormatter = templateFormatter
+	}
+
+	return chosenFormatter, err
+}
+
+func (templateFormatter *SecretTemplateFormatter) GetName() string {
+	return templateFormatter.formatter.GetName()
+}
+
+func (templateFormatter *SecretTemplateFormatter) FormatSecrets(secrets []galasaapi.GalasaSecret) (string, error) {
+	items := make([]interface{}, 0)
+	for _, secret := range secrets {
+		items = append(items, secret)
+	}
+	return templateFormatter.formatter.FormatItems(items)
+}
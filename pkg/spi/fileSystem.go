@@ -15,6 +15,10 @@ type FileSystem interface {
 	ReadBinaryFile(filePath string) ([]byte, error)
 	WriteTextFile(targetFilePath string, desiredContents string) error
 	WriteBinaryFile(targetFilePath string, desiredContents []byte) error
+
+	// WritePrivateBinaryFile writes a file which only its owner can read or write, for files which hold secrets.
+	// Fails without writing the contents if the file can't be made private.
+	WritePrivateBinaryFile(targetFilePath string, desiredContents []byte) error
 	Exists(path string) (bool, error)
 	DirExists(path string) (bool, error)
 	GetUserHomeDirPath() (string, error)
@@ -23,9 +27,15 @@ type FileSystem interface {
 	DeleteDir(path string)
 	DeleteFile(path string)
 
+	// Rename moves a file, replacing any file already at the new path in one step.
+	Rename(fromPath string, toPath string) error
+
 	// Creates a file in the file system if it can.
 	Create(path string) (io.WriteCloser, error)
 
+	// Opens a file so anything written is added to the end of it, creating the file if it isn't there.
+	Append(path string) (io.WriteCloser, error)
+
 	// Returns the normal extension used for executable files.
 	// ie: The .exe suffix in windows, or "" in unix-like systems.
 	GetExecutableExtension() string
@@ -0,0 +1,240 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+
+package templateformatter
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+	"time"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/galasa-dev/cli/pkg/spi"
+	"gopkg.in/yaml.v3"
+)
+
+// -----------------------------------------------------
+// Go template format.
+//
+// The --format flag of the 'get' commands can be given as:
+//
+//	template=<go-template>
+//	template-file=<path to a file holding a go template>
+//
+// Each item which is got is turned into the same data model which the yaml
+// format renders, then the template is run against it, and the output is
+// written on its own line. For example:
+//
+//	--format 'template={{.metadata.name}} {{.data.status}}'
+const (
+	TEMPLATE_FORMATTER_NAME     = "template"
+	TEMPLATE_FORMAT_PREFIX      = TEMPLATE_FORMATTER_NAME + "="
+	TEMPLATE_FILE_FORMAT_PREFIX = "template-file="
+
+	// Added to the help text of each --format flag which supports templates.
+	TEMPLATE_FORMATS_HELP = "Use 'template=<go-template>' to format each item with a go template, " +
+		"or 'template-file=<path>' to read the go template from a file."
+)
+
+type TemplateFormatter struct {
+	template *template.Template
+}
+
+// IsTemplateFormat - Is the --format flag value one of the go template formats ?
+func IsTemplateFormat(format string) bool {
+	return strings.HasPrefix(format, TEMPLATE_FORMAT_PREFIX) ||
+		strings.HasPrefix(format, TEMPLATE_FILE_FORMAT_PREFIX)
+}
+
+// ResolveTemplateFile - Turns a 'template-file=<path>' format into a 'template=<go-template>'
+// format, holding the contents of the file. Any other format is returned unchanged.
+func ResolveTemplateFile(fileSystem spi.FileSystem, format string) (string, error) {
+	var err error
+	resolvedFormat := format
+
+	if strings.HasPrefix(format, TEMPLATE_FILE_FORMAT_PREFIX) {
+		templateFilePath := strings.TrimPrefix(format, TEMPLATE_FILE_FORMAT_PREFIX)
+
+		var expandedPath string
+		expandedPath, err = files.TildaExpansion(fileSystem, templateFilePath)
+		if err == nil {
+			var templateText string
+			templateText, err = fileSystem.ReadTextFile(expandedPath)
+			if err == nil {
+				resolvedFormat = TEMPLATE_FORMAT_PREFIX + templateText
+			}
+		}
+
+		if err != nil {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_OUTPUT_TEMPLATE_FILE_NOT_READ, templateFilePath, err.Error())
+		}
+	}
+
+	return resolvedFormat, err
+}
+
+// NewTemplateFormatter - Parses the go template in a 'template=<go-template>' format.
+func NewTemplateFormatter(format string) (*TemplateFormatter, error) {
+	var err error
+	var formatter *TemplateFormatter
+
+	templateText := strings.TrimPrefix(format, TEMPLATE_FORMAT_PREFIX)
+
+	var parsedTemplate *template.Template
+	parsedTemplate, err = template.New(TEMPLATE_FORMATTER_NAME).
+		Option("missingkey=zero").
+		Funcs(getTemplateFunctions()).
+		Parse(templateText)
+
+	if err != nil {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_BAD_OUTPUT_TEMPLATE, err.Error())
+	} else {
+		formatter = new(TemplateFormatter)
+		formatter.template = parsedTemplate
+	}
+
+	return formatter, err
+}
+
+func (formatter *TemplateFormatter) GetName() string {
+	return TEMPLATE_FORMATTER_NAME
+}
+
+// FormatItems - Runs the template against each item in turn, each output on its own line.
+func (formatter *TemplateFormatter) FormatItems(items []interface{}) (string, error) {
+	var err error
+	buff := strings.Builder{}
+
+	for _, item := range items {
+		var dataModel interface{}
+		dataModel, err = getDataModel(item)
+
+		if err == nil {
+			itemBuff := strings.Builder{}
+			err = formatter.template.Execute(&itemBuff, dataModel)
+			if err != nil {
+				err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_OUTPUT_TEMPLATE_FAILED, err.Error())
+			} else {
+				itemText := itemBuff.String()
+				buff.WriteString(itemText)
+				if !strings.HasSuffix(itemText, "\n") {
+					buff.WriteString("\n")
+				}
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	return buff.String(), err
+}
+
+// getDataModel - Turns an item into the maps and lists the yaml format would render,
+// so the template uses the same field names as the yaml output.
+func getDataModel(item interface{}) (interface{}, error) {
+	var dataModel interface{}
+
+	yamlBytes, err := yaml.Marshal(item)
+	if err == nil {
+		err = yaml.Unmarshal(yamlBytes, &dataModel)
+	}
+
+	if err != nil {
+		log.Printf("Failed to turn an item into a data model for a template. Reason: %s\n", err.Error())
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_OUTPUT_TEMPLATE_FAILED, err.Error())
+	}
+
+	return dataModel, err
+}
+
+func getTemplateFunctions() template.FuncMap {
+	return template.FuncMap{
+		"formatDate": formatDate,
+		"duration":   duration,
+		"join":       join,
+		"padRight":   padRight,
+		"padLeft":    padLeft,
+	}
+}
+
+// formatDate - Formats an RFC3339 date-time using a go time layout, in UTC.
+// Values which are not RFC3339 date-times are returned as they are.
+func formatDate(value interface{}, layout string) string {
+	result := toText(value)
+	parsedTime, isTime := toTime(value)
+	if isTime {
+		result = parsedTime.UTC().Format(layout)
+	}
+	return result
+}
+
+// duration - The time between two RFC3339 date-times, such as "1m30s".
+// Blank if either is missing.
+func duration(start interface{}, end interface{}) string {
+	result := ""
+	startTime, isStartTime := toTime(start)
+	endTime, isEndTime := toTime(end)
+	if isStartTime && isEndTime {
+		result = endTime.Sub(startTime).Round(time.Millisecond).String()
+	}
+	return result
+}
+
+// join - Joins the items in a list with a separator.
+func join(list interface{}, separator string) string {
+	var texts []string
+	switch items := list.(type) {
+	case []interface{}:
+		for _, item := range items {
+			texts = append(texts, toText(item))
+		}
+	case []string:
+		texts = items
+	case nil:
+	default:
+		texts = append(texts, toText(items))
+	}
+	return strings.Join(texts, separator)
+}
+
+// padRight - Adds spaces to the end of a value until it is at least width characters wide.
+func padRight(value interface{}, width int) string {
+	return fmt.Sprintf("%-*s", width, toText(value))
+}
+
+// padLeft - Adds spaces to the start of a value until it is at least width characters wide.
+func padLeft(value interface{}, width int) string {
+	return fmt.Sprintf("%*s", width, toText(value))
+}
+
+func toText(value interface{}) string {
+	text := ""
+	if value != nil {
+		text = fmt.Sprint(value)
+	}
+	return text
+}
+
+func toTime(value interface{}) (time.Time, bool) {
+	var parsedTime time.Time
+	var err error
+	isTime := false
+
+	switch typedValue := value.(type) {
+	case time.Time:
+		parsedTime = typedValue
+		isTime = true
+	case string:
+		parsedTime, err = time.Parse(time.RFC3339, typedValue)
+		isTime = (err == nil)
+	}
+	return parsedTime, isTime
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package templateformatter
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/files"
+	"github.com/stretchr/testify/assert"
+)
+
+type templateTestItemMetadata struct {
+	Name string `yaml:"name"`
+}
+
+type templateTestItemData struct {
+	StartTime string   `yaml:"startTime,omitempty"`
+	EndTime   string   `yaml:"endTime,omitempty"`
+	Tags      []string `yaml:"tags,omitempty"`
+}
+
+type templateTestItem struct {
+	Metadata templateTestItemMetadata `yaml:"metadata"`
+	Data     templateTestItemData     `yaml:"data"`
+}
+
+func newTemplateTestItem(name string) templateTestItem {
+	item := templateTestItem{}
+	item.Metadata.Name = name
+	item.Data.StartTime = "2023-05-10T06:00:13.043037Z"
+	item.Data.EndTime = "2023-05-10T06:01:43.543037Z"
+	item.Data.Tags = []string{"core", "smoke"}
+	return item
+}
+
+func TestIsTemplateFormatSpotsBothTemplateFormats(t *testing.T) {
+	assert.True(t, IsTemplateFormat("template={{.name}}"))
+	assert.True(t, IsTemplateFormat("template-file=my.tmpl"))
+	assert.False(t, IsTemplateFormat("summary"))
+	assert.False(t, IsTemplateFormat("templates"))
+}
+
+func TestTemplateFormatterUsesFieldNamesOfYamlFormat(t *testing.T) {
+	// Given...
+	formatter, err := NewTemplateFormatter("template={{.metadata.name}}")
+	assert.Nil(t, err)
+
+	// When...
+	output, err := formatter.FormatItems([]interface{}{newTemplateTestItem("U1"), newTemplateTestItem("U2")})
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "U1\nU2\n", output)
+}
+
+func TestTemplateFormatterWithNoItemsReturnsNothing(t *testing.T) {
+	// Given...
+	formatter, err := NewTemplateFormatter("template={{.metadata.name}}")
+	assert.Nil(t, err)
+
+	// When...
+	output, err := formatter.FormatItems([]interface{}{})
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "", output)
+}
+
+func TestTemplateFormatterDoesNotAddNewLineIfTemplateEndsWithOne(t *testing.T) {
+	// Given...
+	formatter, err := NewTemplateFormatter("template={{.metadata.name}}\n")
+	assert.Nil(t, err)
+
+	// When...
+	output, err := formatter.FormatItems([]interface{}{newTemplateTestItem("U1")})
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "U1\n", output)
+}
+
+func TestTemplateFormatterHelperFunctions(t *testing.T) {
+	// Given...
+	formatter, err := NewTemplateFormatter(`template=[{{padRight .metadata.name 4}}][{{padLeft .metadata.name 4}}] ` +
+		`{{formatDate .data.startTime "2006-01-02 15:04"}} {{duration .data.startTime .data.endTime}} {{join .data.tags ","}}`)
+	assert.Nil(t, err)
+
+	// When...
+	output, err := formatter.FormatItems([]interface{}{newTemplateTestItem("U1")})
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "[U1  ][  U1] 2023-05-10 06:00 1m30.5s core,smoke\n", output)
+}
+
+func TestTemplateFormatterHelpersCopeWithMissingValues(t *testing.T) {
+	// Given...
+	formatter, err := NewTemplateFormatter(`template=[{{formatDate .data.queued "2006"}}][{{duration .data.startTime .data.queued}}][{{join .data.missing ","}}]`)
+	assert.Nil(t, err)
+
+	// When...
+	output, err := formatter.FormatItems([]interface{}{newTemplateTestItem("U1")})
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "[][][]\n", output)
+}
+
+func TestTemplateFormatterWithBadTemplateFails(t *testing.T) {
+	// When...
+	_, err := NewTemplateFormatter("template={{.metadata.name")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1309E")
+}
+
+func TestTemplateFormatterWhichFailsToRunReturnsError(t *testing.T) {
+	// Given...
+	formatter, err := NewTemplateFormatter("template={{padRight .metadata.name .metadata.name}}")
+	assert.Nil(t, err)
+
+	// When...
+	_, err = formatter.FormatItems([]interface{}{newTemplateTestItem("U1")})
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1311E")
+}
+
+func TestResolveTemplateFileReadsTheTemplateFromTheFile(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+	fs.WriteTextFile("/my/templates/names.tmpl", "{{.metadata.name}}\n")
+
+	// When...
+	format, err := ResolveTemplateFile(fs, "template-file=/my/templates/names.tmpl")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "template={{.metadata.name}}\n", format)
+}
+
+func TestResolveTemplateFileWithMissingFileFails(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+
+	// When...
+	_, err := ResolveTemplateFile(fs, "template-file=/my/templates/missing.tmpl")
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1310E")
+	assert.Contains(t, err.Error(), "/my/templates/missing.tmpl")
+}
+
+func TestResolveTemplateFileLeavesOtherFormatsAlone(t *testing.T) {
+	// Given...
+	fs := files.NewMockFileSystem()
+
+	// When...
+	format, err := ResolveTemplateFile(fs, "summary")
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "summary", format)
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package tokensformatter
+
+import (
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// -----------------------------------------------------
+// CSV format. The table the summary format writes, as comma-separated values,
+// without the totals.
+type TokenCsvFormatter struct {
+	utils.TableLayout
+}
+
+func NewTokenCsvFormatter() TokenFormatter {
+	return new(TokenCsvFormatter)
+}
+
+func (*TokenCsvFormatter) GetName() string {
+	return utils.CSV_FORMATTER_NAME
+}
+
+func (formatter *TokenCsvFormatter) FormatTokens(authTokens []galasaapi.AuthToken) (string, error) {
+	buff := strings.Builder{}
+
+	err := formatter.WriteCsvTable(getTokensTable(authTokens), &buff)
+
+	return buff.String(), err
+}
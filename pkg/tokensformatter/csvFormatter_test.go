@@ -0,0 +1,52 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package tokensformatter
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenCsvFormatterWritesTokensWithoutTotals(t *testing.T) {
+	// Given...
+	formatter := NewTokenCsvFormatter()
+	tokens := []galasaapi.AuthToken{
+		*CreateMockAuthToken("098234980123-1283182389", "2023-12-03T18:25:43.511Z", "mcobbett", "So I can access ecosystem1 from my laptop."),
+		*CreateMockAuthToken("87a6sd87ahq2-2y8hqwdjj273", "2023-08-04T23:00:23.511Z", "savvas", "CLI access from vscode"),
+	}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatTokens(tokens)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "tokenid,created(YYYY-MM-DD),user,description\n"+
+		"098234980123-1283182389,2023-12-03,mcobbett,So I can access ecosystem1 from my laptop.\n"+
+		"87a6sd87ahq2-2y8hqwdjj273,2023-08-04,savvas,CLI access from vscode\n", actualFormattedOutput)
+}
+
+func TestTokenCsvFormatterWithTableOptions(t *testing.T) {
+	// Given...
+	formatter := NewTokenCsvFormatter()
+	err := utils.ApplyTableOptions(formatter, utils.NewTableOptions([]string{"user", "tokenid"}, "created", true))
+	assert.Nil(t, err)
+
+	tokens := []galasaapi.AuthToken{
+		*CreateMockAuthToken("098234980123-1283182389", "2023-12-03T18:25:43.511Z", "mcobbett", "So I can access ecosystem1 from my laptop."),
+		*CreateMockAuthToken("87a6sd87ahq2-2y8hqwdjj273", "2023-08-04T23:00:23.511Z", "savvas", "CLI access from vscode"),
+	}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatTokens(tokens)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "savvas,87a6sd87ahq2-2y8hqwdjj273\n"+
+		"mcobbett,098234980123-1283182389\n", actualFormattedOutput)
+}
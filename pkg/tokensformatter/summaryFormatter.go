@@ -20,6 +20,7 @@ const (
 )
 
 type TokenSummaryFormatter struct {
+	utils.TableLayout
 }
 
 func NewTokenSummaryFormatter() TokenFormatter {
@@ -30,38 +31,44 @@ func (*TokenSummaryFormatter) GetName() string {
 	return SUMMARY_FORMATTER_NAME
 }
 
-func (*TokenSummaryFormatter) FormatTokens(authTokens []galasaapi.AuthToken) (string, error) {
+func (formatter *TokenSummaryFormatter) FormatTokens(authTokens []galasaapi.AuthToken) (string, error) {
 	var result string = ""
 	var err error = nil
 	buff := strings.Builder{}
 	totalTokens := len(authTokens)
 
 	if totalTokens > 0 {
-		var table [][]string
+		err = formatter.WriteTable(getTokensTable(authTokens), &buff)
 
-		var headers = []string{HEADER_TOKEN_ID, HEADER_TOKEN_CREATION_TIME, HEADER_TOKEN_USER, HEADER_TOKEN_DESCRIPTION}
+		buff.WriteString("\n")
 
-		table = append(table, headers)
-		for _, token := range authTokens {
-			var line []string
-			id := token.GetTokenId()
-			creationTime := utils.FormatTimeToNearestDate(token.GetCreationTime())
-			owner := token.GetOwner()
-			ownerLoginId := owner.GetLoginId()
-			description := token.GetDescription()
+	}
+	buff.WriteString("Total:" + strconv.Itoa(totalTokens) + "\n")
+
+	if err == nil {
+		result = buff.String()
+	}
+	return result, err
+}
 
-			line = append(line, id, creationTime, ownerLoginId, description)
-			table = append(table, line)
-		}
+// getTokensTable - The table of tokens which the summary and csv formats write.
+func getTokensTable(authTokens []galasaapi.AuthToken) [][]string {
+	var table [][]string
 
-		columnLengths := utils.CalculateMaxLengthOfEachColumn(table)
-		utils.WriteFormattedTableToStringBuilder(table, &buff, columnLengths)
+	var headers = []string{HEADER_TOKEN_ID, HEADER_TOKEN_CREATION_TIME, HEADER_TOKEN_USER, HEADER_TOKEN_DESCRIPTION}
 
-		buff.WriteString("\n")
+	table = append(table, headers)
+	for _, token := range authTokens {
+		var line []string
+		id := token.GetTokenId()
+		creationTime := utils.FormatTimeToNearestDate(token.GetCreationTime())
+		owner := token.GetOwner()
+		ownerLoginId := owner.GetLoginId()
+		description := token.GetDescription()
 
+		line = append(line, id, creationTime, ownerLoginId, description)
+		table = append(table, line)
 	}
-	buff.WriteString("Total:" + strconv.Itoa(totalTokens) + "\n")
 
-	result = buff.String()
-	return result, err
+	return table
 }
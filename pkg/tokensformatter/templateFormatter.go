@@ -0,0 +1,42 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package tokensformatter
+
+import (
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
+)
+
+// -----------------------------------------------------
+// Go template format. The template is run against each token in turn.
+type TokenTemplateFormatter struct {
+	formatter *templateformatter.TemplateFormatter
+}
+
+func NewTokenTemplateFormatter(format string) (TokenFormatter, error) {
+	var chosenFormatter TokenFormatter
+
+	formatter, err := templateformatter.NewTemplateFormatter(format)
+	if err == nil {
+		templateFormatter := new(TokenTemplateFormatter)
+		templateFormatter.formatter = formatter
+		chosenFormatter = templateFormatter
+	}
+
+	return chosenFormatter, err
+}
+
+func (templateFormatter *TokenTemplateFormatter) GetName() string {
+	return templateFormatter.formatter.GetName()
+}
+
+func (templateFormatter *TokenTemplateFormatter) FormatTokens(tokens []galasaapi.AuthToken) (string, error) {
+	items := make([]interface{}, 0)
+	for _, token := range tokens {
+		items = append(items, token)
+	}
+	return templateFormatter.formatter.FormatItems(items)
+}
@@ -10,30 +10,45 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/galasa-dev/cli/pkg/embedded"
 	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
 	"github.com/galasa-dev/cli/pkg/galasaapi"
 	"github.com/galasa-dev/cli/pkg/spi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
 	"github.com/galasa-dev/cli/pkg/usersformatter"
+	"github.com/galasa-dev/cli/pkg/utils"
 )
 
-func GetUsers(loginId string, apiClient *galasaapi.APIClient, console spi.Console) error {
+var (
+	formatters = createFormatters()
+)
+
+func GetUsers(loginId string, outputFormat string, tableOptions *utils.TableOptions, apiClient *galasaapi.APIClient, console spi.Console) error {
 
-	userData, err := getUserDataFromRestApi(loginId, apiClient)
+	chosenFormatter, err := validateFormatFlag(outputFormat)
+
+	if err == nil {
+		err = utils.ApplyTableOptions(chosenFormatter, tableOptions)
+	}
 
 	if err == nil {
-		err = formatFetchedUsersAndWriteToConsole(userData, console)
+		var userData []galasaapi.UserData
+		userData, err = getUserDataFromRestApi(loginId, apiClient)
+
+		if err == nil {
+			err = formatFetchedUsersAndWriteToConsole(userData, chosenFormatter, console)
+		}
 	}
 
 	return err
 }
 
-func formatFetchedUsersAndWriteToConsole(users []galasaapi.UserData, console spi.Console) error {
+func formatFetchedUsersAndWriteToConsole(users []galasaapi.UserData, chosenFormatter usersformatter.UserFormatter, console spi.Console) error {
 
-	summaryFormatter := usersformatter.NewUserSummaryFormatter()
-
-	outputText, err := summaryFormatter.FormatUsers(users)
+	outputText, err := chosenFormatter.FormatUsers(users)
 
 	if err == nil {
 		console.WriteString(outputText)
@@ -42,6 +57,54 @@ func formatFetchedUsersAndWriteToConsole(users []galasaapi.UserData, console spi
 	return err
 }
 
+func createFormatters() map[string]usersformatter.UserFormatter {
+	formatters := make(map[string]usersformatter.UserFormatter, 0)
+	summaryFormatter := usersformatter.NewUserSummaryFormatter()
+	csvFormatter := usersformatter.NewUserCsvFormatter()
+
+	formatters[summaryFormatter.GetName()] = summaryFormatter
+	formatters[csvFormatter.GetName()] = csvFormatter
+
+	return formatters
+}
+
+func GetFormatterNamesAsString() string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	formatterNames := strings.Builder{}
+
+	for index, formatterName := range names {
+
+		if index != 0 {
+			formatterNames.WriteString(", ")
+		}
+		formatterNames.WriteString("'" + formatterName + "'")
+	}
+
+	return formatterNames.String()
+}
+
+func validateFormatFlag(outputFormatString string) (usersformatter.UserFormatter, error) {
+	var err error
+	var chosenFormatter usersformatter.UserFormatter
+
+	if templateformatter.IsTemplateFormat(outputFormatString) {
+		chosenFormatter, err = usersformatter.NewUserTemplateFormatter(outputFormatString)
+	} else {
+		var isPresent bool
+		chosenFormatter, isPresent = formatters[outputFormatString]
+
+		if !isPresent {
+			err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_INVALID_OUTPUT_FORMAT, outputFormatString, GetFormatterNamesAsString())
+		}
+	}
+
+	return chosenFormatter, err
+}
+
 func getUserDataFromRestApi(
 	loginId string, // Optional. Could be ""
 	apiClient *galasaapi.APIClient,
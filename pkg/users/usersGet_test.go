@@ -110,7 +110,7 @@ Total:3
 `
 
 	//When
-	err := GetUsers("", apiClient, console)
+	err := GetUsers("", "summary", nil, apiClient, console)
 
 	//Then
 	assert.Nil(t, err)
@@ -143,7 +143,7 @@ func TestMissingLoginIdFlagReturnsBadRequest(t *testing.T) {
 	expectedOutput := `GAL1155E: The loginId provided by the --login-id field cannot be an empty string.`
 
 	//When
-	err := GetUsers("     ", apiClient, console)
+	err := GetUsers("     ", "summary", nil, apiClient, console)
 
 	//Then
 	assert.NotNil(t, err)
@@ -200,9 +200,73 @@ Total:1
 `
 
 	//When
-	err := GetUsers("test-user", apiClient, console)
+	err := GetUsers("test-user", "summary", nil, apiClient, console)
 
 	//Then
 	assert.Nil(t, err)
 	assert.Equal(t, expectedOutput, console.ReadText())
 }
+
+func TestMultipleUsersGetTemplateFormatsResultsOk(t *testing.T) {
+	//Given...
+
+	body := `
+[
+	{
+		"url": "http://localhost:8080/users/d2055afbc0ae6e513fa9b23c1a000d9f",
+		"login-id": "test-user",
+		"id": "d2055afbc0ae6e513fa9b23c1a000d9f",
+		"clients": [
+			{
+				"last-login": "2024-10-28T14:54:49.546029Z",
+				"client-name": "web-ui"
+			},
+			{
+				"last-login": "2024-10-28T15:32:49.546029Z",
+				"client-name": "rest-api"
+			}
+		]
+	}
+]
+`
+
+	getUsersInteraction := utils.NewHttpInteraction("/users", http.MethodGet)
+	getUsersInteraction.WriteHttpResponseFunc = func(writer http.ResponseWriter, req *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("ClientApiVersion", "myVersion")
+		writer.WriteHeader(http.StatusOK)
+		writer.Write([]byte(body))
+	}
+
+	interactions := []utils.HttpInteraction{
+		getUsersInteraction,
+	}
+
+	server := utils.NewMockHttpServer(t, interactions)
+	defer server.Server.Close()
+
+	apiClient := api.InitialiseAPI(server.Server.URL)
+
+	console := utils.NewMockConsole()
+	outputFormat := `template={{index . "login-id"}}{{range .clients}} {{index . "client-name"}}@{{formatDate (index . "last-login") "15:04"}}{{end}}`
+
+	//When
+	err := GetUsers("", outputFormat, nil, apiClient, console)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, "test-user web-ui@14:54 rest-api@15:32\n", console.ReadText())
+}
+
+func TestUsersGetWithUnknownFormatReturnsError(t *testing.T) {
+	//Given...
+	apiClient := api.InitialiseAPI("http://localhost:8080")
+	console := utils.NewMockConsole()
+
+	//When
+	err := GetUsers("", "yaml", nil, apiClient, console)
+
+	//Then
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1067E")
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package usersformatter
+
+import (
+	"strings"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/utils"
+)
+
+// -----------------------------------------------------
+// CSV format. The table the summary format writes, as comma-separated values,
+// without the totals.
+type UserCsvFormatter struct {
+	utils.TableLayout
+}
+
+func NewUserCsvFormatter() UserFormatter {
+	return new(UserCsvFormatter)
+}
+
+func (*UserCsvFormatter) GetName() string {
+	return utils.CSV_FORMATTER_NAME
+}
+
+func (formatter *UserCsvFormatter) FormatUsers(users []galasaapi.UserData) (string, error) {
+	buff := strings.Builder{}
+
+	err := formatter.WriteCsvTable(getUsersTable(users), &buff)
+
+	return buff.String(), err
+}
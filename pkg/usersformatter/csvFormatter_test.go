@@ -0,0 +1,31 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package usersformatter
+
+import (
+	"testing"
+
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserCsvFormatterWritesUsersWithoutTotals(t *testing.T) {
+	// Given...
+	formatter := NewUserCsvFormatter()
+	users := []galasaapi.UserData{
+		*CreateMockUser("test-user", "web-ui", "2023-12-03T18:25:43.511Z"),
+		*CreateMockUser("test-user-2", "web-ui", "2023-12-04T18:25:43.511Z"),
+	}
+
+	// When...
+	actualFormattedOutput, err := formatter.FormatUsers(users)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "login-id,role,web-last-login(UTC),rest-api-last-login(UTC)\n"+
+		"test-user,admin,2023-12-03 18:25,2023-12-03 18:25\n"+
+		"test-user-2,admin,2023-12-04 18:25,2023-12-04 18:25\n", actualFormattedOutput)
+}
@@ -23,6 +23,7 @@ const CLIENT_WEB_UI = "web-ui"
 const CLIENT_REST_API = "rest-api"
 
 type UserSummaryFormatter struct {
+	utils.TableLayout
 }
 
 func NewUserSummaryFormatter() UserFormatter {
@@ -33,50 +34,56 @@ func (*UserSummaryFormatter) GetName() string {
 	return SUMMARY_FORMATTER_NAME
 }
 
-func (*UserSummaryFormatter) FormatUsers(users []galasaapi.UserData) (string, error) {
+func (formatter *UserSummaryFormatter) FormatUsers(users []galasaapi.UserData) (string, error) {
 	var result string
 	var err error = nil
 	buff := strings.Builder{}
 	totalUsers := len(users)
 
 	if totalUsers > 0 {
-		var table [][]string
+		err = formatter.WriteTable(getUsersTable(users), &buff)
 
-		var headers = []string{HEADER_USER_LOGIN_ID, HEADER_USER_ROLE, HEADER_WEBUI_LAST_LOGIN, HEADER_RESTAPI_LAST_LOGIN}
+		buff.WriteString("\n")
 
-		table = append(table, headers)
-		for _, user := range users {
+	}
+	buff.WriteString("Total:" + strconv.Itoa(totalUsers) + "\n")
 
-			var line []string
+	if err == nil {
+		result = buff.String()
+	}
+	return result, err
+}
 
-			clients := user.GetClients()
+// getUsersTable - The table of users which the summary and csv formats write.
+func getUsersTable(users []galasaapi.UserData) [][]string {
+	var table [][]string
 
-			loginId := user.GetLoginId()
-			var webLastLogin, restLastLogin string
+	var headers = []string{HEADER_USER_LOGIN_ID, HEADER_USER_ROLE, HEADER_WEBUI_LAST_LOGIN, HEADER_RESTAPI_LAST_LOGIN}
 
-			for _, client := range clients {
-				switch client.GetClientName() {
-				case CLIENT_WEB_UI:
-					webLastLogin = utils.FormatTimeToNearestDateTimeMins(client.GetLastLogin().String())
-				case CLIENT_REST_API:
-					restLastLogin = utils.FormatTimeToNearestDateTimeMins(client.GetLastLogin().String())
-				}
-			}
+	table = append(table, headers)
+	for _, user := range users {
 
-			userRole := user.Synthetic.GetRole().Metadata.GetName()
+		var line []string
 
-			line = append(line, loginId, userRole, webLastLogin, restLastLogin)
-			table = append(table, line)
-		}
+		clients := user.GetClients()
 
-		columnLengths := utils.CalculateMaxLengthOfEachColumn(table)
-		utils.WriteFormattedTableToStringBuilder(table, &buff, columnLengths)
+		loginId := user.GetLoginId()
+		var webLastLogin, restLastLogin string
 
-		buff.WriteString("\n")
+		for _, client := range clients {
+			switch client.GetClientName() {
+			case CLIENT_WEB_UI:
+				webLastLogin = utils.FormatTimeToNearestDateTimeMins(client.GetLastLogin().String())
+			case CLIENT_REST_API:
+				restLastLogin = utils.FormatTimeToNearestDateTimeMins(client.GetLastLogin().String())
+			}
+		}
 
+		userRole := user.Synthetic.GetRole().Metadata.GetName()
+
+		line = append(line, loginId, userRole, webLastLogin, restLastLogin)
+		table = append(table, line)
 	}
-	buff.WriteString("Total:" + strconv.Itoa(totalUsers) + "\n")
 
-	result = buff.String()
-	return result, err
+	return table
 }
@@ -0,0 +1,42 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package usersformatter
+
+import (
+	"github.com/galasa-dev/cli/pkg/galasaapi"
+	"github.com/galasa-dev/cli/pkg/templateformatter"
+)
+
+// -----------------------------------------------------
+// Go template format. The template is run against each user in turn.
+type UserTemplateFormatter struct {
+	formatter *templateformatter.TemplateFormatter
+}
+
+func NewUserTemplateFormatter(format string) (UserFormatter, error) {
+	var chosenFormatter UserFormatter
+
+	formatter, err := templateformatter.NewTemplateFormatter(format)
+	if err == nil {
+		templateFormatter := new(UserTemplateFormatter)
+		templateFormatter.formatter = formatter
+		chosenFormatter = templateFormatter
+	}
+
+	return chosenFormatter, err
+}
+
+func (templateFormatter *UserTemplateFormatter) GetName() string {
+	return templateFormatter.formatter.GetName()
+}
+
+func (templateFormatter *UserTemplateFormatter) FormatUsers(users []galasaapi.UserData) (string, error) {
+	items := make([]interface{}, 0)
+	for _, user := range users {
+		items = append(items, user)
+	}
+	return templateFormatter.formatter.FormatItems(items)
+}
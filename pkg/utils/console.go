@@ -8,6 +8,8 @@ package utils
 import (
 	"os"
 	"strings"
+
+	"github.com/galasa-dev/cli/pkg/spi"
 )
 
 // -------------------------------------------------
@@ -29,6 +31,26 @@ func (*RealConsole) Write(p []byte) (n int, err error) {
 	return n, err
 }
 
+// IsTerminal - Is stdout a terminal, rather than being redirected to a file or a pipe ?
+func (*RealConsole) IsTerminal() bool {
+	isTerminal := false
+	fileInfo, err := os.Stdout.Stat()
+	if err == nil {
+		isTerminal = (fileInfo.Mode() & os.ModeCharDevice) != 0
+	}
+	return isTerminal
+}
+
+// IsTerminal - Can things written to the console be redrawn in place ?
+// Only consoles which know they are terminals can be.
+func IsTerminal(console spi.Console) bool {
+	isTerminal := false
+	if terminal, ok := console.(interface{ IsTerminal() bool }); ok {
+		isTerminal = terminal.IsTerminal()
+	}
+	return isTerminal
+}
+
 // -------------------------------------------------
 // A mock implementation which writes text to a buffer
 // Useful for unit testing.
@@ -5,15 +5,26 @@
  */
 package utils
 
+import "time"
+
 // RunsSubmitCmdParameters - Holds variables set by cobra's command-line parsing.
 // We collect the parameters here so that our unit tests can feed in different values
 // easily.
 type RunsSubmitCmdValues struct {
 	PollIntervalSeconds           int
 	NoExitCodeOnTestFailures      bool
+	ResultPolicyFileName          string
+	ExcusedResults                []string
+	MinPassRate                   float64
+	Quarantine                    []string
 	ReportYamlFilename            string
 	ReportJsonFilename            string
 	ReportJunitFilename           string
+	ReportHtmlFilename            string
+	ReportTapFilename             string
+	ReportCtrfFilename            string
+	ReportMarkdownFilename        string
+	ReportJunitRunLogLines        int
 	GroupName                     string
 	ProgressReportIntervalMinutes int
 	Throttle                      int
@@ -25,4 +36,49 @@ type RunsSubmitCmdValues struct {
 	PortfolioFileName             string
 	OverrideFilePath              string
 	TestSelectionFlagValues       *TestSelectionFlagValues
+
+	// Retry policy. How many times each test class may be attempted in total,
+	// which results cause a re-submission, and how long to wait before re-submitting.
+	MaxAttempts         int
+	RetryResults        []string
+	RetryBackoffSeconds int
+
+	// The file the state of the submission is recorded in as it progresses,
+	// and the file to resume an earlier submission from.
+	JournalFileName       string
+	ResumeJournalFileName string
+
+	// What to do if the submission is interrupted. Whether to cancel the runs in progress,
+	// and how long to wait for them to finish afterwards.
+	CancelOnInterrupt    bool
+	InterruptWaitSeconds int
+
+	// Policies which stop the submission early, cancelling the runs in progress.
+	// Stop as soon as any test fails, and the longest the whole submission may take (zero for no limit).
+	FailFast bool
+	Timeout  time.Duration
+
+	// The file announcing where 'runs control' commands can send requests to control the submission.
+	ControlFileName string
+
+	// Only submit one shard of the tests, such as "2/5", and how the tests are shared out between the shards.
+	Shard         string
+	ShardStrategy string
+
+	// The order to submit the tests in. Either the order of the portfolio, or longest-running first.
+	Schedule string
+
+	// The file each lifecycle event of the submission is written to as a line of json.
+	EventsFileName string
+
+	// The URL the final results are posted to, the go template file which builds the json payload,
+	// and whether each test run is also posted to the URL as it finishes.
+	WebhookUrl          string
+	WebhookTemplateFile string
+	IsWebhookForEachRun bool
+
+	// Fail when an override refers to an environment variable which is not set and has no default,
+	// and whether to show the overrides each test will be submitted with before submitting them.
+	StrictEnvVars           bool
+	PrintEffectiveOverrides bool
 }
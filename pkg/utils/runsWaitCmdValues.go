@@ -0,0 +1,31 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package utils
+
+import "time"
+
+// RunsWaitCmdValues - Holds variables set by cobra's command-line parsing.
+// We collect the parameters here so that our unit tests can feed in different values
+// easily.
+type RunsWaitCmdValues struct {
+	GroupName                     string
+	PollIntervalSeconds           int
+	ProgressReportIntervalMinutes int
+	Timeout                       time.Duration
+	NoExitCodeOnTestFailures      bool
+	ResultPolicyFileName          string
+	ExcusedResults                []string
+	MinPassRate                   float64
+	Quarantine                    []string
+	ReportYamlFilename            string
+	ReportJsonFilename            string
+	ReportJunitFilename           string
+	ReportHtmlFilename            string
+	ReportTapFilename             string
+	ReportCtrfFilename            string
+	ReportMarkdownFilename        string
+	ReportJunitRunLogLines        int
+}
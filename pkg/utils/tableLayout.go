@@ -0,0 +1,320 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package utils
+
+import (
+	"encoding/csv"
+	"sort"
+	"strings"
+
+	galasaErrors "github.com/galasa-dev/cli/pkg/errors"
+)
+
+// -----------------------------------------------------
+// Table layout, shared by every formatter which writes its results as a table.
+//
+// The first row of a table holds the headers. The --columns, --sort-by and --no-headers
+// flags change which columns are shown, the order of the rows, and whether the headers
+// are shown, in the same way for every such formatter.
+const (
+	CSV_FORMATTER_NAME = "csv"
+
+	// Added to a --sort-by column to sort the rows in descending order.
+	SORT_DESCENDING_SUFFIX = ":desc"
+	SORT_ASCENDING_SUFFIX  = ":asc"
+)
+
+type TableOptions struct {
+	// The columns to show, in the order to show them. Empty shows every column.
+	columns []string
+
+	// The column to sort the rows on. Empty leaves the rows in the order they were got.
+	sortBy       string
+	isDescending bool
+
+	isNoHeaders bool
+}
+
+// TableFormatter - Implemented by formatters which write their results as a table,
+// so they can be given the table options.
+type TableFormatter interface {
+	SetTableOptions(options *TableOptions)
+}
+
+// NamedFormatter - Every formatter has the name which the --format flag chooses it with.
+type NamedFormatter interface {
+	GetName() string
+}
+
+// TableLayout - Embedded in formatters which write their results as a table.
+type TableLayout struct {
+	tableOptions *TableOptions
+}
+
+// TableStream - Writes a table a page of rows at a time, for formatters which write their
+// results as they arrive. The headers are only written with the first page. Columns are lined
+// up with the widest value seen so far, so a wider value on a later page can push its row out of line.
+type TableStream struct {
+	layout        *TableLayout
+	columnLengths []int
+	isStarted     bool
+}
+
+func NewTableOptions(columns []string, sortBy string, isNoHeaders bool) *TableOptions {
+	options := new(TableOptions)
+
+	for _, column := range columns {
+		column = strings.TrimSpace(column)
+		if column != "" {
+			options.columns = append(options.columns, column)
+		}
+	}
+
+	sortBy = strings.TrimSpace(sortBy)
+	if strings.HasSuffix(sortBy, SORT_DESCENDING_SUFFIX) {
+		sortBy = strings.TrimSuffix(sortBy, SORT_DESCENDING_SUFFIX)
+		options.isDescending = true
+	} else {
+		sortBy = strings.TrimSuffix(sortBy, SORT_ASCENDING_SUFFIX)
+	}
+	options.sortBy = sortBy
+
+	options.isNoHeaders = isNoHeaders
+	return options
+}
+
+// IsDefault - Do the options leave a table as it is ? Nil options are the defaults.
+func (options *TableOptions) IsDefault() bool {
+	return options == nil || (len(options.columns) == 0 && options.sortBy == "" && !options.isNoHeaders)
+}
+
+// IsColumnChosen - Was a column with this header chosen explicitly with the --columns flag ?
+func (options *TableOptions) IsColumnChosen(header string) bool {
+	isChosen := false
+	if options != nil {
+		for _, column := range options.columns {
+			if isColumnNamed(header, column) {
+				isChosen = true
+				break
+			}
+		}
+	}
+	return isChosen
+}
+
+// IsSorted - Was a column chosen to sort the rows on ?
+func (options *TableOptions) IsSorted() bool {
+	return options != nil && options.sortBy != ""
+}
+
+func (options *TableOptions) isShowingHeaders() bool {
+	return options == nil || !options.isNoHeaders
+}
+
+// ApplyTableOptions - Gives the table options to the chosen formatter.
+// Options other than the defaults can only be used with formatters which write a table.
+func ApplyTableOptions(formatter NamedFormatter, options *TableOptions) error {
+	var err error
+
+	tableFormatter, isTableFormatter := formatter.(TableFormatter)
+	if isTableFormatter {
+		tableFormatter.SetTableOptions(options)
+	} else if !options.IsDefault() {
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_TABLE_OPTIONS_NOT_SUPPORTED, formatter.GetName())
+	}
+
+	return err
+}
+
+func (layout *TableLayout) SetTableOptions(options *TableOptions) {
+	layout.tableOptions = options
+}
+
+func (layout *TableLayout) GetTableOptions() *TableOptions {
+	return layout.tableOptions
+}
+
+// WriteTable - Writes the table with its columns lined up.
+func (layout *TableLayout) WriteTable(table [][]string, buff *strings.Builder) error {
+	laidOutTable, err := layout.layOutTable(table)
+	if err == nil && len(laidOutTable) > 0 {
+		columnLengths := CalculateMaxLengthOfEachColumn(laidOutTable)
+		WriteFormattedTableToStringBuilder(laidOutTable, buff, columnLengths)
+	}
+	return err
+}
+
+// WriteCsvTable - Writes the table as comma-separated values.
+func (layout *TableLayout) WriteCsvTable(table [][]string, buff *strings.Builder) error {
+	laidOutTable, err := layout.layOutTable(table)
+	if err == nil {
+		csvWriter := csv.NewWriter(buff)
+		err = csvWriter.WriteAll(laidOutTable)
+	}
+	return err
+}
+
+func (layout *TableLayout) NewTableStream() *TableStream {
+	stream := new(TableStream)
+	stream.layout = layout
+	return stream
+}
+
+// WriteRows - Writes the next page of rows, lined up with the rows written before.
+// The page is a table, with the headers in its first row.
+func (stream *TableStream) WriteRows(table [][]string, buff *strings.Builder) error {
+	laidOutRows, err := stream.layOutRows(table)
+	if err == nil && len(laidOutRows) > 0 {
+		pageColumnLengths := CalculateMaxLengthOfEachColumn(laidOutRows)
+		if stream.columnLengths == nil {
+			stream.columnLengths = pageColumnLengths
+		} else {
+			for column, length := range pageColumnLengths {
+				if length > stream.columnLengths[column] {
+					stream.columnLengths[column] = length
+				}
+			}
+		}
+		WriteFormattedTableToStringBuilder(laidOutRows, buff, stream.columnLengths)
+	}
+	return err
+}
+
+// WriteCsvRows - Writes the next page of rows as comma-separated values.
+// The page is a table, with the headers in its first row.
+func (stream *TableStream) WriteCsvRows(table [][]string, buff *strings.Builder) error {
+	laidOutRows, err := stream.layOutRows(table)
+	if err == nil {
+		csvWriter := csv.NewWriter(buff)
+		err = csvWriter.WriteAll(laidOutRows)
+	}
+	return err
+}
+
+// layOutRows - Lays out a page of rows, dropping the headers from every page but the first.
+func (stream *TableStream) layOutRows(table [][]string) ([][]string, error) {
+	laidOutRows, err := stream.layout.layOutTable(table)
+	if err == nil {
+		if stream.isStarted && stream.layout.tableOptions.isShowingHeaders() && len(laidOutRows) > 0 {
+			laidOutRows = laidOutRows[1:]
+		}
+		stream.isStarted = true
+	}
+	return laidOutRows, err
+}
+
+// layOutTable - Chooses the columns, sorts the rows and drops the headers, as the options say.
+func (layout *TableLayout) layOutTable(table [][]string) ([][]string, error) {
+	var err error
+	laidOutTable := table
+	options := layout.tableOptions
+
+	if !options.IsDefault() && len(table) > 0 {
+		headers := table[0]
+
+		var sortColumnIndex int = -1
+		if options.sortBy != "" {
+			sortColumnIndex, err = getColumnIndex(headers, options.sortBy, "--sort-by")
+		}
+
+		if err == nil {
+			rows := make([][]string, len(table)-1)
+			copy(rows, table[1:])
+
+			if sortColumnIndex >= 0 {
+				sortRows(rows, sortColumnIndex, options.isDescending)
+			}
+
+			laidOutTable = append([][]string{headers}, rows...)
+
+			if len(options.columns) > 0 {
+				laidOutTable, err = chooseColumns(laidOutTable, options.columns)
+			}
+		}
+
+		if err == nil && !options.isShowingHeaders() {
+			laidOutTable = laidOutTable[1:]
+		}
+	}
+
+	return laidOutTable, err
+}
+
+func sortRows(rows [][]string, columnIndex int, isDescending bool) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		var isBefore bool
+		if isDescending {
+			isBefore = rows[i][columnIndex] > rows[j][columnIndex]
+		} else {
+			isBefore = rows[i][columnIndex] < rows[j][columnIndex]
+		}
+		return isBefore
+	})
+}
+
+func chooseColumns(table [][]string, columns []string) ([][]string, error) {
+	var err error
+	var chosenTable [][]string
+	var columnIndexes []int
+
+	for _, column := range columns {
+		var columnIndex int
+		columnIndex, err = getColumnIndex(table[0], column, "--columns")
+		if err != nil {
+			break
+		}
+		columnIndexes = append(columnIndexes, columnIndex)
+	}
+
+	if err == nil {
+		for _, row := range table {
+			var chosenRow []string
+			for _, columnIndex := range columnIndexes {
+				chosenRow = append(chosenRow, row[columnIndex])
+			}
+			chosenTable = append(chosenTable, chosenRow)
+		}
+	}
+
+	return chosenTable, err
+}
+
+func getColumnIndex(headers []string, column string, flagName string) (int, error) {
+	var err error
+	columnIndex := -1
+
+	for index, header := range headers {
+		if isColumnNamed(header, column) {
+			columnIndex = index
+			break
+		}
+	}
+
+	if columnIndex < 0 {
+		var columnNames []string
+		for _, header := range headers {
+			columnNames = append(columnNames, "'"+getColumnName(header)+"'")
+		}
+		err = galasaErrors.NewGalasaError(galasaErrors.GALASA_ERROR_UNKNOWN_TABLE_COLUMN, column, flagName, strings.Join(columnNames, ", "))
+	}
+
+	return columnIndex, err
+}
+
+// isColumnNamed - A column can be named by its header, or by its header without the part
+// in brackets. For example "submitted-time" names the "submitted-time(UTC)" column.
+func isColumnNamed(header string, column string) bool {
+	return strings.EqualFold(header, column) || strings.EqualFold(getColumnName(header), column)
+}
+
+func getColumnName(header string) string {
+	columnName := header
+	bracketIndex := strings.Index(header, "(")
+	if bracketIndex > 0 {
+		columnName = header[:bracketIndex]
+	}
+	return columnName
+}
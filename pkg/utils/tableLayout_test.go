@@ -0,0 +1,254 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testYamlFormatter struct {
+}
+
+func (*testYamlFormatter) GetName() string {
+	return "yaml"
+}
+
+type testSummaryFormatter struct {
+	TableLayout
+}
+
+func (*testSummaryFormatter) GetName() string {
+	return "summary"
+}
+
+func newTestTable() [][]string {
+	return [][]string{
+		{"name", "created(UTC)", "description"},
+		{"bob", "2024-03-01", "Bob's token"},
+		{"anne", "2024-01-01", "Anne's token, for the build"},
+		{"carl", "2024-02-01", "Carl's token"},
+	}
+}
+
+func TestTableLayoutWithNoOptionsWritesTheTableAsItIs(t *testing.T) {
+	// Given...
+	layout := new(TableLayout)
+	buff := strings.Builder{}
+
+	// When...
+	err := layout.WriteTable(newTestTable(), &buff)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "name created(UTC) description\n"+
+		"bob  2024-03-01   Bob's token\n"+
+		"anne 2024-01-01   Anne's token, for the build\n"+
+		"carl 2024-02-01   Carl's token\n", buff.String())
+}
+
+func TestTableLayoutChoosesColumnsInTheOrderGiven(t *testing.T) {
+	// Given...
+	layout := new(TableLayout)
+	layout.SetTableOptions(NewTableOptions([]string{"created", "NAME"}, "", false))
+	buff := strings.Builder{}
+
+	// When...
+	err := layout.WriteTable(newTestTable(), &buff)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "created(UTC) name\n"+
+		"2024-03-01   bob\n"+
+		"2024-01-01   anne\n"+
+		"2024-02-01   carl\n", buff.String())
+}
+
+func TestTableLayoutSortsRowsButNotHeaders(t *testing.T) {
+	// Given...
+	layout := new(TableLayout)
+	layout.SetTableOptions(NewTableOptions([]string{"name"}, "created(UTC)", false))
+	buff := strings.Builder{}
+
+	// When...
+	err := layout.WriteTable(newTestTable(), &buff)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "name\nanne\ncarl\nbob\n", buff.String())
+}
+
+func TestTableLayoutSortsRowsInDescendingOrder(t *testing.T) {
+	// Given...
+	layout := new(TableLayout)
+	layout.SetTableOptions(NewTableOptions([]string{"name"}, "name:desc", true))
+	buff := strings.Builder{}
+
+	// When...
+	err := layout.WriteTable(newTestTable(), &buff)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "carl\nbob\nanne\n", buff.String())
+}
+
+func TestTableLayoutWithUnknownColumnFails(t *testing.T) {
+	// Given...
+	layout := new(TableLayout)
+	layout.SetTableOptions(NewTableOptions([]string{"name", "owner"}, "", false))
+	buff := strings.Builder{}
+
+	// When...
+	err := layout.WriteTable(newTestTable(), &buff)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1312E")
+	assert.Contains(t, err.Error(), "'owner' given with the --columns flag")
+	assert.Contains(t, err.Error(), "'name', 'created', 'description'")
+}
+
+func TestTableLayoutWithUnknownSortColumnFails(t *testing.T) {
+	// Given...
+	layout := new(TableLayout)
+	layout.SetTableOptions(NewTableOptions(nil, "owner", false))
+	buff := strings.Builder{}
+
+	// When...
+	err := layout.WriteTable(newTestTable(), &buff)
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1312E")
+	assert.Contains(t, err.Error(), "--sort-by")
+}
+
+func TestTableLayoutWritesCsvQuotingValuesWithCommas(t *testing.T) {
+	// Given...
+	layout := new(TableLayout)
+	buff := strings.Builder{}
+
+	// When...
+	err := layout.WriteCsvTable(newTestTable(), &buff)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "name,created(UTC),description\n"+
+		"bob,2024-03-01,Bob's token\n"+
+		"anne,2024-01-01,\"Anne's token, for the build\"\n"+
+		"carl,2024-02-01,Carl's token\n", buff.String())
+}
+
+func TestTableLayoutWritesCsvWithoutHeaders(t *testing.T) {
+	// Given...
+	layout := new(TableLayout)
+	layout.SetTableOptions(NewTableOptions([]string{"name"}, "name", true))
+	buff := strings.Builder{}
+
+	// When...
+	err := layout.WriteCsvTable(newTestTable(), &buff)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "anne\nbob\ncarl\n", buff.String())
+}
+
+func TestApplyTableOptionsToFormatterWhichIsNotATableFails(t *testing.T) {
+	// Given...
+	notATable := new(testYamlFormatter)
+
+	// When...
+	err := ApplyTableOptions(notATable, NewTableOptions(nil, "", true))
+
+	// Then...
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "GAL1313E")
+	assert.Contains(t, err.Error(), "'yaml'")
+}
+
+func TestApplyDefaultTableOptionsToFormatterWhichIsNotATableIsOk(t *testing.T) {
+	// When...
+	err := ApplyTableOptions(new(testYamlFormatter), nil)
+
+	// Then...
+	assert.Nil(t, err)
+}
+
+func TestApplyTableOptionsGivesThemToATableFormatter(t *testing.T) {
+	// Given...
+	formatter := new(testSummaryFormatter)
+	options := NewTableOptions([]string{"name"}, "", false)
+
+	// When...
+	err := ApplyTableOptions(formatter, options)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, options, formatter.GetTableOptions())
+	assert.True(t, options.IsColumnChosen("name"))
+	assert.False(t, options.IsColumnChosen("created(UTC)"))
+}
+
+func TestTableStreamWritesHeadersWithFirstPageOnly(t *testing.T) {
+	// Given...
+	layout := new(TableLayout)
+	stream := layout.NewTableStream()
+	buff := strings.Builder{}
+
+	// When...
+	err := stream.WriteRows([][]string{{"name", "description"}, {"bob", "Bob's token"}}, &buff)
+	assert.Nil(t, err)
+	err = stream.WriteRows([][]string{{"name", "description"}, {"anne", "Anne's token"}}, &buff)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "name description\n"+
+		"bob  Bob's token\n"+
+		"anne Anne's token\n", buff.String())
+}
+
+func TestTableStreamWidensColumnsForLaterPages(t *testing.T) {
+	// Given...
+	layout := new(TableLayout)
+	stream := layout.NewTableStream()
+	buff := strings.Builder{}
+
+	// When...
+	err := stream.WriteRows([][]string{{"name", "created"}, {"bob", "2024-03-01"}}, &buff)
+	assert.Nil(t, err)
+	err = stream.WriteRows([][]string{{"name", "created"}, {"annabelle", "2024-01-01"}}, &buff)
+	assert.Nil(t, err)
+	err = stream.WriteRows([][]string{{"name", "created"}, {"carl", "2024-02-01"}}, &buff)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "name created\n"+
+		"bob  2024-03-01\n"+
+		"annabelle 2024-01-01\n"+
+		"carl      2024-02-01\n", buff.String())
+}
+
+func TestTableStreamWritesChosenColumnsWithoutHeaders(t *testing.T) {
+	// Given...
+	layout := new(TableLayout)
+	layout.SetTableOptions(NewTableOptions([]string{"description"}, "", true))
+	stream := layout.NewTableStream()
+	buff := strings.Builder{}
+
+	// When...
+	err := stream.WriteCsvRows(newTestTable(), &buff)
+	assert.Nil(t, err)
+	err = stream.WriteCsvRows([][]string{{"name", "created(UTC)", "description"}, {"dave", "2024-04-01", "Dave's token"}}, &buff)
+
+	// Then...
+	assert.Nil(t, err)
+	assert.Equal(t, "Bob's token\n"+
+		"\"Anne's token, for the build\"\n"+
+		"Carl's token\n"+
+		"Dave's token\n", buff.String())
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright contributors to the Galasa project
+ *
+ * SPDX-License-Identifier: EPL-2.0
+ */
+package utils
+
+import (
+	"time"
+)
+
+// MockTimedSleeper doesn't really sleep. It moves the mock clock on instead,
+// so tests which wait for time to pass complete straight away.
+type MockTimedSleeper struct {
+	timeService *MockTimeService
+	SleepCount  int
+}
+
+func NewMockTimedSleeper(timeService *MockTimeService) *MockTimedSleeper {
+	sleeper := new(MockTimedSleeper)
+	sleeper.timeService = timeService
+	return sleeper
+}
+
+func (sleeper *MockTimedSleeper) Sleep(duration time.Duration) {
+	sleeper.SleepCount += 1
+	sleeper.timeService.AdvanceClock(duration)
+}
+
+func (sleeper *MockTimedSleeper) Interrupt(message string) {
+	// Nothing to interrupt, as the mock never really sleeps.
+}